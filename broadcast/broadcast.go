@@ -0,0 +1,50 @@
+// Package broadcast wakes up subscribers, such as live streams, when something they wait for happened. Subscribers
+// only learn that they should look again, what happened is read from the database.
+package broadcast
+
+import "sync"
+
+// Group keeps the subscribers per key, the zero value is ready to use
+type Group[K comparable] struct {
+	mu          sync.Mutex
+	subscribers map[K]map[chan struct{}]bool
+}
+
+// Subscribe returns a channel that receives a value when the key is woken up, the channel is buffered so wake-ups are
+// not lost while the subscriber is busy. The returned function ends the subscription.
+func (g *Group[K]) Subscribe(key K) (<-chan struct{}, func()) {
+	ch := make(chan struct{}, 1)
+
+	g.mu.Lock()
+	if g.subscribers == nil {
+		g.subscribers = map[K]map[chan struct{}]bool{}
+	}
+	if g.subscribers[key] == nil {
+		g.subscribers[key] = map[chan struct{}]bool{}
+	}
+	g.subscribers[key][ch] = true
+	g.mu.Unlock()
+
+	return ch, func() {
+		g.mu.Lock()
+		delete(g.subscribers[key], ch)
+		if len(g.subscribers[key]) == 0 {
+			delete(g.subscribers, key)
+		}
+		g.mu.Unlock()
+	}
+}
+
+// Wake wakes up the subscribers of the key, subscribers that were not done with an earlier wake-up are woken up once
+func (g *Group[K]) Wake(key K) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	for ch := range g.subscribers[key] {
+		select {
+		case ch <- struct{}{}:
+		default:
+			// A wake-up is already pending
+		}
+	}
+}
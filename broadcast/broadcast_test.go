@@ -0,0 +1,40 @@
+package broadcast
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// requireWoken checks whether the subscription received a wake-up
+func requireWoken(t *testing.T, ch <-chan struct{}, expected bool) {
+	select {
+	case <-ch:
+		require.True(t, expected, "unexpected wake-up")
+	case <-time.After(10 * time.Millisecond):
+		require.False(t, expected, "no wake-up")
+	}
+}
+
+func TestWake(t *testing.T) {
+	group := Group[int64]{}
+
+	woken, unsubscribe := group.Subscribe(2)
+	other, unsubscribeOther := group.Subscribe(3)
+	defer unsubscribeOther()
+
+	// Wake-ups while the subscriber is busy are delivered once
+	group.Wake(2)
+	group.Wake(2)
+	requireWoken(t, woken, true)
+	requireWoken(t, woken, false)
+	requireWoken(t, other, false)
+
+	// Wake-ups stop after unsubscribing
+	unsubscribe()
+	group.Wake(2)
+	requireWoken(t, woken, false)
+	require.NotContains(t, group.subscribers, int64(2))
+	require.Len(t, group.subscribers, 1)
+}
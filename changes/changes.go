@@ -0,0 +1,63 @@
+// Package changes keeps the log of changes to platforms, contacts, articles and projects. Changes are stored in the
+// database and passed on to the subscribed webhooks, subscribers such as live change feeds are woken up when a change
+// is logged.
+package changes
+
+import (
+	"database/sql"
+	"errors"
+
+	"github.com/webstradev/rsdb-backend/broadcast"
+	"github.com/webstradev/rsdb-backend/db"
+	"github.com/webstradev/rsdb-backend/webhooks"
+)
+
+// Servicer is the part of the feed the handlers use, it is implemented by *Feed
+type Servicer interface {
+	Publish(entityType, action string, entityId, actorId int64) error
+	Subscribe() (<-chan struct{}, func())
+}
+
+type Feed struct {
+	db       *db.Database
+	webhooks *webhooks.Dispatcher
+
+	subscribers broadcast.Group[struct{}]
+}
+
+// New returns a feed that passes the changes on to the webhooks of the dispatcher, which may be nil
+func New(database *db.Database, dispatcher *webhooks.Dispatcher) *Feed {
+	return &Feed{db: database, webhooks: dispatcher}
+}
+
+// Publish logs the action on a record by the actor (0 when not known), wakes up the subscribers and queues the change
+// for the webhooks. A failure to log the change does not keep it from the webhooks, the errors are joined.
+func (f *Feed) Publish(entityType, action string, entityId, actorId int64) error {
+	errs := []error{}
+
+	_, err := f.db.InsertChangeEvent(db.ChangeEvent{
+		EntityType: entityType,
+		EntityId:   entityId,
+		Action:     action,
+		ActorId:    sql.NullInt64{Int64: actorId, Valid: actorId != 0},
+	})
+	if err != nil {
+		errs = append(errs, err)
+	} else {
+		f.subscribers.Wake(struct{}{})
+	}
+
+	if f.webhooks != nil {
+		err = f.webhooks.Publish(entityType, action, entityId, actorId)
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// Subscribe returns a channel that receives a value when a change is logged, see broadcast.Group.Subscribe
+func (f *Feed) Subscribe() (<-chan struct{}, func()) {
+	return f.subscribers.Subscribe(struct{}{})
+}
@@ -0,0 +1,117 @@
+package changes
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/require"
+	"github.com/webstradev/rsdb-backend/db"
+	"github.com/webstradev/rsdb-backend/webhooks"
+)
+
+// requireWoken checks whether the subscription received a wake-up
+func requireWoken(t *testing.T, ch <-chan struct{}, expected bool) {
+	select {
+	case <-ch:
+		require.True(t, expected, "unexpected wake-up")
+	case <-time.After(10 * time.Millisecond):
+		require.False(t, expected, "no wake-up")
+	}
+}
+
+func TestPublish(t *testing.T) {
+	tests := []struct {
+		Name       string
+		Action     string
+		ActorId    int64
+		Webhooks   bool
+		MockDbCall func(sqlmock.Sqlmock)
+		Error      string
+		Woken      bool
+	}{
+		{
+			"Publish - logged without webhooks",
+			db.CHANGE_CREATED,
+			2,
+			false,
+			func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec("INSERT INTO change_events (.+) SELECT privacy = 'private' FROM platforms").WithArgs("platform", 3, "created", 2, 3).WillReturnResult(sqlmock.NewResult(1, 1))
+			},
+			"",
+			true,
+		},
+		{
+			"Publish - logged and queued for webhooks without actor",
+			db.CHANGE_DELETED,
+			0,
+			true,
+			func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec("INSERT INTO change_events").WithArgs("platform", 3, "deleted", nil, 3).WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec("INSERT INTO webhook_deliveries").WithArgs("platform.deleted", sqlmock.AnyArg(), sqlmock.AnyArg(), "platform.deleted").WillReturnResult(sqlmock.NewResult(1, 1))
+			},
+			"",
+			true,
+		},
+		{
+			"Publish - sql errors on InsertChangeEvent and QueueWebhookDeliveries",
+			db.CHANGE_UPDATED,
+			2,
+			true,
+			func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec("INSERT INTO change_events").WithArgs("platform", 3, "updated", 2, 3).WillReturnError(errors.New("log"))
+				mock.ExpectExec("INSERT INTO webhook_deliveries").WithArgs("platform.updated", sqlmock.AnyArg(), sqlmock.AnyArg(), "platform.updated").WillReturnError(errors.New("queue"))
+			},
+			"log\nqueue",
+			false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			mockDb, mock, err := sqlmock.New()
+			require.NoError(t, err)
+			defer mockDb.Close()
+
+			test.MockDbCall(mock)
+
+			database := db.SetupMockDB(sqlx.NewDb(mockDb, "sqlmock"))
+
+			var dispatcher *webhooks.Dispatcher
+			if test.Webhooks {
+				dispatcher = webhooks.New(database, http.DefaultClient)
+			}
+			feed := New(database, dispatcher)
+
+			woken, unsubscribe := feed.Subscribe()
+			defer unsubscribe()
+
+			err = feed.Publish(db.PLATFORM_ENTITY, test.Action, 3, test.ActorId)
+			if test.Error == "" {
+				require.NoError(t, err)
+			} else {
+				require.EqualError(t, err, test.Error)
+			}
+
+			requireWoken(t, woken, test.Woken)
+
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestPublishInvalidEntityType(t *testing.T) {
+	mockDb, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer mockDb.Close()
+
+	feed := New(db.SetupMockDB(sqlx.NewDb(mockDb, "sqlmock")), nil)
+
+	err = feed.Publish(db.TASK_ENTITY, db.CHANGE_CREATED, 1, 2)
+	require.EqualError(t, err, db.ErrInvalidEntityType.Error())
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,116 @@
+// Command import imports platforms and contacts from a CSV or XLSX file, the same way as the import endpoint.
+//
+//	go run ./cmd/import -file platforms.xlsx -mapping mapping.json -dry-run
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"log"
+	"net/http"
+	"os"
+
+	"github.com/joho/godotenv"
+	"github.com/webstradev/rsdb-backend/changes"
+	"github.com/webstradev/rsdb-backend/db"
+	"github.com/webstradev/rsdb-backend/spreadsheet"
+	"github.com/webstradev/rsdb-backend/webhooks"
+)
+
+func main() {
+	filename := flag.String("file", "", "CSV or XLSX file to import")
+	format := flag.String("format", "", "file format (csv or xlsx), derived from the file extension by default")
+	mappingFile := flag.String("mapping", "", "JSON file mapping column headers to fields")
+	dryRun := flag.Bool("dry-run", false, "only validate the file and report duplicates")
+	userId := flag.Int64("user", 0, "ID of the user the import is recorded for")
+	flag.Parse()
+
+	if *filename == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	if *format == "" {
+		*format = spreadsheet.FormatFromFilename(*filename)
+	}
+
+	// If a database connection string is not yet set in environment variables then load the .env file
+	if os.Getenv("DB_CONNECTION_STRING") == "" {
+		err := godotenv.Load(".env")
+		if err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	mapping := map[string]string{}
+	if *mappingFile != "" {
+		data, err := os.ReadFile(*mappingFile)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		err = json.Unmarshal(data, &mapping)
+		if err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	file, err := os.Open(*filename)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer file.Close()
+
+	rows, err := spreadsheet.Read(file, *format)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	database, err := db.Setup(os.Getenv("DB_CONNECTION_STRING"), nil)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	report, err := database.PlanImport(rows, mapping)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	output := json.NewEncoder(os.Stdout)
+	output.SetIndent("", "  ")
+
+	if *dryRun || !report.Valid {
+		output.Encode(report)
+		if !report.Valid {
+			os.Exit(1)
+		}
+		return
+	}
+
+	id, err := database.RunImport(report, *filename, *userId)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// Log the created records for open frontends and queue them for the subscribed webhooks, the running server sends
+	// the queued deliveries
+	feed := changes.New(database, webhooks.New(database, http.DefaultClient))
+	for _, result := range report.Results {
+		if result.Platform == db.IMPORT_PLATFORM_CREATE {
+			err = feed.Publish(db.PLATFORM_ENTITY, db.CHANGE_CREATED, result.PlatformId, *userId)
+			if err != nil {
+				log.Println(err)
+			}
+		}
+
+		if result.ContactId != 0 {
+			err = feed.Publish(db.CONTACT_ENTITY, db.CHANGE_CREATED, result.ContactId, *userId)
+			if err != nil {
+				log.Println(err)
+			}
+		}
+	}
+
+	output.Encode(report)
+	log.Printf("Import %d finished: %d platforms and %d contacts created", id, report.PlatformsCreated, report.ContactsCreated)
+}
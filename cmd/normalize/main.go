@@ -0,0 +1,47 @@
+// Command normalize converts the countries, email addresses and phone numbers that were stored before they were
+// normalized on write. Values that can not be parsed are left unchanged and reported so they can be fixed by hand.
+//
+//	go run ./cmd/normalize -dry-run
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"log"
+	"os"
+
+	"github.com/joho/godotenv"
+	"github.com/webstradev/rsdb-backend/db"
+)
+
+func main() {
+	dryRun := flag.Bool("dry-run", false, "only report the changes without storing them")
+	flag.Parse()
+
+	// If a database connection string is not yet set in environment variables then load the .env file
+	if os.Getenv("DB_CONNECTION_STRING") == "" {
+		err := godotenv.Load(".env")
+		if err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	database, err := db.Setup(os.Getenv("DB_CONNECTION_STRING"), nil)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	report, err := database.NormalizeExisting(*dryRun)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	output := json.NewEncoder(os.Stdout)
+	output.SetIndent("", "  ")
+	output.Encode(report.Issues)
+
+	log.Printf("%d platforms and %d contacts updated, %d values could not be normalized", report.PlatformsUpdated, report.ContactsUpdated, len(report.Issues))
+	if *dryRun {
+		log.Println("Dry run, nothing was stored")
+	}
+}
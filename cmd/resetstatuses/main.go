@@ -0,0 +1,63 @@
+// Command resetstatuses moves the projects with a status that is not in the configured workflow to its first status,
+// e.g. after a status was removed from PROJECT_WORKFLOW. Every move is recorded in the status history.
+//
+//	go run ./cmd/resetstatuses -dry-run
+package main
+
+import (
+	"flag"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/joho/godotenv"
+	"github.com/webstradev/rsdb-backend/db"
+	"github.com/webstradev/rsdb-backend/workflow"
+)
+
+func main() {
+	dryRun := flag.Bool("dry-run", false, "only report the unknown statuses without moving the projects")
+	userId := flag.Int64("user", 0, "ID of the user the status changes are recorded for")
+	flag.Parse()
+
+	// If a database connection string is not yet set in environment variables then load the .env file
+	if os.Getenv("DB_CONNECTION_STRING") == "" {
+		err := godotenv.Load(".env")
+		if err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	projectWorkflow, err := workflow.FromEnv()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	database, err := db.Setup(os.Getenv("DB_CONNECTION_STRING"), nil)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	unknown, err := database.GetUnknownProjectStatuses(projectWorkflow.Statuses)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if len(unknown) == 0 {
+		log.Println("All projects have a status of the workflow")
+		return
+	}
+
+	log.Printf("Statuses that are not in the workflow: %s", strings.Join(unknown, ", "))
+	if *dryRun {
+		log.Println("Dry run, nothing was stored")
+		return
+	}
+
+	reset, err := database.ResetUnknownProjectStatuses(projectWorkflow.Statuses, projectWorkflow.Initial(), *userId)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	log.Printf("Moved %d projects to %s", reset, projectWorkflow.Initial())
+}
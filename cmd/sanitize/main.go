@@ -0,0 +1,42 @@
+// Command sanitize sanitizes the bodies of the articles and projects that were stored before bodies were sanitized on
+// write, and renders their HTML and plain text.
+//
+//	go run ./cmd/sanitize -dry-run
+package main
+
+import (
+	"flag"
+	"log"
+	"os"
+
+	"github.com/joho/godotenv"
+	"github.com/webstradev/rsdb-backend/db"
+)
+
+func main() {
+	dryRun := flag.Bool("dry-run", false, "only report the changes without storing them")
+	flag.Parse()
+
+	// If a database connection string is not yet set in environment variables then load the .env file
+	if os.Getenv("DB_CONNECTION_STRING") == "" {
+		err := godotenv.Load(".env")
+		if err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	database, err := db.Setup(os.Getenv("DB_CONNECTION_STRING"), nil)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	report, err := database.SanitizeExisting(*dryRun)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	log.Printf("%d articles and %d projects updated", report.ArticlesUpdated, report.ProjectsUpdated)
+	if *dryRun {
+		log.Println("Dry run, nothing was stored")
+	}
+}
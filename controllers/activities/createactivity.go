@@ -0,0 +1,79 @@
+package activities
+
+import (
+	"database/sql"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/webstradev/rsdb-backend/auth"
+	"github.com/webstradev/rsdb-backend/db"
+	"github.com/webstradev/rsdb-backend/utils"
+)
+
+type activityInput struct {
+	Type       string    `json:"type" binding:"required"`
+	Date       time.Time `json:"date" binding:"required"`
+	Summary    string    `json:"summary"`
+	PlatformId int64     `json:"platformId"`
+	ProjectId  int64     `json:"projectId"`
+	Contacts   []int64   `json:"contacts"`
+	Users      []int64   `json:"users"`
+}
+
+func (input activityInput) activity() db.Activity {
+	return db.Activity{
+		Type:       input.Type,
+		Date:       input.Date,
+		Summary:    input.Summary,
+		PlatformId: sql.NullInt64{Int64: input.PlatformId, Valid: input.PlatformId != 0},
+		ProjectId:  sql.NullInt64{Int64: input.ProjectId, Valid: input.ProjectId != 0},
+	}
+}
+
+func CreateActivity(env *utils.Environment) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		user, err := auth.GetUserFromContext(c)
+		if err != nil {
+			log.Println(err)
+			c.AbortWithStatus(http.StatusInternalServerError)
+			return
+		}
+
+		input := activityInput{}
+		err = c.ShouldBindJSON(&input)
+		if err != nil {
+			log.Println(err)
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		activity := input.activity()
+		activity.CreatedBy = sql.NullInt64{Int64: user.UserID, Valid: true}
+
+		err = activity.Validate(input.Contacts)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		// The user logging the activity took part in it unless other users are given
+		if len(input.Users) == 0 {
+			input.Users = []int64{user.UserID}
+		}
+
+		id, err := env.DB.InsertActivity(activity, input.Contacts, input.Users)
+		if err != nil {
+			if db.IsForeignKeyError(err) {
+				c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Linked platform, project, contact or user not found"})
+				return
+			}
+			log.Println(err)
+			c.AbortWithStatus(http.StatusInternalServerError)
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"id": id, "message": "Activity created successfully"})
+	}
+}
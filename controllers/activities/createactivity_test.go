@@ -0,0 +1,172 @@
+package activities
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gin-gonic/gin"
+	"github.com/go-sql-driver/mysql"
+	"github.com/stretchr/testify/require"
+	"github.com/webstradev/rsdb-backend/auth"
+	"github.com/webstradev/rsdb-backend/utils"
+)
+
+func TestCreateActivity(t *testing.T) {
+	date := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		Name       string
+		User       auth.TokenData
+		MockDbCall func(sqlmock.Sqlmock)
+		StatusCode int
+		Body       string
+		Response   string
+	}{
+		{
+			"CreateActivity - User Missing from Context",
+			auth.TokenData{},
+			nil,
+			http.StatusInternalServerError,
+			`{}`,
+			`{}`,
+		},
+		{
+			"CreateActivity - missing required fields",
+			auth.TokenData{UserID: 1},
+			nil,
+			http.StatusBadRequest,
+			`{}`,
+			`{"error":"Key: 'activityInput.Type' Error:Field validation for 'Type' failed on the 'required' tag\nKey: 'activityInput.Date' Error:Field validation for 'Date' failed on the 'required' tag"}`,
+		},
+		{
+			"CreateActivity - invalid type",
+			auth.TokenData{UserID: 1},
+			nil,
+			http.StatusBadRequest,
+			`{"type":"lunch","date":"2024-05-01T10:00:00Z","contacts":[3]}`,
+			`{"error":"invalid activity type, use call, meeting, email or other"}`,
+		},
+		{
+			"CreateActivity - no platform or contacts",
+			auth.TokenData{UserID: 1},
+			nil,
+			http.StatusBadRequest,
+			`{"type":"call","date":"2024-05-01T10:00:00Z"}`,
+			`{"error":"an activity needs a platform or at least one contact"}`,
+		},
+		{
+			"CreateActivity - sql error on InsertActivity",
+			auth.TokenData{UserID: 1},
+			func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec("INSERT INTO activities").WillReturnError(errors.New("test"))
+				mock.ExpectRollback()
+			},
+			http.StatusInternalServerError,
+			`{"type":"call","date":"2024-05-01T10:00:00Z","contacts":[3]}`,
+			`{}`,
+		},
+		{
+			"CreateActivity - unknown contact",
+			auth.TokenData{UserID: 1},
+			func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec("INSERT INTO activities").WillReturnResult(sqlmock.NewResult(5, 1))
+				mock.ExpectExec("INSERT IGNORE INTO activities_contacts").WithArgs(5, 9).WillReturnError(&mysql.MySQLError{Number: 1452, Message: "Cannot add or update a child row: a foreign key constraint fails"})
+				mock.ExpectRollback()
+			},
+			http.StatusBadRequest,
+			`{"type":"call","date":"2024-05-01T10:00:00Z","contacts":[9]}`,
+			`{"error":"Linked platform, project, contact or user not found"}`,
+		},
+		{
+			"CreateActivity - Valid Request",
+			auth.TokenData{UserID: 1},
+			func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec("INSERT INTO activities").
+					WithArgs("meeting", date, "Discussed the launch", 2, 4, 1).
+					WillReturnResult(sqlmock.NewResult(5, 1))
+				mock.ExpectExec("INSERT IGNORE INTO activities_contacts").WithArgs(5, 3).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("INSERT IGNORE INTO activities_users").WithArgs(5, 1).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+			http.StatusOK,
+			`{"type":"meeting","date":"2024-05-01T10:00:00Z","summary":"Discussed the launch","platformId":2,"projectId":4,"contacts":[3]}`,
+			`{"id":5,"message":"Activity created successfully"}`,
+		},
+		{
+			"CreateActivity - Valid Request with other users",
+			auth.TokenData{UserID: 1},
+			func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec("INSERT INTO activities").
+					WithArgs("email", date, "", 2, nil, 1).
+					WillReturnResult(sqlmock.NewResult(6, 1))
+				mock.ExpectExec("INSERT IGNORE INTO activities_users").WithArgs(6, 7).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("INSERT IGNORE INTO activities_users").WithArgs(6, 8).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+			http.StatusOK,
+			`{"type":"email","date":"2024-05-01T10:00:00Z","platformId":2,"users":[7,8]}`,
+			`{"id":6,"message":"Activity created successfully"}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			// Initilize test router, environemnt and mock database
+			r, mockDb, mockSql, env, err := utils.SetupTestEnvironment(test.MockDbCall)
+			// Close the mock database at the end of the test
+			defer mockDb.Close()
+
+			// Check for errors during setup
+			require.NoError(t, err)
+
+			// Register handler
+			r.POST("/api/v1/activities", func(c *gin.Context) {
+				// Add user to context if it exists
+				if test.User.UserID != 0 {
+					c.Set("user", test.User)
+				}
+
+				// Call handler
+				CreateActivity(env)(c)
+			})
+
+			// Create httptest request
+			req, _ := http.NewRequest("POST", "/api/v1/activities", strings.NewReader(test.Body))
+			w := httptest.NewRecorder()
+
+			// Mock request
+			r.ServeHTTP(w, req)
+
+			// Read response data
+			responseData, _ := io.ReadAll(w.Body)
+
+			// Check response status
+			require.Equal(t, test.StatusCode, w.Code)
+
+			// Handle empty responses
+			response := string(responseData)
+			if response == "" {
+				response = "{}"
+			}
+
+			// Check response body
+			require.JSONEq(t, test.Response, response)
+
+			// Check for any remaining expectations
+			// we make sure that all expectations were met
+			if err := mockSql.ExpectationsWereMet(); err != nil {
+				t.Fatalf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,74 @@
+package activities
+
+import (
+	"database/sql"
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/webstradev/rsdb-backend/db"
+	"github.com/webstradev/rsdb-backend/utils"
+)
+
+func EditActivity(env *utils.Environment) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		idString := c.Param("activityId")
+		id, err := strconv.ParseInt(idString, 10, 64)
+		if err != nil {
+			log.Println(err)
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Invalid ID"})
+			return
+		}
+
+		input := activityInput{}
+		err = c.ShouldBindJSON(&input)
+		if err != nil {
+			log.Println(err)
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		activity := input.activity()
+		activity.ID = id
+
+		err = activity.Validate(input.Contacts)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		// Make sure the activity exists
+		existing, err := env.DB.GetActivity(id)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				c.AbortWithStatus(http.StatusNotFound)
+				return
+			}
+			log.Println(err)
+			c.AbortWithStatus(http.StatusInternalServerError)
+			return
+		}
+
+		// Participating users are kept when they are left out
+		if input.Users == nil {
+			for _, user := range existing.Users {
+				input.Users = append(input.Users, user.UserId)
+			}
+		}
+
+		err = env.DB.UpdateActivity(activity, input.Contacts, input.Users)
+		if err != nil {
+			if db.IsForeignKeyError(err) {
+				c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Linked platform, project, contact or user not found"})
+				return
+			}
+			log.Println(err)
+			c.AbortWithStatus(http.StatusInternalServerError)
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"message": "Activity updated successfully"})
+	}
+}
@@ -0,0 +1,153 @@
+package activities
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/go-sql-driver/mysql"
+	"github.com/stretchr/testify/require"
+	"github.com/webstradev/rsdb-backend/utils"
+)
+
+func TestEditActivity(t *testing.T) {
+	date := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+
+	// The existing activity, with user 1 as participant
+	expectExisting := func(mock sqlmock.Sqlmock) {
+		rows := sqlmock.NewRows(activityColumns).AddRow(1, "call", date, "Intro", 2, nil, 1, "platform", nil)
+		mock.ExpectQuery("SELECT a.(.+) FROM activities a").WithArgs(1).WillReturnRows(rows)
+		mock.ExpectQuery("SELECT ac.activity_id(.+) FROM activities_contacts").WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"activity_id", "contact_id", "name", "platform_id"}))
+		mock.ExpectQuery("SELECT au.activity_id(.+) FROM activities_users").WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"activity_id", "user_id", "email"}).AddRow(1, 1, "user@example.com"))
+	}
+
+	tests := []struct {
+		Name       string
+		IdString   string
+		MockDbCall func(sqlmock.Sqlmock)
+		StatusCode int
+		Body       string
+		Response   string
+	}{
+		{
+			"EditActivity - non int id",
+			"notanint",
+			nil,
+			http.StatusBadRequest,
+			`{}`,
+			`{"error":"Invalid ID"}`,
+		},
+		{
+			"EditActivity - invalid type",
+			"1",
+			nil,
+			http.StatusBadRequest,
+			`{"type":"lunch","date":"2024-05-01T10:00:00Z","platformId":2}`,
+			`{"error":"invalid activity type, use call, meeting, email or other"}`,
+		},
+		{
+			"EditActivity - activity not found",
+			"1",
+			func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT a.(.+) FROM activities a").WithArgs(1).WillReturnRows(sqlmock.NewRows(activityColumns))
+			},
+			http.StatusNotFound,
+			`{"type":"call","date":"2024-05-01T10:00:00Z","platformId":2}`,
+			`{}`,
+		},
+		{
+			"EditActivity - sql error on UpdateActivity",
+			"1",
+			func(mock sqlmock.Sqlmock) {
+				expectExisting(mock)
+				mock.ExpectBegin()
+				mock.ExpectExec("UPDATE activities").WillReturnError(errors.New("test"))
+				mock.ExpectRollback()
+			},
+			http.StatusInternalServerError,
+			`{"type":"call","date":"2024-05-01T10:00:00Z","platformId":2}`,
+			`{}`,
+		},
+		{
+			"EditActivity - unknown platform",
+			"1",
+			func(mock sqlmock.Sqlmock) {
+				expectExisting(mock)
+				mock.ExpectBegin()
+				mock.ExpectExec("UPDATE activities").WillReturnError(&mysql.MySQLError{Number: 1452, Message: "Cannot add or update a child row: a foreign key constraint fails"})
+				mock.ExpectRollback()
+			},
+			http.StatusBadRequest,
+			`{"type":"call","date":"2024-05-01T10:00:00Z","platformId":9}`,
+			`{"error":"Linked platform, project, contact or user not found"}`,
+		},
+		{
+			"EditActivity - Valid Request",
+			"1",
+			func(mock sqlmock.Sqlmock) {
+				expectExisting(mock)
+				mock.ExpectBegin()
+				mock.ExpectExec("UPDATE activities").WithArgs("meeting", date, "Follow up", 2, nil, 1).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("DELETE FROM activities_contacts").WithArgs(1).WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectExec("DELETE FROM activities_users").WithArgs(1).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("INSERT IGNORE INTO activities_contacts").WithArgs(1, 3).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("INSERT IGNORE INTO activities_users").WithArgs(1, 1).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+			http.StatusOK,
+			`{"type":"meeting","date":"2024-05-01T10:00:00Z","summary":"Follow up","platformId":2,"contacts":[3]}`,
+			`{"message":"Activity updated successfully"}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			// Initilize test router, environemnt and mock database
+			r, mockDb, mockSql, env, err := utils.SetupTestEnvironment(test.MockDbCall)
+			// Close the mock database at the end of the test
+			defer mockDb.Close()
+
+			// Check for errors during setup
+			require.NoError(t, err)
+
+			// Register handler
+			r.PUT("/api/v1/activities/:activityId", EditActivity(env))
+
+			// Create httptest request
+			req, _ := http.NewRequest("PUT", fmt.Sprintf("/api/v1/activities/%s", test.IdString), strings.NewReader(test.Body))
+			w := httptest.NewRecorder()
+
+			// Mock request
+			r.ServeHTTP(w, req)
+
+			// Read response data
+			responseData, _ := io.ReadAll(w.Body)
+
+			// Check response status
+			require.Equal(t, test.StatusCode, w.Code)
+
+			// Handle empty responses
+			response := string(responseData)
+			if response == "" {
+				response = "{}"
+			}
+
+			// Check response body
+			require.JSONEq(t, test.Response, response)
+
+			// Check for any remaining expectations
+			// we make sure that all expectations were met
+			if err := mockSql.ExpectationsWereMet(); err != nil {
+				t.Fatalf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
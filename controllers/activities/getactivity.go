@@ -0,0 +1,37 @@
+package activities
+
+import (
+	"database/sql"
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/webstradev/rsdb-backend/utils"
+)
+
+func GetActivity(env *utils.Environment) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		idString := c.Param("activityId")
+		id, err := strconv.ParseInt(idString, 10, 64)
+		if err != nil {
+			log.Println(err)
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Invalid ID"})
+			return
+		}
+
+		activity, err := env.DB.GetActivity(id)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				c.AbortWithStatus(http.StatusNotFound)
+				return
+			}
+			log.Println(err)
+			c.AbortWithStatus(http.StatusInternalServerError)
+			return
+		}
+
+		c.JSON(http.StatusOK, activity)
+	}
+}
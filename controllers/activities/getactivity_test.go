@@ -0,0 +1,123 @@
+package activities
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/require"
+	"github.com/webstradev/rsdb-backend/utils"
+)
+
+// Columns of the activity query
+var activityColumns = []string{"id", "type", "date", "summary", "platform_id", "project_id", "created_by", "platform_name", "project_title"}
+
+func TestGetActivity(t *testing.T) {
+	date := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		Name       string
+		IdString   string
+		MockDbCall func(sqlmock.Sqlmock)
+		StatusCode int
+		Response   string
+	}{
+		{
+			"GetActivity - non int id",
+			"notanint",
+			nil,
+			http.StatusBadRequest,
+			`{"error":"Invalid ID"}`,
+		},
+		{
+			"GetActivity - activity not found",
+			"1",
+			func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT a.(.+) FROM activities a").WithArgs(1).WillReturnRows(sqlmock.NewRows(activityColumns))
+			},
+			http.StatusNotFound,
+			`{}`,
+		},
+		{
+			"GetActivity - sql error on participants",
+			"1",
+			func(mock sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows(activityColumns).AddRow(1, "call", date, "Intro", 2, nil, 1, "platform", nil)
+				mock.ExpectQuery("SELECT a.(.+) FROM activities a").WithArgs(1).WillReturnRows(rows)
+				mock.ExpectQuery("SELECT ac.activity_id(.+) FROM activities_contacts").WithArgs(1).WillReturnError(errors.New("test"))
+			},
+			http.StatusInternalServerError,
+			`{}`,
+		},
+		{
+			"GetActivity - Valid Request",
+			"1",
+			func(mock sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows(activityColumns).AddRow(1, "call", date, "Intro", 2, 4, 1, "platform", "project")
+				mock.ExpectQuery("SELECT a.(.+) FROM activities a").WithArgs(1).WillReturnRows(rows)
+
+				rows = sqlmock.NewRows([]string{"activity_id", "contact_id", "name", "platform_id"}).AddRow(1, 3, "Jane Doe", 2)
+				mock.ExpectQuery("SELECT ac.activity_id(.+) FROM activities_contacts").WithArgs(1).WillReturnRows(rows)
+
+				rows = sqlmock.NewRows([]string{"activity_id", "user_id", "email"}).AddRow(1, 1, "user@example.com")
+				mock.ExpectQuery("SELECT au.activity_id(.+) FROM activities_users").WithArgs(1).WillReturnRows(rows)
+			},
+			http.StatusOK,
+			`{"id":1,"createdAt":"0001-01-01T00:00:00Z","modifiedAt":"0001-01-01T00:00:00Z","deletedAt":{"Time":"0001-01-01T00:00:00Z","Valid":false},
+				"type":"call","date":"2024-05-01T10:00:00Z","summary":"Intro",
+				"platformId":{"Int64":2,"Valid":true},"platformName":{"String":"platform","Valid":true},
+				"projectId":{"Int64":4,"Valid":true},"projectTitle":{"String":"project","Valid":true},
+				"createdBy":{"Int64":1,"Valid":true},
+				"contacts":[{"contactId":3,"name":"Jane Doe","platformId":2}],
+				"users":[{"userId":1,"email":"user@example.com"}]}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			// Initilize test router, environemnt and mock database
+			r, mockDb, mockSql, env, err := utils.SetupTestEnvironment(test.MockDbCall)
+			// Close the mock database at the end of the test
+			defer mockDb.Close()
+
+			// Check for errors during setup
+			require.NoError(t, err)
+
+			// Register handler
+			r.GET("/api/v1/activities/:activityId", GetActivity(env))
+
+			// Create httptest request
+			req, _ := http.NewRequest("GET", fmt.Sprintf("/api/v1/activities/%s", test.IdString), nil)
+			w := httptest.NewRecorder()
+
+			// Mock request
+			r.ServeHTTP(w, req)
+
+			// Read response data
+			responseData, _ := io.ReadAll(w.Body)
+
+			// Check response status
+			require.Equal(t, test.StatusCode, w.Code)
+
+			// Handle empty responses
+			response := string(responseData)
+			if response == "" {
+				response = "{}"
+			}
+
+			// Check response body
+			require.JSONEq(t, test.Response, response)
+
+			// Check for any remaining expectations
+			// we make sure that all expectations were met
+			if err := mockSql.ExpectationsWereMet(); err != nil {
+				t.Fatalf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
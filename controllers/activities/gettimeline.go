@@ -0,0 +1,54 @@
+package activities
+
+import (
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/webstradev/rsdb-backend/utils"
+)
+
+// GetContactActivities lists the activities with a contact, the latest first
+func GetContactActivities(env *utils.Environment) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		idString := c.Param("contactId")
+		id, err := strconv.ParseInt(idString, 10, 64)
+		if err != nil {
+			log.Println(err)
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Invalid ID"})
+			return
+		}
+
+		activities, err := env.DB.GetContactActivities(id)
+		if err != nil {
+			log.Println(err)
+			c.AbortWithStatus(http.StatusInternalServerError)
+			return
+		}
+
+		c.JSON(http.StatusOK, activities)
+	}
+}
+
+// GetPlatformActivities lists the activities with a platform and its contacts, the latest first
+func GetPlatformActivities(env *utils.Environment) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		idString := c.Param("platformId")
+		id, err := strconv.ParseInt(idString, 10, 64)
+		if err != nil {
+			log.Println(err)
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Invalid ID"})
+			return
+		}
+
+		activities, err := env.DB.GetPlatformActivities(id)
+		if err != nil {
+			log.Println(err)
+			c.AbortWithStatus(http.StatusInternalServerError)
+			return
+		}
+
+		c.JSON(http.StatusOK, activities)
+	}
+}
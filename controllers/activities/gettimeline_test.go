@@ -0,0 +1,136 @@
+package activities
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/require"
+	"github.com/webstradev/rsdb-backend/utils"
+)
+
+func TestGetTimeline(t *testing.T) {
+	date := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		Name       string
+		Path       string
+		MockDbCall func(sqlmock.Sqlmock)
+		StatusCode int
+		Response   string
+	}{
+		{
+			"GetContactActivities - non int id",
+			"/api/v1/contacts/notanint/activities",
+			nil,
+			http.StatusBadRequest,
+			`{"error":"Invalid ID"}`,
+		},
+		{
+			"GetContactActivities - sql error",
+			"/api/v1/contacts/3/activities",
+			func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT a.(.+) FROM activities a").WithArgs(3).WillReturnError(errors.New("test"))
+			},
+			http.StatusInternalServerError,
+			`{}`,
+		},
+		{
+			"GetContactActivities - no activities",
+			"/api/v1/contacts/3/activities",
+			func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT a.(.+) FROM activities a").WithArgs(3).WillReturnRows(sqlmock.NewRows(activityColumns))
+			},
+			http.StatusOK,
+			`[]`,
+		},
+		{
+			"GetPlatformActivities - non int id",
+			"/api/v1/platforms/notanint/activities",
+			nil,
+			http.StatusBadRequest,
+			`{"error":"Invalid ID"}`,
+		},
+		{
+			"GetPlatformActivities - Valid Request",
+			"/api/v1/platforms/2/activities",
+			func(mock sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows(activityColumns).
+					AddRow(2, "email", date, "Sent the press kit", nil, nil, 1, nil, nil).
+					AddRow(1, "call", date.Add(-time.Hour), "Intro", 2, nil, 1, "platform", nil)
+				mock.ExpectQuery("SELECT a.(.+) FROM activities a").WithArgs(2, 2).WillReturnRows(rows)
+
+				rows = sqlmock.NewRows([]string{"activity_id", "contact_id", "name", "platform_id"}).AddRow(2, 3, "Jane Doe", 2)
+				mock.ExpectQuery("SELECT ac.activity_id(.+) FROM activities_contacts").WithArgs(2, 1).WillReturnRows(rows)
+
+				rows = sqlmock.NewRows([]string{"activity_id", "user_id", "email"}).AddRow(2, 1, "user@example.com").AddRow(1, 1, "user@example.com")
+				mock.ExpectQuery("SELECT au.activity_id(.+) FROM activities_users").WithArgs(2, 1).WillReturnRows(rows)
+			},
+			http.StatusOK,
+			`[
+				{"id":2,"createdAt":"0001-01-01T00:00:00Z","modifiedAt":"0001-01-01T00:00:00Z","deletedAt":{"Time":"0001-01-01T00:00:00Z","Valid":false},
+				"type":"email","date":"2024-05-01T10:00:00Z","summary":"Sent the press kit",
+				"platformId":{"Int64":0,"Valid":false},"platformName":{"String":"","Valid":false},
+				"projectId":{"Int64":0,"Valid":false},"projectTitle":{"String":"","Valid":false},
+				"createdBy":{"Int64":1,"Valid":true},
+				"contacts":[{"contactId":3,"name":"Jane Doe","platformId":2}],
+				"users":[{"userId":1,"email":"user@example.com"}]},
+				{"id":1,"createdAt":"0001-01-01T00:00:00Z","modifiedAt":"0001-01-01T00:00:00Z","deletedAt":{"Time":"0001-01-01T00:00:00Z","Valid":false},
+				"type":"call","date":"2024-05-01T09:00:00Z","summary":"Intro",
+				"platformId":{"Int64":2,"Valid":true},"platformName":{"String":"platform","Valid":true},
+				"projectId":{"Int64":0,"Valid":false},"projectTitle":{"String":"","Valid":false},
+				"createdBy":{"Int64":1,"Valid":true},
+				"contacts":[],
+				"users":[{"userId":1,"email":"user@example.com"}]}
+			]`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			// Initilize test router, environemnt and mock database
+			r, mockDb, mockSql, env, err := utils.SetupTestEnvironment(test.MockDbCall)
+			// Close the mock database at the end of the test
+			defer mockDb.Close()
+
+			// Check for errors during setup
+			require.NoError(t, err)
+
+			// Register handlers
+			r.GET("/api/v1/contacts/:contactId/activities", GetContactActivities(env))
+			r.GET("/api/v1/platforms/:platformId/activities", GetPlatformActivities(env))
+
+			// Create httptest request
+			req, _ := http.NewRequest("GET", test.Path, nil)
+			w := httptest.NewRecorder()
+
+			// Mock request
+			r.ServeHTTP(w, req)
+
+			// Read response data
+			responseData, _ := io.ReadAll(w.Body)
+
+			// Check response status
+			require.Equal(t, test.StatusCode, w.Code)
+
+			// Handle empty responses
+			response := string(responseData)
+			if response == "" {
+				response = "{}"
+			}
+
+			// Check response body
+			require.JSONEq(t, test.Response, response)
+
+			// Check for any remaining expectations
+			// we make sure that all expectations were met
+			if err := mockSql.ExpectationsWereMet(); err != nil {
+				t.Fatalf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
@@ -1,11 +1,16 @@
 package articles
 
 import (
+	"database/sql"
+	"errors"
 	"log"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
+	"github.com/webstradev/rsdb-backend/auth"
 	"github.com/webstradev/rsdb-backend/db"
+	"github.com/webstradev/rsdb-backend/enrich"
+	"github.com/webstradev/rsdb-backend/richtext"
 	"github.com/webstradev/rsdb-backend/utils"
 )
 
@@ -13,12 +18,20 @@ type createArticleInput struct {
 	db.Article      `json:"article" binding:"required"`
 	Tags            []int64 `json:"tags"`
 	LinkedPlatforms []int64 `json:"linkedPlatforms"`
+	LinkedProjects  []int64 `json:"linkedProjects"`
 }
 
 func CreateArticle(env *utils.Environment) gin.HandlerFunc {
 	return func(c *gin.Context) {
+		user, err := auth.GetUserFromContext(c)
+		if err != nil {
+			log.Println(err)
+			c.AbortWithStatus(http.StatusInternalServerError)
+			return
+		}
+
 		input := createArticleInput{}
-		err := c.ShouldBindJSON(&input)
+		err = c.ShouldBindJSON(&input)
 		if err != nil {
 			log.Println(err)
 			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
@@ -26,8 +39,13 @@ func CreateArticle(env *utils.Environment) gin.HandlerFunc {
 		}
 
 		// Creat article
+		input.Article.CreatedBy = sql.NullInt64{Int64: user.UserID, Valid: true}
 		articleid, err := env.DB.InsertArticle(input.Article)
 		if err != nil {
+			if errors.Is(err, richtext.ErrInvalidFormat) {
+				c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+				return
+			}
 			log.Println(err)
 			c.AbortWithStatus(http.StatusInternalServerError)
 			return
@@ -41,6 +59,14 @@ func CreateArticle(env *utils.Environment) gin.HandlerFunc {
 			return
 		}
 
+		// Create articles_projects to link the projects the article writes about
+		err = env.DB.InsertArticleProjects(articleid, input.LinkedProjects)
+		if err != nil {
+			log.Println(err)
+			c.AbortWithStatus(http.StatusInternalServerError)
+			return
+		}
+
 		// Create article_tags to link tags to article
 		err = env.DB.InsertArticleTags(articleid, input.Tags)
 		if err != nil {
@@ -49,6 +75,18 @@ func CreateArticle(env *utils.Environment) gin.HandlerFunc {
 			return
 		}
 
+		// Fill the fields that were left empty from the linked page in the background
+		input.Article.ID = articleid
+		if env.Enricher != nil && enrich.NeedsEnrichment(input.Article) {
+			env.Enricher.Enqueue(input.Article)
+		}
+
+		// Let open frontends and the subscribed webhooks know the article was created
+		err = env.Changes.Publish(db.ARTICLE_ENTITY, db.CHANGE_CREATED, articleid, user.UserID)
+		if err != nil {
+			log.Println(err)
+		}
+
 		c.JSON(http.StatusOK, gin.H{"message": "Article created successfully"})
 	}
 }
@@ -9,7 +9,9 @@ import (
 	"testing"
 
 	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/require"
+	"github.com/webstradev/rsdb-backend/auth"
 	"github.com/webstradev/rsdb-backend/utils"
 )
 
@@ -68,6 +70,29 @@ func TestCreateArticle(t *testing.T) {
 			`{"article":{"title":"test","description":"test","link":"test","date":{"Time":"2023-03-05T00:00:00Z","Valid":true},"body":"test"},"linkedPlatforms":[1,2,3],"tags":[1,2]}`,
 			`{"message":"Article created successfully"}`,
 		},
+		{
+			"CreateArticle - sql error on InsertArticleProjects",
+			func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec("INSERT INTO articles").WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec("INSERT INTO platforms_articles").WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec("INSERT INTO articles_projects").WillReturnError(errors.New("test"))
+			},
+			http.StatusInternalServerError,
+			`{"article":{"title":"test","description":"test","link":"test","date":{"Time":"2023-03-05T00:00:00Z","Valid":true},"body":"test"},"linkedPlatforms":[1],"linkedProjects":[4]}`,
+			`{}`,
+		},
+		{
+			"CreateArticle - Valid Request with projects",
+			func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec("INSERT INTO articles").WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec("INSERT INTO platforms_articles").WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec("INSERT INTO articles_projects").WithArgs(4, 1, 5, 1).WillReturnResult(sqlmock.NewResult(1, 2))
+				mock.ExpectExec("INSERT INTO articles_tags").WillReturnResult(sqlmock.NewResult(1, 1))
+			},
+			http.StatusOK,
+			`{"article":{"title":"test","description":"test","link":"test","date":{"Time":"2023-03-05T00:00:00Z","Valid":true},"body":"test"},"linkedPlatforms":[1],"linkedProjects":[4,5],"tags":[1]}`,
+			`{"message":"Article created successfully"}`,
+		},
 	}
 
 	for _, test := range tests {
@@ -81,7 +106,10 @@ func TestCreateArticle(t *testing.T) {
 			require.NoError(t, err)
 
 			// Register handler
-			r.POST("/api/v1/articles", CreateArticle(env))
+			r.POST("/api/v1/articles", func(c *gin.Context) {
+				c.Set("user", auth.TokenData{UserID: 1})
+				CreateArticle(env)(c)
+			})
 
 			// Create httptest request
 			req, _ := http.NewRequest("POST", "/api/v1/articles", strings.NewReader(test.Body))
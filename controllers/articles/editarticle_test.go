@@ -10,7 +10,9 @@ import (
 	"testing"
 
 	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/require"
+	"github.com/webstradev/rsdb-backend/auth"
 	"github.com/webstradev/rsdb-backend/utils"
 )
 
@@ -18,14 +20,25 @@ func TestEditArticle(t *testing.T) {
 	tests := []struct {
 		Name       string
 		IdString   string
+		User       auth.TokenData
 		MockDbCall func(sqlmock.Sqlmock)
 		StatusCode int
 		Body       string
 		Response   string
 	}{
+		{
+			"EditArticle - User Missing from Context",
+			"1",
+			auth.TokenData{},
+			nil,
+			http.StatusInternalServerError,
+			`{}`,
+			`{}`,
+		},
 		{
 			"EditArticle - non int id",
 			"notanint",
+			auth.TokenData{UserID: 1},
 			nil,
 			http.StatusBadRequest,
 			`{}`,
@@ -34,16 +47,62 @@ func TestEditArticle(t *testing.T) {
 		{
 			"EditArticle - Bad json body",
 			"1",
+			auth.TokenData{UserID: 1},
 			nil,
 			http.StatusBadRequest,
 			`{badbody}`,
 			`{"error": "invalid character 'b' looking for beginning of object key string"}`,
 		},
+		{
+			"EditArticle - invalid body format",
+			"1",
+			auth.TokenData{UserID: 1},
+			nil,
+			http.StatusBadRequest,
+			`{"title":"test","body":"test","bodyFormat":"rtf"}`,
+			`{"error":"invalid body format, use markdown or html"}`,
+		},
+		{
+			"EditArticle - article not found",
+			"1",
+			auth.TokenData{UserID: 1},
+			func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT a.(.+) FROM articles").WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"id"}))
+				mock.ExpectRollback()
+			},
+			http.StatusNotFound,
+			`{"title":"test","description":"test","link":"test","date":{"Time":"2023-03-05T00:00:00Z","Valid":true},"body":"test"}`,
+			`{}`,
+		},
+		{
+			"EditArticle - sql error on revision insert",
+			"1",
+			auth.TokenData{UserID: 1},
+			func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT a.(.+) FROM articles").WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"id", "title"}).AddRow(1, "old"))
+				mock.ExpectQuery("SELECT (.+) FROM tags").WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"article_id", "tag_id", "tag"}))
+				mock.ExpectQuery("SELECT (.+) FROM platforms").WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"article_id", "platform_id", "platform_name"}))
+				mock.ExpectQuery("SELECT (.+) FROM projects").WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"article_id", "project_id", "project_title"}))
+				mock.ExpectExec("INSERT INTO revisions").WillReturnError(errors.New("test"))
+				mock.ExpectRollback()
+			},
+			http.StatusInternalServerError,
+			`{"title":"test","description":"test","link":"test","date":{"Time":"2023-03-05T00:00:00Z","Valid":true},"body":"test"}`,
+			`{}`,
+		},
 		{
 			"EditArticle - sql error on EditArticle transaction",
 			"1",
+			auth.TokenData{UserID: 1},
 			func(mock sqlmock.Sqlmock) {
 				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT a.(.+) FROM articles").WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"id", "title"}).AddRow(1, "old"))
+				mock.ExpectQuery("SELECT (.+) FROM tags").WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"article_id", "tag_id", "tag"}))
+				mock.ExpectQuery("SELECT (.+) FROM platforms").WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"article_id", "platform_id", "platform_name"}))
+				mock.ExpectQuery("SELECT (.+) FROM projects").WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"article_id", "project_id", "project_title"}))
+				mock.ExpectExec("INSERT INTO revisions").WithArgs("article", 1, 1, sqlmock.AnyArg(), "article", 1).WillReturnResult(sqlmock.NewResult(1, 1))
 				mock.ExpectExec("UPDATE articles SET").WillReturnError(errors.New("test"))
 				mock.ExpectRollback()
 			},
@@ -51,20 +110,48 @@ func TestEditArticle(t *testing.T) {
 			`{"title":"test","description":"test","link":"test","date":{"Time":"2023-03-05T00:00:00Z","Valid":true},"body":"test"}`,
 			`{}`,
 		},
+		{
+			"EditArticle - Valid Request keeping the project links",
+			"1",
+			auth.TokenData{UserID: 1},
+			func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT a.(.+) FROM articles").WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"id", "title"}).AddRow(1, "old"))
+				mock.ExpectQuery("SELECT (.+) FROM tags").WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"article_id", "tag_id", "tag"}))
+				mock.ExpectQuery("SELECT (.+) FROM platforms").WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"article_id", "platform_id", "platform_name"}))
+				mock.ExpectQuery("SELECT (.+) FROM projects").WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"article_id", "project_id", "project_title"}).AddRow(1, 2, "The Movie"))
+				mock.ExpectExec("INSERT INTO revisions").WithArgs("article", 1, 1, sqlmock.AnyArg(), "article", 1).WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec("UPDATE articles SET").WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec("DELETE FROM articles_tags").WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec("DELETE FROM platforms_articles").WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectCommit()
+			},
+			http.StatusOK,
+			`{"title":"test","description":"test","link":"test","date":{"Time":"2023-03-05T00:00:00Z","Valid":true},"body":"test"}`,
+			`{}`,
+		},
 		{
 			"EditArticle - Valid Request",
 			"1",
+			auth.TokenData{UserID: 1},
 			func(mock sqlmock.Sqlmock) {
 				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT a.(.+) FROM articles").WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"id", "title"}).AddRow(1, "old"))
+				mock.ExpectQuery("SELECT (.+) FROM tags").WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"article_id", "tag_id", "tag"}).AddRow(1, 2, "old"))
+				mock.ExpectQuery("SELECT (.+) FROM platforms").WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"article_id", "platform_id", "platform_name"}))
+				mock.ExpectQuery("SELECT (.+) FROM projects").WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"article_id", "project_id", "project_title"}))
+				mock.ExpectExec("INSERT INTO revisions").WithArgs("article", 1, 1, sqlmock.AnyArg(), "article", 1).WillReturnResult(sqlmock.NewResult(1, 1))
 				mock.ExpectExec("UPDATE articles SET").WillReturnResult(sqlmock.NewResult(1, 1))
 				mock.ExpectExec("DELETE FROM articles_tags").WillReturnResult(sqlmock.NewResult(1, 1))
 				mock.ExpectExec("INSERT INTO articles_tags").WillReturnResult(sqlmock.NewResult(1, 1))
 				mock.ExpectExec("DELETE FROM platforms_articles").WillReturnResult(sqlmock.NewResult(1, 1))
 				mock.ExpectExec("INSERT INTO platforms_articles").WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec("DELETE FROM articles_projects").WithArgs(1).WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec("INSERT INTO articles_projects").WithArgs(2, 1).WillReturnResult(sqlmock.NewResult(1, 1))
 				mock.ExpectCommit()
 			},
 			http.StatusOK,
-			`{"title":"test","description":"test","link":"test","date":{"Time":"2023-03-05T00:00:00Z","Valid":true},"body":"test","tags":[{"id":1,"tag":"test"}],"platforms":[{"id":1,"platform":"test"}]}`,
+			`{"title":"test","description":"test","link":"test","date":{"Time":"2023-03-05T00:00:00Z","Valid":true},"body":"test","tags":[{"id":1,"tag":"test"}],"platforms":[{"id":1,"platform":"test"}],"projects":[{"id":2}]}`,
 			`{}`,
 		},
 	}
@@ -80,7 +167,15 @@ func TestEditArticle(t *testing.T) {
 			require.NoError(t, err)
 
 			// Register handler
-			r.PUT("/api/v1/articles/:articleId", EditArticle(env))
+			r.PUT("/api/v1/articles/:articleId", func(c *gin.Context) {
+				// Add user to context if it exists
+				if test.User.UserID != 0 {
+					c.Set("user", test.User)
+				}
+
+				// Call handler
+				EditArticle(env)(c)
+			})
 
 			// Create httptest request
 			req, _ := http.NewRequest("PUT", fmt.Sprintf("/api/v1/articles/%s", test.IdString), strings.NewReader(test.Body))
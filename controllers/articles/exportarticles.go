@@ -0,0 +1,27 @@
+package articles
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/webstradev/rsdb-backend/auth"
+	"github.com/webstradev/rsdb-backend/db"
+	"github.com/webstradev/rsdb-backend/utils"
+)
+
+// ExportArticles exports all articles with their tags and platforms, private platforms are only listed for admins
+func ExportArticles(env *utils.Environment) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		user, err := auth.GetUserFromContext(c)
+		if err != nil {
+			log.Println(err)
+			c.AbortWithStatus(http.StatusInternalServerError)
+			return
+		}
+
+		utils.StreamExport(c, "articles", db.ArticleExportColumns, func(write func([]string) error) error {
+			return env.DB.ExportArticles(user.IsAdmin(), write)
+		})
+	}
+}
@@ -47,6 +47,13 @@ func GetArticle(env *utils.Environment) gin.HandlerFunc {
 			return
 		}
 
+		err = article.PopulateProjects(env.DB)
+		if err != nil {
+			log.Println(err)
+			c.AbortWithStatus(http.StatusInternalServerError)
+			return
+		}
+
 		c.JSON(http.StatusOK, article)
 	}
 }
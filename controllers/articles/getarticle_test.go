@@ -84,6 +84,27 @@ func TestGetArticle(t *testing.T) {
 			http.StatusInternalServerError,
 			`{}`,
 		},
+		{
+			"GetArticle - sql error on GetProjectsForArticle",
+			"1",
+			func(mock sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows([]string{"id", "title", "description", "link", "date", "body"}).
+					AddRow(1, "test", "test", "test", sql.NullTime{Valid: true, Time: timestamp}, "test")
+				mock.ExpectQuery("SELECT a.(.+)").WithArgs(1).WillReturnRows(rows)
+
+				rows = sqlmock.NewRows([]string{"article_id", "tag_id", "tag"}).
+					AddRow(1, 1, "test")
+				mock.ExpectQuery("SELECT at.(.+)").WithArgs(1).WillReturnRows(rows)
+
+				rows = sqlmock.NewRows([]string{"article_id", "platform_id", "platform_name"}).
+					AddRow(1, 1, "test")
+				mock.ExpectQuery("SELECT pa.(.+)").WithArgs(1).WillReturnRows(rows)
+
+				mock.ExpectQuery("SELECT ap.(.+)").WithArgs(1).WillReturnError(errors.New("test"))
+			},
+			http.StatusInternalServerError,
+			`{}`,
+		},
 		{
 			"GetArticle - Valid Request",
 			"1",
@@ -99,9 +120,13 @@ func TestGetArticle(t *testing.T) {
 				rows = sqlmock.NewRows([]string{"article_id", "platform_id", "platform_name"}).
 					AddRow(1, 1, "test")
 				mock.ExpectQuery("SELECT pa.(.+)").WithArgs(1).WillReturnRows(rows)
+
+				rows = sqlmock.NewRows([]string{"article_id", "project_id", "project_title"}).
+					AddRow(1, 2, "test")
+				mock.ExpectQuery("SELECT ap.(.+)").WithArgs(1).WillReturnRows(rows)
 			},
 			http.StatusOK,
-			`{"id":1,"createdAt":"0001-01-01T00:00:00Z","modifiedAt":"0001-01-01T00:00:00Z","deletedAt":{"Time":"0001-01-01T00:00:00Z","Valid":false},"title":"test","description":"test","link":"test","date":{"Time":"2023-01-01T00:00:00Z","Valid":true},"body":"test","tags":[{"id":1,"tag":"test"}],"platforms":[{"id":1,"platform":"test"}]}`,
+			`{"id":1,"createdAt":"0001-01-01T00:00:00Z","modifiedAt":"0001-01-01T00:00:00Z","deletedAt":{"Time":"0001-01-01T00:00:00Z","Valid":false},"title":"test","description":"test","link":"test","date":{"Time":"2023-01-01T00:00:00Z","Valid":true},"image":"","body":"test","createdBy":{"Int64":0,"Valid":false},"bodyFormat":"","bodyHtml":"","bodyText":"","tags":[{"id":1,"tag":"test"}],"platforms":[{"id":1,"platform":"test"}],"projects":[{"id":2,"project":"test"}]}`,
 		},
 	}
 
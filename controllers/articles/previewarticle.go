@@ -0,0 +1,32 @@
+package articles
+
+import (
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/webstradev/rsdb-backend/enrich"
+	"github.com/webstradev/rsdb-backend/utils"
+)
+
+// PreviewArticle proposes the title, description, date and image of an article from the page at ?link=
+func PreviewArticle(env *utils.Environment) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		meta, err := env.Enricher.Preview(c.Request.Context(), c.Query("link"))
+		if err != nil {
+			// Errors of requests (e.g. to a redirect) include the address, only the reason is shown
+			for _, reason := range []error{enrich.ErrInvalidLink, enrich.ErrNotHTML, enrich.ErrForbiddenAddress} {
+				if errors.Is(err, reason) {
+					c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": reason.Error()})
+					return
+				}
+			}
+			log.Println(err)
+			c.AbortWithStatusJSON(http.StatusBadGateway, gin.H{"error": "Could not fetch the link"})
+			return
+		}
+
+		c.JSON(http.StatusOK, meta)
+	}
+}
@@ -0,0 +1,116 @@
+package articles
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/webstradev/rsdb-backend/enrich"
+	"github.com/webstradev/rsdb-backend/utils"
+)
+
+func TestPreviewArticle(t *testing.T) {
+	// Local pages to preview
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/review":
+			w.Header().Set("Content-Type", "text/html")
+			w.Write([]byte(`<html><head>
+				<meta property="og:title" content="The Movie reviewed">
+				<meta property="og:description" content="Four stars">
+				<meta property="og:image" content="/poster.jpg">
+				<meta property="article:published_time" content="2023-03-05T10:00:00Z">
+			</head></html>`))
+		case "/poster.jpg":
+			w.Header().Set("Content-Type", "image/jpeg")
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		Name       string
+		Link       string
+		StatusCode int
+		Response   string
+	}{
+		{
+			"PreviewArticle - missing link",
+			"",
+			http.StatusBadRequest,
+			`{"error":"invalid link, use an http or https URL"}`,
+		},
+		{
+			"PreviewArticle - not a page",
+			server.URL + "/poster.jpg",
+			http.StatusBadRequest,
+			`{"error":"the link does not point to an html page"}`,
+		},
+		{
+			"PreviewArticle - private address",
+			"http://169.254.169.254/latest/meta-data",
+			http.StatusBadRequest,
+			`{"error":"the link does not point to a public address"}`,
+		},
+		{
+			"PreviewArticle - page not found",
+			server.URL + "/missing",
+			http.StatusBadGateway,
+			`{"error":"Could not fetch the link"}`,
+		},
+		{
+			"PreviewArticle - Valid Request",
+			server.URL + "/review",
+			http.StatusOK,
+			`{"title":"The Movie reviewed","description":"Four stars","date":{"Time":"2023-03-05T00:00:00Z","Valid":true},"image":"` + server.URL + `/poster.jpg"}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			// Initilize test router, environemnt and mock database
+			r, mockDb, mockSql, env, err := utils.SetupTestEnvironment(nil)
+			// Close the mock database at the end of the test
+			defer mockDb.Close()
+
+			// Check for errors during setup
+			require.NoError(t, err)
+
+			// Fetch the pages from the local server
+			fetcher := enrich.NewHTTPFetcher(time.Second)
+			fetcher.Allowed = func(ip netip.Addr) bool { return ip.IsLoopback() }
+			env.Enricher = enrich.NewService(fetcher, env.DB)
+
+			// Register handler
+			r.GET("/api/v1/articles/preview", PreviewArticle(env))
+
+			// Create httptest request
+			req, _ := http.NewRequest("GET", "/api/v1/articles/preview?link="+url.QueryEscape(test.Link), nil)
+			w := httptest.NewRecorder()
+
+			// Mock request
+			r.ServeHTTP(w, req)
+
+			// Read response data
+			responseData, _ := io.ReadAll(w.Body)
+
+			// Check response status
+			require.Equal(t, test.StatusCode, w.Code)
+
+			// Check response body
+			require.JSONEq(t, test.Response, string(responseData))
+
+			// Check for any remaining expectations
+			// we make sure that all expectations were met
+			if err := mockSql.ExpectationsWereMet(); err != nil {
+				t.Fatalf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,145 @@
+package attachments
+
+import (
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"io"
+	"log"
+	"mime"
+	"net/http"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/webstradev/rsdb-backend/auth"
+	"github.com/webstradev/rsdb-backend/db"
+	"github.com/webstradev/rsdb-backend/utils"
+)
+
+// Largest file that can be attached
+const MAX_ATTACHMENT_SIZE = 25 << 20
+
+// Time uploads and downloads may take, the server timeouts are too short for large files
+const TRANSFER_TIMEOUT = 5 * time.Minute
+
+// CreateAttachment stores the file of the multipart form and attaches it to the record of the given type,
+// with its ID in the idParam URL parameter
+func CreateAttachment(env *utils.Environment, entityType, idParam string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		user, err := auth.GetUserFromContext(c)
+		if err != nil {
+			log.Println(err)
+			c.AbortWithStatus(http.StatusInternalServerError)
+			return
+		}
+
+		id, err := strconv.ParseInt(c.Param(idParam), 10, 64)
+		if err != nil {
+			log.Println(err)
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Invalid ID"})
+			return
+		}
+
+		if !utils.CheckRecordAccess(c, env, user, entityType, id) {
+			return
+		}
+
+		// The write timeout of the server runs from the start of the request, so it is extended as well to keep the
+		// response of a slow upload
+		rc := http.NewResponseController(c.Writer)
+		rc.SetReadDeadline(time.Now().Add(TRANSFER_TIMEOUT))
+		rc.SetWriteDeadline(time.Now().Add(TRANSFER_TIMEOUT))
+
+		// Leave room for the rest of the multipart form
+		c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, MAX_ATTACHMENT_SIZE+1<<20)
+
+		header, err := c.FormFile("file")
+		if err != nil {
+			var tooLarge *http.MaxBytesError
+			if errors.As(err, &tooLarge) {
+				c.AbortWithStatusJSON(http.StatusRequestEntityTooLarge, gin.H{"error": "File too large"})
+				return
+			}
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Missing file"})
+			return
+		}
+
+		if header.Size > MAX_ATTACHMENT_SIZE {
+			c.AbortWithStatusJSON(http.StatusRequestEntityTooLarge, gin.H{"error": "File too large"})
+			return
+		}
+
+		file, err := header.Open()
+		if err != nil {
+			log.Println(err)
+			c.AbortWithStatus(http.StatusInternalServerError)
+			return
+		}
+		defer file.Close()
+
+		attachment := db.Attachment{
+			EntityType:  entityType,
+			EntityId:    id,
+			Filename:    filename(header.Filename),
+			ContentType: header.Header.Get("Content-Type"),
+			Size:        header.Size,
+			StorageKey:  "attachments/" + env.UUID.Generate(),
+			CreatedBy:   sql.NullInt64{Int64: user.UserID, Valid: true},
+		}
+
+		// Browsers send application/octet-stream for types they do not know, look at the file instead
+		if mediaType, _, err := mime.ParseMediaType(attachment.ContentType); err != nil || mediaType == "application/octet-stream" {
+			head := make([]byte, 512)
+			n, _ := io.ReadFull(file, head)
+			attachment.ContentType = http.DetectContentType(head[:n])
+
+			_, err = file.Seek(0, io.SeekStart)
+			if err != nil {
+				log.Println(err)
+				c.AbortWithStatus(http.StatusInternalServerError)
+				return
+			}
+		}
+
+		hash := sha256.New()
+		err = env.Storage.Put(c.Request.Context(), attachment.StorageKey, io.TeeReader(file, hash), attachment.Size, attachment.ContentType)
+		if err != nil {
+			log.Println(err)
+			c.AbortWithStatus(http.StatusInternalServerError)
+			return
+		}
+		attachment.Checksum = hex.EncodeToString(hash.Sum(nil))
+
+		attachmentId, err := env.DB.InsertAttachment(attachment)
+		if err != nil {
+			log.Println(err)
+			// Do not leave a file behind that nothing refers to
+			if err := env.Storage.Delete(c.Request.Context(), attachment.StorageKey); err != nil {
+				log.Println(err)
+			}
+			c.AbortWithStatus(http.StatusInternalServerError)
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"id": attachmentId, "checksum": attachment.Checksum, "message": "Attachment created successfully"})
+	}
+}
+
+// filename strips the path some browsers send along and limits the length of the name of an uploaded file
+func filename(name string) string {
+	name = filepath.Base(strings.ReplaceAll(name, "\\", "/"))
+	if name == "." || name == "/" {
+		return "file"
+	}
+
+	// Keep the end of long names, it has the extension
+	if runes := []rune(name); len(runes) > 255 {
+		name = string(runes[len(runes)-255:])
+	}
+
+	return name
+}
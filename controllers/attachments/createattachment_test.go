@@ -0,0 +1,245 @@
+package attachments
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"net/textproto"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+	"github.com/webstradev/rsdb-backend/auth"
+	"github.com/webstradev/rsdb-backend/db"
+	"github.com/webstradev/rsdb-backend/mocks"
+	"github.com/webstradev/rsdb-backend/storage"
+	"github.com/webstradev/rsdb-backend/utils"
+)
+
+var (
+	admin = auth.TokenData{UserID: 1, Role: auth.AdminRole}
+	user  = auth.TokenData{UserID: 2, Role: auth.UserRole}
+)
+
+// multipartBody creates a form with a file field, without a file when filename is empty
+func multipartBody(filename, contentType string, content []byte) (*bytes.Buffer, string) {
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+
+	if filename != "" {
+		header := textproto.MIMEHeader{}
+		header.Set("Content-Disposition", fmt.Sprintf(`form-data; name="file"; filename="%s"`, filename))
+		header.Set("Content-Type", contentType)
+		part, _ := writer.CreatePart(header)
+		part.Write(content)
+	}
+
+	writer.Close()
+	return body, writer.FormDataContentType()
+}
+
+func TestCreateAttachment(t *testing.T) {
+	deck := []byte("%PDF-1.4 pitch deck")
+	deckChecksum := "ced2f6b4351fd10a268bc9b08ea3656b3723cc97a6c13ff7bf5da27d1ed6931a"
+
+	tests := []struct {
+		Name        string
+		IdString    string
+		User        auth.TokenData
+		Filename    string
+		ContentType string
+		Content     []byte
+		MockDbCall  func(sqlmock.Sqlmock)
+		StatusCode  int
+		Response    string
+		Stored      bool
+	}{
+		{
+			"CreateAttachment - non int id",
+			"notanint",
+			user,
+			"deck.pdf",
+			"application/pdf",
+			deck,
+			nil,
+			http.StatusBadRequest,
+			`{"error":"Invalid ID"}`,
+			false,
+		},
+		{
+			"CreateAttachment - platform not found",
+			"1",
+			user,
+			"deck.pdf",
+			"application/pdf",
+			deck,
+			func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT privacy = (.+) FROM platforms").WithArgs(db.PRIVACY_PRIVATE, 1).WillReturnRows(sqlmock.NewRows([]string{"private"}))
+			},
+			http.StatusNotFound,
+			`{"error":"Record not found"}`,
+			false,
+		},
+		{
+			"CreateAttachment - private platform",
+			"1",
+			user,
+			"deck.pdf",
+			"application/pdf",
+			deck,
+			func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT privacy = (.+) FROM platforms").WithArgs(db.PRIVACY_PRIVATE, 1).WillReturnRows(sqlmock.NewRows([]string{"private"}).AddRow(true))
+			},
+			http.StatusForbidden,
+			`{}`,
+			false,
+		},
+		{
+			"CreateAttachment - missing file",
+			"1",
+			user,
+			"",
+			"",
+			nil,
+			func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT privacy = (.+) FROM platforms").WithArgs(db.PRIVACY_PRIVATE, 1).WillReturnRows(sqlmock.NewRows([]string{"private"}).AddRow(false))
+			},
+			http.StatusBadRequest,
+			`{"error":"Missing file"}`,
+			false,
+		},
+		{
+			"CreateAttachment - file too large",
+			"1",
+			user,
+			"film.mov",
+			"video/quicktime",
+			make([]byte, MAX_ATTACHMENT_SIZE+1),
+			func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT privacy = (.+) FROM platforms").WithArgs(db.PRIVACY_PRIVATE, 1).WillReturnRows(sqlmock.NewRows([]string{"private"}).AddRow(false))
+			},
+			http.StatusRequestEntityTooLarge,
+			`{"error":"File too large"}`,
+			false,
+		},
+		{
+			"CreateAttachment - sql error on InsertAttachment",
+			"1",
+			user,
+			"deck.pdf",
+			"application/pdf",
+			deck,
+			func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT privacy = (.+) FROM platforms").WithArgs(db.PRIVACY_PRIVATE, 1).WillReturnRows(sqlmock.NewRows([]string{"private"}).AddRow(false))
+				mock.ExpectExec("INSERT INTO attachments").WillReturnError(errors.New("test"))
+			},
+			http.StatusInternalServerError,
+			`{}`,
+			false,
+		},
+		{
+			"CreateAttachment - Valid Request",
+			"1",
+			user,
+			"C:\\Users\\jane\\deck.pdf",
+			"application/octet-stream",
+			deck,
+			func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT privacy = (.+) FROM platforms").WithArgs(db.PRIVACY_PRIVATE, 1).WillReturnRows(sqlmock.NewRows([]string{"private"}).AddRow(false))
+				mock.ExpectExec("INSERT INTO attachments").
+					WithArgs(db.PLATFORM_ENTITY, 1, "deck.pdf", "application/pdf", len(deck), deckChecksum, "attachments/mock-uuid", 2).
+					WillReturnResult(sqlmock.NewResult(5, 1))
+			},
+			http.StatusOK,
+			`{"id":5,"checksum":"` + deckChecksum + `","message":"Attachment created successfully"}`,
+			true,
+		},
+		{
+			"CreateAttachment - Valid Request on private platform as admin",
+			"1",
+			admin,
+			"contract.docx",
+			"application/vnd.openxmlformats-officedocument.wordprocessingml.document",
+			deck,
+			func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT privacy = (.+) FROM platforms").WithArgs(db.PRIVACY_PRIVATE, 1).WillReturnRows(sqlmock.NewRows([]string{"private"}).AddRow(true))
+				mock.ExpectExec("INSERT INTO attachments").
+					WithArgs(db.PLATFORM_ENTITY, 1, "contract.docx", "application/vnd.openxmlformats-officedocument.wordprocessingml.document", len(deck), deckChecksum, "attachments/mock-uuid", 1).
+					WillReturnResult(sqlmock.NewResult(6, 1))
+			},
+			http.StatusOK,
+			`{"id":6,"checksum":"` + deckChecksum + `","message":"Attachment created successfully"}`,
+			true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			// Initilize test router, environemnt and mock database
+			r, mockDb, mockSql, env, err := utils.SetupTestEnvironment(test.MockDbCall)
+			// Close the mock database at the end of the test
+			defer mockDb.Close()
+
+			// Check for errors during setup
+			require.NoError(t, err)
+
+			// Store files in a temporary directory
+			env.Storage, err = storage.NewLocalStorage(t.TempDir())
+			require.NoError(t, err)
+			env.UUID = mocks.NewMockUUIDService()
+
+			// Register handler
+			r.POST("/api/v1/platforms/:platformId/attachments", func(c *gin.Context) {
+				c.Set("user", test.User)
+				CreateAttachment(env, db.PLATFORM_ENTITY, "platformId")(c)
+			})
+
+			// Create httptest request
+			body, contentType := multipartBody(test.Filename, test.ContentType, test.Content)
+			req, _ := http.NewRequest("POST", fmt.Sprintf("/api/v1/platforms/%s/attachments", test.IdString), body)
+			req.Header.Set("Content-Type", contentType)
+			w := httptest.NewRecorder()
+
+			// Mock request
+			r.ServeHTTP(w, req)
+
+			// Read response data
+			responseData, _ := io.ReadAll(w.Body)
+
+			// Check response status
+			require.Equal(t, test.StatusCode, w.Code)
+
+			// Handle empty responses
+			response := string(responseData)
+			if response == "" {
+				response = "{}"
+			}
+
+			// Check response body
+			require.JSONEq(t, test.Response, response)
+
+			// Check that the file was only kept when the attachment was created
+			file, err := env.Storage.Get(context.Background(), "attachments/mock-uuid")
+			if test.Stored {
+				require.NoError(t, err)
+				stored, _ := io.ReadAll(file)
+				file.Close()
+				require.Equal(t, test.Content, stored)
+			} else {
+				require.ErrorIs(t, err, storage.ErrNotFound)
+			}
+
+			// Check for any remaining expectations
+			// we make sure that all expectations were met
+			if err := mockSql.ExpectationsWereMet(); err != nil {
+				t.Fatalf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
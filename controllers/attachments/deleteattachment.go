@@ -0,0 +1,61 @@
+package attachments
+
+import (
+	"database/sql"
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/webstradev/rsdb-backend/auth"
+	"github.com/webstradev/rsdb-backend/utils"
+)
+
+// DeleteAttachment removes an attachment, only the user that uploaded it and admins can
+func DeleteAttachment(env *utils.Environment) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		user, err := auth.GetUserFromContext(c)
+		if err != nil {
+			log.Println(err)
+			c.AbortWithStatus(http.StatusInternalServerError)
+			return
+		}
+
+		id, err := strconv.ParseInt(c.Param("attachmentId"), 10, 64)
+		if err != nil {
+			log.Println(err)
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Invalid ID"})
+			return
+		}
+
+		attachment, err := env.DB.GetAttachment(id)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				c.AbortWithStatus(http.StatusNotFound)
+				return
+			}
+			log.Println(err)
+			c.AbortWithStatus(http.StatusInternalServerError)
+			return
+		}
+
+		if !utils.CheckRecordAccess(c, env, user, attachment.EntityType, attachment.EntityId) {
+			return
+		}
+
+		if attachment.CreatedBy.Int64 != user.UserID && !user.IsAdmin() {
+			c.AbortWithStatus(http.StatusForbidden)
+			return
+		}
+
+		err = env.DB.DeleteAttachment(id)
+		if err != nil {
+			log.Println(err)
+			c.AbortWithStatus(http.StatusInternalServerError)
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"message": "Attachment deleted successfully"})
+	}
+}
@@ -0,0 +1,145 @@
+package attachments
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+	"github.com/webstradev/rsdb-backend/auth"
+	"github.com/webstradev/rsdb-backend/db"
+	"github.com/webstradev/rsdb-backend/utils"
+)
+
+func TestDeleteAttachment(t *testing.T) {
+	attachmentRow := func(createdBy any) *sqlmock.Rows {
+		return sqlmock.NewRows(attachmentColumns).AddRow(1, time.Time{}, db.PROJECT_ENTITY, 4, "deck.pdf", "application/pdf", 10, "abc", "attachments/deck", createdBy)
+	}
+
+	tests := []struct {
+		Name       string
+		IdString   string
+		User       auth.TokenData
+		MockDbCall func(sqlmock.Sqlmock)
+		StatusCode int
+		Response   string
+	}{
+		{
+			"DeleteAttachment - non int id",
+			"notanint",
+			user,
+			nil,
+			http.StatusBadRequest,
+			`{"error":"Invalid ID"}`,
+		},
+		{
+			"DeleteAttachment - attachment not found",
+			"1",
+			user,
+			func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT (.+) FROM attachments").WithArgs(1).WillReturnRows(sqlmock.NewRows(attachmentColumns))
+			},
+			http.StatusNotFound,
+			`{}`,
+		},
+		{
+			"DeleteAttachment - uploaded by another user",
+			"1",
+			user,
+			func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT (.+) FROM attachments").WithArgs(1).WillReturnRows(attachmentRow(3))
+				mock.ExpectQuery("SELECT FALSE FROM projects").WithArgs(4).WillReturnRows(sqlmock.NewRows([]string{"private"}).AddRow(false))
+			},
+			http.StatusForbidden,
+			`{}`,
+		},
+		{
+			"DeleteAttachment - sql error on DeleteAttachment",
+			"1",
+			user,
+			func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT (.+) FROM attachments").WithArgs(1).WillReturnRows(attachmentRow(2))
+				mock.ExpectQuery("SELECT FALSE FROM projects").WithArgs(4).WillReturnRows(sqlmock.NewRows([]string{"private"}).AddRow(false))
+				mock.ExpectExec("UPDATE attachments SET deleted_at").WithArgs(1).WillReturnError(errors.New("test"))
+			},
+			http.StatusInternalServerError,
+			`{}`,
+		},
+		{
+			"DeleteAttachment - Valid Request",
+			"1",
+			user,
+			func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT (.+) FROM attachments").WithArgs(1).WillReturnRows(attachmentRow(2))
+				mock.ExpectQuery("SELECT FALSE FROM projects").WithArgs(4).WillReturnRows(sqlmock.NewRows([]string{"private"}).AddRow(false))
+				mock.ExpectExec("UPDATE attachments SET deleted_at").WithArgs(1).WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			http.StatusOK,
+			`{"message":"Attachment deleted successfully"}`,
+		},
+		{
+			"DeleteAttachment - Valid Request as admin",
+			"1",
+			admin,
+			func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT (.+) FROM attachments").WithArgs(1).WillReturnRows(attachmentRow(3))
+				mock.ExpectQuery("SELECT FALSE FROM projects").WithArgs(4).WillReturnRows(sqlmock.NewRows([]string{"private"}).AddRow(false))
+				mock.ExpectExec("UPDATE attachments SET deleted_at").WithArgs(1).WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			http.StatusOK,
+			`{"message":"Attachment deleted successfully"}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			// Initilize test router, environemnt and mock database
+			r, mockDb, mockSql, env, err := utils.SetupTestEnvironment(test.MockDbCall)
+			// Close the mock database at the end of the test
+			defer mockDb.Close()
+
+			// Check for errors during setup
+			require.NoError(t, err)
+
+			// Register handler
+			r.DELETE("/api/v1/attachments/:attachmentId", func(c *gin.Context) {
+				c.Set("user", test.User)
+				DeleteAttachment(env)(c)
+			})
+
+			// Create httptest request
+			req, _ := http.NewRequest("DELETE", fmt.Sprintf("/api/v1/attachments/%s", test.IdString), nil)
+			w := httptest.NewRecorder()
+
+			// Mock request
+			r.ServeHTTP(w, req)
+
+			// Read response data
+			responseData, _ := io.ReadAll(w.Body)
+
+			// Check response status
+			require.Equal(t, test.StatusCode, w.Code)
+
+			// Handle empty responses
+			response := string(responseData)
+			if response == "" {
+				response = "{}"
+			}
+
+			// Check response body
+			require.JSONEq(t, test.Response, response)
+
+			// Check for any remaining expectations
+			// we make sure that all expectations were met
+			if err := mockSql.ExpectationsWereMet(); err != nil {
+				t.Fatalf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,66 @@
+package attachments
+
+import (
+	"database/sql"
+	"errors"
+	"log"
+	"mime"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/webstradev/rsdb-backend/auth"
+	"github.com/webstradev/rsdb-backend/utils"
+)
+
+// DownloadAttachment sends the attached file
+func DownloadAttachment(env *utils.Environment) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		user, err := auth.GetUserFromContext(c)
+		if err != nil {
+			log.Println(err)
+			c.AbortWithStatus(http.StatusInternalServerError)
+			return
+		}
+
+		id, err := strconv.ParseInt(c.Param("attachmentId"), 10, 64)
+		if err != nil {
+			log.Println(err)
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Invalid ID"})
+			return
+		}
+
+		attachment, err := env.DB.GetAttachment(id)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				c.AbortWithStatus(http.StatusNotFound)
+				return
+			}
+			log.Println(err)
+			c.AbortWithStatus(http.StatusInternalServerError)
+			return
+		}
+
+		if !utils.CheckRecordAccess(c, env, user, attachment.EntityType, attachment.EntityId) {
+			return
+		}
+
+		file, err := env.Storage.Get(c.Request.Context(), attachment.StorageKey)
+		if err != nil {
+			log.Println(err)
+			c.AbortWithStatus(http.StatusInternalServerError)
+			return
+		}
+		defer file.Close()
+
+		http.NewResponseController(c.Writer).SetWriteDeadline(time.Now().Add(TRANSFER_TIMEOUT))
+
+		// Files are always downloaded and never rendered by the browser
+		c.DataFromReader(http.StatusOK, attachment.Size, attachment.ContentType, file, map[string]string{
+			"Content-Disposition":    mime.FormatMediaType("attachment", map[string]string{"filename": attachment.Filename}),
+			"X-Content-Type-Options": "nosniff",
+			"ETag":                   `"` + attachment.Checksum + `"`,
+		})
+	}
+}
@@ -0,0 +1,155 @@
+package attachments
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+	"github.com/webstradev/rsdb-backend/auth"
+	"github.com/webstradev/rsdb-backend/db"
+	"github.com/webstradev/rsdb-backend/storage"
+	"github.com/webstradev/rsdb-backend/utils"
+)
+
+func TestDownloadAttachment(t *testing.T) {
+	clipping := "press clipping"
+
+	tests := []struct {
+		Name        string
+		IdString    string
+		User        auth.TokenData
+		MockDbCall  func(sqlmock.Sqlmock)
+		StatusCode  int
+		Response    string
+		Disposition string
+	}{
+		{
+			"DownloadAttachment - non int id",
+			"notanint",
+			user,
+			nil,
+			http.StatusBadRequest,
+			`{"error":"Invalid ID"}`,
+			"",
+		},
+		{
+			"DownloadAttachment - attachment not found",
+			"1",
+			user,
+			func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT (.+) FROM attachments").WithArgs(1).WillReturnRows(sqlmock.NewRows(attachmentColumns))
+			},
+			http.StatusNotFound,
+			``,
+			"",
+		},
+		{
+			"DownloadAttachment - sql error on GetAttachment",
+			"1",
+			user,
+			func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT (.+) FROM attachments").WithArgs(1).WillReturnError(errors.New("test"))
+			},
+			http.StatusInternalServerError,
+			``,
+			"",
+		},
+		{
+			"DownloadAttachment - article deleted",
+			"1",
+			user,
+			func(mock sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows(attachmentColumns).AddRow(1, time.Time{}, db.ARTICLE_ENTITY, 4, "clipping.txt", "text/plain", len(clipping), "abc", "attachments/clipping", 2)
+				mock.ExpectQuery("SELECT (.+) FROM attachments").WithArgs(1).WillReturnRows(rows)
+				mock.ExpectQuery("SELECT FALSE FROM articles").WithArgs(4).WillReturnRows(sqlmock.NewRows([]string{"private"}))
+			},
+			http.StatusNotFound,
+			`{"error":"Record not found"}`,
+			"",
+		},
+		{
+			"DownloadAttachment - file missing from storage",
+			"2",
+			user,
+			func(mock sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows(attachmentColumns).AddRow(2, time.Time{}, db.ARTICLE_ENTITY, 4, "gone.txt", "text/plain", 10, "abc", "attachments/gone", 2)
+				mock.ExpectQuery("SELECT (.+) FROM attachments").WithArgs(2).WillReturnRows(rows)
+				mock.ExpectQuery("SELECT FALSE FROM articles").WithArgs(4).WillReturnRows(sqlmock.NewRows([]string{"private"}).AddRow(false))
+			},
+			http.StatusInternalServerError,
+			``,
+			"",
+		},
+		{
+			"DownloadAttachment - Valid Request",
+			"1",
+			user,
+			func(mock sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows(attachmentColumns).AddRow(1, time.Time{}, db.ARTICLE_ENTITY, 4, "Clipping – Variety.txt", "text/plain", len(clipping), "abc", "attachments/clipping", 2)
+				mock.ExpectQuery("SELECT (.+) FROM attachments").WithArgs(1).WillReturnRows(rows)
+				mock.ExpectQuery("SELECT FALSE FROM articles").WithArgs(4).WillReturnRows(sqlmock.NewRows([]string{"private"}).AddRow(false))
+			},
+			http.StatusOK,
+			clipping,
+			"attachment; filename*=utf-8''Clipping%20%E2%80%93%20Variety.txt",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			// Initilize test router, environemnt and mock database
+			r, mockDb, mockSql, env, err := utils.SetupTestEnvironment(test.MockDbCall)
+			// Close the mock database at the end of the test
+			defer mockDb.Close()
+
+			// Check for errors during setup
+			require.NoError(t, err)
+
+			// Store files in a temporary directory
+			env.Storage, err = storage.NewLocalStorage(t.TempDir())
+			require.NoError(t, err)
+			require.NoError(t, env.Storage.Put(context.Background(), "attachments/clipping", strings.NewReader(clipping), int64(len(clipping)), "text/plain"))
+
+			// Register handler
+			r.GET("/api/v1/attachments/:attachmentId", func(c *gin.Context) {
+				c.Set("user", test.User)
+				DownloadAttachment(env)(c)
+			})
+
+			// Create httptest request
+			req, _ := http.NewRequest("GET", fmt.Sprintf("/api/v1/attachments/%s", test.IdString), nil)
+			w := httptest.NewRecorder()
+
+			// Mock request
+			r.ServeHTTP(w, req)
+
+			// Read response data
+			responseData, _ := io.ReadAll(w.Body)
+
+			// Check response status and body
+			require.Equal(t, test.StatusCode, w.Code)
+			require.Equal(t, test.Response, string(responseData))
+
+			if test.Disposition != "" {
+				require.Equal(t, test.Disposition, w.Header().Get("Content-Disposition"))
+				require.Equal(t, "text/plain", w.Header().Get("Content-Type"))
+				require.Equal(t, "nosniff", w.Header().Get("X-Content-Type-Options"))
+			}
+
+			// Check for any remaining expectations
+			// we make sure that all expectations were met
+			if err := mockSql.ExpectationsWereMet(); err != nil {
+				t.Fatalf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,43 @@
+package attachments
+
+import (
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/webstradev/rsdb-backend/auth"
+	"github.com/webstradev/rsdb-backend/utils"
+)
+
+// GetAttachments lists the attachments of the record of the given type, with its ID in the idParam URL parameter
+func GetAttachments(env *utils.Environment, entityType, idParam string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		user, err := auth.GetUserFromContext(c)
+		if err != nil {
+			log.Println(err)
+			c.AbortWithStatus(http.StatusInternalServerError)
+			return
+		}
+
+		id, err := strconv.ParseInt(c.Param(idParam), 10, 64)
+		if err != nil {
+			log.Println(err)
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Invalid ID"})
+			return
+		}
+
+		if !utils.CheckRecordAccess(c, env, user, entityType, id) {
+			return
+		}
+
+		attachments, err := env.DB.GetAttachments(entityType, id)
+		if err != nil {
+			log.Println(err)
+			c.AbortWithStatus(http.StatusInternalServerError)
+			return
+		}
+
+		c.JSON(http.StatusOK, attachments)
+	}
+}
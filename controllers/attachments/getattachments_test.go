@@ -0,0 +1,136 @@
+package attachments
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+	"github.com/webstradev/rsdb-backend/auth"
+	"github.com/webstradev/rsdb-backend/db"
+	"github.com/webstradev/rsdb-backend/utils"
+)
+
+var attachmentColumns = []string{"id", "created_at", "entity_type", "entity_id", "filename", "content_type", "size", "checksum", "storage_key", "created_by"}
+
+func TestGetAttachments(t *testing.T) {
+	created := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		Name       string
+		IdString   string
+		User       auth.TokenData
+		MockDbCall func(sqlmock.Sqlmock)
+		StatusCode int
+		Response   string
+	}{
+		{
+			"GetAttachments - non int id",
+			"notanint",
+			user,
+			nil,
+			http.StatusBadRequest,
+			`{"error":"Invalid ID"}`,
+		},
+		{
+			"GetAttachments - sql error on RecordIsPrivate",
+			"3",
+			user,
+			func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT (.+) FROM contacts c").WithArgs(db.PRIVACY_PRIVATE, db.PRIVACY_PRIVATE, 3).WillReturnError(errors.New("test"))
+			},
+			http.StatusInternalServerError,
+			`{}`,
+		},
+		{
+			"GetAttachments - contact of a private platform",
+			"3",
+			user,
+			func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT (.+) FROM contacts c").WithArgs(db.PRIVACY_PRIVATE, db.PRIVACY_PRIVATE, 3).WillReturnRows(sqlmock.NewRows([]string{"private"}).AddRow(true))
+			},
+			http.StatusForbidden,
+			`{}`,
+		},
+		{
+			"GetAttachments - sql error on GetAttachments",
+			"3",
+			user,
+			func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT (.+) FROM contacts c").WithArgs(db.PRIVACY_PRIVATE, db.PRIVACY_PRIVATE, 3).WillReturnRows(sqlmock.NewRows([]string{"private"}).AddRow(false))
+				mock.ExpectQuery("SELECT (.+) FROM attachments").WithArgs(db.CONTACT_ENTITY, 3).WillReturnError(errors.New("test"))
+			},
+			http.StatusInternalServerError,
+			`{}`,
+		},
+		{
+			"GetAttachments - Valid Request",
+			"3",
+			admin,
+			func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT (.+) FROM contacts c").WithArgs(db.PRIVACY_PRIVATE, db.PRIVACY_PRIVATE, 3).WillReturnRows(sqlmock.NewRows([]string{"private"}).AddRow(true))
+				rows := sqlmock.NewRows(attachmentColumns).
+					AddRow(2, created, db.CONTACT_ENTITY, 3, "contract.pdf", "application/pdf", 1024, "abc", "attachments/2", 1).
+					AddRow(1, created, db.CONTACT_ENTITY, 3, "photo.jpg", "image/jpeg", 2048, "def", "attachments/1", nil)
+				mock.ExpectQuery("SELECT (.+) FROM attachments").WithArgs(db.CONTACT_ENTITY, 3).WillReturnRows(rows)
+			},
+			http.StatusOK,
+			`[
+				{"id":2,"createdAt":"2024-05-01T10:00:00Z","modifiedAt":"0001-01-01T00:00:00Z","deletedAt":{"Time":"0001-01-01T00:00:00Z","Valid":false},"entityType":"contact","entityId":3,"filename":"contract.pdf","contentType":"application/pdf","size":1024,"checksum":"abc","createdBy":{"Int64":1,"Valid":true}},
+				{"id":1,"createdAt":"2024-05-01T10:00:00Z","modifiedAt":"0001-01-01T00:00:00Z","deletedAt":{"Time":"0001-01-01T00:00:00Z","Valid":false},"entityType":"contact","entityId":3,"filename":"photo.jpg","contentType":"image/jpeg","size":2048,"checksum":"def","createdBy":{"Int64":0,"Valid":false}}
+			]`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			// Initilize test router, environemnt and mock database
+			r, mockDb, mockSql, env, err := utils.SetupTestEnvironment(test.MockDbCall)
+			// Close the mock database at the end of the test
+			defer mockDb.Close()
+
+			// Check for errors during setup
+			require.NoError(t, err)
+
+			// Register handler
+			r.GET("/api/v1/contacts/:contactId/attachments", func(c *gin.Context) {
+				c.Set("user", test.User)
+				GetAttachments(env, db.CONTACT_ENTITY, "contactId")(c)
+			})
+
+			// Create httptest request
+			req, _ := http.NewRequest("GET", fmt.Sprintf("/api/v1/contacts/%s/attachments", test.IdString), nil)
+			w := httptest.NewRecorder()
+
+			// Mock request
+			r.ServeHTTP(w, req)
+
+			// Read response data
+			responseData, _ := io.ReadAll(w.Body)
+
+			// Check response status
+			require.Equal(t, test.StatusCode, w.Code)
+
+			// Handle empty responses
+			response := string(responseData)
+			if response == "" {
+				response = "{}"
+			}
+
+			// Check response body
+			require.JSONEq(t, test.Response, response)
+
+			// Check for any remaining expectations
+			// we make sure that all expectations were met
+			if err := mockSql.ExpectationsWereMet(); err != nil {
+				t.Fatalf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
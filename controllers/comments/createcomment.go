@@ -0,0 +1,88 @@
+package comments
+
+import (
+	"database/sql"
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/webstradev/rsdb-backend/auth"
+	"github.com/webstradev/rsdb-backend/db"
+	"github.com/webstradev/rsdb-backend/utils"
+)
+
+type commentInput struct {
+	Body     string `json:"body" binding:"required"`
+	ParentId int64  `json:"parentId"`
+}
+
+// CreateComment adds a comment to the record of the given type, with its ID in the idParam URL parameter.
+// Comments with a parentId are replies to that comment, the users mentioned in the comment are notified.
+func CreateComment(env *utils.Environment, entityType, idParam string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		user, err := auth.GetUserFromContext(c)
+		if err != nil {
+			log.Println(err)
+			c.AbortWithStatus(http.StatusInternalServerError)
+			return
+		}
+
+		id, err := strconv.ParseInt(c.Param(idParam), 10, 64)
+		if err != nil {
+			log.Println(err)
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Invalid ID"})
+			return
+		}
+
+		input := commentInput{}
+		err = c.ShouldBindJSON(&input)
+		if err != nil {
+			log.Println(err)
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		input.Body = strings.TrimSpace(input.Body)
+		if input.Body == "" {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Comment can't be empty"})
+			return
+		}
+
+		if !utils.CheckRecordAccess(c, env, user, entityType, id) {
+			return
+		}
+
+		mentioned, err := mentionedUsers(env, entityType, id, input.Body, user.UserID)
+		if err != nil {
+			log.Println(err)
+			c.AbortWithStatus(http.StatusInternalServerError)
+			return
+		}
+
+		comment := db.Comment{
+			EntityType: entityType,
+			EntityId:   id,
+			ParentId:   sql.NullInt64{Int64: input.ParentId, Valid: input.ParentId != 0},
+			AuthorId:   sql.NullInt64{Int64: user.UserID, Valid: true},
+			Body:       input.Body,
+		}
+
+		comment.ID, err = env.DB.InsertComment(comment, userIds(mentioned))
+		if err != nil {
+			if errors.Is(err, db.ErrInvalidParentComment) {
+				c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+				return
+			}
+			log.Println(err)
+			c.AbortWithStatus(http.StatusInternalServerError)
+			return
+		}
+
+		notifyMentions(env, comment, mentioned)
+
+		c.JSON(http.StatusOK, gin.H{"id": comment.ID, "message": "Comment created successfully"})
+	}
+}
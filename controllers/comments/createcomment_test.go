@@ -0,0 +1,229 @@
+package comments
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+	"github.com/webstradev/rsdb-backend/auth"
+	"github.com/webstradev/rsdb-backend/db"
+	"github.com/webstradev/rsdb-backend/notify"
+	"github.com/webstradev/rsdb-backend/utils"
+)
+
+var userColumns = []string{"id", "email", "role"}
+
+// recordingNotifier passes the messages it is asked to send on to a channel
+type recordingNotifier struct {
+	messages chan notify.Message
+}
+
+func (n *recordingNotifier) Notify(ctx context.Context, message notify.Message) error {
+	n.messages <- message
+	return nil
+}
+
+// requireNotified checks the mentioned users were notified, notifications are sent in the background
+func requireNotified(t *testing.T, notifier *recordingNotifier, expected []notify.Message) {
+	for _, message := range expected {
+		select {
+		case sent := <-notifier.messages:
+			require.Equal(t, message, sent)
+		case <-time.After(time.Second):
+			t.Fatalf("no notification sent to %s", message.To)
+		}
+	}
+
+	select {
+	case sent := <-notifier.messages:
+		t.Fatalf("unexpected notification sent to %s", sent.To)
+	case <-time.After(10 * time.Millisecond):
+	}
+}
+
+func TestCreateComment(t *testing.T) {
+	tests := []struct {
+		Name       string
+		IdString   string
+		User       auth.TokenData
+		Body       string
+		MockDbCall func(sqlmock.Sqlmock)
+		StatusCode int
+		Response   string
+		Notified   []notify.Message
+	}{
+		{
+			"CreateComment - non int id",
+			"notanint",
+			user,
+			`{"body":"test"}`,
+			nil,
+			http.StatusBadRequest,
+			`{"error":"Invalid ID"}`,
+			nil,
+		},
+		{
+			"CreateComment - missing body",
+			"4",
+			user,
+			`{}`,
+			nil,
+			http.StatusBadRequest,
+			`{"error":"Key: 'commentInput.Body' Error:Field validation for 'Body' failed on the 'required' tag"}`,
+			nil,
+		},
+		{
+			"CreateComment - blank body",
+			"4",
+			user,
+			`{"body":"  "}`,
+			nil,
+			http.StatusBadRequest,
+			`{"error":"Comment can't be empty"}`,
+			nil,
+		},
+		{
+			"CreateComment - project not found",
+			"4",
+			user,
+			`{"body":"test"}`,
+			func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT FALSE FROM projects").WithArgs(4).WillReturnRows(sqlmock.NewRows([]string{"private"}))
+			},
+			http.StatusNotFound,
+			`{"error":"Record not found"}`,
+			nil,
+		},
+		{
+			"CreateComment - sql error on GetMentionableUsers",
+			"4",
+			user,
+			`{"body":"@jane test"}`,
+			func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT FALSE FROM projects").WithArgs(4).WillReturnRows(sqlmock.NewRows([]string{"private"}).AddRow(false))
+				mock.ExpectQuery("SELECT (.+) FROM users").WithArgs("jane", "jane").WillReturnError(errors.New("test"))
+			},
+			http.StatusInternalServerError,
+			`{}`,
+			nil,
+		},
+		{
+			"CreateComment - reply to a comment on another record",
+			"4",
+			user,
+			`{"body":"test","parentId":9}`,
+			func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT FALSE FROM projects").WithArgs(4).WillReturnRows(sqlmock.NewRows([]string{"private"}).AddRow(false))
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT COUNT(.+) FROM comments").WithArgs(9, db.PROJECT_ENTITY, 4).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+				mock.ExpectRollback()
+			},
+			http.StatusBadRequest,
+			`{"error":"invalid parent comment, replies must be to a comment on the same record"}`,
+			nil,
+		},
+		{
+			"CreateComment - sql error on InsertComment",
+			"4",
+			user,
+			`{"body":"test"}`,
+			func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT FALSE FROM projects").WithArgs(4).WillReturnRows(sqlmock.NewRows([]string{"private"}).AddRow(false))
+				mock.ExpectBegin()
+				mock.ExpectExec("INSERT INTO comments").WithArgs(db.PROJECT_ENTITY, 4, nil, 2, "test").WillReturnError(errors.New("test"))
+				mock.ExpectRollback()
+			},
+			http.StatusInternalServerError,
+			`{}`,
+			nil,
+		},
+		{
+			"CreateComment - Valid Request",
+			"4",
+			user,
+			`{"body":"@jane can you call them? cc @JOHN@example.org @me","parentId":9}`,
+			func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT FALSE FROM projects").WithArgs(4).WillReturnRows(sqlmock.NewRows([]string{"private"}).AddRow(false))
+				rows := sqlmock.NewRows(userColumns).
+					AddRow(2, "me@example.com", auth.UserRole).
+					AddRow(5, "jane@example.com", auth.UserRole).
+					AddRow(6, "john@example.org", auth.UserRole)
+				mock.ExpectQuery("SELECT (.+) FROM users").WithArgs("jane", "john@example.org", "me", "jane", "john@example.org", "me").WillReturnRows(rows)
+				mock.ExpectQuery("SELECT FALSE FROM projects").WithArgs(4).WillReturnRows(sqlmock.NewRows([]string{"private"}).AddRow(false))
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT COUNT(.+) FROM comments").WithArgs(9, db.PROJECT_ENTITY, 4).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+				mock.ExpectExec("INSERT INTO comments").WithArgs(db.PROJECT_ENTITY, 4, 9, 2, "@jane can you call them? cc @JOHN@example.org @me").WillReturnResult(sqlmock.NewResult(7, 1))
+				mock.ExpectExec("INSERT INTO comments_mentions").WithArgs(7, 5).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("INSERT INTO comments_mentions").WithArgs(7, 6).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+			http.StatusOK,
+			`{"id":7,"message":"Comment created successfully"}`,
+			[]notify.Message{
+				{To: "jane@example.com", Subject: "You were mentioned in a comment on project 4", Body: "@jane can you call them? cc @JOHN@example.org @me"},
+				{To: "john@example.org", Subject: "You were mentioned in a comment on project 4", Body: "@jane can you call them? cc @JOHN@example.org @me"},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			// Initilize test router, environemnt and mock database
+			r, mockDb, mockSql, env, err := utils.SetupTestEnvironment(test.MockDbCall)
+			// Close the mock database at the end of the test
+			defer mockDb.Close()
+
+			// Check for errors during setup
+			require.NoError(t, err)
+
+			notifier := &recordingNotifier{messages: make(chan notify.Message, 10)}
+			env.Notifier = notifier
+
+			// Register handler
+			r.POST("/api/v1/projects/:projectId/comments", func(c *gin.Context) {
+				c.Set("user", test.User)
+				CreateComment(env, db.PROJECT_ENTITY, "projectId")(c)
+			})
+
+			// Create httptest request
+			req, _ := http.NewRequest("POST", fmt.Sprintf("/api/v1/projects/%s/comments", test.IdString), bytes.NewBufferString(test.Body))
+			w := httptest.NewRecorder()
+
+			// Mock request
+			r.ServeHTTP(w, req)
+
+			// Read response data
+			responseData, _ := io.ReadAll(w.Body)
+
+			// Check response status
+			require.Equal(t, test.StatusCode, w.Code)
+
+			// Handle empty responses
+			response := string(responseData)
+			if response == "" {
+				response = "{}"
+			}
+
+			// Check response body
+			require.JSONEq(t, test.Response, response)
+
+			// Check the mentioned users were notified
+			requireNotified(t, notifier, test.Notified)
+
+			// Check for any remaining expectations
+			// we make sure that all expectations were met
+			if err := mockSql.ExpectationsWereMet(); err != nil {
+				t.Fatalf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,62 @@
+package comments
+
+import (
+	"database/sql"
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/webstradev/rsdb-backend/auth"
+	"github.com/webstradev/rsdb-backend/utils"
+)
+
+// DeleteComment removes a comment, only its author can. Replies to the comment are kept.
+func DeleteComment(env *utils.Environment) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		user, err := auth.GetUserFromContext(c)
+		if err != nil {
+			log.Println(err)
+			c.AbortWithStatus(http.StatusInternalServerError)
+			return
+		}
+
+		id, err := strconv.ParseInt(c.Param("commentId"), 10, 64)
+		if err != nil {
+			log.Println(err)
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Invalid ID"})
+			return
+		}
+
+		comment, err := env.DB.GetComment(id)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				c.AbortWithStatus(http.StatusNotFound)
+				return
+			}
+			log.Println(err)
+			c.AbortWithStatus(http.StatusInternalServerError)
+			return
+		}
+
+		// Comments share the access of their record
+		if !utils.CheckRecordAccess(c, env, user, comment.EntityType, comment.EntityId) {
+			return
+		}
+
+		if comment.AuthorId.Int64 != user.UserID {
+			c.AbortWithStatus(http.StatusForbidden)
+			return
+		}
+
+		err = env.DB.DeleteComment(id)
+		if err != nil {
+			log.Println(err)
+			c.AbortWithStatus(http.StatusInternalServerError)
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"message": "Comment deleted successfully"})
+	}
+}
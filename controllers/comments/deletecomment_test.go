@@ -0,0 +1,165 @@
+package comments
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+	"github.com/webstradev/rsdb-backend/auth"
+	"github.com/webstradev/rsdb-backend/db"
+	"github.com/webstradev/rsdb-backend/utils"
+)
+
+func TestDeleteComment(t *testing.T) {
+	commentRow := func(authorId any) *sqlmock.Rows {
+		return sqlmock.NewRows(commentColumns).AddRow(1, time.Time{}, nil, db.CONTACT_ENTITY, 5, nil, authorId, "test", nil, "")
+	}
+
+	tests := []struct {
+		Name       string
+		IdString   string
+		User       auth.TokenData
+		MockDbCall func(sqlmock.Sqlmock)
+		StatusCode int
+		Response   string
+	}{
+		{
+			"DeleteComment - non int id",
+			"notanint",
+			user,
+			nil,
+			http.StatusBadRequest,
+			`{"error":"Invalid ID"}`,
+		},
+		{
+			"DeleteComment - comment not found",
+			"1",
+			user,
+			func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT cm.(.+) FROM comments cm").WithArgs(1).WillReturnRows(sqlmock.NewRows(commentColumns))
+			},
+			http.StatusNotFound,
+			`{}`,
+		},
+		{
+			"DeleteComment - sql error on GetComment",
+			"1",
+			user,
+			func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT cm.(.+) FROM comments cm").WithArgs(1).WillReturnError(errors.New("test"))
+			},
+			http.StatusInternalServerError,
+			`{}`,
+		},
+		{
+			"DeleteComment - contact became private",
+			"1",
+			user,
+			func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT cm.(.+) FROM comments cm").WithArgs(1).WillReturnRows(commentRow(2))
+				mock.ExpectQuery("SELECT c.privacy = \\? OR p.privacy = \\? FROM contacts c").WithArgs("private", "private", 5).WillReturnRows(sqlmock.NewRows([]string{"private"}).AddRow(true))
+			},
+			http.StatusForbidden,
+			`{}`,
+		},
+		{
+			"DeleteComment - written by another user",
+			"1",
+			admin,
+			func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT cm.(.+) FROM comments cm").WithArgs(1).WillReturnRows(commentRow(2))
+				mock.ExpectQuery("SELECT c.privacy = \\? OR p.privacy = \\? FROM contacts c").WithArgs("private", "private", 5).WillReturnRows(sqlmock.NewRows([]string{"private"}).AddRow(false))
+			},
+			http.StatusForbidden,
+			`{}`,
+		},
+		{
+			"DeleteComment - author was removed",
+			"1",
+			user,
+			func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT cm.(.+) FROM comments cm").WithArgs(1).WillReturnRows(commentRow(nil))
+				mock.ExpectQuery("SELECT c.privacy = \\? OR p.privacy = \\? FROM contacts c").WithArgs("private", "private", 5).WillReturnRows(sqlmock.NewRows([]string{"private"}).AddRow(false))
+			},
+			http.StatusForbidden,
+			`{}`,
+		},
+		{
+			"DeleteComment - sql error on DeleteComment",
+			"1",
+			user,
+			func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT cm.(.+) FROM comments cm").WithArgs(1).WillReturnRows(commentRow(2))
+				mock.ExpectQuery("SELECT c.privacy = \\? OR p.privacy = \\? FROM contacts c").WithArgs("private", "private", 5).WillReturnRows(sqlmock.NewRows([]string{"private"}).AddRow(false))
+				mock.ExpectExec("UPDATE comments SET deleted_at").WithArgs(1).WillReturnError(errors.New("test"))
+			},
+			http.StatusInternalServerError,
+			`{}`,
+		},
+		{
+			"DeleteComment - Valid Request",
+			"1",
+			user,
+			func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT cm.(.+) FROM comments cm").WithArgs(1).WillReturnRows(commentRow(2))
+				mock.ExpectQuery("SELECT c.privacy = \\? OR p.privacy = \\? FROM contacts c").WithArgs("private", "private", 5).WillReturnRows(sqlmock.NewRows([]string{"private"}).AddRow(false))
+				mock.ExpectExec("UPDATE comments SET deleted_at").WithArgs(1).WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			http.StatusOK,
+			`{"message":"Comment deleted successfully"}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			// Initilize test router, environemnt and mock database
+			r, mockDb, mockSql, env, err := utils.SetupTestEnvironment(test.MockDbCall)
+			// Close the mock database at the end of the test
+			defer mockDb.Close()
+
+			// Check for errors during setup
+			require.NoError(t, err)
+
+			// Register handler
+			r.DELETE("/api/v1/comments/:commentId", func(c *gin.Context) {
+				c.Set("user", test.User)
+				DeleteComment(env)(c)
+			})
+
+			// Create httptest request
+			req, _ := http.NewRequest("DELETE", fmt.Sprintf("/api/v1/comments/%s", test.IdString), nil)
+			w := httptest.NewRecorder()
+
+			// Mock request
+			r.ServeHTTP(w, req)
+
+			// Read response data
+			responseData, _ := io.ReadAll(w.Body)
+
+			// Check response status
+			require.Equal(t, test.StatusCode, w.Code)
+
+			// Handle empty responses
+			response := string(responseData)
+			if response == "" {
+				response = "{}"
+			}
+
+			// Check response body
+			require.JSONEq(t, test.Response, response)
+
+			// Check for any remaining expectations
+			// we make sure that all expectations were met
+			if err := mockSql.ExpectationsWereMet(); err != nil {
+				t.Fatalf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,117 @@
+package comments
+
+import (
+	"database/sql"
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/webstradev/rsdb-backend/auth"
+	"github.com/webstradev/rsdb-backend/db"
+	"github.com/webstradev/rsdb-backend/utils"
+)
+
+type editCommentInput struct {
+	Body string `json:"body" binding:"required"`
+}
+
+// EditComment changes the body of a comment, only its author can. Users that are newly mentioned are notified.
+func EditComment(env *utils.Environment) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		user, err := auth.GetUserFromContext(c)
+		if err != nil {
+			log.Println(err)
+			c.AbortWithStatus(http.StatusInternalServerError)
+			return
+		}
+
+		id, err := strconv.ParseInt(c.Param("commentId"), 10, 64)
+		if err != nil {
+			log.Println(err)
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Invalid ID"})
+			return
+		}
+
+		input := editCommentInput{}
+		err = c.ShouldBindJSON(&input)
+		if err != nil {
+			log.Println(err)
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		input.Body = strings.TrimSpace(input.Body)
+		if input.Body == "" {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Comment can't be empty"})
+			return
+		}
+
+		comment, err := env.DB.GetComment(id)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				c.AbortWithStatus(http.StatusNotFound)
+				return
+			}
+			log.Println(err)
+			c.AbortWithStatus(http.StatusInternalServerError)
+			return
+		}
+
+		// Comments share the access of their record
+		if !utils.CheckRecordAccess(c, env, user, comment.EntityType, comment.EntityId) {
+			return
+		}
+
+		if comment.AuthorId.Int64 != user.UserID {
+			c.AbortWithStatus(http.StatusForbidden)
+			return
+		}
+
+		previous, err := env.DB.GetCommentMentions(id)
+		if err != nil {
+			log.Println(err)
+			c.AbortWithStatus(http.StatusInternalServerError)
+			return
+		}
+
+		mentioned, err := mentionedUsers(env, comment.EntityType, comment.EntityId, input.Body, user.UserID)
+		if err != nil {
+			log.Println(err)
+			c.AbortWithStatus(http.StatusInternalServerError)
+			return
+		}
+
+		err = env.DB.EditComment(id, input.Body, userIds(mentioned))
+		if err != nil {
+			log.Println(err)
+			c.AbortWithStatus(http.StatusInternalServerError)
+			return
+		}
+
+		// Users that were already mentioned have been notified before
+		newlyMentioned := []db.User{}
+		for _, mentionedUser := range mentioned {
+			if !containsId(previous, mentionedUser.ID) {
+				newlyMentioned = append(newlyMentioned, mentionedUser)
+			}
+		}
+
+		comment.Body = input.Body
+		notifyMentions(env, comment, newlyMentioned)
+
+		c.JSON(http.StatusOK, gin.H{"message": "Comment edited successfully"})
+	}
+}
+
+func containsId(ids []int64, id int64) bool {
+	for _, candidate := range ids {
+		if candidate == id {
+			return true
+		}
+	}
+
+	return false
+}
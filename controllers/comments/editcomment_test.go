@@ -0,0 +1,206 @@
+package comments
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+	"github.com/webstradev/rsdb-backend/auth"
+	"github.com/webstradev/rsdb-backend/db"
+	"github.com/webstradev/rsdb-backend/notify"
+	"github.com/webstradev/rsdb-backend/utils"
+)
+
+func TestEditComment(t *testing.T) {
+	commentRow := func(authorId any) *sqlmock.Rows {
+		return sqlmock.NewRows(commentColumns).AddRow(1, time.Time{}, nil, db.ARTICLE_ENTITY, 8, nil, authorId, "@jane test", nil, "me@example.com")
+	}
+
+	tests := []struct {
+		Name       string
+		IdString   string
+		User       auth.TokenData
+		Body       string
+		MockDbCall func(sqlmock.Sqlmock)
+		StatusCode int
+		Response   string
+		Notified   []notify.Message
+	}{
+		{
+			"EditComment - non int id",
+			"notanint",
+			user,
+			`{"body":"test"}`,
+			nil,
+			http.StatusBadRequest,
+			`{"error":"Invalid ID"}`,
+			nil,
+		},
+		{
+			"EditComment - blank body",
+			"1",
+			user,
+			`{"body":"\n"}`,
+			nil,
+			http.StatusBadRequest,
+			`{"error":"Comment can't be empty"}`,
+			nil,
+		},
+		{
+			"EditComment - comment not found",
+			"1",
+			user,
+			`{"body":"test"}`,
+			func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT cm.(.+) FROM comments cm").WithArgs(1).WillReturnRows(sqlmock.NewRows(commentColumns))
+			},
+			http.StatusNotFound,
+			`{}`,
+			nil,
+		},
+		{
+			"EditComment - article was deleted",
+			"1",
+			user,
+			`{"body":"test"}`,
+			func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT cm.(.+) FROM comments cm").WithArgs(1).WillReturnRows(commentRow(2))
+				mock.ExpectQuery("SELECT FALSE FROM articles").WithArgs(8).WillReturnRows(sqlmock.NewRows([]string{"private"}))
+			},
+			http.StatusNotFound,
+			`{"error":"Record not found"}`,
+			nil,
+		},
+		{
+			"EditComment - written by another user",
+			"1",
+			admin,
+			`{"body":"test"}`,
+			func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT cm.(.+) FROM comments cm").WithArgs(1).WillReturnRows(commentRow(2))
+				mock.ExpectQuery("SELECT FALSE FROM articles").WithArgs(8).WillReturnRows(sqlmock.NewRows([]string{"private"}).AddRow(false))
+			},
+			http.StatusForbidden,
+			`{}`,
+			nil,
+		},
+		{
+			"EditComment - sql error on GetCommentMentions",
+			"1",
+			user,
+			`{"body":"test"}`,
+			func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT cm.(.+) FROM comments cm").WithArgs(1).WillReturnRows(commentRow(2))
+				mock.ExpectQuery("SELECT FALSE FROM articles").WithArgs(8).WillReturnRows(sqlmock.NewRows([]string{"private"}).AddRow(false))
+				mock.ExpectQuery("SELECT user_id FROM comments_mentions").WithArgs(1).WillReturnError(errors.New("test"))
+			},
+			http.StatusInternalServerError,
+			`{}`,
+			nil,
+		},
+		{
+			"EditComment - sql error on EditComment",
+			"1",
+			user,
+			`{"body":"test"}`,
+			func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT cm.(.+) FROM comments cm").WithArgs(1).WillReturnRows(commentRow(2))
+				mock.ExpectQuery("SELECT FALSE FROM articles").WithArgs(8).WillReturnRows(sqlmock.NewRows([]string{"private"}).AddRow(false))
+				mock.ExpectQuery("SELECT user_id FROM comments_mentions").WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"user_id"}))
+				mock.ExpectBegin()
+				mock.ExpectExec("UPDATE comments SET body").WithArgs("test", 1).WillReturnError(errors.New("test"))
+				mock.ExpectRollback()
+			},
+			http.StatusInternalServerError,
+			`{}`,
+			nil,
+		},
+		{
+			"EditComment - Valid Request",
+			"1",
+			user,
+			`{"body":"@jane and @john test"}`,
+			func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT cm.(.+) FROM comments cm").WithArgs(1).WillReturnRows(commentRow(2))
+				mock.ExpectQuery("SELECT FALSE FROM articles").WithArgs(8).WillReturnRows(sqlmock.NewRows([]string{"private"}).AddRow(false))
+				mock.ExpectQuery("SELECT user_id FROM comments_mentions").WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"user_id"}).AddRow(5))
+				rows := sqlmock.NewRows(userColumns).
+					AddRow(5, "jane@example.com", auth.UserRole).
+					AddRow(6, "john@example.com", auth.UserRole)
+				mock.ExpectQuery("SELECT (.+) FROM users").WithArgs("jane", "john", "jane", "john").WillReturnRows(rows)
+				mock.ExpectQuery("SELECT FALSE FROM articles").WithArgs(8).WillReturnRows(sqlmock.NewRows([]string{"private"}).AddRow(false))
+				mock.ExpectBegin()
+				mock.ExpectExec("UPDATE comments SET body").WithArgs("@jane and @john test", 1).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("DELETE FROM comments_mentions").WithArgs(1).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("INSERT INTO comments_mentions").WithArgs(1, 5).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("INSERT INTO comments_mentions").WithArgs(1, 6).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+			http.StatusOK,
+			`{"message":"Comment edited successfully"}`,
+			[]notify.Message{
+				{To: "john@example.com", Subject: "You were mentioned in a comment on article 8", Body: "@jane and @john test"},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			// Initilize test router, environemnt and mock database
+			r, mockDb, mockSql, env, err := utils.SetupTestEnvironment(test.MockDbCall)
+			// Close the mock database at the end of the test
+			defer mockDb.Close()
+
+			// Check for errors during setup
+			require.NoError(t, err)
+
+			notifier := &recordingNotifier{messages: make(chan notify.Message, 10)}
+			env.Notifier = notifier
+
+			// Register handler
+			r.PUT("/api/v1/comments/:commentId", func(c *gin.Context) {
+				c.Set("user", test.User)
+				EditComment(env)(c)
+			})
+
+			// Create httptest request
+			req, _ := http.NewRequest("PUT", fmt.Sprintf("/api/v1/comments/%s", test.IdString), bytes.NewBufferString(test.Body))
+			w := httptest.NewRecorder()
+
+			// Mock request
+			r.ServeHTTP(w, req)
+
+			// Read response data
+			responseData, _ := io.ReadAll(w.Body)
+
+			// Check response status
+			require.Equal(t, test.StatusCode, w.Code)
+
+			// Handle empty responses
+			response := string(responseData)
+			if response == "" {
+				response = "{}"
+			}
+
+			// Check response body
+			require.JSONEq(t, test.Response, response)
+
+			// Check only newly mentioned users were notified
+			requireNotified(t, notifier, test.Notified)
+
+			// Check for any remaining expectations
+			// we make sure that all expectations were met
+			if err := mockSql.ExpectationsWereMet(); err != nil {
+				t.Fatalf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,149 @@
+package comments
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+	"github.com/webstradev/rsdb-backend/auth"
+	"github.com/webstradev/rsdb-backend/db"
+	"github.com/webstradev/rsdb-backend/utils"
+)
+
+var (
+	admin = auth.TokenData{UserID: 1, Role: auth.AdminRole}
+	user  = auth.TokenData{UserID: 2, Role: auth.UserRole}
+)
+
+var commentColumns = []string{"id", "created_at", "deleted_at", "entity_type", "entity_id", "parent_id", "author_id", "body", "edited_at", "author_email"}
+
+func TestGetComments(t *testing.T) {
+	created := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	deleted := time.Date(2024, 5, 2, 10, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		Name       string
+		IdString   string
+		User       auth.TokenData
+		MockDbCall func(sqlmock.Sqlmock)
+		StatusCode int
+		Response   string
+	}{
+		{
+			"GetComments - non int id",
+			"notanint",
+			user,
+			nil,
+			http.StatusBadRequest,
+			`{"error":"Invalid ID"}`,
+		},
+		{
+			"GetComments - platform not found",
+			"3",
+			user,
+			func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT privacy = (.+) FROM platforms").WithArgs(db.PRIVACY_PRIVATE, 3).WillReturnRows(sqlmock.NewRows([]string{"private"}))
+			},
+			http.StatusNotFound,
+			`{"error":"Record not found"}`,
+		},
+		{
+			"GetComments - private platform",
+			"3",
+			user,
+			func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT privacy = (.+) FROM platforms").WithArgs(db.PRIVACY_PRIVATE, 3).WillReturnRows(sqlmock.NewRows([]string{"private"}).AddRow(true))
+			},
+			http.StatusForbidden,
+			`{}`,
+		},
+		{
+			"GetComments - sql error on GetComments",
+			"3",
+			user,
+			func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT privacy = (.+) FROM platforms").WithArgs(db.PRIVACY_PRIVATE, 3).WillReturnRows(sqlmock.NewRows([]string{"private"}).AddRow(false))
+				mock.ExpectQuery("SELECT cm.(.+) FROM comments cm").WithArgs(db.PLATFORM_ENTITY, 3).WillReturnError(errors.New("test"))
+			},
+			http.StatusInternalServerError,
+			`{}`,
+		},
+		{
+			"GetComments - Valid Request",
+			"3",
+			admin,
+			func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT privacy = (.+) FROM platforms").WithArgs(db.PRIVACY_PRIVATE, 3).WillReturnRows(sqlmock.NewRows([]string{"private"}).AddRow(true))
+				rows := sqlmock.NewRows(commentColumns).
+					AddRow(1, created, nil, db.PLATFORM_ENTITY, 3, nil, 2, "First", nil, "jane@example.com").
+					AddRow(2, created, deleted, db.PLATFORM_ENTITY, 3, nil, 2, "Removed", nil, "jane@example.com").
+					AddRow(3, created, nil, db.PLATFORM_ENTITY, 3, 2, 1, "Reply to removed", nil, "admin@example.com").
+					AddRow(4, created, deleted, db.PLATFORM_ENTITY, 3, 1, 1, "Removed reply", nil, "admin@example.com").
+					AddRow(5, created, nil, db.PLATFORM_ENTITY, 3, 1, 1, "Reply", deleted, "admin@example.com")
+				mock.ExpectQuery("SELECT cm.(.+) FROM comments cm").WithArgs(db.PLATFORM_ENTITY, 3).WillReturnRows(rows)
+			},
+			http.StatusOK,
+			`[
+				{"id":1,"createdAt":"2024-05-01T10:00:00Z","modifiedAt":"0001-01-01T00:00:00Z","deletedAt":{"Time":"0001-01-01T00:00:00Z","Valid":false},"entityType":"platform","entityId":3,"parentId":{"Int64":0,"Valid":false},"authorId":{"Int64":2,"Valid":true},"authorEmail":"jane@example.com","body":"First","editedAt":{"Time":"0001-01-01T00:00:00Z","Valid":false},"replies":[
+					{"id":5,"createdAt":"2024-05-01T10:00:00Z","modifiedAt":"0001-01-01T00:00:00Z","deletedAt":{"Time":"0001-01-01T00:00:00Z","Valid":false},"entityType":"platform","entityId":3,"parentId":{"Int64":1,"Valid":true},"authorId":{"Int64":1,"Valid":true},"authorEmail":"admin@example.com","body":"Reply","editedAt":{"Time":"2024-05-02T10:00:00Z","Valid":true},"replies":[]}
+				]},
+				{"id":2,"createdAt":"2024-05-01T10:00:00Z","modifiedAt":"0001-01-01T00:00:00Z","deletedAt":{"Time":"2024-05-02T10:00:00Z","Valid":true},"entityType":"platform","entityId":3,"parentId":{"Int64":0,"Valid":false},"authorId":{"Int64":0,"Valid":false},"authorEmail":"","body":"","editedAt":{"Time":"0001-01-01T00:00:00Z","Valid":false},"replies":[
+					{"id":3,"createdAt":"2024-05-01T10:00:00Z","modifiedAt":"0001-01-01T00:00:00Z","deletedAt":{"Time":"0001-01-01T00:00:00Z","Valid":false},"entityType":"platform","entityId":3,"parentId":{"Int64":2,"Valid":true},"authorId":{"Int64":1,"Valid":true},"authorEmail":"admin@example.com","body":"Reply to removed","editedAt":{"Time":"0001-01-01T00:00:00Z","Valid":false},"replies":[]}
+				]}
+			]`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			// Initilize test router, environemnt and mock database
+			r, mockDb, mockSql, env, err := utils.SetupTestEnvironment(test.MockDbCall)
+			// Close the mock database at the end of the test
+			defer mockDb.Close()
+
+			// Check for errors during setup
+			require.NoError(t, err)
+
+			// Register handler
+			r.GET("/api/v1/platforms/:platformId/comments", func(c *gin.Context) {
+				c.Set("user", test.User)
+				GetComments(env, db.PLATFORM_ENTITY, "platformId")(c)
+			})
+
+			// Create httptest request
+			req, _ := http.NewRequest("GET", fmt.Sprintf("/api/v1/platforms/%s/comments", test.IdString), nil)
+			w := httptest.NewRecorder()
+
+			// Mock request
+			r.ServeHTTP(w, req)
+
+			// Read response data
+			responseData, _ := io.ReadAll(w.Body)
+
+			// Check response status
+			require.Equal(t, test.StatusCode, w.Code)
+
+			// Handle empty responses
+			response := string(responseData)
+			if response == "" {
+				response = "{}"
+			}
+
+			// Check response body
+			require.JSONEq(t, test.Response, response)
+
+			// Check for any remaining expectations
+			// we make sure that all expectations were met
+			if err := mockSql.ExpectationsWereMet(); err != nil {
+				t.Fatalf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
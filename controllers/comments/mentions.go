@@ -0,0 +1,111 @@
+package comments
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/webstradev/rsdb-backend/auth"
+	"github.com/webstradev/rsdb-backend/db"
+	"github.com/webstradev/rsdb-backend/mentions"
+	"github.com/webstradev/rsdb-backend/notify"
+	"github.com/webstradev/rsdb-backend/utils"
+)
+
+// Time the notifier gets to send the notifications of a comment
+const NOTIFY_TIMEOUT = 30 * time.Second
+
+// mentionedUsers returns the users mentioned in the body of a comment on a record, authors mentioning themselves are
+// left out. Only admins can be mentioned on private records (or records that were deleted), other users can't see them.
+func mentionedUsers(env *utils.Environment, entityType string, entityId int64, body string, authorId int64) ([]db.User, error) {
+	handles := mentions.Parse(body)
+
+	candidates, err := env.DB.GetMentionableUsers(handles)
+	if err != nil {
+		return nil, err
+	}
+
+	emails := []string{}
+	byEmail := map[string]db.User{}
+	for _, candidate := range candidates {
+		emails = append(emails, candidate.Email)
+		byEmail[candidate.Email] = candidate
+	}
+
+	users := []db.User{}
+	nonAdmins := false
+	for _, email := range mentions.Match(handles, emails) {
+		if byEmail[email].ID != authorId {
+			users = append(users, byEmail[email])
+			nonAdmins = nonAdmins || byEmail[email].Role != auth.AdminRole
+		}
+	}
+
+	if !nonAdmins {
+		return users, nil
+	}
+
+	private, err := env.DB.RecordIsPrivate(entityType, entityId)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, err
+	}
+	if err == nil && !private {
+		return users, nil
+	}
+
+	admins := []db.User{}
+	for _, user := range users {
+		if user.Role == auth.AdminRole {
+			admins = append(admins, user)
+		}
+	}
+
+	return admins, nil
+}
+
+func userIds(users []db.User) []int64 {
+	ids := []int64{}
+	for _, user := range users {
+		ids = append(ids, user.ID)
+	}
+
+	return ids
+}
+
+// notifyMentions lets the users know they were mentioned in the comment, in their inbox and through the notifier.
+// The messages are sent in the background so the request doesn't wait for the notifier, failures are logged.
+func notifyMentions(env *utils.Environment, comment db.Comment, users []db.User) {
+	if len(users) > 0 {
+		err := env.Inbox.Mentioned(comment, userIds(users))
+		if err != nil {
+			log.Println(err)
+		}
+	}
+
+	if env.Notifier == nil || len(users) == 0 {
+		return
+	}
+
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), NOTIFY_TIMEOUT)
+		defer cancel()
+
+		for _, user := range users {
+			err := env.Notifier.Notify(ctx, mentionMessage(user.Email, comment))
+			if err != nil {
+				log.Printf("mention of user %d in comment %d: %v", user.ID, comment.ID, err)
+			}
+		}
+	}()
+}
+
+func mentionMessage(to string, comment db.Comment) notify.Message {
+	return notify.Message{
+		To:      to,
+		Subject: fmt.Sprintf("You were mentioned in a comment on %s %d", comment.EntityType, comment.EntityId),
+		Body:    comment.Body,
+	}
+}
@@ -0,0 +1,106 @@
+package comments
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/require"
+	"github.com/webstradev/rsdb-backend/auth"
+	"github.com/webstradev/rsdb-backend/db"
+	"github.com/webstradev/rsdb-backend/utils"
+)
+
+func TestMentionedUsers(t *testing.T) {
+	expectUsers := func(mock sqlmock.Sqlmock) {
+		rows := sqlmock.NewRows(userColumns).
+			AddRow(5, "jane@example.com", auth.UserRole).
+			AddRow(6, "john@example.com", auth.AdminRole)
+		mock.ExpectQuery("SELECT (.+) FROM users").WithArgs("jane", "john", "jane", "john").WillReturnRows(rows)
+	}
+
+	tests := []struct {
+		Name       string
+		Body       string
+		MockDbCall func(sqlmock.Sqlmock)
+		Error      string
+		Expected   []int64
+	}{
+		{
+			"mentionedUsers - only admins mentioned",
+			"@john test",
+			func(mock sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows(userColumns).AddRow(6, "john@example.com", auth.AdminRole)
+				mock.ExpectQuery("SELECT (.+) FROM users").WithArgs("john", "john").WillReturnRows(rows)
+			},
+			"",
+			[]int64{6},
+		},
+		{
+			"mentionedUsers - public platform",
+			"@jane @john test",
+			func(mock sqlmock.Sqlmock) {
+				expectUsers(mock)
+				mock.ExpectQuery("SELECT privacy = \\? FROM platforms").WithArgs("private", 3).WillReturnRows(sqlmock.NewRows([]string{"private"}).AddRow(false))
+			},
+			"",
+			[]int64{5, 6},
+		},
+		{
+			"mentionedUsers - private platform",
+			"@jane @john test",
+			func(mock sqlmock.Sqlmock) {
+				expectUsers(mock)
+				mock.ExpectQuery("SELECT privacy = \\? FROM platforms").WithArgs("private", 3).WillReturnRows(sqlmock.NewRows([]string{"private"}).AddRow(true))
+			},
+			"",
+			[]int64{6},
+		},
+		{
+			"mentionedUsers - deleted platform",
+			"@jane @john test",
+			func(mock sqlmock.Sqlmock) {
+				expectUsers(mock)
+				mock.ExpectQuery("SELECT privacy = \\? FROM platforms").WithArgs("private", 3).WillReturnRows(sqlmock.NewRows([]string{"private"}))
+			},
+			"",
+			[]int64{6},
+		},
+		{
+			"mentionedUsers - sql error on RecordIsPrivate",
+			"@jane @john test",
+			func(mock sqlmock.Sqlmock) {
+				expectUsers(mock)
+				mock.ExpectQuery("SELECT privacy = \\? FROM platforms").WithArgs("private", 3).WillReturnError(errors.New("test"))
+			},
+			"test",
+			nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			// Initilize test router, environemnt and mock database
+			_, mockDb, mockSql, env, err := utils.SetupTestEnvironment(test.MockDbCall)
+			// Close the mock database at the end of the test
+			defer mockDb.Close()
+
+			// Check for errors during setup
+			require.NoError(t, err)
+
+			users, err := mentionedUsers(env, db.PLATFORM_ENTITY, 3, test.Body, 2)
+			if test.Error == "" {
+				require.NoError(t, err)
+				require.Equal(t, test.Expected, userIds(users))
+			} else {
+				require.EqualError(t, err, test.Error)
+			}
+
+			// Check for any remaining expectations
+			// we make sure that all expectations were met
+			if err := mockSql.ExpectationsWereMet(); err != nil {
+				t.Fatalf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,33 @@
+package contacts
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/webstradev/rsdb-backend/auth"
+	"github.com/webstradev/rsdb-backend/db"
+	"github.com/webstradev/rsdb-backend/utils"
+)
+
+// ExportContacts exports the contacts directory with the same filters as GetContacts, private contacts are only exported for admins
+func ExportContacts(env *utils.Environment) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		user, err := auth.GetUserFromContext(c)
+		if err != nil {
+			log.Println(err)
+			c.AbortWithStatus(http.StatusInternalServerError)
+			return
+		}
+
+		filter, err := contactFilterFromQuery(c)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		utils.StreamExport(c, "contacts", db.ContactExportColumns, func(write func([]string) error) error {
+			return env.DB.ExportContacts(filter, user.IsAdmin(), write)
+		})
+	}
+}
@@ -0,0 +1,138 @@
+package contacts
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+	"github.com/webstradev/rsdb-backend/auth"
+	"github.com/webstradev/rsdb-backend/utils"
+)
+
+func TestExportContacts(t *testing.T) {
+	tests := []struct {
+		Name        string
+		User        auth.TokenData
+		Query       string
+		MockDbCall  func(sqlmock.Sqlmock)
+		StatusCode  int
+		ContentType string
+		Response    string
+	}{
+		{
+			"ExportContacts - User Missing from Context",
+			auth.TokenData{},
+			"",
+			nil,
+			http.StatusInternalServerError,
+			"",
+			``,
+		},
+		{
+			"ExportContacts - Invalid category",
+			auth.TokenData{UserID: 1, Role: auth.UserRole},
+			"?category=notanint",
+			nil,
+			http.StatusBadRequest,
+			"application/json; charset=utf-8",
+			`{"error":"Invalid category"}`,
+		},
+		{
+			"ExportContacts - Invalid format",
+			auth.TokenData{UserID: 1, Role: auth.UserRole},
+			"?format=pdf",
+			nil,
+			http.StatusBadRequest,
+			"application/json; charset=utf-8",
+			`{"error":"Invalid format"}`,
+		},
+		{
+			"ExportContacts - sql error on ExportContacts",
+			auth.TokenData{UserID: 1, Role: auth.UserRole},
+			"",
+			func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT (.+) FROM contacts c").WithArgs("private", "private").WillReturnError(errors.New("test"))
+			},
+			http.StatusInternalServerError,
+			"",
+			``,
+		},
+		{
+			"ExportContacts - Filtered CSV without private contacts",
+			auth.TokenData{UserID: 1, Role: auth.UserRole},
+			"?country=NL&title=editor",
+			func(mock sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows([]string{"id", "name", "title", "email", "phone", "phone2", "address", "source", "privacy", "notes", "platform_id", "platform_name", "platform_country", "platform_categories"}).
+					AddRow(1, "John", "Editor", "john@example.com", "", "", "", "", "public", "", 2, "Acme TV", "NL", "news")
+				mock.ExpectQuery("SELECT (.+) FROM contacts c").WithArgs("NL", "%editor%", "private", "private").WillReturnRows(rows)
+			},
+			http.StatusOK,
+			"text/csv; charset=utf-8",
+			"id,name,title,email,phone,phone2,address,source,privacy,notes,platformId,platform,country,categories\n1,John,Editor,john@example.com,,,,,public,,2,Acme TV,NL,news\n",
+		},
+		{
+			"ExportContacts - NDJSON for admins",
+			auth.TokenData{UserID: 1, Role: auth.AdminRole},
+			"?format=ndjson",
+			func(mock sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows([]string{"id", "name", "title", "email", "phone", "phone2", "address", "source", "privacy", "notes", "platform_id", "platform_name", "platform_country", "platform_categories"}).
+					AddRow(1, "John", "", "", "", "", "", "", "private", "", 2, "Acme TV", "NL", "")
+				mock.ExpectQuery("SELECT (.+) FROM contacts c").WithoutArgs().WillReturnRows(rows)
+			},
+			http.StatusOK,
+			"application/x-ndjson",
+			`{"id":"1","name":"John","title":"","email":"","phone":"","phone2":"","address":"","source":"","privacy":"private","notes":"","platformId":"2","platform":"Acme TV","country":"NL","categories":""}` + "\n",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			// Initilize test router, environemnt and mock database
+			r, mockDb, mockSql, env, err := utils.SetupTestEnvironment(test.MockDbCall)
+			// Close the mock database at the end of the test
+			defer mockDb.Close()
+
+			// Check for errors during setup
+			require.NoError(t, err)
+
+			// Register handler
+			r.GET("/api/v1/contacts/export", func(c *gin.Context) {
+				// Add user to context if it exists
+				if test.User.UserID != 0 {
+					c.Set("user", test.User)
+				}
+
+				// Call handler
+				ExportContacts(env)(c)
+			})
+
+			// Create httptest request
+			req, _ := http.NewRequest("GET", "/api/v1/contacts/export"+test.Query, nil)
+			w := httptest.NewRecorder()
+
+			// Mock request
+			r.ServeHTTP(w, req)
+
+			// Read response data
+			responseData, _ := io.ReadAll(w.Body)
+
+			// Check response status and type
+			require.Equal(t, test.StatusCode, w.Code)
+			require.Equal(t, test.ContentType, w.Header().Get("Content-Type"))
+
+			// Check response body
+			require.Equal(t, test.Response, string(responseData))
+
+			// Check for any remaining expectations
+			// we make sure that all expectations were met
+			if err := mockSql.ExpectationsWereMet(); err != nil {
+				t.Fatalf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,95 @@
+package contacts
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/require"
+	"github.com/webstradev/rsdb-backend/utils"
+)
+
+func TestGetContactMerges(t *testing.T) {
+	tests := []struct {
+		Name       string
+		IdString   string
+		MockDbCall func(sqlmock.Sqlmock)
+		StatusCode int
+		Response   string
+	}{
+		{
+			"GetContactMerges - non int id",
+			"notanint",
+			nil,
+			http.StatusBadRequest,
+			`{"error":"Invalid ID"}`,
+		},
+		{
+			"GetContactMerges - sql error on GetContactMerges",
+			"1",
+			func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT (.+) FROM contact_merges").WithArgs(1).WillReturnError(errors.New("test"))
+			},
+			http.StatusInternalServerError,
+			`{}`,
+		},
+		{
+			"GetContactMerges - Valid Request",
+			"1",
+			func(mock sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows([]string{"id", "contact_id", "merged_contact_id", "merged_by", "fields", "merged_contact"}).
+					AddRow(5, 1, 2, 1, []byte(`{"name":"duplicate"}`), []byte(`{"id":2}`))
+				mock.ExpectQuery("SELECT (.+) FROM contact_merges").WithArgs(1).WillReturnRows(rows)
+			},
+			http.StatusOK,
+			`[{"id":5,"createdAt":"0001-01-01T00:00:00Z","modifiedAt":"0001-01-01T00:00:00Z","deletedAt":{"Time":"0001-01-01T00:00:00Z","Valid":false},"contactId":1,"mergedContactId":2,"mergedBy":{"Int64":1,"Valid":true},"fields":{"name":"duplicate"},"mergedContact":{"id":2}}]`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			// Initilize test router, environemnt and mock database
+			r, mockDb, mockSql, env, err := utils.SetupTestEnvironment(test.MockDbCall)
+			// Close the mock database at the end of the test
+			defer mockDb.Close()
+
+			// Check for errors during setup
+			require.NoError(t, err)
+
+			// Register handler
+			r.GET("/api/v1/admin/contacts/:contactId/merges", GetContactMerges(env))
+
+			// Create httptest request
+			req, _ := http.NewRequest("GET", fmt.Sprintf("/api/v1/admin/contacts/%s/merges", test.IdString), nil)
+			w := httptest.NewRecorder()
+
+			// Mock request
+			r.ServeHTTP(w, req)
+
+			// Read response data
+			responseData, _ := io.ReadAll(w.Body)
+
+			// Check response status
+			require.Equal(t, test.StatusCode, w.Code)
+
+			// Handle empty responses
+			response := string(responseData)
+			if response == "" {
+				response = "{}"
+			}
+
+			// Check response body
+			require.JSONEq(t, test.Response, response)
+
+			// Check for any remaining expectations
+			// we make sure that all expectations were met
+			if err := mockSql.ExpectationsWereMet(); err != nil {
+				t.Fatalf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,53 @@
+package contacts
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/webstradev/rsdb-backend/auth"
+	"github.com/webstradev/rsdb-backend/db"
+	"github.com/webstradev/rsdb-backend/utils"
+)
+
+// GetContacts lists a page of the contacts directory, private contacts only for admins
+func GetContacts(env *utils.Environment) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		user, err := auth.GetUserFromContext(c)
+		if err != nil {
+			log.Println(err)
+			c.AbortWithStatus(http.StatusInternalServerError)
+			return
+		}
+
+		page := c.MustGet("page").(int)
+		pageSize := c.MustGet("pageSize").(int)
+
+		filter, err := contactFilterFromQuery(c)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		contacts, err := env.DB.GetContactsDirectory(filter, user.IsAdmin(), page, pageSize)
+		if err != nil {
+			log.Println(err)
+			c.AbortWithStatus(http.StatusInternalServerError)
+			return
+		}
+
+		count, err := env.DB.CountContactsDirectory(filter, user.IsAdmin())
+		if err != nil {
+			log.Println(err)
+			c.AbortWithStatus(http.StatusInternalServerError)
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"total": count, "contacts": contacts})
+	}
+}
+
+// contactFilterFromQuery reads the filters of the contacts directory from the query string
+func contactFilterFromQuery(c *gin.Context) (db.ContactFilter, error) {
+	return db.ParseContactFilter(c.Request.URL.Query())
+}
@@ -0,0 +1,159 @@
+package contacts
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+	"github.com/webstradev/gin-pagination/v2/pkg/pagination"
+	"github.com/webstradev/rsdb-backend/auth"
+	"github.com/webstradev/rsdb-backend/utils"
+)
+
+func TestGetContacts(t *testing.T) {
+	tests := []struct {
+		Name       string
+		Query      string
+		User       auth.TokenData
+		MockDbCall func(sqlmock.Sqlmock)
+		StatusCode int
+		Response   string
+	}{
+		{
+			"GetContacts - User Missing from Context",
+			"page=0&pageSize=10",
+			auth.TokenData{},
+			nil,
+			http.StatusInternalServerError,
+			`{}`,
+		},
+		{
+			"GetContacts - Invalid category",
+			"page=0&pageSize=10&category=notanint",
+			auth.TokenData{UserID: 1, Role: auth.UserRole},
+			nil,
+			http.StatusBadRequest,
+			`{"error":"Invalid category"}`,
+		},
+		{
+			"GetContacts - Invalid sort",
+			"page=0&pageSize=10&sort=email",
+			auth.TokenData{UserID: 1, Role: auth.UserRole},
+			nil,
+			http.StatusBadRequest,
+			`{"error":"Invalid sort, use name, platform or created, prefixed with - for descending order"}`,
+		},
+		{
+			"GetContacts - Sorted request",
+			"page=0&pageSize=10&sort=-created",
+			auth.TokenData{UserID: 1, Role: auth.UserRole},
+			func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT c.(.+) FROM contacts c (.+) ORDER BY c.created_at DESC, c.id DESC").WithArgs("private", "private", 10, 0).WillReturnRows(sqlmock.NewRows([]string{"id"}))
+				mock.ExpectQuery("SELECT COUNT(.+) AS count FROM contacts c").WithArgs("private", "private").WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+			},
+			http.StatusOK,
+			`{"total":0,"contacts":[]}`,
+		},
+		{
+			"GetContacts - sql error on GetContactsDirectory",
+			"page=0&pageSize=10",
+			auth.TokenData{UserID: 1, Role: auth.UserRole},
+			func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT c.(.+) FROM contacts c").WithArgs("private", "private", 10, 0).WillReturnError(errors.New("test"))
+			},
+			http.StatusInternalServerError,
+			`{}`,
+		},
+		{
+			"GetContacts - sql error on CountContactsDirectory",
+			"page=0&pageSize=10",
+			auth.TokenData{UserID: 1, Role: auth.UserRole},
+			func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT c.(.+) FROM contacts c").WithArgs("private", "private", 10, 0).WillReturnRows(sqlmock.NewRows([]string{"id"}))
+				mock.ExpectQuery("SELECT COUNT(.+) AS count FROM contacts c").WithArgs("private", "private").WillReturnError(errors.New("test"))
+			},
+			http.StatusInternalServerError,
+			`{}`,
+		},
+		{
+			"GetContacts - Filtered request including private contacts for admins",
+			"page=1&pageSize=2&search=jo%25&country=netherlands&category=3&title=editor&privacy=public",
+			auth.TokenData{UserID: 1, Role: auth.AdminRole},
+			func(mock sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows([]string{"id", "name", "title", "email", "privacy", "platform_id", "person_id", "current", "platform_name", "platform_country", "platform_categories"}).
+					AddRow(1, "John", "Chief editor", "john@example.com", "public", 2, 1, true, "platform", "NL", "news,sports")
+				mock.ExpectQuery("SELECT c.(.+) FROM contacts c").
+					WithArgs(`%jo\%%`, `%jo\%%`, `%jo\%%`, `%jo\%%`, "NL", 3, "%editor%", "public", 2, 2).
+					WillReturnRows(rows)
+				mock.ExpectQuery("SELECT COUNT(.+) AS count FROM contacts c").
+					WithArgs(`%jo\%%`, `%jo\%%`, `%jo\%%`, `%jo\%%`, "NL", 3, "%editor%", "public").
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+			},
+			http.StatusOK,
+			`{"total":3,"contacts":[{"id":1,"createdAt":"0001-01-01T00:00:00Z","modifiedAt":"0001-01-01T00:00:00Z","deletedAt":{"Time":"0001-01-01T00:00:00Z","Valid":false},"name":"John","title":"Chief editor","email":"john@example.com","phone":"","phone2":"","address":"","notes":"","source":"","privacy":"public","createdBy":{"Int64":0,"Valid":false},"platformId":2,"personId":1,"startDate":{"Time":"0001-01-01T00:00:00Z","Valid":false},"endDate":{"Time":"0001-01-01T00:00:00Z","Valid":false},"current":true,"platformName":"platform","platformCountry":"NL","categoryString":"news,sports"}]}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			// Initilize test router, environemnt and mock database
+			r, mockDb, mockSql, env, err := utils.SetupTestEnvironment(test.MockDbCall)
+			// Close the mock database at the end of the test
+			defer mockDb.Close()
+
+			// Check for errors during setup
+			require.NoError(t, err)
+
+			// Register handler
+			r.GET("/api/v1/contacts",
+				pagination.New(
+					pagination.WithSizeText("pageSize"),
+					pagination.WithMinPageSize(1),
+					pagination.WithMaxPageSize(100),
+				),
+				func(c *gin.Context) {
+					// Add user to context if it exists
+					if test.User.UserID != 0 {
+						c.Set("user", test.User)
+					}
+
+					// Call handler
+					GetContacts(env)(c)
+				},
+			)
+
+			// Create httptest request
+			req, _ := http.NewRequest("GET", "/api/v1/contacts?"+test.Query, nil)
+			w := httptest.NewRecorder()
+
+			// Mock request
+			r.ServeHTTP(w, req)
+
+			// Read response data
+			responseData, _ := io.ReadAll(w.Body)
+
+			// Check response status
+			require.Equal(t, test.StatusCode, w.Code)
+
+			// Handle empty responses
+			response := string(responseData)
+			if response == "" {
+				response = "{}"
+			}
+
+			// Check response body
+			require.JSONEq(t, test.Response, response)
+
+			// Check for any remaining expectations
+			// we make sure that all expectations were met
+			if err := mockSql.ExpectationsWereMet(); err != nil {
+				t.Fatalf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
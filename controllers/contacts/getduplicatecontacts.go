@@ -0,0 +1,23 @@
+package contacts
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/webstradev/rsdb-backend/db"
+	"github.com/webstradev/rsdb-backend/utils"
+)
+
+func GetDuplicateContacts(env *utils.Environment) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		contacts, err := env.DB.GetContactsForDuplicateCheck()
+		if err != nil {
+			log.Println(err)
+			c.AbortWithStatus(http.StatusInternalServerError)
+			return
+		}
+
+		c.JSON(http.StatusOK, db.FindDuplicateContacts(contacts))
+	}
+}
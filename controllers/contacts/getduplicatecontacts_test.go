@@ -0,0 +1,99 @@
+package contacts
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/require"
+	"github.com/webstradev/rsdb-backend/utils"
+)
+
+func TestGetDuplicateContacts(t *testing.T) {
+	tests := []struct {
+		Name       string
+		MockDbCall func(sqlmock.Sqlmock)
+		StatusCode int
+		Response   string
+	}{
+		{
+			"GetDuplicateContacts - sql error on GetContactsForDuplicateCheck",
+			func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT (.+) FROM contacts").WillReturnError(errors.New("test"))
+			},
+			http.StatusInternalServerError,
+			`{}`,
+		},
+		{
+			"GetDuplicateContacts - Valid Request",
+			func(mock sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows([]string{"id", "name", "title", "email", "phone", "phone2", "platform_id", "platform_name"}).
+					AddRow(1, "Erik Westra", "CEO", "Erik@Example.com", "+31 6 1234 5678", "", 1, "Acme").
+					AddRow(2, "Westra, Erik", "", "erik@example.com ", "", "06-12345678", 2, "Acme Films").
+					AddRow(3, "Someone Else", "", "someone@example.com", "", "", 2, "Acme Films")
+				mock.ExpectQuery("SELECT (.+) FROM contacts").WillReturnRows(rows)
+			},
+			http.StatusOK,
+			`[
+				{"match":"email","key":"erik@example.com","contacts":[
+					{"id":1,"name":"Erik Westra","title":"CEO","email":"Erik@Example.com","phone":"+31 6 1234 5678","phone2":"","platformId":1,"platformName":"Acme"},
+					{"id":2,"name":"Westra, Erik","title":"","email":"erik@example.com ","phone":"","phone2":"06-12345678","platformId":2,"platformName":"Acme Films"}
+				]},
+				{"match":"name","key":"erik westra","contacts":[
+					{"id":1,"name":"Erik Westra","title":"CEO","email":"Erik@Example.com","phone":"+31 6 1234 5678","phone2":"","platformId":1,"platformName":"Acme"},
+					{"id":2,"name":"Westra, Erik","title":"","email":"erik@example.com ","phone":"","phone2":"06-12345678","platformId":2,"platformName":"Acme Films"}
+				]},
+				{"match":"phone","key":"612345678","contacts":[
+					{"id":1,"name":"Erik Westra","title":"CEO","email":"Erik@Example.com","phone":"+31 6 1234 5678","phone2":"","platformId":1,"platformName":"Acme"},
+					{"id":2,"name":"Westra, Erik","title":"","email":"erik@example.com ","phone":"","phone2":"06-12345678","platformId":2,"platformName":"Acme Films"}
+				]}
+			]`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			// Initilize test router, environemnt and mock database
+			r, mockDb, mockSql, env, err := utils.SetupTestEnvironment(test.MockDbCall)
+			// Close the mock database at the end of the test
+			defer mockDb.Close()
+
+			// Check for errors during setup
+			require.NoError(t, err)
+
+			// Register handler
+			r.GET("/api/v1/admin/contacts/duplicates", GetDuplicateContacts(env))
+
+			// Create httptest request
+			req, _ := http.NewRequest("GET", "/api/v1/admin/contacts/duplicates", nil)
+			w := httptest.NewRecorder()
+
+			// Mock request
+			r.ServeHTTP(w, req)
+
+			// Read response data
+			responseData, _ := io.ReadAll(w.Body)
+
+			// Check response status
+			require.Equal(t, test.StatusCode, w.Code)
+
+			// Handle empty responses
+			response := string(responseData)
+			if response == "" {
+				response = "{}"
+			}
+
+			// Check response body
+			require.JSONEq(t, test.Response, response)
+
+			// Check for any remaining expectations
+			// we make sure that all expectations were met
+			if err := mockSql.ExpectationsWereMet(); err != nil {
+				t.Fatalf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,76 @@
+package contacts
+
+import (
+	"database/sql"
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/webstradev/rsdb-backend/auth"
+	"github.com/webstradev/rsdb-backend/db"
+	"github.com/webstradev/rsdb-backend/utils"
+)
+
+type mergeContactsInput struct {
+	Duplicate int64             `json:"duplicate" binding:"required"`
+	Prefer    map[string]string `json:"prefer"`
+}
+
+func MergeContacts(env *utils.Environment) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		user, err := auth.GetUserFromContext(c)
+		if err != nil {
+			log.Println(err)
+			c.AbortWithStatus(http.StatusInternalServerError)
+			return
+		}
+
+		// Get the surviving contact ID from URL
+		idString := c.Param("contactId")
+		id, err := strconv.ParseInt(idString, 10, 64)
+		if err != nil {
+			log.Println(err)
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Invalid ID"})
+			return
+		}
+
+		// Validate Input
+		input := mergeContactsInput{}
+		err = c.ShouldBindJSON(&input)
+		if err != nil {
+			log.Println(err)
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		merge, err := env.DB.MergeContacts(id, input.Duplicate, input.Prefer, user.UserID)
+		if err != nil {
+			if errors.Is(err, db.ErrInvalidContactMerge) || errors.Is(err, db.ErrInvalidContactPreference) {
+				c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+				return
+			}
+			if errors.Is(err, sql.ErrNoRows) {
+				c.AbortWithStatus(http.StatusNotFound)
+				return
+			}
+			log.Println(err)
+			c.AbortWithStatus(http.StatusInternalServerError)
+			return
+		}
+
+		// Let open frontends and the subscribed webhooks know the duplicate was merged into the contact
+		err = env.Changes.Publish(db.CONTACT_ENTITY, db.CHANGE_UPDATED, id, user.UserID)
+		if err != nil {
+			log.Println(err)
+		}
+
+		err = env.Changes.Publish(db.CONTACT_ENTITY, db.CHANGE_DELETED, input.Duplicate, user.UserID)
+		if err != nil {
+			log.Println(err)
+		}
+
+		c.JSON(http.StatusOK, merge)
+	}
+}
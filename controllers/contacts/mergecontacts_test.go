@@ -0,0 +1,205 @@
+package contacts
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+	"github.com/webstradev/rsdb-backend/auth"
+	"github.com/webstradev/rsdb-backend/utils"
+)
+
+// expectMove expects contact 2 to be merged into contact 1 after the survivor was updated
+func expectMove(mock sqlmock.Sqlmock) {
+	mock.ExpectExec("UPDATE people pe JOIN contacts c").WithArgs(1).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("UPDATE contacts SET deleted_at").WithArgs(2).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("UPDATE tasks SET entity_id = \\? WHERE entity_type = 'contact'").WithArgs(1, 2).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("UPDATE attachments SET entity_id = \\? WHERE entity_type = 'contact'").WithArgs(1, 2).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("UPDATE comments SET entity_id = \\? WHERE entity_type = 'contact'").WithArgs(1, 2).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("INSERT IGNORE INTO favorites").WithArgs(1, 2).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("INSERT IGNORE INTO activities_contacts").WithArgs(1, 2).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("DELETE FROM favorites").WithArgs(2).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("DELETE FROM activities_contacts").WithArgs(2).WillReturnResult(sqlmock.NewResult(0, 1))
+}
+
+func TestMergeContacts(t *testing.T) {
+	contactColumns := []string{"id", "name", "title", "email", "phone", "phone2", "address", "notes", "source", "privacy", "platform_id"}
+
+	tests := []struct {
+		Name       string
+		IdString   string
+		User       auth.TokenData
+		MockDbCall func(sqlmock.Sqlmock)
+		StatusCode int
+		Body       string
+		Response   string
+	}{
+		{
+			"MergeContacts - User Missing from Context",
+			"1",
+			auth.TokenData{},
+			nil,
+			http.StatusInternalServerError,
+			`{"duplicate":2}`,
+			`{}`,
+		},
+		{
+			"MergeContacts - non int id",
+			"notanint",
+			auth.TokenData{UserID: 1},
+			nil,
+			http.StatusBadRequest,
+			`{"duplicate":2}`,
+			`{"error":"Invalid ID"}`,
+		},
+		{
+			"MergeContacts - missing duplicate",
+			"1",
+			auth.TokenData{UserID: 1},
+			nil,
+			http.StatusBadRequest,
+			`{}`,
+			`{"error":"Key: 'mergeContactsInput.Duplicate' Error:Field validation for 'Duplicate' failed on the 'required' tag"}`,
+		},
+		{
+			"MergeContacts - merge into itself",
+			"1",
+			auth.TokenData{UserID: 1},
+			nil,
+			http.StatusBadRequest,
+			`{"duplicate":1}`,
+			`{"error":"a contact can not be merged into itself"}`,
+		},
+		{
+			"MergeContacts - duplicate not found",
+			"1",
+			auth.TokenData{UserID: 1},
+			func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT (.+) FROM contacts").WithArgs(1).WillReturnRows(sqlmock.NewRows(contactColumns).AddRow(1, "Erik", "", "", "", "", "", "", "", "", 1))
+				mock.ExpectQuery("SELECT (.+) FROM contacts").WithArgs(2).WillReturnRows(sqlmock.NewRows(contactColumns))
+				mock.ExpectRollback()
+			},
+			http.StatusNotFound,
+			`{"duplicate":2}`,
+			`{}`,
+		},
+		{
+			"MergeContacts - invalid preference",
+			"1",
+			auth.TokenData{UserID: 1},
+			func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT (.+) FROM contacts").WithArgs(1).WillReturnRows(sqlmock.NewRows(contactColumns).AddRow(1, "Erik", "", "", "", "", "", "", "", "", 1))
+				mock.ExpectQuery("SELECT (.+) FROM contacts").WithArgs(2).WillReturnRows(sqlmock.NewRows(contactColumns).AddRow(2, "Erik W", "", "", "", "", "", "", "", "", 2))
+				mock.ExpectRollback()
+			},
+			http.StatusBadRequest,
+			`{"duplicate":2,"prefer":{"platformId":"duplicate"}}`,
+			`{"error":"invalid field preference, use a contact field with either survivor or duplicate"}`,
+		},
+		{
+			"MergeContacts - sql error on audit insert",
+			"1",
+			auth.TokenData{UserID: 1},
+			func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT (.+) FROM contacts").WithArgs(1).WillReturnRows(sqlmock.NewRows(contactColumns).AddRow(1, "Erik", "", "", "", "", "", "", "", "", 1))
+				mock.ExpectQuery("SELECT (.+) FROM contacts").WithArgs(2).WillReturnRows(sqlmock.NewRows(contactColumns).AddRow(2, "Erik W", "", "", "", "", "", "", "", "", 2))
+				mock.ExpectExec("UPDATE contacts SET").WillReturnResult(sqlmock.NewResult(0, 1))
+				expectMove(mock)
+				mock.ExpectExec("INSERT INTO contact_merges").WillReturnError(errors.New("test"))
+				mock.ExpectRollback()
+			},
+			http.StatusInternalServerError,
+			`{"duplicate":2}`,
+			`{}`,
+		},
+		{
+			"MergeContacts - Valid Request",
+			"1",
+			auth.TokenData{UserID: 1},
+			func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT (.+) FROM contacts").WithArgs(1).
+					WillReturnRows(sqlmock.NewRows(contactColumns).AddRow(1, "Erik", "CEO", "erik@example.com", "", "", "", "met at festival", "a", "private", 1))
+				mock.ExpectQuery("SELECT (.+) FROM contacts").WithArgs(2).
+					WillReturnRows(sqlmock.NewRows(contactColumns).AddRow(2, "Erik Westra", "Founder", "", "+31612345678", "", "Main street 1", "likes docs", "b", "public", 2))
+				mock.ExpectExec("UPDATE contacts SET").
+					WithArgs("Erik Westra", "CEO", "erik@example.com", "+31612345678", "", "Main street 1", "met at festival\n\nlikes docs", "a", "private", 1).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				expectMove(mock)
+				mock.ExpectExec("INSERT INTO contact_merges").
+					WithArgs(1, 2, 1, sqlmock.AnyArg(), sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(5, 1))
+				mock.ExpectCommit()
+			},
+			http.StatusOK,
+			`{"duplicate":2,"prefer":{"name":"duplicate"}}`,
+			`{
+				"id":5,"createdAt":"0001-01-01T00:00:00Z","modifiedAt":"0001-01-01T00:00:00Z","deletedAt":{"Time":"0001-01-01T00:00:00Z","Valid":false},
+				"contactId":1,"mergedContactId":2,"mergedBy":{"Int64":1,"Valid":true},
+				"fields":{"name":"duplicate","title":"survivor","email":"survivor","phone":"duplicate","phone2":"survivor","address":"duplicate","source":"survivor","privacy":"survivor","notes":"combined"},
+				"mergedContact":{"id":2,"createdAt":"0001-01-01T00:00:00Z","modifiedAt":"0001-01-01T00:00:00Z","deletedAt":{"Time":"0001-01-01T00:00:00Z","Valid":false},"name":"Erik Westra","title":"Founder","email":"","phone":"+31612345678","phone2":"","address":"Main street 1","notes":"likes docs","source":"b","privacy":"public","createdBy":{"Int64":0,"Valid":false},"platformId":2,"personId":0,"startDate":{"Time":"0001-01-01T00:00:00Z","Valid":false},"endDate":{"Time":"0001-01-01T00:00:00Z","Valid":false},"current":false}
+			}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			// Initilize test router, environemnt and mock database
+			r, mockDb, mockSql, env, err := utils.SetupTestEnvironment(test.MockDbCall)
+			// Close the mock database at the end of the test
+			defer mockDb.Close()
+
+			// Check for errors during setup
+			require.NoError(t, err)
+
+			// Register handler
+			r.POST("/api/v1/admin/contacts/:contactId/merge", func(c *gin.Context) {
+				// Add user to context if it exists
+				if test.User.UserID != 0 {
+					c.Set("user", test.User)
+				}
+
+				// Call handler
+				MergeContacts(env)(c)
+			})
+
+			// Create httptest request
+			req, _ := http.NewRequest("POST", fmt.Sprintf("/api/v1/admin/contacts/%s/merge", test.IdString), strings.NewReader(test.Body))
+			w := httptest.NewRecorder()
+
+			// Mock request
+			r.ServeHTTP(w, req)
+
+			// Read response data
+			responseData, _ := io.ReadAll(w.Body)
+
+			// Check response status
+			require.Equal(t, test.StatusCode, w.Code)
+
+			// Handle empty responses
+			response := string(responseData)
+			if response == "" {
+				response = "{}"
+			}
+
+			// Check response body
+			require.JSONEq(t, test.Response, response)
+
+			// Check for any remaining expectations
+			// we make sure that all expectations were met
+			if err := mockSql.ExpectationsWereMet(); err != nil {
+				t.Fatalf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,124 @@
+package contacts
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/webstradev/rsdb-backend/auth"
+	"github.com/webstradev/rsdb-backend/db"
+	"github.com/webstradev/rsdb-backend/utils"
+	"github.com/webstradev/rsdb-backend/vcard"
+)
+
+func contactCard(contact db.DirectoryContact) vcard.Card {
+	return vcard.Card{
+		Name:         contact.Name,
+		Title:        contact.Title,
+		Organization: contact.PlatformName,
+		Emails:       []string{contact.Email},
+		Phones:       []string{contact.Phone, contact.Phone2},
+		Address:      contact.Address,
+		Note:         contact.Notes,
+	}
+}
+
+// GetContactVCard returns a single contact as vCard, private contacts only for admins
+func GetContactVCard(env *utils.Environment) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		user, err := auth.GetUserFromContext(c)
+		if err != nil {
+			log.Println(err)
+			c.AbortWithStatus(http.StatusInternalServerError)
+			return
+		}
+
+		idString := c.Param("contactId")
+		id, err := strconv.ParseInt(idString, 10, 64)
+		if err != nil {
+			log.Println(err)
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Invalid ID"})
+			return
+		}
+
+		contact, err := env.DB.GetDirectoryContact(id)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				c.AbortWithStatus(http.StatusNotFound)
+				return
+			}
+			log.Println(err)
+			c.AbortWithStatus(http.StatusInternalServerError)
+			return
+		}
+
+		if !utils.CheckRecordAccess(c, env, user, db.CONTACT_ENTITY, id) {
+			return
+		}
+
+		c.Header("Content-Type", vcard.ContentType)
+		c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="contact-%d.vcf"`, id))
+
+		err = vcard.NewEncoder(c.Writer).Encode(contactCard(contact))
+		if err != nil {
+			log.Println(err)
+			c.Abort()
+		}
+	}
+}
+
+// ExportVCards streams the contacts matching the directory filters as vCards. When platformParam is set
+// only the contacts of the platform in that URL parameter are exported. Private contacts are only exported for admins.
+func ExportVCards(env *utils.Environment, platformParam string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		user, err := auth.GetUserFromContext(c)
+		if err != nil {
+			log.Println(err)
+			c.AbortWithStatus(http.StatusInternalServerError)
+			return
+		}
+
+		filter, err := contactFilterFromQuery(c)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		filename := "contacts.vcf"
+		if platformParam != "" {
+			filter.PlatformId, err = strconv.ParseInt(c.Param(platformParam), 10, 64)
+			if err != nil {
+				log.Println(err)
+				c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Invalid ID"})
+				return
+			}
+			filename = fmt.Sprintf("platform-%d-contacts.vcf", filter.PlatformId)
+		}
+
+		http.NewResponseController(c.Writer).SetWriteDeadline(time.Now().Add(utils.EXPORT_TIMEOUT))
+
+		c.Header("Content-Type", vcard.ContentType)
+		c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, filename))
+
+		encoder := vcard.NewEncoder(c.Writer)
+		err = env.DB.StreamContacts(filter, user.IsAdmin(), func(contact db.DirectoryContact) error {
+			return encoder.Encode(contactCard(contact))
+		})
+		if err != nil {
+			log.Println(err)
+			// Once part of the file has been sent the status can't be changed anymore
+			if !c.Writer.Written() {
+				c.Header("Content-Type", "")
+				c.Header("Content-Disposition", "")
+				c.AbortWithStatus(http.StatusInternalServerError)
+				return
+			}
+			c.Abort()
+		}
+	}
+}
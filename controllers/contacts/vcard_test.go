@@ -0,0 +1,274 @@
+package contacts
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+	"github.com/webstradev/rsdb-backend/auth"
+	"github.com/webstradev/rsdb-backend/utils"
+)
+
+var vCardContactColumns = []string{"id", "name", "title", "email", "phone", "phone2", "address", "notes", "privacy", "platform_id", "platform_name", "platform_country", "platform_categories"}
+
+func TestGetContactVCard(t *testing.T) {
+	tests := []struct {
+		Name       string
+		User       auth.TokenData
+		IdString   string
+		MockDbCall func(sqlmock.Sqlmock)
+		StatusCode int
+		Response   string
+	}{
+		{
+			"GetContactVCard - User Missing from Context",
+			auth.TokenData{},
+			"1",
+			nil,
+			http.StatusInternalServerError,
+			``,
+		},
+		{
+			"GetContactVCard - non int id",
+			auth.TokenData{UserID: 1},
+			"notanint",
+			nil,
+			http.StatusBadRequest,
+			`{"error":"Invalid ID"}`,
+		},
+		{
+			"GetContactVCard - contact not found",
+			auth.TokenData{UserID: 1},
+			"1",
+			func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT c.(.+) FROM contacts c").WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"id"}))
+			},
+			http.StatusNotFound,
+			``,
+		},
+		{
+			"GetContactVCard - sql error on GetDirectoryContact",
+			auth.TokenData{UserID: 1},
+			"1",
+			func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT c.(.+) FROM contacts c").WithArgs(1).WillReturnError(errors.New("test"))
+			},
+			http.StatusInternalServerError,
+			``,
+		},
+		{
+			"GetContactVCard - Valid Request",
+			auth.TokenData{UserID: 1},
+			"1",
+			func(mock sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows(vCardContactColumns).
+					AddRow(1, "John Doe", "Editor", "john@example.com", "+31 6 12345678", "", "", "", "public", 2, "Acme TV", "NL", "")
+				mock.ExpectQuery("SELECT c.(.+) FROM contacts c").WithArgs(1).WillReturnRows(rows)
+				mock.ExpectQuery("SELECT c.privacy = \\? OR p.privacy = \\?").WithArgs("private", "private", 1).WillReturnRows(sqlmock.NewRows([]string{"private"}).AddRow(false))
+			},
+			http.StatusOK,
+			"BEGIN:VCARD\r\nVERSION:4.0\r\nFN:John Doe\r\nN:Doe;John;;;\r\nORG:Acme TV\r\nTITLE:Editor\r\nEMAIL;TYPE=work:john@example.com\r\nTEL;TYPE=work,voice:+31 6 12345678\r\nEND:VCARD\r\n",
+		},
+		{
+			"GetContactVCard - private contact for non admin",
+			auth.TokenData{UserID: 1},
+			"1",
+			func(mock sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows(vCardContactColumns).
+					AddRow(1, "John Doe", "Editor", "john@example.com", "+31 6 12345678", "", "", "", "private", 2, "Acme TV", "NL", "")
+				mock.ExpectQuery("SELECT c.(.+) FROM contacts c").WithArgs(1).WillReturnRows(rows)
+				mock.ExpectQuery("SELECT c.privacy = \\? OR p.privacy = \\?").WithArgs("private", "private", 1).WillReturnRows(sqlmock.NewRows([]string{"private"}).AddRow(true))
+			},
+			http.StatusForbidden,
+			``,
+		},
+		{
+			"GetContactVCard - private contact for admin",
+			auth.TokenData{UserID: 1, Role: "admin"},
+			"1",
+			func(mock sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows(vCardContactColumns).
+					AddRow(1, "John Doe", "Editor", "john@example.com", "", "", "", "", "private", 2, "Acme TV", "NL", "")
+				mock.ExpectQuery("SELECT c.(.+) FROM contacts c").WithArgs(1).WillReturnRows(rows)
+				mock.ExpectQuery("SELECT c.privacy = \\? OR p.privacy = \\?").WithArgs("private", "private", 1).WillReturnRows(sqlmock.NewRows([]string{"private"}).AddRow(true))
+			},
+			http.StatusOK,
+			"BEGIN:VCARD\r\nVERSION:4.0\r\nFN:John Doe\r\nN:Doe;John;;;\r\nORG:Acme TV\r\nTITLE:Editor\r\nEMAIL;TYPE=work:john@example.com\r\nEND:VCARD\r\n",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			// Initilize test router, environemnt and mock database
+			r, mockDb, mockSql, env, err := utils.SetupTestEnvironment(test.MockDbCall)
+			// Close the mock database at the end of the test
+			defer mockDb.Close()
+
+			// Check for errors during setup
+			require.NoError(t, err)
+
+			// Register handler
+			r.Use(func(c *gin.Context) {
+				// Add user to context if it exists
+				if test.User.UserID != 0 {
+					c.Set("user", test.User)
+				}
+			})
+			r.GET("/api/v1/contacts/:contactId/vcard", GetContactVCard(env))
+
+			// Create httptest request
+			req, _ := http.NewRequest("GET", fmt.Sprintf("/api/v1/contacts/%s/vcard", test.IdString), nil)
+			w := httptest.NewRecorder()
+
+			// Mock request
+			r.ServeHTTP(w, req)
+
+			// Read response data
+			responseData, _ := io.ReadAll(w.Body)
+
+			// Check response status
+			require.Equal(t, test.StatusCode, w.Code)
+
+			// Check response body
+			require.Equal(t, test.Response, string(responseData))
+
+			// Check for any remaining expectations
+			// we make sure that all expectations were met
+			if err := mockSql.ExpectationsWereMet(); err != nil {
+				t.Fatalf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func TestExportVCards(t *testing.T) {
+	tests := []struct {
+		Name          string
+		User          auth.TokenData
+		PlatformParam string
+		Path          string
+		MockDbCall    func(sqlmock.Sqlmock)
+		StatusCode    int
+		Response      string
+	}{
+		{
+			"ExportVCards - User Missing from Context",
+			auth.TokenData{},
+			"",
+			"/api/v1/contacts/vcard",
+			nil,
+			http.StatusInternalServerError,
+			``,
+		},
+		{
+			"ExportVCards - Invalid category",
+			auth.TokenData{UserID: 1, Role: auth.UserRole},
+			"",
+			"/api/v1/contacts/vcard?category=notanint",
+			nil,
+			http.StatusBadRequest,
+			`{"error":"Invalid category"}`,
+		},
+		{
+			"ExportVCards - non int platform id",
+			auth.TokenData{UserID: 1, Role: auth.UserRole},
+			"platformId",
+			"/api/v1/platforms/notanint/contacts/vcard",
+			nil,
+			http.StatusBadRequest,
+			`{"error":"Invalid ID"}`,
+		},
+		{
+			"ExportVCards - sql error on StreamContacts",
+			auth.TokenData{UserID: 1, Role: auth.UserRole},
+			"",
+			"/api/v1/contacts/vcard?search=john",
+			func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT c.(.+) FROM contacts c").WithArgs("%john%", "%john%", "%john%", "%john%", "private", "private").WillReturnError(errors.New("test"))
+			},
+			http.StatusInternalServerError,
+			``,
+		},
+		{
+			"ExportVCards - Search result",
+			auth.TokenData{UserID: 1, Role: auth.UserRole},
+			"",
+			"/api/v1/contacts/vcard?search=john",
+			func(mock sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows(vCardContactColumns).
+					AddRow(1, "John Doe", "", "john@example.com", "", "", "", "", "public", 2, "Acme TV", "NL", "").
+					AddRow(2, "Johnny", "", "", "", "", "", "", "public", 3, "Other TV", "BE", "")
+				mock.ExpectQuery("SELECT c.(.+) FROM contacts c").WithArgs("%john%", "%john%", "%john%", "%john%", "private", "private").WillReturnRows(rows)
+			},
+			http.StatusOK,
+			"BEGIN:VCARD\r\nVERSION:4.0\r\nFN:John Doe\r\nN:Doe;John;;;\r\nORG:Acme TV\r\nEMAIL;TYPE=work:john@example.com\r\nEND:VCARD\r\n" +
+				"BEGIN:VCARD\r\nVERSION:4.0\r\nFN:Johnny\r\nN:;Johnny;;;\r\nORG:Other TV\r\nEND:VCARD\r\n",
+		},
+		{
+			"ExportVCards - Contacts of a platform for admins",
+			auth.TokenData{UserID: 1, Role: auth.AdminRole},
+			"platformId",
+			"/api/v1/platforms/2/contacts/vcard",
+			func(mock sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows(vCardContactColumns).
+					AddRow(1, "John Doe", "", "", "", "", "", "", "private", 2, "Acme TV", "NL", "")
+				mock.ExpectQuery("SELECT c.(.+) FROM contacts c").WithArgs(2).WillReturnRows(rows)
+			},
+			http.StatusOK,
+			"BEGIN:VCARD\r\nVERSION:4.0\r\nFN:John Doe\r\nN:Doe;John;;;\r\nORG:Acme TV\r\nEND:VCARD\r\n",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			// Initilize test router, environemnt and mock database
+			r, mockDb, mockSql, env, err := utils.SetupTestEnvironment(test.MockDbCall)
+			// Close the mock database at the end of the test
+			defer mockDb.Close()
+
+			// Check for errors during setup
+			require.NoError(t, err)
+
+			// Register handler on both routes
+			handler := func(c *gin.Context) {
+				// Add user to context if it exists
+				if test.User.UserID != 0 {
+					c.Set("user", test.User)
+				}
+
+				// Call handler
+				ExportVCards(env, test.PlatformParam)(c)
+			}
+			r.GET("/api/v1/contacts/vcard", handler)
+			r.GET("/api/v1/platforms/:platformId/contacts/vcard", handler)
+
+			// Create httptest request
+			req, _ := http.NewRequest("GET", test.Path, nil)
+			w := httptest.NewRecorder()
+
+			// Mock request
+			r.ServeHTTP(w, req)
+
+			// Read response data
+			responseData, _ := io.ReadAll(w.Body)
+
+			// Check response status
+			require.Equal(t, test.StatusCode, w.Code)
+
+			// Check response body
+			require.Equal(t, test.Response, string(responseData))
+
+			// Check for any remaining expectations
+			// we make sure that all expectations were met
+			if err := mockSql.ExpectationsWereMet(); err != nil {
+				t.Fatalf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
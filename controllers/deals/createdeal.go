@@ -0,0 +1,150 @@
+package deals
+
+import (
+	"database/sql"
+	"errors"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/webstradev/rsdb-backend/auth"
+	"github.com/webstradev/rsdb-backend/db"
+	"github.com/webstradev/rsdb-backend/normalize"
+	"github.com/webstradev/rsdb-backend/utils"
+)
+
+type dealInput struct {
+	ProjectId   int64    `json:"projectId" binding:"required"`
+	PlatformId  int64    `json:"platformId" binding:"required"`
+	Territories []string `json:"territories"`
+	Media       []string `json:"media"`
+	Exclusive   bool     `json:"exclusive"`
+	WindowStart string   `json:"windowStart" binding:"required"`
+	WindowEnd   string   `json:"windowEnd" binding:"required"`
+	Fee         *int64   `json:"fee"`
+	Currency    string   `json:"currency"`
+	Status      string   `json:"status"`
+}
+
+// deal converts the input, territories are stored as ISO 3166-1 codes and deals are drafts unless stated otherwise
+func (input dealInput) deal() (db.Deal, error) {
+	windowStart, err := time.Parse(time.DateOnly, input.WindowStart)
+	if err != nil {
+		return db.Deal{}, errors.New("Invalid window start, use YYYY-MM-DD")
+	}
+
+	windowEnd, err := time.Parse(time.DateOnly, input.WindowEnd)
+	if err != nil {
+		return db.Deal{}, errors.New("Invalid window end, use YYYY-MM-DD")
+	}
+
+	deal := db.Deal{
+		ProjectId:   input.ProjectId,
+		PlatformId:  input.PlatformId,
+		Exclusive:   input.Exclusive,
+		WindowStart: windowStart,
+		WindowEnd:   windowEnd,
+		Currency:    input.Currency,
+		Status:      input.Status,
+		Territories: []string{},
+		Media:       []string{},
+	}
+
+	if input.Fee != nil {
+		deal.Fee = sql.NullInt64{Int64: *input.Fee, Valid: true}
+	}
+	if deal.Status == "" {
+		deal.Status = db.DEAL_DRAFT
+	}
+
+	for _, territory := range input.Territories {
+		code, err := normalize.Country(territory)
+		if err != nil || code == "" {
+			return db.Deal{}, errors.New("Invalid territory " + territory + ", use an ISO 3166-1 code or English country name")
+		}
+		deal.Territories = append(deal.Territories, code)
+	}
+
+	for _, media := range input.Media {
+		deal.Media = append(deal.Media, strings.ToLower(strings.TrimSpace(media)))
+	}
+
+	return deal, deal.Validate()
+}
+
+// checkDeal makes sure the project and platform of a deal exist. It aborts the request and returns false otherwise.
+func checkDeal(c *gin.Context, env *utils.Environment, deal db.Deal) bool {
+	linked := []struct {
+		entityType string
+		id         int64
+	}{{db.PROJECT_ENTITY, deal.ProjectId}, {db.PLATFORM_ENTITY, deal.PlatformId}}
+
+	for _, entity := range linked {
+		exists, err := env.DB.EntityExists(entity.entityType, entity.id)
+		if err != nil {
+			log.Println(err)
+			c.AbortWithStatus(http.StatusInternalServerError)
+			return false
+		}
+		if !exists {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Linked " + entity.entityType + " not found"})
+			return false
+		}
+	}
+
+	return true
+}
+
+// abortDealError aborts the request with the error of storing a deal, deals that overlap an exclusive deal are
+// refused with the deals they conflict with
+func abortDealError(c *gin.Context, err error) {
+	var conflict *db.DealConflictError
+	if errors.As(err, &conflict) {
+		c.AbortWithStatusJSON(http.StatusConflict, gin.H{"error": "The deal overlaps deals that block it in territory, media and time", "conflicts": conflict.Conflicts})
+		return
+	}
+
+	log.Println(err)
+	c.AbortWithStatus(http.StatusInternalServerError)
+}
+
+func CreateDeal(env *utils.Environment) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		user, err := auth.GetUserFromContext(c)
+		if err != nil {
+			log.Println(err)
+			c.AbortWithStatus(http.StatusInternalServerError)
+			return
+		}
+
+		input := dealInput{}
+		err = c.ShouldBindJSON(&input)
+		if err != nil {
+			log.Println(err)
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		deal, err := input.deal()
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		if !checkDeal(c, env, deal) {
+			return
+		}
+
+		deal.CreatedBy = sql.NullInt64{Int64: user.UserID, Valid: true}
+
+		id, err := env.DB.InsertDeal(deal)
+		if err != nil {
+			abortDealError(c, err)
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"id": id, "message": "Deal created successfully"})
+	}
+}
@@ -0,0 +1,294 @@
+package deals
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+	"github.com/webstradev/rsdb-backend/auth"
+	"github.com/webstradev/rsdb-backend/utils"
+)
+
+// Columns of the deal query and of the territories and media of deals
+var (
+	dealColumns   = []string{"id", "project_id", "project_title", "platform_id", "platform_name", "exclusive", "window_start", "window_end", "fee", "currency", "status", "created_by"}
+	rightsColumns = []string{"deal_id", "kind", "value"}
+)
+
+func TestCreateDeal(t *testing.T) {
+	windowStart := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	windowEnd := time.Date(2025, 12, 31, 0, 0, 0, 0, time.UTC)
+
+	expectLinked := func(mock sqlmock.Sqlmock) {
+		mock.ExpectQuery("SELECT COUNT(.+) FROM projects").WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+		mock.ExpectQuery("SELECT COUNT(.+) FROM platforms").WithArgs(2).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+	}
+
+	expectLocked := func(mock sqlmock.Sqlmock) {
+		mock.ExpectBegin()
+		mock.ExpectQuery("SELECT id FROM projects WHERE id = \\? FOR UPDATE").WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	}
+
+	tests := []struct {
+		Name       string
+		User       auth.TokenData
+		MockDbCall func(sqlmock.Sqlmock)
+		StatusCode int
+		Body       string
+		Response   string
+	}{
+		{
+			"CreateDeal - User Missing from Context",
+			auth.TokenData{},
+			nil,
+			http.StatusInternalServerError,
+			`{}`,
+			`{}`,
+		},
+		{
+			"CreateDeal - missing required fields",
+			auth.TokenData{UserID: 1},
+			nil,
+			http.StatusBadRequest,
+			`{"projectId":1,"platformId":2}`,
+			`{"error":"Key: 'dealInput.WindowStart' Error:Field validation for 'WindowStart' failed on the 'required' tag\nKey: 'dealInput.WindowEnd' Error:Field validation for 'WindowEnd' failed on the 'required' tag"}`,
+		},
+		{
+			"CreateDeal - invalid window start",
+			auth.TokenData{UserID: 1},
+			nil,
+			http.StatusBadRequest,
+			`{"projectId":1,"platformId":2,"windowStart":"01-01-2025","windowEnd":"2025-12-31"}`,
+			`{"error":"Invalid window start, use YYYY-MM-DD"}`,
+		},
+		{
+			"CreateDeal - invalid territory",
+			auth.TokenData{UserID: 1},
+			nil,
+			http.StatusBadRequest,
+			`{"projectId":1,"platformId":2,"windowStart":"2025-01-01","windowEnd":"2025-12-31","territories":["Atlantis"],"media":["svod"]}`,
+			`{"error":"Invalid territory Atlantis, use an ISO 3166-1 code or English country name"}`,
+		},
+		{
+			"CreateDeal - without media",
+			auth.TokenData{UserID: 1},
+			nil,
+			http.StatusBadRequest,
+			`{"projectId":1,"platformId":2,"windowStart":"2025-01-01","windowEnd":"2025-12-31","territories":["NL"]}`,
+			`{"error":"a deal needs at least one territory and one media"}`,
+		},
+		{
+			"CreateDeal - invalid media",
+			auth.TokenData{UserID: 1},
+			nil,
+			http.StatusBadRequest,
+			`{"projectId":1,"platformId":2,"windowStart":"2025-01-01","windowEnd":"2025-12-31","territories":["NL"],"media":["radio"]}`,
+			`{"error":"invalid media, use svod, avod, tvod, est, tv, theatrical, home_video or airline"}`,
+		},
+		{
+			"CreateDeal - window ends before it starts",
+			auth.TokenData{UserID: 1},
+			nil,
+			http.StatusBadRequest,
+			`{"projectId":1,"platformId":2,"windowStart":"2025-12-31","windowEnd":"2025-01-01","territories":["NL"],"media":["svod"]}`,
+			`{"error":"the window of a deal can not end before it starts"}`,
+		},
+		{
+			"CreateDeal - invalid currency",
+			auth.TokenData{UserID: 1},
+			nil,
+			http.StatusBadRequest,
+			`{"projectId":1,"platformId":2,"windowStart":"2025-01-01","windowEnd":"2025-12-31","territories":["NL"],"media":["svod"],"fee":100000,"currency":"EURO"}`,
+			`{"error":"invalid currency, use an ISO 4217 code"}`,
+		},
+		{
+			"CreateDeal - fee without currency",
+			auth.TokenData{UserID: 1},
+			nil,
+			http.StatusBadRequest,
+			`{"projectId":1,"platformId":2,"windowStart":"2025-01-01","windowEnd":"2025-12-31","territories":["NL"],"media":["svod"],"fee":100000}`,
+			`{"error":"a fee needs a currency"}`,
+		},
+		{
+			"CreateDeal - project not found",
+			auth.TokenData{UserID: 1},
+			func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT COUNT(.+) FROM projects").WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+			},
+			http.StatusBadRequest,
+			`{"projectId":1,"platformId":2,"windowStart":"2025-01-01","windowEnd":"2025-12-31","territories":["NL"],"media":["svod"]}`,
+			`{"error":"Linked project not found"}`,
+		},
+		{
+			"CreateDeal - sql error on EntityExists",
+			auth.TokenData{UserID: 1},
+			func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT COUNT(.+) FROM projects").WithArgs(1).WillReturnError(errors.New("test"))
+			},
+			http.StatusInternalServerError,
+			`{"projectId":1,"platformId":2,"windowStart":"2025-01-01","windowEnd":"2025-12-31","territories":["NL"],"media":["svod"]}`,
+			`{}`,
+		},
+		{
+			"CreateDeal - sql error on locking the project",
+			auth.TokenData{UserID: 1},
+			func(mock sqlmock.Sqlmock) {
+				expectLinked(mock)
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT id FROM projects WHERE id = \\? FOR UPDATE").WithArgs(1).WillReturnError(errors.New("test"))
+				mock.ExpectRollback()
+			},
+			http.StatusInternalServerError,
+			`{"projectId":1,"platformId":2,"exclusive":true,"windowStart":"2025-01-01","windowEnd":"2025-12-31","territories":["NL"],"media":["svod"]}`,
+			`{}`,
+		},
+		{
+			"CreateDeal - sql error on selecting conflicting deals",
+			auth.TokenData{UserID: 1},
+			func(mock sqlmock.Sqlmock) {
+				expectLinked(mock)
+				expectLocked(mock)
+				mock.ExpectQuery("SELECT d.(.+) FROM deals d").WillReturnError(errors.New("test"))
+				mock.ExpectRollback()
+			},
+			http.StatusInternalServerError,
+			`{"projectId":1,"platformId":2,"exclusive":true,"windowStart":"2025-01-01","windowEnd":"2025-12-31","territories":["NL"],"media":["svod"]}`,
+			`{}`,
+		},
+		{
+			"CreateDeal - overlaps an exclusive deal",
+			auth.TokenData{UserID: 1},
+			func(mock sqlmock.Sqlmock) {
+				expectLinked(mock)
+				expectLocked(mock)
+				mock.ExpectQuery("SELECT d.(.+) FROM deals d").
+					WithArgs(1, 0, "cancelled", "2025-12-31", "2025-01-01", "NL", "BE", "svod").
+					WillReturnRows(sqlmock.NewRows(dealColumns).AddRow(3, 1, "The Movie", 4, "Streamer", true, windowStart, windowEnd, nil, "", "signed", 1))
+				mock.ExpectQuery("SELECT deal_id, 'territory' AS kind").WithArgs(3, 3).
+					WillReturnRows(sqlmock.NewRows(rightsColumns).AddRow(3, "media", "svod").AddRow(3, "territory", "NL"))
+				mock.ExpectRollback()
+			},
+			http.StatusConflict,
+			`{"projectId":1,"platformId":2,"exclusive":true,"windowStart":"2025-01-01","windowEnd":"2025-12-31","territories":["Netherlands","be"],"media":["SVOD"]}`,
+			`{"error":"The deal overlaps deals that block it in territory, media and time","conflicts":[{"id":3,"createdAt":"0001-01-01T00:00:00Z","modifiedAt":"0001-01-01T00:00:00Z",
+				"deletedAt":{"Time":"0001-01-01T00:00:00Z","Valid":false},"projectId":1,"projectTitle":"The Movie","platformId":4,"platformName":"Streamer",
+				"exclusive":true,"windowStart":"2025-01-01T00:00:00Z","windowEnd":"2025-12-31T00:00:00Z","fee":{"Int64":0,"Valid":false},"currency":"",
+				"status":"signed","createdBy":{"Int64":1,"Valid":true},"territories":["NL"],"media":["svod"]}]}`,
+		},
+		{
+			"CreateDeal - sql error on InsertDeal",
+			auth.TokenData{UserID: 1},
+			func(mock sqlmock.Sqlmock) {
+				expectLinked(mock)
+				expectLocked(mock)
+				mock.ExpectQuery("SELECT d.(.+) FROM deals d").WillReturnRows(sqlmock.NewRows(dealColumns))
+				mock.ExpectExec("INSERT INTO deals").WillReturnError(errors.New("test"))
+				mock.ExpectRollback()
+			},
+			http.StatusInternalServerError,
+			`{"projectId":1,"platformId":2,"windowStart":"2025-01-01","windowEnd":"2025-12-31","territories":["NL"],"media":["svod"]}`,
+			`{}`,
+		},
+		{
+			"CreateDeal - Valid Request",
+			auth.TokenData{UserID: 1},
+			func(mock sqlmock.Sqlmock) {
+				expectLinked(mock)
+				expectLocked(mock)
+				mock.ExpectQuery("SELECT d.(.+) FROM deals d").
+					WithArgs(1, 0, "cancelled", "2025-12-31", "2025-01-01", "NL", "BE", "svod", "tv").
+					WillReturnRows(sqlmock.NewRows(dealColumns))
+				mock.ExpectExec("INSERT INTO deals").
+					WithArgs(1, 2, true, windowStart, windowEnd, 2500000, "EUR", "signed", 1).
+					WillReturnResult(sqlmock.NewResult(5, 1))
+				mock.ExpectExec("INSERT IGNORE INTO deals_territories").WithArgs(5, "NL").WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("INSERT IGNORE INTO deals_territories").WithArgs(5, "BE").WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("INSERT IGNORE INTO deals_media").WithArgs(5, "svod").WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("INSERT IGNORE INTO deals_media").WithArgs(5, "tv").WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+			http.StatusOK,
+			`{"projectId":1,"platformId":2,"exclusive":true,"windowStart":"2025-01-01","windowEnd":"2025-12-31","territories":["NL","Belgium"],"media":["svod","tv"],"fee":2500000,"currency":"eur","status":"signed"}`,
+			`{"id":5,"message":"Deal created successfully"}`,
+		},
+		{
+			"CreateDeal - Valid Request for a non exclusive draft",
+			auth.TokenData{UserID: 1},
+			func(mock sqlmock.Sqlmock) {
+				expectLinked(mock)
+				expectLocked(mock)
+				// Deals that are not exclusive are only blocked by exclusive deals
+				mock.ExpectQuery("SELECT d.(.+) FROM deals d (.+) AND d.exclusive = 1").
+					WithArgs(1, 0, "cancelled", "2025-12-31", "2025-01-01", "NL", "avod").
+					WillReturnRows(sqlmock.NewRows(dealColumns))
+				mock.ExpectExec("INSERT INTO deals").
+					WithArgs(1, 2, false, windowStart, windowEnd, nil, "", "draft", 1).
+					WillReturnResult(sqlmock.NewResult(6, 1))
+				mock.ExpectExec("INSERT IGNORE INTO deals_territories").WithArgs(6, "NL").WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("INSERT IGNORE INTO deals_media").WithArgs(6, "avod").WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+			http.StatusOK,
+			`{"projectId":1,"platformId":2,"windowStart":"2025-01-01","windowEnd":"2025-12-31","territories":["NL"],"media":["avod"]}`,
+			`{"id":6,"message":"Deal created successfully"}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			// Initilize test router, environemnt and mock database
+			r, mockDb, mockSql, env, err := utils.SetupTestEnvironment(test.MockDbCall)
+			// Close the mock database at the end of the test
+			defer mockDb.Close()
+
+			// Check for errors during setup
+			require.NoError(t, err)
+
+			// Register handler
+			r.POST("/api/v1/deals", func(c *gin.Context) {
+				// Add user to context if it exists
+				if test.User.UserID != 0 {
+					c.Set("user", test.User)
+				}
+
+				// Call handler
+				CreateDeal(env)(c)
+			})
+
+			// Create httptest request
+			req, _ := http.NewRequest("POST", "/api/v1/deals", strings.NewReader(test.Body))
+			w := httptest.NewRecorder()
+
+			// Mock request
+			r.ServeHTTP(w, req)
+
+			// Read response data
+			responseData, _ := io.ReadAll(w.Body)
+
+			// Check response status
+			require.Equal(t, test.StatusCode, w.Code)
+
+			// Handle empty responses
+			response := string(responseData)
+			if response == "" {
+				response = "{}"
+			}
+
+			// Check response body
+			require.JSONEq(t, test.Response, response)
+
+			// Check for any remaining expectations
+			// we make sure that all expectations were met
+			if err := mockSql.ExpectationsWereMet(); err != nil {
+				t.Fatalf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,187 @@
+package deals
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/require"
+	"github.com/webstradev/rsdb-backend/utils"
+)
+
+func TestEditDeal(t *testing.T) {
+	windowStart := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	windowEnd := time.Date(2025, 12, 31, 0, 0, 0, 0, time.UTC)
+
+	expectExisting := func(mock sqlmock.Sqlmock) {
+		rows := sqlmock.NewRows(dealColumns).AddRow(1, 2, "The Movie", 3, "Streamer", false, windowStart, windowEnd, nil, "", "draft", 1)
+		mock.ExpectQuery("SELECT d.(.+) FROM deals d").WithArgs(1).WillReturnRows(rows)
+		mock.ExpectQuery("SELECT deal_id, 'territory' AS kind").WithArgs(1, 1).WillReturnRows(sqlmock.NewRows(rightsColumns))
+	}
+
+	expectLinked := func(mock sqlmock.Sqlmock) {
+		mock.ExpectQuery("SELECT COUNT(.+) FROM projects").WithArgs(2).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+		mock.ExpectQuery("SELECT COUNT(.+) FROM platforms").WithArgs(3).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+	}
+
+	expectLocked := func(mock sqlmock.Sqlmock) {
+		mock.ExpectBegin()
+		mock.ExpectQuery("SELECT id FROM projects WHERE id = \\? FOR UPDATE").WithArgs(2).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(2))
+	}
+
+	tests := []struct {
+		Name       string
+		IdString   string
+		MockDbCall func(sqlmock.Sqlmock)
+		StatusCode int
+		Body       string
+		Response   string
+	}{
+		{
+			"EditDeal - non int id",
+			"notanint",
+			nil,
+			http.StatusBadRequest,
+			`{}`,
+			`{"error":"Invalid ID"}`,
+		},
+		{
+			"EditDeal - invalid status",
+			"1",
+			nil,
+			http.StatusBadRequest,
+			`{"projectId":2,"platformId":3,"windowStart":"2025-01-01","windowEnd":"2025-12-31","territories":["NL"],"media":["svod"],"status":"done"}`,
+			`{"error":"invalid deal status, use draft, negotiating, signed or cancelled"}`,
+		},
+		{
+			"EditDeal - deal not found",
+			"1",
+			func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT d.(.+) FROM deals d").WithArgs(1).WillReturnRows(sqlmock.NewRows(dealColumns))
+			},
+			http.StatusNotFound,
+			`{"projectId":2,"platformId":3,"windowStart":"2025-01-01","windowEnd":"2025-12-31","territories":["NL"],"media":["svod"]}`,
+			`{}`,
+		},
+		{
+			"EditDeal - platform not found",
+			"1",
+			func(mock sqlmock.Sqlmock) {
+				expectExisting(mock)
+				mock.ExpectQuery("SELECT COUNT(.+) FROM projects").WithArgs(2).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+				mock.ExpectQuery("SELECT COUNT(.+) FROM platforms").WithArgs(3).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+			},
+			http.StatusBadRequest,
+			`{"projectId":2,"platformId":3,"windowStart":"2025-01-01","windowEnd":"2025-12-31","territories":["NL"],"media":["svod"]}`,
+			`{"error":"Linked platform not found"}`,
+		},
+		{
+			"EditDeal - overlaps an exclusive deal",
+			"1",
+			func(mock sqlmock.Sqlmock) {
+				expectExisting(mock)
+				expectLinked(mock)
+				expectLocked(mock)
+				mock.ExpectQuery("SELECT d.(.+) FROM deals d").
+					WithArgs(2, 1, "cancelled", "2025-12-31", "2025-01-01", "NL", "svod").
+					WillReturnRows(sqlmock.NewRows(dealColumns).AddRow(4, 2, "The Movie", 5, "Broadcaster", true, windowEnd, windowEnd, nil, "", "negotiating", 1))
+				mock.ExpectQuery("SELECT deal_id, 'territory' AS kind").WithArgs(4, 4).
+					WillReturnRows(sqlmock.NewRows(rightsColumns).AddRow(4, "media", "svod").AddRow(4, "territory", "NL"))
+				mock.ExpectRollback()
+			},
+			http.StatusConflict,
+			`{"projectId":2,"platformId":3,"exclusive":true,"windowStart":"2025-01-01","windowEnd":"2025-12-31","territories":["NL"],"media":["svod"]}`,
+			`{"error":"The deal overlaps deals that block it in territory, media and time","conflicts":[{"id":4,"createdAt":"0001-01-01T00:00:00Z","modifiedAt":"0001-01-01T00:00:00Z",
+				"deletedAt":{"Time":"0001-01-01T00:00:00Z","Valid":false},"projectId":2,"projectTitle":"The Movie","platformId":5,"platformName":"Broadcaster",
+				"exclusive":true,"windowStart":"2025-12-31T00:00:00Z","windowEnd":"2025-12-31T00:00:00Z","fee":{"Int64":0,"Valid":false},"currency":"",
+				"status":"negotiating","createdBy":{"Int64":1,"Valid":true},"territories":["NL"],"media":["svod"]}]}`,
+		},
+		{
+			"EditDeal - sql error on UpdateDeal",
+			"1",
+			func(mock sqlmock.Sqlmock) {
+				expectExisting(mock)
+				expectLinked(mock)
+				expectLocked(mock)
+				mock.ExpectQuery("SELECT d.(.+) FROM deals d").WillReturnRows(sqlmock.NewRows(dealColumns))
+				mock.ExpectExec("UPDATE deals").WillReturnError(errors.New("test"))
+				mock.ExpectRollback()
+			},
+			http.StatusInternalServerError,
+			`{"projectId":2,"platformId":3,"windowStart":"2025-01-01","windowEnd":"2025-12-31","territories":["NL"],"media":["svod"]}`,
+			`{}`,
+		},
+		{
+			"EditDeal - Valid Request",
+			"1",
+			func(mock sqlmock.Sqlmock) {
+				expectExisting(mock)
+				expectLinked(mock)
+				expectLocked(mock)
+				mock.ExpectQuery("SELECT d.(.+) FROM deals d").
+					WithArgs(2, 1, "cancelled", "2025-12-31", "2025-01-01", "NL", "svod").
+					WillReturnRows(sqlmock.NewRows(dealColumns))
+				mock.ExpectExec("UPDATE deals").
+					WithArgs(2, 3, true, windowStart, windowEnd, 100000, "USD", "signed", 1).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("DELETE FROM deals_territories").WithArgs(1).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("DELETE FROM deals_media").WithArgs(1).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("INSERT IGNORE INTO deals_territories").WithArgs(1, "NL").WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("INSERT IGNORE INTO deals_media").WithArgs(1, "svod").WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+			http.StatusOK,
+			`{"projectId":2,"platformId":3,"exclusive":true,"windowStart":"2025-01-01","windowEnd":"2025-12-31","territories":["NL"],"media":["svod"],"fee":100000,"currency":"USD","status":"signed"}`,
+			`{"message":"Deal updated successfully"}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			// Initilize test router, environemnt and mock database
+			r, mockDb, mockSql, env, err := utils.SetupTestEnvironment(test.MockDbCall)
+			// Close the mock database at the end of the test
+			defer mockDb.Close()
+
+			// Check for errors during setup
+			require.NoError(t, err)
+
+			// Register handler
+			r.PUT("/api/v1/deals/:dealId", EditDeal(env))
+
+			// Create httptest request
+			req, _ := http.NewRequest("PUT", fmt.Sprintf("/api/v1/deals/%s", test.IdString), strings.NewReader(test.Body))
+			w := httptest.NewRecorder()
+
+			// Mock request
+			r.ServeHTTP(w, req)
+
+			// Read response data
+			responseData, _ := io.ReadAll(w.Body)
+
+			// Check response status
+			require.Equal(t, test.StatusCode, w.Code)
+
+			// Handle empty responses
+			response := string(responseData)
+			if response == "" {
+				response = "{}"
+			}
+
+			// Check response body
+			require.JSONEq(t, test.Response, response)
+
+			// Check for any remaining expectations
+			// we make sure that all expectations were met
+			if err := mockSql.ExpectationsWereMet(); err != nil {
+				t.Fatalf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
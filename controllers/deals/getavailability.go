@@ -0,0 +1,105 @@
+package deals
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/webstradev/rsdb-backend/db"
+	"github.com/webstradev/rsdb-backend/normalize"
+	"github.com/webstradev/rsdb-backend/rights"
+	"github.com/webstradev/rsdb-backend/utils"
+)
+
+// availabilityFromQuery reads the territories, media and window to check from the query string as a (draft) deal,
+// territories and media can be given as comma separated lists and all media are checked when none are given
+func availabilityFromQuery(c *gin.Context) (db.Deal, error) {
+	deal := db.Deal{Status: db.DEAL_DRAFT, Territories: []string{}, Media: []string{}}
+
+	for _, territory := range strings.Split(c.Query("territory"), ",") {
+		if strings.TrimSpace(territory) == "" {
+			continue
+		}
+		code, err := normalize.Country(territory)
+		if err != nil {
+			return deal, errors.New("Invalid territory " + territory + ", use an ISO 3166-1 code or English country name")
+		}
+		deal.Territories = append(deal.Territories, code)
+	}
+	if len(deal.Territories) == 0 {
+		return deal, errors.New("At least one territory is required")
+	}
+
+	for _, media := range strings.Split(c.Query("media"), ",") {
+		if media = strings.ToLower(strings.TrimSpace(media)); media != "" {
+			deal.Media = append(deal.Media, media)
+		}
+	}
+	if len(deal.Media) == 0 {
+		deal.Media = db.DealMedia
+	}
+
+	var err error
+	deal.WindowStart, err = time.Parse(time.DateOnly, c.Query("from"))
+	if err != nil {
+		return deal, errors.New("Invalid from date, use YYYY-MM-DD")
+	}
+
+	deal.WindowEnd, err = time.Parse(time.DateOnly, c.Query("to"))
+	if err != nil {
+		return deal, errors.New("Invalid to date, use YYYY-MM-DD")
+	}
+
+	if exclusiveString := c.Query("exclusive"); exclusiveString != "" {
+		deal.Exclusive, err = strconv.ParseBool(exclusiveString)
+		if err != nil {
+			return deal, errors.New("Invalid exclusive, use true or false")
+		}
+	}
+
+	return deal, deal.Validate()
+}
+
+// GetAvailability lists the windows of the requested territories and media that are still free to license for a project
+// and the deals blocking the rest. Exclusive deals always block, with exclusive=true other deals block as well.
+func GetAvailability(env *utils.Environment) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		idString := c.Param("projectId")
+		id, err := strconv.ParseInt(idString, 10, 64)
+		if err != nil {
+			log.Println(err)
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Invalid ID"})
+			return
+		}
+
+		request, err := availabilityFromQuery(c)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		exists, err := env.DB.EntityExists(db.PROJECT_ENTITY, id)
+		if err != nil {
+			log.Println(err)
+			c.AbortWithStatus(http.StatusInternalServerError)
+			return
+		}
+		if !exists {
+			c.AbortWithStatus(http.StatusNotFound)
+			return
+		}
+
+		deals, err := env.DB.GetBlockingDeals(id, request.Territories, request.Media, request.WindowStart, request.WindowEnd, request.Exclusive)
+		if err != nil {
+			log.Println(err)
+			c.AbortWithStatus(http.StatusInternalServerError)
+			return
+		}
+
+		c.JSON(http.StatusOK, rights.Available(request.Territories, request.Media, request.WindowStart, request.WindowEnd, deals))
+	}
+}
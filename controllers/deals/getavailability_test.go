@@ -0,0 +1,181 @@
+package deals
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/require"
+	"github.com/webstradev/rsdb-backend/utils"
+)
+
+func TestGetAvailability(t *testing.T) {
+	windowStart := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	windowEnd := time.Date(2025, 5, 31, 0, 0, 0, 0, time.UTC)
+
+	expectProject := func(mock sqlmock.Sqlmock) {
+		mock.ExpectQuery("SELECT COUNT(.+) FROM projects").WithArgs(2).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+	}
+
+	tests := []struct {
+		Name       string
+		Path       string
+		MockDbCall func(sqlmock.Sqlmock)
+		StatusCode int
+		Response   string
+	}{
+		{
+			"GetAvailability - non int id",
+			"/api/v1/projects/notanint/availability",
+			nil,
+			http.StatusBadRequest,
+			`{"error":"Invalid ID"}`,
+		},
+		{
+			"GetAvailability - missing territory",
+			"/api/v1/projects/2/availability?from=2025-01-01&to=2025-12-31",
+			nil,
+			http.StatusBadRequest,
+			`{"error":"At least one territory is required"}`,
+		},
+		{
+			"GetAvailability - invalid territory",
+			"/api/v1/projects/2/availability?territory=Atlantis&from=2025-01-01&to=2025-12-31",
+			nil,
+			http.StatusBadRequest,
+			`{"error":"Invalid territory Atlantis, use an ISO 3166-1 code or English country name"}`,
+		},
+		{
+			"GetAvailability - invalid media",
+			"/api/v1/projects/2/availability?territory=NL&media=radio&from=2025-01-01&to=2025-12-31",
+			nil,
+			http.StatusBadRequest,
+			`{"error":"invalid media, use svod, avod, tvod, est, tv, theatrical, home_video or airline"}`,
+		},
+		{
+			"GetAvailability - missing window",
+			"/api/v1/projects/2/availability?territory=NL",
+			nil,
+			http.StatusBadRequest,
+			`{"error":"Invalid from date, use YYYY-MM-DD"}`,
+		},
+		{
+			"GetAvailability - window ends before it starts",
+			"/api/v1/projects/2/availability?territory=NL&from=2025-12-31&to=2025-01-01",
+			nil,
+			http.StatusBadRequest,
+			`{"error":"the window of a deal can not end before it starts"}`,
+		},
+		{
+			"GetAvailability - invalid exclusive",
+			"/api/v1/projects/2/availability?territory=NL&from=2025-01-01&to=2025-12-31&exclusive=maybe",
+			nil,
+			http.StatusBadRequest,
+			`{"error":"Invalid exclusive, use true or false"}`,
+		},
+		{
+			"GetAvailability - project not found",
+			"/api/v1/projects/2/availability?territory=NL&from=2025-01-01&to=2025-12-31",
+			func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT COUNT(.+) FROM projects").WithArgs(2).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+			},
+			http.StatusNotFound,
+			`{}`,
+		},
+		{
+			"GetAvailability - sql error on GetBlockingDeals",
+			"/api/v1/projects/2/availability?territory=NL&media=svod&from=2025-01-01&to=2025-12-31",
+			func(mock sqlmock.Sqlmock) {
+				expectProject(mock)
+				mock.ExpectQuery("SELECT d.(.+) FROM deals d").WillReturnError(errors.New("test"))
+			},
+			http.StatusInternalServerError,
+			`{}`,
+		},
+		{
+			"GetAvailability - Valid Request without deals",
+			"/api/v1/projects/2/availability?territory=NL,Belgium&media=SVOD&from=2025-01-01&to=2025-12-31&exclusive=true",
+			func(mock sqlmock.Sqlmock) {
+				expectProject(mock)
+				mock.ExpectQuery("SELECT d.(.+) FROM deals d").
+					WithArgs(2, 0, "cancelled", "2025-12-31", "2025-01-01", "NL", "BE", "svod").
+					WillReturnRows(sqlmock.NewRows(dealColumns))
+			},
+			http.StatusOK,
+			`{"available":[
+				{"territory":"NL","media":"svod","from":"2025-01-01T00:00:00Z","to":"2025-12-31T00:00:00Z"},
+				{"territory":"BE","media":"svod","from":"2025-01-01T00:00:00Z","to":"2025-12-31T00:00:00Z"}
+			],"blocked":[],"deals":[]}`,
+		},
+		{
+			"GetAvailability - Valid Request with a blocking deal",
+			"/api/v1/projects/2/availability?territory=NL&media=svod,tv&from=2025-01-01&to=2025-12-31",
+			func(mock sqlmock.Sqlmock) {
+				expectProject(mock)
+				mock.ExpectQuery("SELECT d.(.+) FROM deals d").
+					WithArgs(2, 0, "cancelled", "2025-12-31", "2025-01-01", "NL", "svod", "tv").
+					WillReturnRows(sqlmock.NewRows(dealColumns).AddRow(4, 2, "The Movie", 3, "Streamer", true, windowStart, windowEnd, nil, "", "signed", 1))
+				mock.ExpectQuery("SELECT deal_id, 'territory' AS kind").WithArgs(4, 4).
+					WillReturnRows(sqlmock.NewRows(rightsColumns).AddRow(4, "media", "svod").AddRow(4, "territory", "NL"))
+			},
+			http.StatusOK,
+			`{"available":[
+				{"territory":"NL","media":"svod","from":"2025-01-01T00:00:00Z","to":"2025-02-28T00:00:00Z"},
+				{"territory":"NL","media":"svod","from":"2025-06-01T00:00:00Z","to":"2025-12-31T00:00:00Z"},
+				{"territory":"NL","media":"tv","from":"2025-01-01T00:00:00Z","to":"2025-12-31T00:00:00Z"}
+			],"blocked":[
+				{"territory":"NL","media":"svod","from":"2025-03-01T00:00:00Z","to":"2025-05-31T00:00:00Z","dealIds":[4]}
+			],"deals":[{"id":4,"createdAt":"0001-01-01T00:00:00Z","modifiedAt":"0001-01-01T00:00:00Z",
+				"deletedAt":{"Time":"0001-01-01T00:00:00Z","Valid":false},"projectId":2,"projectTitle":"The Movie","platformId":3,"platformName":"Streamer",
+				"exclusive":true,"windowStart":"2025-03-01T00:00:00Z","windowEnd":"2025-05-31T00:00:00Z","fee":{"Int64":0,"Valid":false},"currency":"",
+				"status":"signed","createdBy":{"Int64":1,"Valid":true},"territories":["NL"],"media":["svod"]}]}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			// Initilize test router, environemnt and mock database
+			r, mockDb, mockSql, env, err := utils.SetupTestEnvironment(test.MockDbCall)
+			// Close the mock database at the end of the test
+			defer mockDb.Close()
+
+			// Check for errors during setup
+			require.NoError(t, err)
+
+			// Register handler
+			r.GET("/api/v1/projects/:projectId/availability", GetAvailability(env))
+
+			// Create httptest request
+			req, _ := http.NewRequest("GET", test.Path, nil)
+			w := httptest.NewRecorder()
+
+			// Mock request
+			r.ServeHTTP(w, req)
+
+			// Read response data
+			responseData, _ := io.ReadAll(w.Body)
+
+			// Check response status
+			require.Equal(t, test.StatusCode, w.Code)
+
+			// Handle empty responses
+			response := string(responseData)
+			if response == "" {
+				response = "{}"
+			}
+
+			// Check response body
+			require.JSONEq(t, test.Response, response)
+
+			// Check for any remaining expectations
+			// we make sure that all expectations were met
+			if err := mockSql.ExpectationsWereMet(); err != nil {
+				t.Fatalf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
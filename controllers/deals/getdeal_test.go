@@ -0,0 +1,123 @@
+package deals
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/require"
+	"github.com/webstradev/rsdb-backend/utils"
+)
+
+func TestGetDeal(t *testing.T) {
+	windowStart := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	windowEnd := time.Date(2025, 12, 31, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		Name       string
+		IdString   string
+		MockDbCall func(sqlmock.Sqlmock)
+		StatusCode int
+		Response   string
+	}{
+		{
+			"GetDeal - non int id",
+			"notanint",
+			nil,
+			http.StatusBadRequest,
+			`{"error":"Invalid ID"}`,
+		},
+		{
+			"GetDeal - deal not found",
+			"1",
+			func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT d.(.+) FROM deals d").WithArgs(1).WillReturnRows(sqlmock.NewRows(dealColumns))
+			},
+			http.StatusNotFound,
+			`{}`,
+		},
+		{
+			"GetDeal - sql error on GetDeal",
+			"1",
+			func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT d.(.+) FROM deals d").WithArgs(1).WillReturnError(errors.New("test"))
+			},
+			http.StatusInternalServerError,
+			`{}`,
+		},
+		{
+			"GetDeal - sql error on territories and media",
+			"1",
+			func(mock sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows(dealColumns).AddRow(1, 2, "The Movie", 3, "Streamer", true, windowStart, windowEnd, 2500000, "EUR", "signed", 1)
+				mock.ExpectQuery("SELECT d.(.+) FROM deals d").WithArgs(1).WillReturnRows(rows)
+				mock.ExpectQuery("SELECT deal_id, 'territory' AS kind").WithArgs(1, 1).WillReturnError(errors.New("test"))
+			},
+			http.StatusInternalServerError,
+			`{}`,
+		},
+		{
+			"GetDeal - Valid Request",
+			"1",
+			func(mock sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows(dealColumns).AddRow(1, 2, "The Movie", 3, "Streamer", true, windowStart, windowEnd, 2500000, "EUR", "signed", 1)
+				mock.ExpectQuery("SELECT d.(.+) FROM deals d").WithArgs(1).WillReturnRows(rows)
+				rights := sqlmock.NewRows(rightsColumns).AddRow(1, "media", "svod").AddRow(1, "media", "tv").AddRow(1, "territory", "BE").AddRow(1, "territory", "NL")
+				mock.ExpectQuery("SELECT deal_id, 'territory' AS kind").WithArgs(1, 1).WillReturnRows(rights)
+			},
+			http.StatusOK,
+			`{"id":1,"createdAt":"0001-01-01T00:00:00Z","modifiedAt":"0001-01-01T00:00:00Z","deletedAt":{"Time":"0001-01-01T00:00:00Z","Valid":false},
+				"projectId":2,"projectTitle":"The Movie","platformId":3,"platformName":"Streamer","exclusive":true,
+				"windowStart":"2025-01-01T00:00:00Z","windowEnd":"2025-12-31T00:00:00Z","fee":{"Int64":2500000,"Valid":true},"currency":"EUR",
+				"status":"signed","createdBy":{"Int64":1,"Valid":true},"territories":["BE","NL"],"media":["svod","tv"]}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			// Initilize test router, environemnt and mock database
+			r, mockDb, mockSql, env, err := utils.SetupTestEnvironment(test.MockDbCall)
+			// Close the mock database at the end of the test
+			defer mockDb.Close()
+
+			// Check for errors during setup
+			require.NoError(t, err)
+
+			// Register handler
+			r.GET("/api/v1/deals/:dealId", GetDeal(env))
+
+			// Create httptest request
+			req, _ := http.NewRequest("GET", fmt.Sprintf("/api/v1/deals/%s", test.IdString), nil)
+			w := httptest.NewRecorder()
+
+			// Mock request
+			r.ServeHTTP(w, req)
+
+			// Read response data
+			responseData, _ := io.ReadAll(w.Body)
+
+			// Check response status
+			require.Equal(t, test.StatusCode, w.Code)
+
+			// Handle empty responses
+			response := string(responseData)
+			if response == "" {
+				response = "{}"
+			}
+
+			// Check response body
+			require.JSONEq(t, test.Response, response)
+
+			// Check for any remaining expectations
+			// we make sure that all expectations were met
+			if err := mockSql.ExpectationsWereMet(); err != nil {
+				t.Fatalf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
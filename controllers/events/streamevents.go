@@ -0,0 +1,95 @@
+package events
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/webstradev/rsdb-backend/auth"
+	"github.com/webstradev/rsdb-backend/utils"
+)
+
+// StreamEvents sends the changes to platforms, contacts, articles and projects as Server-Sent Events while the
+// connection is open, with a "change" event for every record that was created, updated or deleted. Changes to private
+// records are only sent to admins. Clients that reconnect with the Last-Event-ID header also receive the changes they
+// missed.
+func StreamEvents(env *utils.Environment) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		user, err := auth.GetUserFromContext(c)
+		if err != nil {
+			log.Println(err)
+			c.AbortWithStatus(http.StatusInternalServerError)
+			return
+		}
+
+		lastId, resumed, err := utils.LastEventId(c)
+		if err != nil {
+			log.Println(err)
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Invalid Last-Event-ID"})
+			return
+		}
+
+		if !resumed {
+			// Only changes made from now on are streamed, the client loads the current records itself
+			lastId, err = env.DB.LatestChangeEventId()
+			if err != nil {
+				log.Println(err)
+				c.AbortWithStatus(http.StatusInternalServerError)
+				return
+			}
+		}
+
+		wake, unsubscribe := env.Changes.Subscribe()
+		defer unsubscribe()
+
+		utils.StartEventStream(c)
+
+		if resumed {
+			lastId, err = writeChangesAfter(c, env, user.IsAdmin(), lastId)
+			if err != nil {
+				log.Println(err)
+				return
+			}
+		}
+
+		utils.RunEventStream(c, wake, func() error {
+			lastId, err = writeChangesAfter(c, env, user.IsAdmin(), lastId)
+			return err
+		})
+	}
+}
+
+// writeChangesAfter sends the changes after the given one that the user may see. It returns the ID of the last change
+// sent.
+func writeChangesAfter(c *gin.Context, env *utils.Environment, includePrivate bool, lastId int64) (int64, error) {
+	for {
+		events, err := env.DB.GetChangeEventsAfter(lastId, includePrivate, utils.STREAM_BATCH_SIZE)
+		if err != nil {
+			return lastId, err
+		}
+
+		for _, event := range events {
+			data, err := json.Marshal(event)
+			if err != nil {
+				return lastId, err
+			}
+
+			_, err = fmt.Fprintf(c.Writer, "id: %d\nevent: change\ndata: %s\n\n", event.ID, data)
+			if err != nil {
+				return lastId, err
+			}
+
+			lastId = event.ID
+		}
+
+		if len(events) > 0 {
+			c.Writer.Flush()
+		}
+
+		if len(events) < utils.STREAM_BATCH_SIZE {
+			return lastId, nil
+		}
+	}
+}
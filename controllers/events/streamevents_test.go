@@ -0,0 +1,158 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+	"github.com/webstradev/rsdb-backend/auth"
+	"github.com/webstradev/rsdb-backend/changes"
+	"github.com/webstradev/rsdb-backend/db"
+	"github.com/webstradev/rsdb-backend/utils"
+)
+
+var changeEventColumns = []string{"id", "entity_type", "entity_id", "action", "actor_id", "private"}
+
+const (
+	created = "id: 4\nevent: change\ndata: " +
+		`{"id":4,"createdAt":"0001-01-01T00:00:00Z","entityType":"platform","entityId":3,"action":"created","actorId":{"Int64":2,"Valid":true}}` + "\n\n"
+	deleted = "id: 5\nevent: change\ndata: " +
+		`{"id":5,"createdAt":"0001-01-01T00:00:00Z","entityType":"contact","entityId":8,"action":"deleted","actorId":{"Int64":0,"Valid":false}}` + "\n\n"
+)
+
+func TestStreamEvents(t *testing.T) {
+	tests := []struct {
+		Name        string
+		LastEventId string
+		User        auth.TokenData
+		MockDbCall  func(sqlmock.Sqlmock)
+		Publish     bool
+		StatusCode  int
+		// The response expected, Publish is published once the stream has started
+		Response string
+	}{
+		{
+			"StreamEvents - User Missing from Context",
+			"",
+			auth.TokenData{},
+			nil,
+			false,
+			http.StatusInternalServerError,
+			"",
+		},
+		{
+			"StreamEvents - Invalid Last-Event-ID",
+			"abc",
+			auth.TokenData{UserID: 1},
+			nil,
+			false,
+			http.StatusBadRequest,
+			`{"error":"Invalid Last-Event-ID"}`,
+		},
+		{
+			"StreamEvents - sql error - LatestChangeEventId",
+			"",
+			auth.TokenData{UserID: 1},
+			func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT COALESCE(.+) FROM change_events").WillReturnError(errors.New("test"))
+			},
+			false,
+			http.StatusInternalServerError,
+			"",
+		},
+		{
+			"StreamEvents - New change",
+			"",
+			auth.TokenData{UserID: 1, Role: "user"},
+			func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT COALESCE(.+) FROM change_events").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(3))
+
+				mock.ExpectExec("INSERT INTO change_events").WithArgs("platform", 3, "created", 2, 3).WillReturnResult(sqlmock.NewResult(4, 1))
+				rows := sqlmock.NewRows(changeEventColumns).AddRow(4, "platform", 3, "created", 2, false)
+				mock.ExpectQuery("SELECT (.+) FROM change_events WHERE id > \\? AND \\(\\? OR NOT private\\)").WithArgs(3, false, utils.STREAM_BATCH_SIZE).WillReturnRows(rows)
+			},
+			true,
+			http.StatusOK,
+			created,
+		},
+		{
+			"StreamEvents - Resumed with missed changes including private ones",
+			"3",
+			auth.TokenData{UserID: 1, Role: "admin"},
+			func(mock sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows(changeEventColumns).AddRow(4, "platform", 3, "created", 2, false).AddRow(5, "contact", 8, "deleted", nil, true)
+				mock.ExpectQuery("SELECT (.+) FROM change_events WHERE id > \\? AND \\(\\? OR NOT private\\)").WithArgs(3, true, utils.STREAM_BATCH_SIZE).WillReturnRows(rows)
+			},
+			false,
+			http.StatusOK,
+			created + deleted,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			// Initilize test router, environemnt and mock database
+			r, mockDb, mockSql, env, err := utils.SetupTestEnvironment(test.MockDbCall)
+			// Close the mock database at the end of the test
+			defer mockDb.Close()
+
+			// Check for errors during setup
+			require.NoError(t, err)
+
+			feed := changes.New(env.DB, nil)
+			env.Changes = feed
+
+			// Register handler
+			r.Use(func(c *gin.Context) {
+				// Add user to context if it exists
+				if test.User.UserID != 0 {
+					c.Set("user", test.User)
+				}
+			})
+			r.GET("/api/v1/events", StreamEvents(env))
+
+			// The stream only ends when the client goes away, so it is served over a real connection
+			server := httptest.NewServer(r)
+			ctx, cancel := context.WithCancel(context.Background())
+
+			// Create request
+			req, _ := http.NewRequestWithContext(ctx, "GET", server.URL+"/api/v1/events", nil)
+			if test.LastEventId != "" {
+				req.Header.Set("Last-Event-ID", test.LastEventId)
+			}
+
+			res, err := http.DefaultClient.Do(req)
+			require.NoError(t, err)
+
+			// Check response status
+			require.Equal(t, test.StatusCode, res.StatusCode)
+
+			if test.Publish {
+				require.NoError(t, feed.Publish(db.PLATFORM_ENTITY, db.CHANGE_CREATED, 3, 2))
+			}
+
+			// Read and check the response
+			buffer := make([]byte, len(test.Response))
+			_, err = io.ReadFull(res.Body, buffer)
+			require.NoError(t, err)
+			require.Equal(t, test.Response, string(buffer))
+
+			// Disconnect and wait for the handler to finish
+			cancel()
+			res.Body.Close()
+			server.Close()
+
+			// Check for any remaining expectations
+			// we make sure that all expectations were met
+			if err := mockSql.ExpectationsWereMet(); err != nil {
+				t.Fatalf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
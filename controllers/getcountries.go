@@ -0,0 +1,16 @@
+package controllers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/webstradev/rsdb-backend/normalize"
+	"github.com/webstradev/rsdb-backend/utils"
+)
+
+// GetCountries lists the countries platforms can be assigned to, the code is what is stored and the name is for display
+func GetCountries(env *utils.Environment) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.JSON(http.StatusOK, normalize.Countries())
+	}
+}
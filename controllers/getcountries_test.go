@@ -0,0 +1,45 @@
+package controllers
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/webstradev/rsdb-backend/normalize"
+	"github.com/webstradev/rsdb-backend/utils"
+)
+
+func TestGetCountries(t *testing.T) {
+	// Initilize test router, environemnt and mock database
+	r, mockDb, _, env, err := utils.SetupTestEnvironment(nil)
+	// Close the mock database at the end of the test
+	defer mockDb.Close()
+
+	// Check for errors during setup
+	require.NoError(t, err)
+
+	// Register handler
+	r.GET("/api/v1/countries", GetCountries(env))
+
+	// Create httptest request
+	req, _ := http.NewRequest("GET", "/api/v1/countries", nil)
+	w := httptest.NewRecorder()
+
+	// Mock request
+	r.ServeHTTP(w, req)
+
+	// Read response data
+	responseData, _ := io.ReadAll(w.Body)
+
+	// Check response status
+	require.Equal(t, http.StatusOK, w.Code)
+
+	countries := []normalize.ISOCountry{}
+	require.NoError(t, json.Unmarshal(responseData, &countries))
+	require.Len(t, countries, len(normalize.Countries()))
+	require.Equal(t, normalize.ISOCountry{Code: "AF", Name: "Afghanistan"}, countries[0])
+	require.Contains(t, countries, normalize.ISOCountry{Code: "NL", Name: "Netherlands"})
+}
@@ -0,0 +1,138 @@
+package imports
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/webstradev/rsdb-backend/auth"
+	"github.com/webstradev/rsdb-backend/db"
+	"github.com/webstradev/rsdb-backend/spreadsheet"
+	"github.com/webstradev/rsdb-backend/utils"
+)
+
+// Largest file that can be imported
+const MAX_IMPORT_SIZE = 10 << 20
+
+// Time an import may take to upload and process, the server timeouts are too short for large files
+const IMPORT_TIMEOUT = 5 * time.Minute
+
+// CreateImport imports platforms and contacts from an uploaded CSV or XLSX file.
+// The multipart form holds the file and optionally a JSON mapping of column headers to fields,
+// with ?dryRun=true only the report is returned and nothing is stored.
+func CreateImport(env *utils.Environment) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		user, err := auth.GetUserFromContext(c)
+		if err != nil {
+			log.Println(err)
+			c.AbortWithStatus(http.StatusInternalServerError)
+			return
+		}
+
+		dryRun := false
+		if dryRunString := c.Query("dryRun"); dryRunString != "" {
+			dryRun, err = strconv.ParseBool(dryRunString)
+			if err != nil {
+				c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Invalid dryRun"})
+				return
+			}
+		}
+
+		rc := http.NewResponseController(c.Writer)
+		rc.SetReadDeadline(time.Now().Add(IMPORT_TIMEOUT))
+		rc.SetWriteDeadline(time.Now().Add(IMPORT_TIMEOUT))
+
+		// Leave room for the rest of the multipart form
+		c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, MAX_IMPORT_SIZE+1<<20)
+
+		header, err := c.FormFile("file")
+		if err != nil {
+			var tooLarge *http.MaxBytesError
+			if errors.As(err, &tooLarge) {
+				c.AbortWithStatusJSON(http.StatusRequestEntityTooLarge, gin.H{"error": "File too large"})
+				return
+			}
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Missing file"})
+			return
+		}
+
+		if header.Size > MAX_IMPORT_SIZE {
+			c.AbortWithStatusJSON(http.StatusRequestEntityTooLarge, gin.H{"error": "File too large"})
+			return
+		}
+
+		mapping := map[string]string{}
+		if mappingString := c.PostForm("mapping"); mappingString != "" {
+			err = json.Unmarshal([]byte(mappingString), &mapping)
+			if err != nil {
+				c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Invalid mapping"})
+				return
+			}
+		}
+
+		file, err := header.Open()
+		if err != nil {
+			log.Println(err)
+			c.AbortWithStatus(http.StatusInternalServerError)
+			return
+		}
+		defer file.Close()
+
+		rows, err := spreadsheet.Read(file, c.DefaultPostForm("format", spreadsheet.FormatFromFilename(header.Filename)))
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		report, err := env.DB.PlanImport(rows, mapping)
+		if err != nil {
+			if errors.Is(err, db.ErrInvalidImportMapping) {
+				c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+				return
+			}
+			log.Println(err)
+			c.AbortWithStatus(http.StatusInternalServerError)
+			return
+		}
+
+		if dryRun {
+			c.JSON(http.StatusOK, gin.H{"report": report})
+			return
+		}
+
+		if !report.Valid {
+			c.AbortWithStatusJSON(http.StatusUnprocessableEntity, gin.H{"error": db.ErrInvalidImport.Error(), "report": report})
+			return
+		}
+
+		id, err := env.DB.RunImport(report, header.Filename, user.UserID)
+		if err != nil {
+			log.Println(err)
+			c.AbortWithStatus(http.StatusInternalServerError)
+			return
+		}
+
+		// Let open frontends and the subscribed webhooks know the platforms and contacts were created
+		for _, result := range report.Results {
+			if result.Platform == db.IMPORT_PLATFORM_CREATE {
+				err = env.Changes.Publish(db.PLATFORM_ENTITY, db.CHANGE_CREATED, result.PlatformId, user.UserID)
+				if err != nil {
+					log.Println(err)
+				}
+			}
+
+			if result.ContactId != 0 {
+				err = env.Changes.Publish(db.CONTACT_ENTITY, db.CHANGE_CREATED, result.ContactId, user.UserID)
+				if err != nil {
+					log.Println(err)
+				}
+			}
+		}
+
+		c.JSON(http.StatusOK, gin.H{"id": id, "report": report})
+	}
+}
@@ -0,0 +1,307 @@
+package imports
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+	"github.com/webstradev/rsdb-backend/auth"
+	"github.com/webstradev/rsdb-backend/utils"
+)
+
+const importMapping = `{"Name":"platform.name","Website":"platform.website","Country":"platform.country","Categories":"platform.categories","Contact":"contact.name","Email":"contact.email"}`
+
+const importFile = `Name,Website,Country,Categories,Contact,Email
+Acme TV,https://www.acme.tv,NL,Broadcaster,Jane Doe,jane@acme.tv
+Acme TV,acme.tv,NL,,John Doe,john@acme.tv
+Existing Media,https://existing.com,BE,,,
+,,,,,
+Nameless,,,Unknown,,
+`
+
+func expectImportLookups(mock sqlmock.Sqlmock) {
+	mock.ExpectQuery("SELECT (.+) FROM categories").WillReturnRows(sqlmock.NewRows([]string{"id", "category"}).AddRow(1, "Broadcaster"))
+	mock.ExpectQuery("SELECT (.+) FROM platforms").WillReturnRows(sqlmock.NewRows([]string{"id", "name", "website", "country", "source"}).
+		AddRow(5, "Existing Media", "existing.com", "BE", "").
+		AddRow(6, "Acme TVs", "", "NL", ""))
+	mock.ExpectQuery("SELECT (.+) FROM contacts").WillReturnRows(sqlmock.NewRows([]string{"id", "name", "title", "email", "phone", "phone2", "platform_id", "platform_name"}).
+		AddRow(9, "Jane D", "", "JANE@acme.tv", "", "", 6, "Acme TVs"))
+}
+
+func TestCreateImport(t *testing.T) {
+	tests := []struct {
+		Name       string
+		User       auth.TokenData
+		Query      string
+		Filename   string
+		File       string
+		Mapping    string
+		MockDbCall func(sqlmock.Sqlmock)
+		StatusCode int
+		Response   string
+	}{
+		{
+			"CreateImport - User Missing from Context",
+			auth.TokenData{},
+			"",
+			"import.csv",
+			importFile,
+			"",
+			nil,
+			http.StatusInternalServerError,
+			`{}`,
+		},
+		{
+			"CreateImport - Invalid dryRun",
+			auth.TokenData{UserID: 1},
+			"?dryRun=maybe",
+			"import.csv",
+			importFile,
+			"",
+			nil,
+			http.StatusBadRequest,
+			`{"error":"Invalid dryRun"}`,
+		},
+		{
+			"CreateImport - Missing file",
+			auth.TokenData{UserID: 1},
+			"",
+			"",
+			"",
+			"",
+			nil,
+			http.StatusBadRequest,
+			`{"error":"Missing file"}`,
+		},
+		{
+			"CreateImport - File too large",
+			auth.TokenData{UserID: 1},
+			"",
+			"import.csv",
+			strings.Repeat("x", MAX_IMPORT_SIZE+1),
+			"",
+			nil,
+			http.StatusRequestEntityTooLarge,
+			`{"error":"File too large"}`,
+		},
+		{
+			"CreateImport - Body too large",
+			auth.TokenData{UserID: 1},
+			"",
+			"import.csv",
+			strings.Repeat("x", MAX_IMPORT_SIZE+2<<20),
+			"",
+			nil,
+			http.StatusRequestEntityTooLarge,
+			`{"error":"File too large"}`,
+		},
+		{
+			"CreateImport - Invalid mapping",
+			auth.TokenData{UserID: 1},
+			"",
+			"import.csv",
+			importFile,
+			`{badmapping}`,
+			nil,
+			http.StatusBadRequest,
+			`{"error":"Invalid mapping"}`,
+		},
+		{
+			"CreateImport - Unknown format",
+			auth.TokenData{UserID: 1},
+			"",
+			"import.pdf",
+			importFile,
+			"",
+			nil,
+			http.StatusBadRequest,
+			`{"error":"unknown file format, use csv or xlsx"}`,
+		},
+		{
+			"CreateImport - Mapping to an unknown field",
+			auth.TokenData{UserID: 1},
+			"",
+			"import.csv",
+			importFile,
+			`{"Name":"platform.owner"}`,
+			nil,
+			http.StatusBadRequest,
+			`{"error":"invalid column mapping: unknown field \"platform.owner\""}`,
+		},
+		{
+			"CreateImport - Platform name not mapped",
+			auth.TokenData{UserID: 1},
+			"",
+			"import.csv",
+			importFile,
+			"",
+			nil,
+			http.StatusBadRequest,
+			`{"error":"invalid column mapping: platform.name is not mapped"}`,
+		},
+		{
+			"CreateImport - sql error on categories",
+			auth.TokenData{UserID: 1},
+			"",
+			"import.csv",
+			importFile,
+			importMapping,
+			func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT (.+) FROM categories").WillReturnError(errors.New("test"))
+			},
+			http.StatusInternalServerError,
+			`{}`,
+		},
+		{
+			"CreateImport - Dry run",
+			auth.TokenData{UserID: 1},
+			"?dryRun=true",
+			"import.csv",
+			importFile,
+			importMapping,
+			expectImportLookups,
+			http.StatusOK,
+			`{"report":{"valid":false,"rows":4,"platformsCreated":1,"contactsCreated":2,"results":[
+				{"row":2,"platform":"create","contact":true,"errors":[],"duplicates":[
+					{"type":"platform","id":6,"name":"Acme TVs","score":0.875,"reasons":["name","country"]},
+					{"type":"contact","id":9,"name":"Jane D","reasons":["email"]}
+				]},
+				{"row":3,"platform":"file","platformRow":2,"contact":true,"errors":[],"duplicates":[]},
+				{"row":4,"platform":"existing","platformId":5,"contact":false,"errors":[],"duplicates":[]},
+				{"row":6,"platform":"create","contact":false,"errors":["unknown category \"Unknown\""],"duplicates":[]}
+			]}}`,
+		},
+		{
+			"CreateImport - Invalid rows",
+			auth.TokenData{UserID: 1},
+			"",
+			"import.csv",
+			"platform.name,platform.country,contact.email,contact.phone\n,Atlantis,jane@acme.tv,12\n",
+			"",
+			func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT (.+) FROM categories").WillReturnRows(sqlmock.NewRows([]string{"id", "category"}))
+				mock.ExpectQuery("SELECT (.+) FROM platforms").WillReturnRows(sqlmock.NewRows([]string{"id", "name", "website", "country", "source"}))
+				mock.ExpectQuery("SELECT (.+) FROM contacts").WillReturnRows(sqlmock.NewRows([]string{"id", "name", "title", "email", "phone", "phone2", "platform_id", "platform_name"}))
+			},
+			http.StatusUnprocessableEntity,
+			`{"error":"the import contains invalid rows","report":{"valid":false,"rows":1,"platformsCreated":0,"contactsCreated":0,"results":[
+				{"row":2,"platform":"create","contact":true,"errors":["platform.name is required","platform.country: unknown country, use an ISO 3166-1 code or English country name","contact.name is required","contact.phone: invalid phone number, use international format (+31 20 123 4567) if the number is not from the platform's country"],"duplicates":[]}
+			]}}`,
+		},
+		{
+			"CreateImport - sql error during import",
+			auth.TokenData{UserID: 1},
+			"",
+			"import.csv",
+			"platform.name,platform.website\nAcme TV,acme.tv\n",
+			"",
+			func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT (.+) FROM categories").WillReturnRows(sqlmock.NewRows([]string{"id", "category"}))
+				mock.ExpectQuery("SELECT (.+) FROM platforms").WillReturnRows(sqlmock.NewRows([]string{"id", "name", "website", "country", "source"}))
+				mock.ExpectQuery("SELECT (.+) FROM contacts").WillReturnRows(sqlmock.NewRows([]string{"id", "name", "title", "email", "phone", "phone2", "platform_id", "platform_name"}))
+				mock.ExpectBegin()
+				mock.ExpectExec("INSERT INTO platforms").WillReturnError(errors.New("test"))
+				mock.ExpectRollback()
+			},
+			http.StatusInternalServerError,
+			`{}`,
+		},
+		{
+			"CreateImport - Valid Request",
+			auth.TokenData{UserID: 1},
+			"",
+			"import.csv",
+			"platform.name,platform.website,platform.country,platform.categories,contact.name,contact.email\nAcme TV,acme.tv,Netherlands,broadcaster,Jane Doe,Jane@Acme.tv\n",
+			"",
+			func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT (.+) FROM categories").WillReturnRows(sqlmock.NewRows([]string{"id", "category"}).AddRow(1, "Broadcaster"))
+				mock.ExpectQuery("SELECT (.+) FROM platforms").WillReturnRows(sqlmock.NewRows([]string{"id", "name", "website", "country", "source"}))
+				mock.ExpectQuery("SELECT (.+) FROM contacts").WillReturnRows(sqlmock.NewRows([]string{"id", "name", "title", "email", "phone", "phone2", "platform_id", "platform_name"}))
+				mock.ExpectBegin()
+				mock.ExpectExec("INSERT INTO platforms").WithArgs("Acme TV", "acme.tv", "NL", "", "", "", "private", 1).WillReturnResult(sqlmock.NewResult(3, 1))
+				mock.ExpectExec("INSERT IGNORE INTO platforms_categories").WithArgs(3, 1).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("INSERT INTO people").WithArgs("Jane Doe", "").WillReturnResult(sqlmock.NewResult(4, 1))
+				mock.ExpectExec("INSERT INTO contacts").WillReturnResult(sqlmock.NewResult(7, 1))
+				mock.ExpectExec("INSERT INTO imports").WithArgs(1, "import.csv", 1, 1, sqlmock.AnyArg()).WillReturnResult(sqlmock.NewResult(2, 1))
+				mock.ExpectCommit()
+			},
+			http.StatusOK,
+			`{"id":2,"report":{"valid":true,"rows":1,"platformsCreated":1,"contactsCreated":1,"results":[
+				{"row":2,"platform":"create","platformId":3,"contact":true,"contactId":7,"errors":[],"duplicates":[]}
+			]}}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			// Initilize test router, environemnt and mock database
+			r, mockDb, mockSql, env, err := utils.SetupTestEnvironment(test.MockDbCall)
+			// Close the mock database at the end of the test
+			defer mockDb.Close()
+
+			// Check for errors during setup
+			require.NoError(t, err)
+
+			// Register handler
+			r.POST("/api/v1/imports", func(c *gin.Context) {
+				// Add user to context if it exists
+				if test.User.UserID != 0 {
+					c.Set("user", test.User)
+				}
+
+				// Call handler
+				CreateImport(env)(c)
+			})
+
+			// Create multipart body
+			body := &bytes.Buffer{}
+			form := multipart.NewWriter(body)
+			if test.Filename != "" {
+				part, err := form.CreateFormFile("file", test.Filename)
+				require.NoError(t, err)
+				part.Write([]byte(test.File))
+			}
+			if test.Mapping != "" {
+				form.WriteField("mapping", test.Mapping)
+			}
+			form.Close()
+
+			// Create httptest request
+			req, _ := http.NewRequest("POST", "/api/v1/imports"+test.Query, body)
+			req.Header.Set("Content-Type", form.FormDataContentType())
+			w := httptest.NewRecorder()
+
+			// Mock request
+			r.ServeHTTP(w, req)
+
+			// Read response data
+			responseData, _ := io.ReadAll(w.Body)
+
+			// Check response status
+			require.Equal(t, test.StatusCode, w.Code)
+
+			// Handle empty responses
+			response := string(responseData)
+			if response == "" {
+				response = "{}"
+			}
+
+			// Check response body
+			require.JSONEq(t, test.Response, response)
+
+			// Check for any remaining expectations
+			// we make sure that all expectations were met
+			if err := mockSql.ExpectationsWereMet(); err != nil {
+				t.Fatalf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
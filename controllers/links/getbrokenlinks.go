@@ -0,0 +1,30 @@
+package links
+
+import (
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/webstradev/rsdb-backend/db"
+	"github.com/webstradev/rsdb-backend/utils"
+)
+
+// GetBrokenLinks reports the links of articles, projects and platforms that were broken when last checked,
+// ?type= limits the report to one of them
+func GetBrokenLinks(env *utils.Environment) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		links, err := env.DB.GetBrokenLinks(c.Query("type"))
+		if err != nil {
+			if errors.Is(err, db.ErrInvalidLinkEntityType) {
+				c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+				return
+			}
+			log.Println(err)
+			c.AbortWithStatus(http.StatusInternalServerError)
+			return
+		}
+
+		c.JSON(http.StatusOK, links)
+	}
+}
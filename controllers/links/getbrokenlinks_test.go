@@ -0,0 +1,112 @@
+package links
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/require"
+	"github.com/webstradev/rsdb-backend/utils"
+)
+
+func TestGetBrokenLinks(t *testing.T) {
+	checked := time.Date(2024, 5, 1, 3, 0, 0, 0, time.UTC)
+
+	checkColumns := []string{"entity_type", "entity_id", "name", "url", "status_code", "redirect_url", "error", "broken", "checked_at"}
+
+	tests := []struct {
+		Name       string
+		Query      string
+		MockDbCall func(sqlmock.Sqlmock)
+		StatusCode int
+		Response   string
+	}{
+		{
+			"GetBrokenLinks - invalid type",
+			"?type=contact",
+			nil,
+			http.StatusBadRequest,
+			`{"error":"invalid entity type, use article, project or platform"}`,
+		},
+		{
+			"GetBrokenLinks - sql error on GetBrokenLinks",
+			"",
+			func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT (.+) FROM (.+) JOIN link_checks lc").WithArgs("article", "project", "platform").WillReturnError(errors.New("test"))
+			},
+			http.StatusInternalServerError,
+			`{}`,
+		},
+		{
+			"GetBrokenLinks - Valid Request",
+			"",
+			func(mock sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows(checkColumns).
+					AddRow("article", 1, "Review", "https://example.com/review", 404, "", "", true, checked).
+					AddRow("platform", 2, "Example", "example.org", nil, "", "dial tcp: lookup example.org: no such host", true, checked)
+				mock.ExpectQuery("SELECT (.+) FROM (.+) JOIN link_checks lc").WithArgs("article", "project", "platform").WillReturnRows(rows)
+			},
+			http.StatusOK,
+			`[
+				{"entityType":"article","entityId":1,"name":"Review","url":"https://example.com/review","statusCode":{"Int64":404,"Valid":true},"redirectUrl":"","error":"","broken":true,"checkedAt":"2024-05-01T03:00:00Z"},
+				{"entityType":"platform","entityId":2,"name":"Example","url":"example.org","statusCode":{"Int64":0,"Valid":false},"redirectUrl":"","error":"dial tcp: lookup example.org: no such host","broken":true,"checkedAt":"2024-05-01T03:00:00Z"}
+			]`,
+		},
+		{
+			"GetBrokenLinks - Valid Request for projects",
+			"?type=project",
+			func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT (.+) FROM (.+) JOIN link_checks lc").WithArgs("article", "project", "platform", "project").WillReturnRows(sqlmock.NewRows(checkColumns))
+			},
+			http.StatusOK,
+			`[]`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			// Initilize test router, environemnt and mock database
+			r, mockDb, mockSql, env, err := utils.SetupTestEnvironment(test.MockDbCall)
+			// Close the mock database at the end of the test
+			defer mockDb.Close()
+
+			// Check for errors during setup
+			require.NoError(t, err)
+
+			// Register handler
+			r.GET("/api/v1/links/broken", GetBrokenLinks(env))
+
+			// Create httptest request
+			req, _ := http.NewRequest("GET", "/api/v1/links/broken"+test.Query, nil)
+			w := httptest.NewRecorder()
+
+			// Mock request
+			r.ServeHTTP(w, req)
+
+			// Read response data
+			responseData, _ := io.ReadAll(w.Body)
+
+			// Check response status
+			require.Equal(t, test.StatusCode, w.Code)
+
+			// Handle empty responses
+			response := string(responseData)
+			if response == "" {
+				response = "{}"
+			}
+
+			// Check response body
+			require.JSONEq(t, test.Response, response)
+
+			// Check for any remaining expectations
+			// we make sure that all expectations were met
+			if err := mockSql.ExpectationsWereMet(); err != nil {
+				t.Fatalf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
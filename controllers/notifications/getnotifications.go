@@ -0,0 +1,74 @@
+package notifications
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/webstradev/rsdb-backend/auth"
+	"github.com/webstradev/rsdb-backend/utils"
+)
+
+// GetNotifications lists the notifications of the current user, the latest first. Only unread notifications are
+// listed with ?unread=true.
+func GetNotifications(env *utils.Environment) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		user, err := auth.GetUserFromContext(c)
+		if err != nil {
+			log.Println(err)
+			c.AbortWithStatus(http.StatusInternalServerError)
+			return
+		}
+
+		page := c.MustGet("page").(int)
+		pageSize := c.MustGet("pageSize").(int)
+		unreadOnly := c.Query("unread") == "true"
+
+		notifications, err := env.DB.GetNotifications(user.UserID, unreadOnly, page, pageSize)
+		if err != nil {
+			log.Println(err)
+			c.AbortWithStatus(http.StatusInternalServerError)
+			return
+		}
+
+		total, err := env.DB.CountNotifications(user.UserID, unreadOnly)
+		if err != nil {
+			log.Println(err)
+			c.AbortWithStatus(http.StatusInternalServerError)
+			return
+		}
+
+		unread := total
+		if !unreadOnly {
+			unread, err = env.DB.CountNotifications(user.UserID, true)
+			if err != nil {
+				log.Println(err)
+				c.AbortWithStatus(http.StatusInternalServerError)
+				return
+			}
+		}
+
+		c.JSON(http.StatusOK, gin.H{"notifications": notifications, "total": total, "unread": unread})
+	}
+}
+
+// GetUnreadCount returns the number of unread notifications of the current user
+func GetUnreadCount(env *utils.Environment) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		user, err := auth.GetUserFromContext(c)
+		if err != nil {
+			log.Println(err)
+			c.AbortWithStatus(http.StatusInternalServerError)
+			return
+		}
+
+		count, err := env.DB.CountNotifications(user.UserID, true)
+		if err != nil {
+			log.Println(err)
+			c.AbortWithStatus(http.StatusInternalServerError)
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"count": count})
+	}
+}
@@ -0,0 +1,185 @@
+package notifications
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+	"github.com/webstradev/gin-pagination/v2/pkg/pagination"
+	"github.com/webstradev/rsdb-backend/auth"
+	"github.com/webstradev/rsdb-backend/utils"
+)
+
+var notificationColumns = []string{"id", "user_id", "kind", "entity_type", "entity_id", "actor_id", "message", "read_at", "actor_email"}
+
+const notification = `{"id":4,"createdAt":"0001-01-01T00:00:00Z","modifiedAt":"0001-01-01T00:00:00Z","deletedAt":{"Time":"0001-01-01T00:00:00Z","Valid":false},
+	"userId":1,"kind":"record_edited","entityType":{"String":"article","Valid":true},"entityId":{"Int64":7,"Valid":true},
+	"actorId":{"Int64":2,"Valid":true},"actorEmail":"jane@example.com","message":"Your article 7 was edited","readAt":{"Time":"0001-01-01T00:00:00Z","Valid":false}}`
+
+func TestGetNotifications(t *testing.T) {
+	tests := []struct {
+		Name       string
+		Path       string
+		User       auth.TokenData
+		MockDbCall func(sqlmock.Sqlmock)
+		StatusCode int
+		Response   string
+	}{
+		{
+			"GetNotifications - User Missing from Context",
+			"/api/v1/notifications",
+			auth.TokenData{},
+			nil,
+			http.StatusInternalServerError,
+			`{}`,
+		},
+		{
+			"GetNotifications - sql error - GetNotifications",
+			"/api/v1/notifications?page=0&pageSize=10",
+			auth.TokenData{UserID: 1},
+			func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT n.(.+) FROM notifications n").WithArgs(1, 10, 0).WillReturnError(errors.New("test"))
+			},
+			http.StatusInternalServerError,
+			`{}`,
+		},
+		{
+			"GetNotifications - sql error - CountNotifications",
+			"/api/v1/notifications?page=0&pageSize=10",
+			auth.TokenData{UserID: 1},
+			func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT n.(.+) FROM notifications n").WithArgs(1, 10, 0).WillReturnRows(sqlmock.NewRows(notificationColumns))
+				mock.ExpectQuery("SELECT COUNT(.+) FROM notifications n").WithArgs(1).WillReturnError(errors.New("test"))
+			},
+			http.StatusInternalServerError,
+			`{}`,
+		},
+		{
+			"GetNotifications - sql error - unread count",
+			"/api/v1/notifications?page=0&pageSize=10",
+			auth.TokenData{UserID: 1},
+			func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT n.(.+) FROM notifications n").WithArgs(1, 10, 0).WillReturnRows(sqlmock.NewRows(notificationColumns))
+				mock.ExpectQuery("SELECT COUNT(.+) FROM notifications n").WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+				mock.ExpectQuery("SELECT COUNT(.+) FROM notifications n (.+) n.read_at IS NULL").WithArgs(1).WillReturnError(errors.New("test"))
+			},
+			http.StatusInternalServerError,
+			`{}`,
+		},
+		{
+			"GetNotifications - Valid Request",
+			"/api/v1/notifications?page=1&pageSize=2",
+			auth.TokenData{UserID: 1},
+			func(mock sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows(notificationColumns).AddRow(4, 1, "record_edited", "article", 7, 2, "Your article 7 was edited", nil, "jane@example.com")
+				mock.ExpectQuery("SELECT n.(.+) FROM notifications n (.+) ORDER BY n.id DESC").WithArgs(1, 2, 2).WillReturnRows(rows)
+				mock.ExpectQuery("SELECT COUNT(.+) FROM notifications n").WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+				mock.ExpectQuery("SELECT COUNT(.+) FROM notifications n (.+) n.read_at IS NULL").WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+			},
+			http.StatusOK,
+			`{"notifications":[` + notification + `],"total":3,"unread":1}`,
+		},
+		{
+			"GetNotifications - Unread only",
+			"/api/v1/notifications?page=0&pageSize=10&unread=true",
+			auth.TokenData{UserID: 1},
+			func(mock sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows(notificationColumns).AddRow(4, 1, "record_edited", "article", 7, 2, "Your article 7 was edited", nil, "jane@example.com")
+				mock.ExpectQuery("SELECT n.(.+) FROM notifications n (.+) n.read_at IS NULL").WithArgs(1, 10, 0).WillReturnRows(rows)
+				mock.ExpectQuery("SELECT COUNT(.+) FROM notifications n (.+) n.read_at IS NULL").WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+			},
+			http.StatusOK,
+			`{"notifications":[` + notification + `],"total":1,"unread":1}`,
+		},
+		{
+			"GetUnreadCount - User Missing from Context",
+			"/api/v1/notifications/unread",
+			auth.TokenData{},
+			nil,
+			http.StatusInternalServerError,
+			`{}`,
+		},
+		{
+			"GetUnreadCount - sql error",
+			"/api/v1/notifications/unread",
+			auth.TokenData{UserID: 1},
+			func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT COUNT(.+) FROM notifications n (.+) n.read_at IS NULL").WithArgs(1).WillReturnError(errors.New("test"))
+			},
+			http.StatusInternalServerError,
+			`{}`,
+		},
+		{
+			"GetUnreadCount - Valid Request",
+			"/api/v1/notifications/unread",
+			auth.TokenData{UserID: 1},
+			func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT COUNT(.+) FROM notifications n (.+) n.read_at IS NULL").WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(5))
+			},
+			http.StatusOK,
+			`{"count":5}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			// Initilize test router, environemnt and mock database
+			r, mockDb, mockSql, env, err := utils.SetupTestEnvironment(test.MockDbCall)
+			// Close the mock database at the end of the test
+			defer mockDb.Close()
+
+			// Check for errors during setup
+			require.NoError(t, err)
+
+			// Register handlers
+			r.Use(func(c *gin.Context) {
+				// Add user to context if it exists
+				if test.User.UserID != 0 {
+					c.Set("user", test.User)
+				}
+			})
+			r.GET("/api/v1/notifications",
+				pagination.New(
+					pagination.WithSizeText("pageSize"),
+					pagination.WithMinPageSize(1),
+					pagination.WithMaxPageSize(100),
+				),
+				GetNotifications(env),
+			)
+			r.GET("/api/v1/notifications/unread", GetUnreadCount(env))
+
+			// Create httptest request
+			req, _ := http.NewRequest("GET", test.Path, nil)
+			w := httptest.NewRecorder()
+
+			// Mock request
+			r.ServeHTTP(w, req)
+
+			// Read response data
+			responseData, _ := io.ReadAll(w.Body)
+
+			// Check response status
+			require.Equal(t, test.StatusCode, w.Code)
+
+			// Handle empty responses
+			response := string(responseData)
+			if response == "" {
+				response = "{}"
+			}
+
+			// Check response body
+			require.JSONEq(t, test.Response, response)
+
+			// Check for any remaining expectations
+			// we make sure that all expectations were met
+			if err := mockSql.ExpectationsWereMet(); err != nil {
+				t.Fatalf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
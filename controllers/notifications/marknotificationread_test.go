@@ -0,0 +1,188 @@
+package notifications
+
+import (
+	"database/sql"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+	"github.com/webstradev/rsdb-backend/auth"
+	"github.com/webstradev/rsdb-backend/utils"
+)
+
+func TestMarkNotificationRead(t *testing.T) {
+	tests := []struct {
+		Name       string
+		Method     string
+		Path       string
+		User       auth.TokenData
+		MockDbCall func(sqlmock.Sqlmock)
+		StatusCode int
+		Response   string
+	}{
+		{
+			"MarkNotificationRead - User Missing from Context",
+			"PUT",
+			"/api/v1/notifications/4/read",
+			auth.TokenData{},
+			nil,
+			http.StatusInternalServerError,
+			`{}`,
+		},
+		{
+			"MarkNotificationRead - Invalid ID",
+			"PUT",
+			"/api/v1/notifications/abc/read",
+			auth.TokenData{UserID: 1},
+			nil,
+			http.StatusBadRequest,
+			`{"error":"Invalid ID"}`,
+		},
+		{
+			"MarkNotificationRead - Not Found",
+			"PUT",
+			"/api/v1/notifications/4/read",
+			auth.TokenData{UserID: 1},
+			func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT n.(.+) FROM notifications n").WithArgs(4).WillReturnError(sql.ErrNoRows)
+			},
+			http.StatusNotFound,
+			`{}`,
+		},
+		{
+			"MarkNotificationRead - sql error - GetNotification",
+			"PUT",
+			"/api/v1/notifications/4/read",
+			auth.TokenData{UserID: 1},
+			func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT n.(.+) FROM notifications n").WithArgs(4).WillReturnError(errors.New("test"))
+			},
+			http.StatusInternalServerError,
+			`{}`,
+		},
+		{
+			"MarkNotificationRead - Notification of another user",
+			"PUT",
+			"/api/v1/notifications/4/read",
+			auth.TokenData{UserID: 3},
+			func(mock sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows(notificationColumns).AddRow(4, 1, "record_edited", "article", 7, 2, "Your article 7 was edited", nil, "jane@example.com")
+				mock.ExpectQuery("SELECT n.(.+) FROM notifications n").WithArgs(4).WillReturnRows(rows)
+			},
+			http.StatusNotFound,
+			`{}`,
+		},
+		{
+			"MarkNotificationRead - sql error - MarkNotificationRead",
+			"PUT",
+			"/api/v1/notifications/4/read",
+			auth.TokenData{UserID: 1},
+			func(mock sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows(notificationColumns).AddRow(4, 1, "record_edited", "article", 7, 2, "Your article 7 was edited", nil, "jane@example.com")
+				mock.ExpectQuery("SELECT n.(.+) FROM notifications n").WithArgs(4).WillReturnRows(rows)
+				mock.ExpectExec("UPDATE notifications SET read_at").WithArgs(4).WillReturnError(errors.New("test"))
+			},
+			http.StatusInternalServerError,
+			`{}`,
+		},
+		{
+			"MarkNotificationRead - Valid Request",
+			"PUT",
+			"/api/v1/notifications/4/read",
+			auth.TokenData{UserID: 1},
+			func(mock sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows(notificationColumns).AddRow(4, 1, "record_edited", "article", 7, 2, "Your article 7 was edited", nil, "jane@example.com")
+				mock.ExpectQuery("SELECT n.(.+) FROM notifications n").WithArgs(4).WillReturnRows(rows)
+				mock.ExpectExec("UPDATE notifications SET read_at").WithArgs(4).WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			http.StatusOK,
+			`{"message":"Notification marked as read"}`,
+		},
+		{
+			"MarkAllNotificationsRead - User Missing from Context",
+			"PUT",
+			"/api/v1/notifications/read",
+			auth.TokenData{},
+			nil,
+			http.StatusInternalServerError,
+			`{}`,
+		},
+		{
+			"MarkAllNotificationsRead - sql error",
+			"PUT",
+			"/api/v1/notifications/read",
+			auth.TokenData{UserID: 1},
+			func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec("UPDATE notifications SET read_at (.+) WHERE user_id = ?").WithArgs(1).WillReturnError(errors.New("test"))
+			},
+			http.StatusInternalServerError,
+			`{}`,
+		},
+		{
+			"MarkAllNotificationsRead - Valid Request",
+			"PUT",
+			"/api/v1/notifications/read",
+			auth.TokenData{UserID: 1},
+			func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec("UPDATE notifications SET read_at (.+) WHERE user_id = ?").WithArgs(1).WillReturnResult(sqlmock.NewResult(0, 3))
+			},
+			http.StatusOK,
+			`{"count":3,"message":"Notifications marked as read"}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			// Initilize test router, environemnt and mock database
+			r, mockDb, mockSql, env, err := utils.SetupTestEnvironment(test.MockDbCall)
+			// Close the mock database at the end of the test
+			defer mockDb.Close()
+
+			// Check for errors during setup
+			require.NoError(t, err)
+
+			// Register handlers
+			r.Use(func(c *gin.Context) {
+				// Add user to context if it exists
+				if test.User.UserID != 0 {
+					c.Set("user", test.User)
+				}
+			})
+			r.PUT("/api/v1/notifications/read", MarkAllNotificationsRead(env))
+			r.PUT("/api/v1/notifications/:notificationId/read", MarkNotificationRead(env))
+
+			// Create httptest request
+			req, _ := http.NewRequest(test.Method, test.Path, nil)
+			w := httptest.NewRecorder()
+
+			// Mock request
+			r.ServeHTTP(w, req)
+
+			// Read response data
+			responseData, _ := io.ReadAll(w.Body)
+
+			// Check response status
+			require.Equal(t, test.StatusCode, w.Code)
+
+			// Handle empty responses
+			response := string(responseData)
+			if response == "" {
+				response = "{}"
+			}
+
+			// Check response body
+			require.JSONEq(t, test.Response, response)
+
+			// Check for any remaining expectations
+			// we make sure that all expectations were met
+			if err := mockSql.ExpectationsWereMet(); err != nil {
+				t.Fatalf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,122 @@
+package notifications
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/webstradev/rsdb-backend/auth"
+	"github.com/webstradev/rsdb-backend/utils"
+)
+
+// StreamNotifications sends the notifications of the current user as Server-Sent Events while the connection is open.
+// The stream starts with an "unread" event holding the unread count, followed by a "notification" event for every
+// new notification and an updated "unread" event. Clients that reconnect with the Last-Event-ID header also receive
+// the notifications they missed.
+func StreamNotifications(env *utils.Environment) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		user, err := auth.GetUserFromContext(c)
+		if err != nil {
+			log.Println(err)
+			c.AbortWithStatus(http.StatusInternalServerError)
+			return
+		}
+
+		lastId, resumed, err := utils.LastEventId(c)
+		if err != nil {
+			log.Println(err)
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Invalid Last-Event-ID"})
+			return
+		}
+
+		if !resumed {
+			// Only notifications that arrive from now on are streamed, earlier ones are listed by GetNotifications
+			lastId, err = env.DB.LatestNotificationId(user.UserID)
+			if err != nil {
+				log.Println(err)
+				c.AbortWithStatus(http.StatusInternalServerError)
+				return
+			}
+		}
+
+		// Subscribe before reading the unread count so no notification is missed in between
+		wake, unsubscribe := env.Inbox.Subscribe(user.UserID)
+		defer unsubscribe()
+
+		utils.StartEventStream(c)
+
+		err = writeUnreadCount(c, env, user.UserID)
+		if err != nil {
+			log.Println(err)
+			return
+		}
+
+		if resumed {
+			lastId, err = writeNotificationsAfter(c, env, user.UserID, lastId)
+			if err != nil {
+				log.Println(err)
+				return
+			}
+		}
+
+		utils.RunEventStream(c, wake, func() error {
+			lastId, err = writeNotificationsAfter(c, env, user.UserID, lastId)
+			return err
+		})
+	}
+}
+
+// writeNotificationsAfter sends the notifications of the user after the given one followed by the unread count, if
+// there were any. It returns the ID of the last notification sent.
+func writeNotificationsAfter(c *gin.Context, env *utils.Environment, userId, lastId int64) (int64, error) {
+	sent := false
+
+	for {
+		notifications, err := env.DB.GetNotificationsAfter(userId, lastId, utils.STREAM_BATCH_SIZE)
+		if err != nil {
+			return lastId, err
+		}
+
+		for _, notification := range notifications {
+			data, err := json.Marshal(notification)
+			if err != nil {
+				return lastId, err
+			}
+
+			_, err = fmt.Fprintf(c.Writer, "id: %d\nevent: notification\ndata: %s\n\n", notification.ID, data)
+			if err != nil {
+				return lastId, err
+			}
+
+			lastId = notification.ID
+			sent = true
+		}
+
+		if len(notifications) < utils.STREAM_BATCH_SIZE {
+			break
+		}
+	}
+
+	if !sent {
+		return lastId, nil
+	}
+
+	return lastId, writeUnreadCount(c, env, userId)
+}
+
+func writeUnreadCount(c *gin.Context, env *utils.Environment, userId int64) error {
+	count, err := env.DB.CountNotifications(userId, true)
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(c.Writer, "event: unread\ndata: {\"count\":%d}\n\n", count)
+	if err != nil {
+		return err
+	}
+
+	c.Writer.Flush()
+	return nil
+}
@@ -0,0 +1,169 @@
+package notifications
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+	"github.com/webstradev/rsdb-backend/auth"
+	"github.com/webstradev/rsdb-backend/db"
+	"github.com/webstradev/rsdb-backend/inbox"
+	"github.com/webstradev/rsdb-backend/utils"
+)
+
+func TestStreamNotifications(t *testing.T) {
+	compacted := &bytes.Buffer{}
+	require.NoError(t, json.Compact(compacted, []byte(notification)))
+	event := "id: 4\nevent: notification\ndata: " + compacted.String() + "\n\n"
+
+	tests := []struct {
+		Name        string
+		LastEventId string
+		User        auth.TokenData
+		MockDbCall  func(sqlmock.Sqlmock)
+		Send        *db.Notification
+		StatusCode  int
+		// Parts of the response in the order they are expected, Send is sent after the first part was received
+		Response []string
+	}{
+		{
+			"StreamNotifications - User Missing from Context",
+			"",
+			auth.TokenData{},
+			nil,
+			nil,
+			http.StatusInternalServerError,
+			[]string{""},
+		},
+		{
+			"StreamNotifications - Invalid Last-Event-ID",
+			"abc",
+			auth.TokenData{UserID: 1},
+			nil,
+			nil,
+			http.StatusBadRequest,
+			[]string{`{"error":"Invalid Last-Event-ID"}`},
+		},
+		{
+			"StreamNotifications - sql error - LatestNotificationId",
+			"",
+			auth.TokenData{UserID: 1},
+			func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT COALESCE(.+) FROM notifications").WithArgs(1).WillReturnError(errors.New("test"))
+			},
+			nil,
+			http.StatusInternalServerError,
+			[]string{""},
+		},
+		{
+			"StreamNotifications - New notification",
+			"",
+			auth.TokenData{UserID: 1},
+			func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT COALESCE(.+) FROM notifications").WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(3))
+				mock.ExpectQuery("SELECT COUNT(.+) FROM notifications n (.+) n.read_at IS NULL").WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
+
+				mock.ExpectExec("INSERT INTO notifications").WithArgs(1, "record_edited", "article", 7, 2, "Your article 7 was edited").WillReturnResult(sqlmock.NewResult(4, 1))
+				rows := sqlmock.NewRows(notificationColumns).AddRow(4, 1, "record_edited", "article", 7, 2, "Your article 7 was edited", nil, "jane@example.com")
+				mock.ExpectQuery("SELECT n.(.+) FROM notifications n (.+) n.id > ?").WithArgs(1, 3, utils.STREAM_BATCH_SIZE).WillReturnRows(rows)
+				mock.ExpectQuery("SELECT COUNT(.+) FROM notifications n (.+) n.read_at IS NULL").WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+			},
+			&db.Notification{
+				UserId:     1,
+				Kind:       db.NOTIFICATION_RECORD_EDITED,
+				EntityType: sql.NullString{String: db.ARTICLE_ENTITY, Valid: true},
+				EntityId:   sql.NullInt64{Int64: 7, Valid: true},
+				ActorId:    sql.NullInt64{Int64: 2, Valid: true},
+				Message:    "Your article 7 was edited",
+			},
+			http.StatusOK,
+			[]string{"event: unread\ndata: {\"count\":2}\n\n", event + "event: unread\ndata: {\"count\":3}\n\n"},
+		},
+		{
+			"StreamNotifications - Resumed with missed notifications",
+			"3",
+			auth.TokenData{UserID: 1},
+			func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT COUNT(.+) FROM notifications n (.+) n.read_at IS NULL").WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+				rows := sqlmock.NewRows(notificationColumns).AddRow(4, 1, "record_edited", "article", 7, 2, "Your article 7 was edited", nil, "jane@example.com")
+				mock.ExpectQuery("SELECT n.(.+) FROM notifications n (.+) n.id > ?").WithArgs(1, 3, utils.STREAM_BATCH_SIZE).WillReturnRows(rows)
+				mock.ExpectQuery("SELECT COUNT(.+) FROM notifications n (.+) n.read_at IS NULL").WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+			},
+			nil,
+			http.StatusOK,
+			[]string{"event: unread\ndata: {\"count\":1}\n\n" + event + "event: unread\ndata: {\"count\":1}\n\n"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			// Initilize test router, environemnt and mock database
+			r, mockDb, mockSql, env, err := utils.SetupTestEnvironment(test.MockDbCall)
+			// Close the mock database at the end of the test
+			defer mockDb.Close()
+
+			// Check for errors during setup
+			require.NoError(t, err)
+
+			notifications := inbox.New(env.DB)
+			env.Inbox = notifications
+
+			// Register handler
+			r.Use(func(c *gin.Context) {
+				// Add user to context if it exists
+				if test.User.UserID != 0 {
+					c.Set("user", test.User)
+				}
+			})
+			r.GET("/api/v1/notifications/stream", StreamNotifications(env))
+
+			// The stream only ends when the client goes away, so it is served over a real connection
+			server := httptest.NewServer(r)
+			ctx, cancel := context.WithCancel(context.Background())
+
+			// Create request
+			req, _ := http.NewRequestWithContext(ctx, "GET", server.URL+"/api/v1/notifications/stream", nil)
+			if test.LastEventId != "" {
+				req.Header.Set("Last-Event-ID", test.LastEventId)
+			}
+
+			res, err := http.DefaultClient.Do(req)
+			require.NoError(t, err)
+
+			// Check response status
+			require.Equal(t, test.StatusCode, res.StatusCode)
+
+			// Read and check the parts of the response
+			for i, part := range test.Response {
+				if i == 1 && test.Send != nil {
+					require.NoError(t, notifications.Send(*test.Send))
+				}
+
+				buffer := make([]byte, len(part))
+				_, err = io.ReadFull(res.Body, buffer)
+				require.NoError(t, err)
+				require.Equal(t, part, string(buffer))
+			}
+
+			// Disconnect and wait for the handler to finish
+			cancel()
+			res.Body.Close()
+			server.Close()
+
+			// Check for any remaining expectations
+			// we make sure that all expectations were met
+			if err := mockSql.ExpectationsWereMet(); err != nil {
+				t.Fatalf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,122 @@
+package people
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/require"
+	"github.com/webstradev/rsdb-backend/utils"
+)
+
+func TestGetCareer(t *testing.T) {
+	tests := []struct {
+		Name       string
+		IdString   string
+		MockDbCall func(sqlmock.Sqlmock)
+		StatusCode int
+		Response   string
+	}{
+		{
+			"GetCareer - non int id",
+			"notanint",
+			nil,
+			http.StatusBadRequest,
+			`{"error":"Invalid ID"}`,
+		},
+		{
+			"GetCareer - person not found",
+			"1",
+			func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT (.+) FROM people").WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"id"}))
+			},
+			http.StatusNotFound,
+			`{}`,
+		},
+		{
+			"GetCareer - sql error on GetPerson",
+			"1",
+			func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT (.+) FROM people").WithArgs(1).WillReturnError(errors.New("test"))
+			},
+			http.StatusInternalServerError,
+			`{}`,
+		},
+		{
+			"GetCareer - sql error on GetCareer",
+			"1",
+			func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT (.+) FROM people").WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(1, "test"))
+				mock.ExpectQuery("SELECT (.+) FROM contacts").WithArgs(1).WillReturnError(errors.New("test"))
+			},
+			http.StatusInternalServerError,
+			`{}`,
+		},
+		{
+			"GetCareer - Valid Request",
+			"1",
+			func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT (.+) FROM people").WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"id", "name", "notes"}).AddRow(1, "test", ""))
+				rows := sqlmock.NewRows([]string{"contact_id", "person_id", "person_name", "platform_id", "platform_name", "title", "current"}).
+					AddRow(2, 1, "test", 3, "platform", "editor", true).
+					AddRow(1, 1, "test", 4, "old platform", "writer", false)
+				mock.ExpectQuery("SELECT (.+) FROM contacts").WithArgs(1).WillReturnRows(rows)
+			},
+			http.StatusOK,
+			`{
+				"person":{"id":1,"createdAt":"0001-01-01T00:00:00Z","modifiedAt":"0001-01-01T00:00:00Z","deletedAt":{"Time":"0001-01-01T00:00:00Z","Valid":false},"name":"test","notes":""},
+				"employments":[
+					{"contactId":2,"personId":1,"personName":"test","platformId":3,"platformName":"platform","title":"editor","startDate":{"Time":"0001-01-01T00:00:00Z","Valid":false},"endDate":{"Time":"0001-01-01T00:00:00Z","Valid":false},"current":true},
+					{"contactId":1,"personId":1,"personName":"test","platformId":4,"platformName":"old platform","title":"writer","startDate":{"Time":"0001-01-01T00:00:00Z","Valid":false},"endDate":{"Time":"0001-01-01T00:00:00Z","Valid":false},"current":false}
+				]
+			}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			// Initilize test router, environemnt and mock database
+			r, mockDb, mockSql, env, err := utils.SetupTestEnvironment(test.MockDbCall)
+			// Close the mock database at the end of the test
+			defer mockDb.Close()
+
+			// Check for errors during setup
+			require.NoError(t, err)
+
+			// Register handler
+			r.GET("/api/v1/people/:personId/career", GetCareer(env))
+
+			// Create httptest request
+			req, _ := http.NewRequest("GET", fmt.Sprintf("/api/v1/people/%s/career", test.IdString), nil)
+			w := httptest.NewRecorder()
+
+			// Mock request
+			r.ServeHTTP(w, req)
+
+			// Read response data
+			responseData, _ := io.ReadAll(w.Body)
+
+			// Check response status
+			require.Equal(t, test.StatusCode, w.Code)
+
+			// Handle empty responses
+			response := string(responseData)
+			if response == "" {
+				response = "{}"
+			}
+
+			// Check response body
+			require.JSONEq(t, test.Response, response)
+
+			// Check for any remaining expectations
+			// we make sure that all expectations were met
+			if err := mockSql.ExpectationsWereMet(); err != nil {
+				t.Fatalf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
@@ -1,17 +1,27 @@
 package platforms
 
 import (
+	"database/sql"
+	"errors"
 	"log"
 	"net/http"
 	"strconv"
 
 	"github.com/gin-gonic/gin"
+	"github.com/webstradev/rsdb-backend/auth"
 	"github.com/webstradev/rsdb-backend/db"
 	"github.com/webstradev/rsdb-backend/utils"
 )
 
 func CreateContact(env *utils.Environment) gin.HandlerFunc {
 	return func(c *gin.Context) {
+		user, err := auth.GetUserFromContext(c)
+		if err != nil {
+			log.Println(err)
+			c.AbortWithStatus(http.StatusInternalServerError)
+			return
+		}
+
 		// Get contactId from URL
 		idString := c.Param("platformId")
 		platformId, err := strconv.ParseInt(idString, 10, 64)
@@ -21,8 +31,8 @@ func CreateContact(env *utils.Environment) gin.HandlerFunc {
 			return
 		}
 
-		// Validate Input
-		contact := db.Contact{}
+		// Validate Input (contacts are a current employment unless stated otherwise)
+		contact := db.Contact{Current: true}
 		err = c.ShouldBindJSON(&contact)
 		if err != nil {
 			log.Println(err)
@@ -31,14 +41,39 @@ func CreateContact(env *utils.Environment) gin.HandlerFunc {
 		}
 
 		contact.PlatformId = platformId
+		contact.CreatedBy = sql.NullInt64{Int64: user.UserID, Valid: true}
 
-		err = env.DB.InsertContact(contact)
+		// Phone numbers are read as numbers from the platform's country unless they have a country code
+		country, err := env.DB.GetPlatformCountry(platformId)
 		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				c.AbortWithStatus(http.StatusNotFound)
+				return
+			}
 			log.Println(err)
 			c.AbortWithStatus(http.StatusInternalServerError)
 			return
 		}
 
+		err = contact.Normalize(country)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		id, err := env.DB.InsertContact(contact)
+		if err != nil {
+			log.Println(err)
+			c.AbortWithStatus(http.StatusInternalServerError)
+			return
+		}
+
+		// Let open frontends and the subscribed webhooks know the contact was created
+		err = env.Changes.Publish(db.CONTACT_ENTITY, db.CHANGE_CREATED, id, user.UserID)
+		if err != nil {
+			log.Println(err)
+		}
+
 		c.JSON(http.StatusOK, gin.H{"message": "Contact created successfully"})
 	}
 }
@@ -10,8 +10,12 @@ import (
 	"testing"
 
 	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/require"
+	"github.com/webstradev/rsdb-backend/auth"
+	"github.com/webstradev/rsdb-backend/changes"
 	"github.com/webstradev/rsdb-backend/utils"
+	"github.com/webstradev/rsdb-backend/webhooks"
 )
 
 func TestCreateContact(t *testing.T) {
@@ -39,24 +43,83 @@ func TestCreateContact(t *testing.T) {
 			`{badbody}`,
 			`{"error": "invalid character 'b' looking for beginning of object key string"}`,
 		},
+		{
+			"CreateContact - platform not found",
+			"1",
+			func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT country FROM platforms").WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"country"}))
+			},
+			http.StatusNotFound,
+			`{"name":"test","title":"test","email":"test@example.com","phone":"","phone2":"","address":"","notes":"","source":"test","privacy":"test"}`,
+			`{}`,
+		},
+		{
+			"CreateContact - invalid email",
+			"1",
+			func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT country FROM platforms").WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"country"}).AddRow("NL"))
+			},
+			http.StatusBadRequest,
+			`{"name":"test","title":"test","email":"test","phone":"","phone2":"","address":"","notes":"","source":"test","privacy":"test"}`,
+			`{"error":"email: invalid email address"}`,
+		},
+		{
+			"CreateContact - invalid phone number",
+			"1",
+			func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT country FROM platforms").WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"country"}).AddRow("NL"))
+			},
+			http.StatusBadRequest,
+			`{"name":"test","title":"test","email":"","phone":"12","phone2":"","address":"","notes":"","source":"test","privacy":"test"}`,
+			`{"error":"phone: invalid phone number, use international format (+31 20 123 4567) if the number is not from the platform's country"}`,
+		},
 		{
 			"CreateContact - sql error on InsertContact",
 			"1",
 			func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT country FROM platforms").WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"country"}).AddRow("NL"))
+				mock.ExpectBegin()
+				mock.ExpectExec("INSERT INTO people").WithArgs("test", "").WillReturnResult(sqlmock.NewResult(3, 1))
 				mock.ExpectExec("INSERT INTO contacts").WillReturnError(errors.New("test"))
+				mock.ExpectRollback()
 			},
 			http.StatusInternalServerError,
-			`{"name":"test","title":"test","email":"test","phone":"","phone2":"","address":"","notes":"","source":"test","privacy":"test"}`,
+			`{"name":"test","title":"test","email":" Test@Example.com ","phone":"","phone2":"","address":"","notes":"","source":"test","privacy":"test"}`,
 			`{}`,
 		},
 		{
 			"CreateContact - Valid Request",
 			"1",
 			func(mock sqlmock.Sqlmock) {
-				mock.ExpectExec("INSERT INTO contacts").WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectQuery("SELECT country FROM platforms").WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"country"}).AddRow("NL"))
+				mock.ExpectBegin()
+				mock.ExpectExec("INSERT INTO people").WithArgs("test", "").WillReturnResult(sqlmock.NewResult(3, 1))
+				mock.ExpectExec("INSERT INTO contacts").
+					WithArgs("test", "test", "test@example.com", "+31201234567", "", "", "", "test", "test", 1, 3, sqlmock.AnyArg(), sqlmock.AnyArg(), true, 1).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectCommit()
+				mock.ExpectExec("INSERT INTO change_events").WithArgs("contact", 1, "created", 1, 1).WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec("INSERT INTO webhook_deliveries").WithArgs("contact.created", sqlmock.AnyArg(), sqlmock.AnyArg(), "contact.created").WillReturnResult(sqlmock.NewResult(0, 0))
 			},
 			http.StatusOK,
-			`{"name":"test","title":"test","email":"test","phone":"","phone2":"","address":"","notes":"","source":"test","privacy":"test"}`,
+			`{"name":"test","title":"test","email":" Test@Example.com ","phone":"020 123 4567","phone2":"","address":"","notes":"","source":"test","privacy":"test"}`,
+			`{"message":"Contact created successfully"}`,
+		},
+		{
+			"CreateContact - Valid Request for an existing person",
+			"1",
+			func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT country FROM platforms").WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"country"}).AddRow("NL"))
+				mock.ExpectBegin()
+				mock.ExpectExec("INSERT INTO contacts").
+					WithArgs("test", "test", "test@example.com", "", "", "", "", "test", "test", 1, 7, sqlmock.AnyArg(), sqlmock.AnyArg(), false, 1).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectCommit()
+				mock.ExpectExec("INSERT INTO change_events").WithArgs("contact", 1, "created", 1, 1).WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec("INSERT INTO webhook_deliveries").WithArgs("contact.created", sqlmock.AnyArg(), sqlmock.AnyArg(), "contact.created").WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+			http.StatusOK,
+			`{"name":"test","title":"test","email":" Test@Example.com ","phone":"","phone2":"","address":"","notes":"","source":"test","privacy":"test","personId":7,"current":false}`,
 			`{"message":"Contact created successfully"}`,
 		},
 	}
@@ -71,8 +134,14 @@ func TestCreateContact(t *testing.T) {
 			// Check for errors during setup
 			require.NoError(t, err)
 
+			// New contacts are logged for open frontends and sent to the subscribed webhooks
+			env.Changes = changes.New(env.DB, webhooks.New(env.DB, http.DefaultClient))
+
 			// Register handler
-			r.POST("/api/v1/platforms/:platformId/contacts", CreateContact(env))
+			r.POST("/api/v1/platforms/:platformId/contacts", func(c *gin.Context) {
+				c.Set("user", auth.TokenData{UserID: 1})
+				CreateContact(env)(c)
+			})
 
 			// Create httptest request
 			req, _ := http.NewRequest("POST", fmt.Sprintf("/api/v1/platforms/%s/contacts", test.IdString), strings.NewReader(test.Body))
@@ -5,6 +5,9 @@ import (
 	"net/http"
 
 	"github.com/gin-gonic/gin"
+	"github.com/webstradev/rsdb-backend/auth"
+	"github.com/webstradev/rsdb-backend/db"
+	"github.com/webstradev/rsdb-backend/normalize"
 	"github.com/webstradev/rsdb-backend/utils"
 )
 
@@ -21,16 +24,30 @@ type createPlatformInput struct {
 
 func CreatePlatform(env *utils.Environment) gin.HandlerFunc {
 	return func(c *gin.Context) {
+		user, err := auth.GetUserFromContext(c)
+		if err != nil {
+			log.Println(err)
+			c.AbortWithStatus(http.StatusInternalServerError)
+			return
+		}
+
 		// Validate Input
 		input := createPlatformInput{}
-		err := c.ShouldBindJSON(&input)
+		err = c.ShouldBindJSON(&input)
 		if err != nil {
 			log.Println(err)
 			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 			return
 		}
 
-		insertId, err := env.DB.CreatePlatform(input.Name, input.Website, input.Country, input.Source, input.Notes, input.Comment, input.Privacy)
+		// Store the country as ISO 3166-1 code
+		input.Country, err = normalize.Country(input.Country)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		insertId, err := env.DB.CreatePlatform(input.Name, input.Website, input.Country, input.Source, input.Notes, input.Comment, input.Privacy, user.UserID)
 		if err != nil {
 			log.Println(err)
 			c.AbortWithStatus(http.StatusInternalServerError)
@@ -43,5 +60,11 @@ func CreatePlatform(env *utils.Environment) gin.HandlerFunc {
 			c.AbortWithStatus(http.StatusInternalServerError)
 			return
 		}
+
+		// Let open frontends and the subscribed webhooks know the platform was created
+		err = env.Changes.Publish(db.PLATFORM_ENTITY, db.CHANGE_CREATED, insertId, user.UserID)
+		if err != nil {
+			log.Println(err)
+		}
 	}
 }
@@ -9,7 +9,9 @@ import (
 	"testing"
 
 	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/require"
+	"github.com/webstradev/rsdb-backend/auth"
 	"github.com/webstradev/rsdb-backend/utils"
 )
 
@@ -28,13 +30,20 @@ func TestCreatePlatform(t *testing.T) {
 			`{}`,
 			`{"error":"Key: 'createPlatformInput.Name' Error:Field validation for 'Name' failed on the 'required' tag\nKey: 'createPlatformInput.Country' Error:Field validation for 'Country' failed on the 'required' tag\nKey: 'createPlatformInput.Privacy' Error:Field validation for 'Privacy' failed on the 'required' tag\nKey: 'createPlatformInput.Categories' Error:Field validation for 'Categories' failed on the 'required' tag"}`,
 		},
+		{
+			"CreatePlatform - unknown country",
+			nil,
+			http.StatusBadRequest,
+			`{"name":"test", "country":"Atlantis", "privacy":"Private", "categories":[]}`,
+			`{"error":"unknown country, use an ISO 3166-1 code or English country name"}`,
+		},
 		{
 			"CreatePlatform - sql error on CreatePlatform",
 			func(mock sqlmock.Sqlmock) {
-				mock.ExpectExec("INSERT INTO platforms").WillReturnError(errors.New("test"))
+				mock.ExpectExec("INSERT INTO platforms").WithArgs("test", "", "NL", "", "", "", "Private", 1).WillReturnError(errors.New("test"))
 			},
 			http.StatusInternalServerError,
-			`{"name":"test", "country":"test", "privacy":"Private", "categories":[]}`,
+			`{"name":"test", "country":"Netherlands", "privacy":"Private", "categories":[]}`,
 			`{}`,
 		},
 		{
@@ -45,7 +54,7 @@ func TestCreatePlatform(t *testing.T) {
 				mock.ExpectExec("INSERT INTO platforms_categories").WillReturnError(errors.New("test"))
 			},
 			http.StatusInternalServerError,
-			`{"name":"test", "country":"test", "privacy":"Private", "categories":[1]}`,
+			`{"name":"test", "country":"Netherlands", "privacy":"Private", "categories":[1]}`,
 			`{}`,
 		},
 		{
@@ -56,7 +65,7 @@ func TestCreatePlatform(t *testing.T) {
 				mock.ExpectExec("INSERT INTO platforms_categories").WillReturnResult(sqlmock.NewResult(1, 1))
 			},
 			http.StatusOK,
-			`{"name":"test", "country":"test", "privacy":"Private", "categories":[1]}`,
+			`{"name":"test", "country":"Netherlands", "privacy":"Private", "categories":[1]}`,
 			`{}`,
 		},
 	}
@@ -72,7 +81,10 @@ func TestCreatePlatform(t *testing.T) {
 			require.NoError(t, err)
 
 			// Register handler
-			r.POST("/api/v1/platforms", CreatePlatform(env))
+			r.POST("/api/v1/platforms", func(c *gin.Context) {
+				c.Set("user", auth.TokenData{UserID: 1})
+				CreatePlatform(env)(c)
+			})
 
 			// Create httptest request
 			req, _ := http.NewRequest("POST", "/api/v1/platforms", strings.NewReader(test.Body))
@@ -10,7 +10,9 @@ import (
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/stretchr/testify/require"
+	"github.com/webstradev/rsdb-backend/changes"
 	"github.com/webstradev/rsdb-backend/utils"
+	"github.com/webstradev/rsdb-backend/webhooks"
 )
 
 func TestDeletePlatform(t *testing.T) {
@@ -42,6 +44,19 @@ func TestDeletePlatform(t *testing.T) {
 			"1",
 			func(mock sqlmock.Sqlmock) {
 				mock.ExpectExec("UPDATE platforms SET").WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec("INSERT INTO change_events").WithArgs("platform", 1, "deleted", nil, 1).WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec("INSERT INTO webhook_deliveries").WithArgs("platform.deleted", sqlmock.AnyArg(), sqlmock.AnyArg(), "platform.deleted").WillReturnResult(sqlmock.NewResult(1, 1))
+			},
+			http.StatusOK,
+			`{"message": "Platform deleted successfully"}`,
+		},
+		{
+			"DeletePlatform - sql errors on InsertChangeEvent and QueueWebhookDeliveries",
+			"1",
+			func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec("UPDATE platforms SET").WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec("INSERT INTO change_events").WillReturnError(errors.New("test"))
+				mock.ExpectExec("INSERT INTO webhook_deliveries").WillReturnError(errors.New("test"))
 			},
 			http.StatusOK,
 			`{"message": "Platform deleted successfully"}`,
@@ -58,6 +73,9 @@ func TestDeletePlatform(t *testing.T) {
 			// Check for errors during setup
 			require.NoError(t, err)
 
+			// Deletions are logged for open frontends and sent to the subscribed webhooks
+			env.Changes = changes.New(env.DB, webhooks.New(env.DB, http.DefaultClient))
+
 			// Register handler
 			r.DELETE("/api/v1/platforms/:platformId", DeletePlatform(env))
 
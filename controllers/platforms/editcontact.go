@@ -1,17 +1,33 @@
 package platforms
 
 import (
+	"database/sql"
+	"errors"
 	"log"
 	"net/http"
 	"strconv"
 
 	"github.com/gin-gonic/gin"
+	"github.com/webstradev/rsdb-backend/auth"
 	"github.com/webstradev/rsdb-backend/db"
 	"github.com/webstradev/rsdb-backend/utils"
 )
 
+type editContactInput struct {
+	db.Contact
+	// Left out to keep whether the contact is a current employment
+	Current *bool `json:"current"`
+}
+
 func EditContact(env *utils.Environment) gin.HandlerFunc {
 	return func(c *gin.Context) {
+		user, err := auth.GetUserFromContext(c)
+		if err != nil {
+			log.Println(err)
+			c.AbortWithStatus(http.StatusInternalServerError)
+			return
+		}
+
 		// Get contactId from URL
 		idString := c.Param("id")
 		id, err := strconv.ParseInt(idString, 10, 64)
@@ -30,24 +46,60 @@ func EditContact(env *utils.Environment) gin.HandlerFunc {
 		}
 
 		// Validate Input
-		contact := db.Contact{}
-		err = c.ShouldBindJSON(&contact)
+		input := editContactInput{}
+		err = c.ShouldBindJSON(&input)
 		if err != nil {
 			log.Println(err)
 			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 			return
 		}
 
+		contact := input.Contact
+		current := sql.NullBool{}
+		if input.Current != nil {
+			current = sql.NullBool{Bool: *input.Current, Valid: true}
+		}
+
 		contact.ID = id
 		contact.PlatformId = platformId
 
-		err = env.DB.EditContact(contact)
+		// Phone numbers are read as numbers from the platform's country unless they have a country code
+		country, err := env.DB.GetPlatformCountry(platformId)
 		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				c.AbortWithStatus(http.StatusNotFound)
+				return
+			}
 			log.Println(err)
 			c.AbortWithStatus(http.StatusInternalServerError)
 			return
 		}
 
+		err = contact.Normalize(country)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		err = env.DB.EditContact(contact, current)
+		if err != nil {
+			log.Println(err)
+			c.AbortWithStatus(http.StatusInternalServerError)
+			return
+		}
+
+		// Let the owners know their record was edited
+		err = env.Inbox.RecordEdited(db.CONTACT_ENTITY, id, user.UserID)
+		if err != nil {
+			log.Println(err)
+		}
+
+		// Let open frontends and the subscribed webhooks know the contact was edited
+		err = env.Changes.Publish(db.CONTACT_ENTITY, db.CHANGE_UPDATED, id, user.UserID)
+		if err != nil {
+			log.Println(err)
+		}
+
 		c.Status(http.StatusOK)
 	}
 }
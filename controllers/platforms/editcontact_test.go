@@ -1,6 +1,7 @@
 package platforms
 
 import (
+	"database/sql/driver"
 	"errors"
 	"fmt"
 	"io"
@@ -10,11 +11,22 @@ import (
 	"testing"
 
 	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/require"
+	"github.com/webstradev/rsdb-backend/auth"
 	"github.com/webstradev/rsdb-backend/utils"
 )
 
 func TestEditContact(t *testing.T) {
+	// Arguments of the contact update, only whether the contact is current and the IDs are checked
+	updateArgs := func(current any) []driver.Value {
+		args := []driver.Value{}
+		for i := 0; i < 12; i++ {
+			args = append(args, sqlmock.AnyArg())
+		}
+		return append(args, current, 1, 1)
+	}
+
 	tests := []struct {
 		Name             string
 		IdString         string
@@ -51,26 +63,85 @@ func TestEditContact(t *testing.T) {
 			`{badbody}`,
 			`{"error": "invalid character 'b' looking for beginning of object key string"}`,
 		},
+		{
+			"EditContact - platform not found",
+			"1",
+			"1",
+			func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT country FROM platforms").WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"country"}))
+			},
+			http.StatusNotFound,
+			`{"name":"test","title":"test","email":"test@example.com","phone":"","phone2":"","address":"","notes":"","source":"test","privacy":"test"}`,
+			`{}`,
+		},
+		{
+			"EditContact - invalid phone number",
+			"1",
+			"1",
+			func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT country FROM platforms").WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"country"}).AddRow("NL"))
+			},
+			http.StatusBadRequest,
+			`{"name":"test","title":"test","email":"","phone":"","phone2":"not a number","address":"","notes":"","source":"test","privacy":"test"}`,
+			`{"error":"phone2: invalid phone number, use international format (+31 20 123 4567) if the number is not from the platform's country"}`,
+		},
 		{
 			"EditContact - sql error on EditPlatform",
 			"1",
 			"1",
 			func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT country FROM platforms").WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"country"}).AddRow("NL"))
+				mock.ExpectBegin()
 				mock.ExpectExec("UPDATE contacts SET").WillReturnError(errors.New("test"))
+				mock.ExpectRollback()
 			},
 			http.StatusInternalServerError,
-			`{"name":"test","title":"test","email":"test","phone":"","phone2":"","address":"","notes":"","source":"test","privacy":"test"}`,
+			`{"name":"test","title":"test","email":"test@example.com","phone":"","phone2":"","address":"","notes":"","source":"test","privacy":"test"}`,
+			`{}`,
+		},
+		{
+			"EditContact - sql error on renaming the person",
+			"1",
+			"1",
+			func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT country FROM platforms").WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"country"}).AddRow("NL"))
+				mock.ExpectBegin()
+				mock.ExpectExec("UPDATE contacts SET").WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("UPDATE people pe JOIN contacts c").WithArgs(1, 1).WillReturnError(errors.New("test"))
+				mock.ExpectRollback()
+			},
+			http.StatusInternalServerError,
+			`{"name":"test","title":"test","email":"test@example.com","phone":"","phone2":"","address":"","notes":"","source":"test","privacy":"test"}`,
+			`{}`,
+		},
+		{
+			"EditContact - Valid Request keeping whether the contact is current",
+			"1",
+			"1",
+			func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT country FROM platforms").WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"country"}).AddRow("NL"))
+				mock.ExpectBegin()
+				mock.ExpectExec("UPDATE contacts SET (.+) current = COALESCE\\(\\?, current\\)").WithArgs(updateArgs(nil)...).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("UPDATE people pe JOIN contacts c").WithArgs(1, 1).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+			http.StatusOK,
+			`{"name":"test","title":"test","email":"test@example.com","phone":"","phone2":"","address":"","notes":"","source":"test","privacy":"test"}`,
 			`{}`,
 		},
 		{
-			"EditPlatform - Valid Request",
+			"EditContact - Valid Request ending the employment",
 			"1",
 			"1",
 			func(mock sqlmock.Sqlmock) {
-				mock.ExpectExec("UPDATE contacts SET").WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectQuery("SELECT country FROM platforms").WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"country"}).AddRow("NL"))
+				mock.ExpectBegin()
+				mock.ExpectExec("UPDATE contacts SET").WithArgs(updateArgs(false)...).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("UPDATE people pe JOIN contacts c").WithArgs(1, 1).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
 			},
 			http.StatusOK,
-			`{"name":"test","title":"test","email":"test","phone":"","phone2":"","address":"","notes":"","source":"test","privacy":"test"}`,
+			`{"name":"test","title":"test","email":"test@example.com","phone":"","phone2":"","address":"","notes":"","source":"test","privacy":"test","current":false}`,
 			`{}`,
 		},
 	}
@@ -86,7 +157,10 @@ func TestEditContact(t *testing.T) {
 			require.NoError(t, err)
 
 			// Register handler
-			r.PUT("/api/v1/platforms/:platformId/contacts/:id", EditContact(env))
+			r.PUT("/api/v1/platforms/:platformId/contacts/:id", func(c *gin.Context) {
+				c.Set("user", auth.TokenData{UserID: 1})
+				EditContact(env)(c)
+			})
 
 			// Create httptest request
 			req, _ := http.NewRequest("PUT", fmt.Sprintf("/api/v1/platforms/%s/contacts/%s", test.PlatformIdString, test.IdString), strings.NewReader(test.Body))
@@ -1,11 +1,16 @@
 package platforms
 
 import (
+	"database/sql"
+	"errors"
 	"log"
 	"net/http"
 	"strconv"
 
 	"github.com/gin-gonic/gin"
+	"github.com/webstradev/rsdb-backend/auth"
+	"github.com/webstradev/rsdb-backend/db"
+	"github.com/webstradev/rsdb-backend/normalize"
 	"github.com/webstradev/rsdb-backend/utils"
 )
 
@@ -22,6 +27,13 @@ type editPlatformInput struct {
 
 func EditPlatform(env *utils.Environment) gin.HandlerFunc {
 	return func(c *gin.Context) {
+		user, err := auth.GetUserFromContext(c)
+		if err != nil {
+			log.Println(err)
+			c.AbortWithStatus(http.StatusInternalServerError)
+			return
+		}
+
 		// Get platform ID from URL
 		idString := c.Param("platformId")
 		id, err := strconv.ParseInt(idString, 10, 64)
@@ -40,18 +52,47 @@ func EditPlatform(env *utils.Environment) gin.HandlerFunc {
 			return
 		}
 
-		err = env.DB.EditPlatform(input.Name, input.Website, input.Country, input.Source, input.Notes, input.Comment, input.Privacy, id)
+		platform := db.Platform{
+			Model:      db.Model{ID: id},
+			Name:       input.Name,
+			Website:    input.Website,
+			Country:    input.Country,
+			Source:     input.Source,
+			Notes:      input.Notes,
+			Comment:    input.Comment,
+			Privacy:    input.Privacy,
+			Categories: []db.PlatformCategory{},
+		}
+		for _, categoryId := range input.Categories {
+			platform.Categories = append(platform.Categories, db.PlatformCategory{CategoryID: categoryId})
+		}
+
+		// The current version of the platform is kept as a revision, the country is stored as ISO 3166-1 code
+		err = env.DB.EditPlatform(platform, user.UserID)
 		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				c.AbortWithStatus(http.StatusNotFound)
+				return
+			}
+			if errors.Is(err, normalize.ErrInvalidCountry) {
+				c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+				return
+			}
 			log.Println(err)
 			c.AbortWithStatus(http.StatusInternalServerError)
 			return
 		}
 
-		err = env.DB.UpdatePlatformCategories(id, input.Categories)
+		// Let the owners know their record was edited
+		err = env.Inbox.RecordEdited(db.PLATFORM_ENTITY, id, user.UserID)
+		if err != nil {
+			log.Println(err)
+		}
+
+		// Let open frontends and the subscribed webhooks know the platform was edited
+		err = env.Changes.Publish(db.PLATFORM_ENTITY, db.CHANGE_UPDATED, id, user.UserID)
 		if err != nil {
 			log.Println(err)
-			c.AbortWithStatus(http.StatusInternalServerError)
-			return
 		}
 	}
 }
@@ -10,7 +10,9 @@ import (
 	"testing"
 
 	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/require"
+	"github.com/webstradev/rsdb-backend/auth"
 	"github.com/webstradev/rsdb-backend/utils"
 )
 
@@ -18,14 +20,25 @@ func TestEditPlatform(t *testing.T) {
 	tests := []struct {
 		Name       string
 		IdString   string
+		User       auth.TokenData
 		MockDbCall func(sqlmock.Sqlmock)
 		StatusCode int
 		Body       string
 		Response   string
 	}{
+		{
+			"EditPlatform - User Missing from Context",
+			"1",
+			auth.TokenData{},
+			nil,
+			http.StatusInternalServerError,
+			`{}`,
+			`{}`,
+		},
 		{
 			"EditPlatform - non int id",
 			"notanint",
+			auth.TokenData{UserID: 1},
 			nil,
 			http.StatusBadRequest,
 			`{}`,
@@ -34,59 +47,156 @@ func TestEditPlatform(t *testing.T) {
 		{
 			"EditPlatform - missing required fields",
 			"1",
+			auth.TokenData{UserID: 1},
 			nil,
 			http.StatusBadRequest,
 			`{}`,
 			`{"error":"Key: 'editPlatformInput.Name' Error:Field validation for 'Name' failed on the 'required' tag\nKey: 'editPlatformInput.Country' Error:Field validation for 'Country' failed on the 'required' tag\nKey: 'editPlatformInput.Privacy' Error:Field validation for 'Privacy' failed on the 'required' tag\nKey: 'editPlatformInput.Categories' Error:Field validation for 'Categories' failed on the 'required' tag"}`,
 		},
 		{
-			"EditPlatform - sql error on EditPlatform",
+			"EditPlatform - unknown country",
 			"1",
+			auth.TokenData{UserID: 1},
 			func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT id FROM platforms WHERE id = \\? AND deleted_at IS NULL FOR UPDATE").WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+				mock.ExpectQuery("SELECT p.(.+) FROM platforms").WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"id", "name", "country"}).AddRow(1, "old", "NL"))
+				mock.ExpectQuery("SELECT (.+) FROM categories").WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"platform_id", "category_id", "category"}))
+				mock.ExpectRollback()
+			},
+			http.StatusBadRequest,
+			`{"name":"test", "country":"Atlantis", "privacy":"Private", "categories":[]}`,
+			`{"error":"unknown country, use an ISO 3166-1 code or English country name"}`,
+		},
+		{
+			"EditPlatform - platform not found",
+			"1",
+			auth.TokenData{UserID: 1},
+			func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT id FROM platforms WHERE id = \\? AND deleted_at IS NULL FOR UPDATE").WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"id"}))
+				mock.ExpectRollback()
+			},
+			http.StatusNotFound,
+			`{"name":"test", "country":"Netherlands", "privacy":"Private", "categories":[]}`,
+			`{}`,
+		},
+		{
+			"EditPlatform - sql error on insertRevision",
+			"1",
+			auth.TokenData{UserID: 1},
+			func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT id FROM platforms WHERE id = \\? AND deleted_at IS NULL FOR UPDATE").WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+				mock.ExpectQuery("SELECT p.(.+) FROM platforms").WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(1, "old"))
+				mock.ExpectQuery("SELECT (.+) FROM categories").WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"platform_id", "category_id", "category"}))
+				mock.ExpectExec("INSERT INTO revisions").WillReturnError(errors.New("test"))
+				mock.ExpectRollback()
+			},
+			http.StatusInternalServerError,
+			`{"name":"test", "country":"Netherlands", "privacy":"Private", "categories":[]}`,
+			`{}`,
+		},
+		{
+			"EditPlatform - sql error on EditPlatform(UPDATE)",
+			"1",
+			auth.TokenData{UserID: 1},
+			func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT id FROM platforms WHERE id = \\? AND deleted_at IS NULL FOR UPDATE").WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+				mock.ExpectQuery("SELECT p.(.+) FROM platforms").WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(1, "old"))
+				mock.ExpectQuery("SELECT (.+) FROM categories").WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"platform_id", "category_id", "category"}))
+				mock.ExpectExec("INSERT INTO revisions").WithArgs("platform", 1, 1, sqlmock.AnyArg(), "platform", 1).WillReturnResult(sqlmock.NewResult(1, 1))
+
 				mock.ExpectExec("UPDATE platforms SET").WillReturnError(errors.New("test"))
+				mock.ExpectRollback()
 			},
 			http.StatusInternalServerError,
-			`{"name":"test", "country":"test", "privacy":"Private", "categories":[]}`,
+			`{"name":"test", "country":"Netherlands", "privacy":"Private", "categories":[]}`,
 			`{}`,
 		},
 		{
-			"EditPlatform - sql error on UpdatePlatformCategories(DELETE)",
+			"EditPlatform - sql error on EditPlatform(DELETE categories)",
 			"1",
+			auth.TokenData{UserID: 1},
 			func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT id FROM platforms WHERE id = \\? AND deleted_at IS NULL FOR UPDATE").WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+				mock.ExpectQuery("SELECT p.(.+) FROM platforms").WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(1, "old"))
+				mock.ExpectQuery("SELECT (.+) FROM categories").WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"platform_id", "category_id", "category"}))
+				mock.ExpectExec("INSERT INTO revisions").WithArgs("platform", 1, 1, sqlmock.AnyArg(), "platform", 1).WillReturnResult(sqlmock.NewResult(1, 1))
+
 				mock.ExpectExec("UPDATE platforms SET").WillReturnResult(sqlmock.NewResult(1, 1))
 
 				mock.ExpectExec("DELETE FROM platforms_categories").WillReturnError(errors.New("test"))
+				mock.ExpectRollback()
 			},
 			http.StatusInternalServerError,
-			`{"name":"test", "country":"test", "privacy":"Private", "categories":[]}`,
+			`{"name":"test", "country":"Netherlands", "privacy":"Private", "categories":[]}`,
 			`{}`,
 		},
 		{
-			"EditPlatform - sql error on UpdatePlatformCategories(INSERT)",
+			"EditPlatform - sql error on EditPlatform(INSERT categories)",
 			"1",
+			auth.TokenData{UserID: 1},
 			func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT id FROM platforms WHERE id = \\? AND deleted_at IS NULL FOR UPDATE").WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+				mock.ExpectQuery("SELECT p.(.+) FROM platforms").WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(1, "old"))
+				mock.ExpectQuery("SELECT (.+) FROM categories").WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"platform_id", "category_id", "category"}))
+				mock.ExpectExec("INSERT INTO revisions").WithArgs("platform", 1, 1, sqlmock.AnyArg(), "platform", 1).WillReturnResult(sqlmock.NewResult(1, 1))
+
 				mock.ExpectExec("UPDATE platforms SET").WillReturnResult(sqlmock.NewResult(1, 1))
 
 				mock.ExpectExec("DELETE FROM platforms_categories").WillReturnResult(sqlmock.NewResult(1, 1))
 
 				mock.ExpectExec("INSERT INTO platforms_categories").WillReturnError(errors.New("test"))
+				mock.ExpectRollback()
 			},
 			http.StatusInternalServerError,
-			`{"name":"test", "country":"test", "privacy":"Private", "categories":[1]}`,
+			`{"name":"test", "country":"Netherlands", "privacy":"Private", "categories":[1]}`,
 			`{}`,
 		},
 		{
 			"EditPlatform - Valid Request",
 			"1",
+			auth.TokenData{UserID: 1},
 			func(mock sqlmock.Sqlmock) {
-				mock.ExpectExec("UPDATE platforms SET").WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT id FROM platforms WHERE id = \\? AND deleted_at IS NULL FOR UPDATE").WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+				mock.ExpectQuery("SELECT p.(.+) FROM platforms").WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(1, "old"))
+				mock.ExpectQuery("SELECT (.+) FROM categories").WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"platform_id", "category_id", "category"}))
+				mock.ExpectExec("INSERT INTO revisions").WithArgs("platform", 1, 1, sqlmock.AnyArg(), "platform", 1).WillReturnResult(sqlmock.NewResult(1, 1))
+
+				mock.ExpectExec("UPDATE platforms SET").WithArgs("test", "", "NL", "", "", "", "Private", 1).WillReturnResult(sqlmock.NewResult(1, 1))
 
 				mock.ExpectExec("DELETE FROM platforms_categories").WillReturnResult(sqlmock.NewResult(1, 1))
 
-				mock.ExpectExec("INSERT INTO platforms_categories").WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec("INSERT INTO platforms_categories").WithArgs(1, 1).WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectCommit()
+			},
+			http.StatusOK,
+			`{"name":"test", "country":"Netherlands", "privacy":"Private", "categories":[1]}`,
+			`{}`,
+		},
+		{
+			"EditPlatform - Valid Request with unchanged legacy country",
+			"1",
+			auth.TokenData{UserID: 1},
+			func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT id FROM platforms WHERE id = \\? AND deleted_at IS NULL FOR UPDATE").WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+				mock.ExpectQuery("SELECT p.(.+) FROM platforms").WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"id", "name", "country"}).AddRow(1, "old", "Holland & Belgium"))
+				mock.ExpectQuery("SELECT (.+) FROM categories").WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"platform_id", "category_id", "category"}))
+				mock.ExpectExec("INSERT INTO revisions").WithArgs("platform", 1, 1, sqlmock.AnyArg(), "platform", 1).WillReturnResult(sqlmock.NewResult(1, 1))
+
+				mock.ExpectExec("UPDATE platforms SET").WithArgs("test", "", "Holland & Belgium", "", "", "", "Private", 1).WillReturnResult(sqlmock.NewResult(1, 1))
+
+				mock.ExpectExec("DELETE FROM platforms_categories").WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectCommit()
 			},
 			http.StatusOK,
-			`{"name":"test", "country":"test", "privacy":"Private", "categories":[1]}`,
+			`{"name":"test", "country":"Holland & Belgium", "privacy":"Private", "categories":[]}`,
 			`{}`,
 		},
 	}
@@ -102,7 +212,15 @@ func TestEditPlatform(t *testing.T) {
 			require.NoError(t, err)
 
 			// Register handler
-			r.PUT("/api/v1/platforms/:platformId", EditPlatform(env))
+			r.PUT("/api/v1/platforms/:platformId", func(c *gin.Context) {
+				// Add user to context if it exists
+				if test.User.UserID != 0 {
+					c.Set("user", test.User)
+				}
+
+				// Call handler
+				EditPlatform(env)(c)
+			})
 
 			// Create httptest request
 			req, _ := http.NewRequest("PUT", fmt.Sprintf("/api/v1/platforms/%s", test.IdString), strings.NewReader(test.Body))
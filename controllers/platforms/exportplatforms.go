@@ -0,0 +1,27 @@
+package platforms
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/webstradev/rsdb-backend/auth"
+	"github.com/webstradev/rsdb-backend/db"
+	"github.com/webstradev/rsdb-backend/utils"
+)
+
+// ExportPlatforms exports all platforms, private platforms are only included for admins
+func ExportPlatforms(env *utils.Environment) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		user, err := auth.GetUserFromContext(c)
+		if err != nil {
+			log.Println(err)
+			c.AbortWithStatus(http.StatusInternalServerError)
+			return
+		}
+
+		utils.StreamExport(c, "platforms", db.PlatformExportColumns, func(write func([]string) error) error {
+			return env.DB.ExportPlatforms(user.IsAdmin(), write)
+		})
+	}
+}
@@ -0,0 +1,129 @@
+package platforms
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+	"github.com/webstradev/rsdb-backend/auth"
+	"github.com/webstradev/rsdb-backend/utils"
+)
+
+func TestExportPlatforms(t *testing.T) {
+	tests := []struct {
+		Name        string
+		User        auth.TokenData
+		Query       string
+		MockDbCall  func(sqlmock.Sqlmock)
+		StatusCode  int
+		ContentType string
+		Response    string
+	}{
+		{
+			"ExportPlatforms - User Missing from Context",
+			auth.TokenData{},
+			"",
+			nil,
+			http.StatusInternalServerError,
+			"",
+			``,
+		},
+		{
+			"ExportPlatforms - Invalid format",
+			auth.TokenData{UserID: 1, Role: auth.UserRole},
+			"?format=pdf",
+			nil,
+			http.StatusBadRequest,
+			"application/json; charset=utf-8",
+			`{"error":"Invalid format"}`,
+		},
+		{
+			"ExportPlatforms - sql error on ExportPlatforms",
+			auth.TokenData{UserID: 1, Role: auth.UserRole},
+			"",
+			func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT (.+) FROM platforms").WithArgs("private").WillReturnError(errors.New("test"))
+			},
+			http.StatusInternalServerError,
+			"",
+			``,
+		},
+		{
+			"ExportPlatforms - CSV without private platforms",
+			auth.TokenData{UserID: 1, Role: auth.UserRole},
+			"",
+			func(mock sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows([]string{"id", "name", "website", "country", "source", "privacy", "platform_categories", "notes", "comment"}).
+					AddRow(1, "Acme TV", "acme.tv", "NL", "test", "public", "news,sports", "", "")
+				mock.ExpectQuery("SELECT (.+) FROM platforms").WithArgs("private").WillReturnRows(rows)
+			},
+			http.StatusOK,
+			"text/csv; charset=utf-8",
+			"id,name,website,country,source,privacy,categories,notes,comment\n1,Acme TV,acme.tv,NL,test,public,\"news,sports\",,\n",
+		},
+		{
+			"ExportPlatforms - NDJSON for admins",
+			auth.TokenData{UserID: 1, Role: auth.AdminRole},
+			"?format=ndjson",
+			func(mock sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows([]string{"id", "name", "website", "country", "source", "privacy", "platform_categories", "notes", "comment"}).
+					AddRow(1, "Acme TV", "acme.tv", "NL", "test", "private", "", "", "")
+				mock.ExpectQuery("SELECT (.+) FROM platforms").WithoutArgs().WillReturnRows(rows)
+			},
+			http.StatusOK,
+			"application/x-ndjson",
+			`{"id":"1","name":"Acme TV","website":"acme.tv","country":"NL","source":"test","privacy":"private","categories":"","notes":"","comment":""}` + "\n",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			// Initilize test router, environemnt and mock database
+			r, mockDb, mockSql, env, err := utils.SetupTestEnvironment(test.MockDbCall)
+			// Close the mock database at the end of the test
+			defer mockDb.Close()
+
+			// Check for errors during setup
+			require.NoError(t, err)
+
+			// Register handler
+			r.GET("/api/v1/platforms/export", func(c *gin.Context) {
+				// Add user to context if it exists
+				if test.User.UserID != 0 {
+					c.Set("user", test.User)
+				}
+
+				// Call handler
+				ExportPlatforms(env)(c)
+			})
+
+			// Create httptest request
+			req, _ := http.NewRequest("GET", "/api/v1/platforms/export"+test.Query, nil)
+			w := httptest.NewRecorder()
+
+			// Mock request
+			r.ServeHTTP(w, req)
+
+			// Read response data
+			responseData, _ := io.ReadAll(w.Body)
+
+			// Check response status and type
+			require.Equal(t, test.StatusCode, w.Code)
+			require.Equal(t, test.ContentType, w.Header().Get("Content-Type"))
+
+			// Check response body
+			require.Equal(t, test.Response, string(responseData))
+
+			// Check for any remaining expectations
+			// we make sure that all expectations were met
+			if err := mockSql.ExpectationsWereMet(); err != nil {
+				t.Fatalf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
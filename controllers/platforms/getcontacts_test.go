@@ -7,6 +7,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/stretchr/testify/require"
@@ -41,12 +42,12 @@ func TestGetContacts(t *testing.T) {
 			"GetContacts - Valid Request",
 			"1",
 			func(mock sqlmock.Sqlmock) {
-				rows := sqlmock.NewRows([]string{"id", "name", "title", "email", "phone", "phone2", "address", "notes", "source", "privacy", "platform_id"}).
-					AddRow(1, "test", "test", "test", "test", "test", "test", "test", "test", "test", 1)
+				rows := sqlmock.NewRows([]string{"id", "name", "title", "email", "phone", "phone2", "address", "notes", "source", "privacy", "platform_id", "person_id", "current", "last_contacted", "comments_count"}).
+					AddRow(1, "test", "test", "test", "test", "test", "test", "test", "test", "test", 1, 1, true, time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC), 4)
 				mock.ExpectQuery("SELECT .+ FROM contacts").WithArgs(1).WillReturnRows(rows)
 			},
 			http.StatusOK,
-			`[{"platformId":1,"id":1,"createdAt":"0001-01-01T00:00:00Z","modifiedAt":"0001-01-01T00:00:00Z","deletedAt":{"Time":"0001-01-01T00:00:00Z","Valid":false},"name":"test","title":"test","email":"test","phone":"test","phone2":"test","address":"test","notes":"test","source":"test","privacy":"test"}]`,
+			`[{"createdBy":{"Int64":0,"Valid":false},"platformId":1,"personId":1,"startDate":{"Time":"0001-01-01T00:00:00Z","Valid":false},"endDate":{"Time":"0001-01-01T00:00:00Z","Valid":false},"current":true,"id":1,"createdAt":"0001-01-01T00:00:00Z","modifiedAt":"0001-01-01T00:00:00Z","deletedAt":{"Time":"0001-01-01T00:00:00Z","Valid":false},"name":"test","title":"test","email":"test","phone":"test","phone2":"test","address":"test","notes":"test","source":"test","privacy":"test","lastContacted":"2024-05-01T10:00:00Z","commentsCount":4}]`,
 		},
 	}
 
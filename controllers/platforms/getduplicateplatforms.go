@@ -0,0 +1,35 @@
+package platforms
+
+import (
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/webstradev/rsdb-backend/db"
+	"github.com/webstradev/rsdb-backend/utils"
+)
+
+func GetDuplicatePlatforms(env *utils.Environment) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		// Optional similarity threshold for platform names (between 0 and 1)
+		threshold := db.DEFAULT_DUPLICATE_THRESHOLD
+		if thresholdString := c.Query("threshold"); thresholdString != "" {
+			var err error
+			threshold, err = strconv.ParseFloat(thresholdString, 64)
+			if err != nil || threshold <= 0 || threshold > 1 {
+				c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Invalid threshold"})
+				return
+			}
+		}
+
+		platforms, err := env.DB.GetPossibleDuplicatePlatforms()
+		if err != nil {
+			log.Println(err)
+			c.AbortWithStatus(http.StatusInternalServerError)
+			return
+		}
+
+		c.JSON(http.StatusOK, db.FindDuplicatePlatforms(platforms, threshold))
+	}
+}
@@ -0,0 +1,103 @@
+package platforms
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/require"
+	"github.com/webstradev/rsdb-backend/utils"
+)
+
+func TestGetDuplicatePlatforms(t *testing.T) {
+	tests := []struct {
+		Name       string
+		Query      string
+		MockDbCall func(sqlmock.Sqlmock)
+		StatusCode int
+		Response   string
+	}{
+		{
+			"GetDuplicatePlatforms - invalid threshold",
+			"threshold=2",
+			nil,
+			http.StatusBadRequest,
+			`{"error":"Invalid threshold"}`,
+		},
+		{
+			"GetDuplicatePlatforms - sql error on GetPossibleDuplicatePlatforms",
+			"",
+			func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT (.+) FROM platforms").WillReturnError(errors.New("test"))
+			},
+			http.StatusInternalServerError,
+			`{}`,
+		},
+		{
+			"GetDuplicatePlatforms - Valid Request",
+			"",
+			func(mock sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows([]string{"id", "name", "website", "country", "source"}).
+					AddRow(1, "Acme Films Ltd.", "https://www.acme.com/about", "Netherlands", "a").
+					AddRow(2, "ACME films", "", "netherlands", "b").
+					AddRow(3, "Totally Different", "http://acme.com", "Germany", "c").
+					AddRow(4, "Acme Films", "", "Germany", "d").
+					AddRow(5, "Some Page", "https://facebook.com/somepage", "Belgium", "e").
+					AddRow(6, "Other Page", "https://facebook.com/otherpage", "Belgium", "f")
+				mock.ExpectQuery("SELECT (.+) FROM platforms").WillReturnRows(rows)
+			},
+			http.StatusOK,
+			`[
+				{"platform":{"id":1,"name":"Acme Films Ltd.","website":"https://www.acme.com/about","country":"Netherlands","source":"a"},"duplicate":{"id":2,"name":"ACME films","website":"","country":"netherlands","source":"b"},"score":1,"reasons":["name","country"]},
+				{"platform":{"id":1,"name":"Acme Films Ltd.","website":"https://www.acme.com/about","country":"Netherlands","source":"a"},"duplicate":{"id":3,"name":"Totally Different","website":"http://acme.com","country":"Germany","source":"c"},"score":1,"reasons":["website"]}
+			]`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			// Initilize test router, environemnt and mock database
+			r, mockDb, mockSql, env, err := utils.SetupTestEnvironment(test.MockDbCall)
+			// Close the mock database at the end of the test
+			defer mockDb.Close()
+
+			// Check for errors during setup
+			require.NoError(t, err)
+
+			// Register handler
+			r.GET("/api/v1/admin/platforms/duplicates", GetDuplicatePlatforms(env))
+
+			// Create httptest request
+			req, _ := http.NewRequest("GET", fmt.Sprintf("/api/v1/admin/platforms/duplicates?%s", test.Query), nil)
+			w := httptest.NewRecorder()
+
+			// Mock request
+			r.ServeHTTP(w, req)
+
+			// Read response data
+			responseData, _ := io.ReadAll(w.Body)
+
+			// Check response status
+			require.Equal(t, test.StatusCode, w.Code)
+
+			// Handle empty responses
+			response := string(responseData)
+			if response == "" {
+				response = "{}"
+			}
+
+			// Check response body
+			require.JSONEq(t, test.Response, response)
+
+			// Check for any remaining expectations
+			// we make sure that all expectations were met
+			if err := mockSql.ExpectationsWereMet(); err != nil {
+				t.Fatalf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
@@ -8,11 +8,20 @@ import (
 	"strconv"
 
 	"github.com/gin-gonic/gin"
+	"github.com/webstradev/rsdb-backend/auth"
+	"github.com/webstradev/rsdb-backend/db"
 	"github.com/webstradev/rsdb-backend/utils"
 )
 
 func GetPlatform(env *utils.Environment) gin.HandlerFunc {
 	return func(c *gin.Context) {
+		user, err := auth.GetUserFromContext(c)
+		if err != nil {
+			log.Println(err)
+			c.AbortWithStatus(http.StatusInternalServerError)
+			return
+		}
+
 		idString := c.Param("platformId")
 
 		id, err := strconv.ParseInt(idString, 10, 64)
@@ -40,6 +49,13 @@ func GetPlatform(env *utils.Environment) gin.HandlerFunc {
 			return
 		}
 
+		platform.IsFavorite, err = env.DB.IsFavorite(user.UserID, db.PLATFORM_ENTITY, id)
+		if err != nil {
+			log.Println(err)
+			c.AbortWithStatus(http.StatusInternalServerError)
+			return
+		}
+
 		c.JSON(http.StatusOK, platform)
 	}
 }
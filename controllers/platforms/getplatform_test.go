@@ -8,9 +8,12 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/require"
+	"github.com/webstradev/rsdb-backend/auth"
 	"github.com/webstradev/rsdb-backend/utils"
 )
 
@@ -61,7 +64,7 @@ func TestGetPlatform(t *testing.T) {
 			`{}`,
 		},
 		{
-			"GetPlatform - Valid Request",
+			"GetPlatform - sql error on IsFavorite",
 			"1",
 			func(mock sqlmock.Sqlmock) {
 				rows := sqlmock.NewRows([]string{"id", "name", "website", "country", "source", "notes", "comment", "privacy", "contacts_count", "articles_count", "projects_count"}).
@@ -71,9 +74,28 @@ func TestGetPlatform(t *testing.T) {
 				rows = sqlmock.NewRows([]string{"platform_id", "category_id", "category"}).
 					AddRow(1, 1, "test")
 				mock.ExpectQuery("SELECT pc.(.+)").WithArgs(1).WillReturnRows(rows)
+
+				mock.ExpectQuery("SELECT COUNT(.+) FROM favorites").WithArgs(1, "platform", 1).WillReturnError(errors.New("test"))
+			},
+			http.StatusInternalServerError,
+			`{}`,
+		},
+		{
+			"GetPlatform - Valid Request",
+			"1",
+			func(mock sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows([]string{"id", "name", "website", "country", "source", "notes", "comment", "privacy", "contacts_count", "articles_count", "projects_count", "last_contacted"}).
+					AddRow(1, "test", "test", "test", "test", "test", "test", "test", 1, 1, 1, time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC))
+				mock.ExpectQuery("SELECT p.(.+)").WithArgs(1).WillReturnRows(rows)
+
+				rows = sqlmock.NewRows([]string{"platform_id", "category_id", "category"}).
+					AddRow(1, 1, "test")
+				mock.ExpectQuery("SELECT pc.(.+)").WithArgs(1).WillReturnRows(rows)
+
+				mock.ExpectQuery("SELECT COUNT(.+) FROM favorites").WithArgs(1, "platform", 1).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
 			},
 			http.StatusOK,
-			`{"id":1,"createdAt":"0001-01-01T00:00:00Z","modifiedAt":"0001-01-01T00:00:00Z","deletedAt":{"Time":"0001-01-01T00:00:00Z","Valid":false},"name":"test","website":"test","country":"test","source":"test","notes":"test","privacy":"test","comment":"test","categories":[{"id":1, "category":"test"}],"contactsCount":1,"articlesCount":1,"projectsCount":1}`,
+			`{"id":1,"createdAt":"0001-01-01T00:00:00Z","modifiedAt":"0001-01-01T00:00:00Z","deletedAt":{"Time":"0001-01-01T00:00:00Z","Valid":false},"name":"test","website":"test","country":"test","source":"test","notes":"test","privacy":"test","comment":"test","categories":[{"id":1, "category":"test"}],"createdBy":{"Int64":0,"Valid":false},"contactsCount":1,"articlesCount":1,"projectsCount":1,"lastContacted":"2024-05-01T10:00:00Z","isFavorite":true}`,
 		},
 	}
 
@@ -88,7 +110,10 @@ func TestGetPlatform(t *testing.T) {
 			require.NoError(t, err)
 
 			// Register handler
-			r.GET("/api/platforms/:platformId", GetPlatform(env))
+			r.GET("/api/platforms/:platformId", func(c *gin.Context) {
+				c.Set("user", auth.TokenData{UserID: 1})
+				GetPlatform(env)(c)
+			})
 
 			// Create httptest request
 			req, _ := http.NewRequest("GET", fmt.Sprintf("/api/platforms/%s", test.IdString), nil)
@@ -5,15 +5,23 @@ import (
 	"net/http"
 
 	"github.com/gin-gonic/gin"
+	"github.com/webstradev/rsdb-backend/auth"
 	"github.com/webstradev/rsdb-backend/utils"
 )
 
 func GetPlatforms(env *utils.Environment) gin.HandlerFunc {
 	return func(c *gin.Context) {
+		user, err := auth.GetUserFromContext(c)
+		if err != nil {
+			log.Println(err)
+			c.AbortWithStatus(http.StatusInternalServerError)
+			return
+		}
+
 		page := c.MustGet("page").(int)
 		pageSize := c.MustGet("pageSize").(int)
 
-		platforms, err := env.DB.GetPlatforms(page, pageSize)
+		platforms, err := env.DB.GetPlatforms(user.UserID, page, pageSize)
 		if err != nil {
 			c.AbortWithStatus(http.StatusInternalServerError)
 			return
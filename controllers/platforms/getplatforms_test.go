@@ -9,8 +9,10 @@ import (
 	"testing"
 
 	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/require"
 	"github.com/webstradev/gin-pagination/v2/pkg/pagination"
+	"github.com/webstradev/rsdb-backend/auth"
 	"github.com/webstradev/rsdb-backend/utils"
 )
 
@@ -28,7 +30,7 @@ func TestGetPlatforms(t *testing.T) {
 			0,
 			10,
 			func(mock sqlmock.Sqlmock) {
-				mock.ExpectQuery("SELECT p.(.+) FROM platforms").WithArgs(10, 0).WillReturnError(errors.New("test"))
+				mock.ExpectQuery("SELECT p.(.+) FROM platforms").WithArgs(1, 10, 0).WillReturnError(errors.New("test"))
 			},
 			http.StatusInternalServerError,
 			`{}`,
@@ -38,10 +40,10 @@ func TestGetPlatforms(t *testing.T) {
 			0,
 			2,
 			func(mock sqlmock.Sqlmock) {
-				rows := sqlmock.NewRows([]string{"id", "name", "website", "country", "source", "notes", "comment", "privacy", "contacts_count", "articles_count", "projects_count", "platform_categories"}).
-					AddRow(1, "test", "test", "test", "test", "test", "test", "test", 1, 1, 1, "test").
-					AddRow(2, "test", "test", "test", "test", "test", "test", "test", 1, 1, 1, "test")
-				mock.ExpectQuery("SELECT p.(.+) FROM platforms").WithArgs(2, 0).WillReturnRows(rows)
+				rows := sqlmock.NewRows([]string{"id", "name", "website", "country", "source", "notes", "comment", "privacy", "contacts_count", "articles_count", "projects_count", "platform_categories", "comments_count", "is_favorite"}).
+					AddRow(1, "test", "test", "test", "test", "test", "test", "test", 1, 1, 1, "test", 2, false).
+					AddRow(2, "test", "test", "test", "test", "test", "test", "test", 1, 1, 1, "test", 2, false)
+				mock.ExpectQuery("SELECT p.(.+) FROM platforms").WithArgs(1, 2, 0).WillReturnRows(rows)
 
 				mock.ExpectQuery("SELECT COUNT(.+) AS count FROM platforms").WillReturnError(errors.New("test"))
 			},
@@ -52,34 +54,34 @@ func TestGetPlatforms(t *testing.T) {
 			0,
 			2,
 			func(mock sqlmock.Sqlmock) {
-				rows := sqlmock.NewRows([]string{"id", "name", "website", "country", "source", "notes", "comment", "privacy", "contacts_count", "articles_count", "projects_count", "platform_categories"}).
-					AddRow(1, "test", "test", "test", "test", "test", "test", "test", 1, 1, 1, "test").
-					AddRow(2, "test", "test", "test", "test", "test", "test", "test", 1, 1, 1, "test")
-				mock.ExpectQuery("SELECT p.(.+) FROM platforms").WithArgs(2, 0).WillReturnRows(rows)
+				rows := sqlmock.NewRows([]string{"id", "name", "website", "country", "source", "notes", "comment", "privacy", "contacts_count", "articles_count", "projects_count", "platform_categories", "comments_count", "is_favorite"}).
+					AddRow(1, "test", "test", "test", "test", "test", "test", "test", 1, 1, 1, "test", 2, false).
+					AddRow(2, "test", "test", "test", "test", "test", "test", "test", 1, 1, 1, "test", 2, false)
+				mock.ExpectQuery("SELECT p.(.+) FROM platforms").WithArgs(1, 2, 0).WillReturnRows(rows)
 
 				rows = sqlmock.NewRows([]string{"count"}).AddRow(10)
 				mock.ExpectQuery("SELECT COUNT(.+) AS count FROM platforms").WillReturnRows(rows)
 			},
 			http.StatusOK,
-			`{"total":10,"platforms":[{"id":1,"createdAt":"0001-01-01T00:00:00Z","modifiedAt":"0001-01-01T00:00:00Z","deletedAt":{"Time":"0001-01-01T00:00:00Z","Valid":false},"name":"test","website":"test","country":"test","source":"test","notes":"test","privacy":"test","comment":"test","categories":null,"categoryString":"test","contactsCount":1,"articlesCount":1,"projectsCount":1},{"id":2,"createdAt":"0001-01-01T00:00:00Z","modifiedAt":"0001-01-01T00:00:00Z","deletedAt":{"Time":"0001-01-01T00:00:00Z","Valid":false},"name":"test","website":"test","country":"test","source":"test","notes":"test","privacy":"test","comment":"test","categories":null,"categoryString":"test","contactsCount":1,"articlesCount":1,"projectsCount":1}]}`,
+			`{"total":10,"platforms":[{"id":1,"createdAt":"0001-01-01T00:00:00Z","modifiedAt":"0001-01-01T00:00:00Z","deletedAt":{"Time":"0001-01-01T00:00:00Z","Valid":false},"name":"test","website":"test","country":"test","source":"test","notes":"test","privacy":"test","comment":"test","categories":null,"categoryString":"test","createdBy":{"Int64":0,"Valid":false},"isFavorite":false,"contactsCount":1,"articlesCount":1,"projectsCount":1,"commentsCount":2},{"id":2,"createdAt":"0001-01-01T00:00:00Z","modifiedAt":"0001-01-01T00:00:00Z","deletedAt":{"Time":"0001-01-01T00:00:00Z","Valid":false},"name":"test","website":"test","country":"test","source":"test","notes":"test","privacy":"test","comment":"test","categories":null,"categoryString":"test","createdBy":{"Int64":0,"Valid":false},"isFavorite":false,"contactsCount":1,"articlesCount":1,"projectsCount":1,"commentsCount":2}]}`,
 		},
 		{
 			"GetPlatforms - 4 platforms from page 2",
 			1,
 			4,
 			func(mock sqlmock.Sqlmock) {
-				rows := sqlmock.NewRows([]string{"id", "name", "website", "country", "source", "notes", "comment", "privacy", "contacts_count", "articles_count", "projects_count", "platform_categories"}).
-					AddRow(3, "test", "test", "test", "test", "test", "test", "test", 1, 1, 1, "test").
-					AddRow(4, "test", "test", "test", "test", "test", "test", "test", 1, 1, 1, "test").
-					AddRow(5, "test", "test", "test", "test", "test", "test", "test", 1, 1, 1, "test").
-					AddRow(6, "test", "test", "test", "test", "test", "test", "test", 1, 1, 1, "test")
-				mock.ExpectQuery("SELECT p.(.+) FROM platforms").WithArgs(4, 4).WillReturnRows(rows)
+				rows := sqlmock.NewRows([]string{"id", "name", "website", "country", "source", "notes", "comment", "privacy", "contacts_count", "articles_count", "projects_count", "platform_categories", "comments_count", "is_favorite"}).
+					AddRow(3, "test", "test", "test", "test", "test", "test", "test", 1, 1, 1, "test", 2, false).
+					AddRow(4, "test", "test", "test", "test", "test", "test", "test", 1, 1, 1, "test", 2, false).
+					AddRow(5, "test", "test", "test", "test", "test", "test", "test", 1, 1, 1, "test", 2, false).
+					AddRow(6, "test", "test", "test", "test", "test", "test", "test", 1, 1, 1, "test", 2, false)
+				mock.ExpectQuery("SELECT p.(.+) FROM platforms").WithArgs(1, 4, 4).WillReturnRows(rows)
 
 				rows = sqlmock.NewRows([]string{"count"}).AddRow(10)
 				mock.ExpectQuery("SELECT COUNT(.+) AS count FROM platforms").WillReturnRows(rows)
 			},
 			http.StatusOK,
-			`{"total":10,"platforms":[{"id":3,"createdAt":"0001-01-01T00:00:00Z","modifiedAt":"0001-01-01T00:00:00Z","deletedAt":{"Time":"0001-01-01T00:00:00Z","Valid":false},"name":"test","website":"test","country":"test","source":"test","notes":"test","privacy":"test","comment":"test","categories":null,"categoryString":"test","contactsCount":1,"articlesCount":1,"projectsCount":1},{"id":4,"createdAt":"0001-01-01T00:00:00Z","modifiedAt":"0001-01-01T00:00:00Z","deletedAt":{"Time":"0001-01-01T00:00:00Z","Valid":false},"name":"test","website":"test","country":"test","source":"test","notes":"test","privacy":"test","comment":"test","categories":null,"categoryString":"test","contactsCount":1,"articlesCount":1,"projectsCount":1},{"id":5,"createdAt":"0001-01-01T00:00:00Z","modifiedAt":"0001-01-01T00:00:00Z","deletedAt":{"Time":"0001-01-01T00:00:00Z","Valid":false},"name":"test","website":"test","country":"test","source":"test","notes":"test","privacy":"test","comment":"test","categories":null,"categoryString":"test","contactsCount":1,"articlesCount":1,"projectsCount":1},{"id":6,"createdAt":"0001-01-01T00:00:00Z","modifiedAt":"0001-01-01T00:00:00Z","deletedAt":{"Time":"0001-01-01T00:00:00Z","Valid":false},"name":"test","website":"test","country":"test","source":"test","notes":"test","privacy":"test","comment":"test","categories":null,"categoryString":"test","contactsCount":1,"articlesCount":1,"projectsCount":1}]}`,
+			`{"total":10,"platforms":[{"id":3,"createdAt":"0001-01-01T00:00:00Z","modifiedAt":"0001-01-01T00:00:00Z","deletedAt":{"Time":"0001-01-01T00:00:00Z","Valid":false},"name":"test","website":"test","country":"test","source":"test","notes":"test","privacy":"test","comment":"test","categories":null,"categoryString":"test","createdBy":{"Int64":0,"Valid":false},"isFavorite":false,"contactsCount":1,"articlesCount":1,"projectsCount":1,"commentsCount":2},{"id":4,"createdAt":"0001-01-01T00:00:00Z","modifiedAt":"0001-01-01T00:00:00Z","deletedAt":{"Time":"0001-01-01T00:00:00Z","Valid":false},"name":"test","website":"test","country":"test","source":"test","notes":"test","privacy":"test","comment":"test","categories":null,"categoryString":"test","createdBy":{"Int64":0,"Valid":false},"isFavorite":false,"contactsCount":1,"articlesCount":1,"projectsCount":1,"commentsCount":2},{"id":5,"createdAt":"0001-01-01T00:00:00Z","modifiedAt":"0001-01-01T00:00:00Z","deletedAt":{"Time":"0001-01-01T00:00:00Z","Valid":false},"name":"test","website":"test","country":"test","source":"test","notes":"test","privacy":"test","comment":"test","categories":null,"categoryString":"test","createdBy":{"Int64":0,"Valid":false},"isFavorite":false,"contactsCount":1,"articlesCount":1,"projectsCount":1,"commentsCount":2},{"id":6,"createdAt":"0001-01-01T00:00:00Z","modifiedAt":"0001-01-01T00:00:00Z","deletedAt":{"Time":"0001-01-01T00:00:00Z","Valid":false},"name":"test","website":"test","country":"test","source":"test","notes":"test","privacy":"test","comment":"test","categories":null,"categoryString":"test","createdBy":{"Int64":0,"Valid":false},"isFavorite":false,"contactsCount":1,"articlesCount":1,"projectsCount":1,"commentsCount":2}]}`,
 		},
 	}
 
@@ -100,7 +102,10 @@ func TestGetPlatforms(t *testing.T) {
 					pagination.WithMinPageSize(1),
 					pagination.WithMaxPageSize(100),
 				),
-				GetPlatforms(env),
+				func(c *gin.Context) {
+					c.Set("user", auth.TokenData{UserID: 1})
+					GetPlatforms(env)(c)
+				},
 			)
 
 			// Create httptest request
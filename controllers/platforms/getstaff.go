@@ -0,0 +1,43 @@
+package platforms
+
+import (
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/webstradev/rsdb-backend/db"
+	"github.com/webstradev/rsdb-backend/utils"
+)
+
+func GetStaff(env *utils.Environment) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		idString := c.Param("platformId")
+		id, err := strconv.ParseInt(idString, 10, 64)
+		if err != nil {
+			log.Println(err)
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Invalid ID"})
+			return
+		}
+
+		staff, err := env.DB.GetStaff(id)
+		if err != nil {
+			log.Println(err)
+			c.AbortWithStatus(http.StatusInternalServerError)
+			return
+		}
+
+		// Split the staff in current and former employees
+		current := []db.Employment{}
+		former := []db.Employment{}
+		for _, employment := range staff {
+			if employment.Current {
+				current = append(current, employment)
+			} else {
+				former = append(former, employment)
+			}
+		}
+
+		c.JSON(http.StatusOK, gin.H{"current": current, "former": former})
+	}
+}
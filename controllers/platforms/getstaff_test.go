@@ -0,0 +1,108 @@
+package platforms
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/require"
+	"github.com/webstradev/rsdb-backend/utils"
+)
+
+func TestGetStaff(t *testing.T) {
+	tests := []struct {
+		Name       string
+		IdString   string
+		MockDbCall func(sqlmock.Sqlmock)
+		StatusCode int
+		Response   string
+	}{
+		{
+			"GetStaff - non int id",
+			"notanint",
+			nil,
+			http.StatusBadRequest,
+			`{"error":"Invalid ID"}`,
+		},
+		{
+			"GetStaff - sql error on GetStaff",
+			"1",
+			func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT (.+) FROM contacts").WithArgs(1).WillReturnError(errors.New("test"))
+			},
+			http.StatusInternalServerError,
+			`{}`,
+		},
+		{
+			"GetStaff - No staff",
+			"1",
+			func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT (.+) FROM contacts").WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"contact_id"}))
+			},
+			http.StatusOK,
+			`{"current":[],"former":[]}`,
+		},
+		{
+			"GetStaff - Valid Request",
+			"1",
+			func(mock sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows([]string{"contact_id", "person_id", "person_name", "platform_id", "platform_name", "title", "current"}).
+					AddRow(1, 1, "current", 1, "platform", "editor", true).
+					AddRow(2, 2, "former", 1, "platform", "writer", false)
+				mock.ExpectQuery("SELECT (.+) FROM contacts").WithArgs(1).WillReturnRows(rows)
+			},
+			http.StatusOK,
+			`{
+				"current":[{"contactId":1,"personId":1,"personName":"current","platformId":1,"platformName":"platform","title":"editor","startDate":{"Time":"0001-01-01T00:00:00Z","Valid":false},"endDate":{"Time":"0001-01-01T00:00:00Z","Valid":false},"current":true}],
+				"former":[{"contactId":2,"personId":2,"personName":"former","platformId":1,"platformName":"platform","title":"writer","startDate":{"Time":"0001-01-01T00:00:00Z","Valid":false},"endDate":{"Time":"0001-01-01T00:00:00Z","Valid":false},"current":false}]
+			}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			// Initilize test router, environemnt and mock database
+			r, mockDb, mockSql, env, err := utils.SetupTestEnvironment(test.MockDbCall)
+			// Close the mock database at the end of the test
+			defer mockDb.Close()
+
+			// Check for errors during setup
+			require.NoError(t, err)
+
+			// Register handler
+			r.GET("/api/v1/platforms/:platformId/staff", GetStaff(env))
+
+			// Create httptest request
+			req, _ := http.NewRequest("GET", fmt.Sprintf("/api/v1/platforms/%s/staff", test.IdString), nil)
+			w := httptest.NewRecorder()
+
+			// Mock request
+			r.ServeHTTP(w, req)
+
+			// Read response data
+			responseData, _ := io.ReadAll(w.Body)
+
+			// Check response status
+			require.Equal(t, test.StatusCode, w.Code)
+
+			// Handle empty responses
+			response := string(responseData)
+			if response == "" {
+				response = "{}"
+			}
+
+			// Check response body
+			require.JSONEq(t, test.Response, response)
+
+			// Check for any remaining expectations
+			// we make sure that all expectations were met
+			if err := mockSql.ExpectationsWereMet(); err != nil {
+				t.Fatalf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
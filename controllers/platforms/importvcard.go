@@ -0,0 +1,142 @@
+package platforms
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/webstradev/rsdb-backend/auth"
+	"github.com/webstradev/rsdb-backend/db"
+	"github.com/webstradev/rsdb-backend/utils"
+	"github.com/webstradev/rsdb-backend/vcard"
+)
+
+// Largest vCard file that can be imported
+const MAX_VCARD_SIZE = 5 << 20
+
+// Time a vCard import may take to upload and process, the server timeouts are too short for large files
+const VCARD_TIMEOUT = 2 * time.Minute
+
+// ImportVCard creates a contact under the platform for every card in the uploaded vCard file
+func ImportVCard(env *utils.Environment) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		user, err := auth.GetUserFromContext(c)
+		if err != nil {
+			log.Println(err)
+			c.AbortWithStatus(http.StatusInternalServerError)
+			return
+		}
+
+		idString := c.Param("platformId")
+		id, err := strconv.ParseInt(idString, 10, 64)
+		if err != nil {
+			log.Println(err)
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Invalid ID"})
+			return
+		}
+
+		rc := http.NewResponseController(c.Writer)
+		rc.SetReadDeadline(time.Now().Add(VCARD_TIMEOUT))
+		rc.SetWriteDeadline(time.Now().Add(VCARD_TIMEOUT))
+
+		// Leave room for the rest of the multipart form
+		c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, MAX_VCARD_SIZE+1<<20)
+
+		header, err := c.FormFile("file")
+		if err != nil {
+			var tooLarge *http.MaxBytesError
+			if errors.As(err, &tooLarge) {
+				c.AbortWithStatusJSON(http.StatusRequestEntityTooLarge, gin.H{"error": "File too large"})
+				return
+			}
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Missing file"})
+			return
+		}
+
+		if header.Size > MAX_VCARD_SIZE {
+			c.AbortWithStatusJSON(http.StatusRequestEntityTooLarge, gin.H{"error": "File too large"})
+			return
+		}
+
+		file, err := header.Open()
+		if err != nil {
+			log.Println(err)
+			c.AbortWithStatus(http.StatusInternalServerError)
+			return
+		}
+		defer file.Close()
+
+		cards, err := vcard.Decode(file)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		if len(cards) == 0 {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "No contacts found in file"})
+			return
+		}
+
+		// Phone numbers are read as numbers from the platform's country unless they have a country code
+		country, err := env.DB.GetPlatformCountry(id)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				c.AbortWithStatus(http.StatusNotFound)
+				return
+			}
+			log.Println(err)
+			c.AbortWithStatus(http.StatusInternalServerError)
+			return
+		}
+
+		contacts := []db.Contact{}
+		for i, card := range cards {
+			if card.Name == "" {
+				c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Contact %d has no name", i+1)})
+				return
+			}
+
+			// Contacts have room for a single email address and two phone numbers
+			contact := db.Contact{Name: card.Name, Title: card.Title, Address: card.Address, Notes: card.Note, Source: "vCard", PlatformId: id, Current: true, CreatedBy: sql.NullInt64{Int64: user.UserID, Valid: true}}
+			if len(card.Emails) > 0 {
+				contact.Email = card.Emails[0]
+			}
+			if len(card.Phones) > 0 {
+				contact.Phone = card.Phones[0]
+			}
+			if len(card.Phones) > 1 {
+				contact.Phone2 = card.Phones[1]
+			}
+
+			err = contact.Normalize(country)
+			if err != nil {
+				c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Contact %d: %s", i+1, err)})
+				return
+			}
+
+			contacts = append(contacts, contact)
+		}
+
+		ids, err := env.DB.InsertContacts(contacts)
+		if err != nil {
+			log.Println(err)
+			c.AbortWithStatus(http.StatusInternalServerError)
+			return
+		}
+
+		// Let open frontends and the subscribed webhooks know the contacts were created
+		for _, contactId := range ids {
+			err = env.Changes.Publish(db.CONTACT_ENTITY, db.CHANGE_CREATED, contactId, user.UserID)
+			if err != nil {
+				log.Println(err)
+			}
+		}
+
+		c.JSON(http.StatusOK, gin.H{"message": "Contacts imported successfully", "count": len(contacts)})
+	}
+}
@@ -0,0 +1,202 @@
+package platforms
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+	"github.com/webstradev/rsdb-backend/auth"
+	"github.com/webstradev/rsdb-backend/utils"
+)
+
+const importVCardFile = "BEGIN:VCARD\r\nVERSION:3.0\r\nFN:John Doe\r\nTITLE:Editor\r\nEMAIL:john@example.com\r\nTEL:+31 6 12345678\r\nTEL:020 123 4567\r\nEND:VCARD\r\n" +
+	"BEGIN:VCARD\r\nVERSION:4.0\r\nN:Doe;Jane;;;\r\nEND:VCARD\r\n"
+
+func TestImportVCard(t *testing.T) {
+	tests := []struct {
+		Name       string
+		IdString   string
+		File       string
+		MockDbCall func(sqlmock.Sqlmock)
+		StatusCode int
+		Response   string
+	}{
+		{
+			"ImportVCard - non int id",
+			"notanint",
+			importVCardFile,
+			nil,
+			http.StatusBadRequest,
+			`{"error":"Invalid ID"}`,
+		},
+		{
+			"ImportVCard - Missing file",
+			"1",
+			"",
+			nil,
+			http.StatusBadRequest,
+			`{"error":"Missing file"}`,
+		},
+		{
+			"ImportVCard - File too large",
+			"1",
+			strings.Repeat("x", MAX_VCARD_SIZE+1),
+			nil,
+			http.StatusRequestEntityTooLarge,
+			`{"error":"File too large"}`,
+		},
+		{
+			"ImportVCard - Body too large",
+			"1",
+			strings.Repeat("x", MAX_VCARD_SIZE+2<<20),
+			nil,
+			http.StatusRequestEntityTooLarge,
+			`{"error":"File too large"}`,
+		},
+		{
+			"ImportVCard - Invalid vCard",
+			"1",
+			"BEGIN:VCARD\r\nFN:John Doe\r\n",
+			nil,
+			http.StatusBadRequest,
+			`{"error":"invalid vCard, missing BEGIN:VCARD or END:VCARD"}`,
+		},
+		{
+			"ImportVCard - No contacts",
+			"1",
+			"not a vcard",
+			nil,
+			http.StatusBadRequest,
+			`{"error":"No contacts found in file"}`,
+		},
+		{
+			"ImportVCard - platform not found",
+			"1",
+			importVCardFile,
+			func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT country FROM platforms").WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"country"}))
+			},
+			http.StatusNotFound,
+			`{}`,
+		},
+		{
+			"ImportVCard - Contact without name",
+			"1",
+			"BEGIN:VCARD\r\nEMAIL:test@example.com\r\nEND:VCARD\r\n",
+			func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT country FROM platforms").WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"country"}).AddRow("NL"))
+			},
+			http.StatusBadRequest,
+			`{"error":"Contact 1 has no name"}`,
+		},
+		{
+			"ImportVCard - invalid email",
+			"1",
+			"BEGIN:VCARD\r\nFN:John Doe\r\nEMAIL:john\r\nEND:VCARD\r\n",
+			func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT country FROM platforms").WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"country"}).AddRow("NL"))
+			},
+			http.StatusBadRequest,
+			`{"error":"Contact 1: email: invalid email address"}`,
+		},
+		{
+			"ImportVCard - sql error on InsertContacts",
+			"1",
+			importVCardFile,
+			func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT country FROM platforms").WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"country"}).AddRow("NL"))
+				mock.ExpectBegin()
+				mock.ExpectExec("INSERT INTO people").WithArgs("John Doe", "").WillReturnError(errors.New("test"))
+				mock.ExpectRollback()
+			},
+			http.StatusInternalServerError,
+			`{}`,
+		},
+		{
+			"ImportVCard - Valid Request",
+			"1",
+			importVCardFile,
+			func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT country FROM platforms").WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"country"}).AddRow("NL"))
+				mock.ExpectBegin()
+				mock.ExpectExec("INSERT INTO people").WithArgs("John Doe", "").WillReturnResult(sqlmock.NewResult(3, 1))
+				mock.ExpectExec("INSERT INTO contacts").
+					WithArgs("John Doe", "Editor", "john@example.com", "+31612345678", "+31201234567", "", "", "vCard", "", 1, 3, sqlmock.AnyArg(), sqlmock.AnyArg(), true, 1).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec("INSERT INTO people").WithArgs("Jane Doe", "").WillReturnResult(sqlmock.NewResult(4, 1))
+				mock.ExpectExec("INSERT INTO contacts").
+					WithArgs("Jane Doe", "", "", "", "", "", "", "vCard", "", 1, 4, sqlmock.AnyArg(), sqlmock.AnyArg(), true, 1).
+					WillReturnResult(sqlmock.NewResult(2, 1))
+				mock.ExpectCommit()
+			},
+			http.StatusOK,
+			`{"message":"Contacts imported successfully","count":2}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			// Initilize test router, environemnt and mock database
+			r, mockDb, mockSql, env, err := utils.SetupTestEnvironment(test.MockDbCall)
+			// Close the mock database at the end of the test
+			defer mockDb.Close()
+
+			// Check for errors during setup
+			require.NoError(t, err)
+
+			// Register handler
+			r.POST("/api/v1/platforms/:platformId/contacts/vcard", func(c *gin.Context) {
+				c.Set("user", auth.TokenData{UserID: 1})
+				ImportVCard(env)(c)
+			})
+
+			// Create multipart body
+			body := &bytes.Buffer{}
+			form := multipart.NewWriter(body)
+			if test.File != "" {
+				part, err := form.CreateFormFile("file", "contacts.vcf")
+				require.NoError(t, err)
+				part.Write([]byte(test.File))
+			}
+			form.Close()
+
+			// Create httptest request
+			req, _ := http.NewRequest("POST", fmt.Sprintf("/api/v1/platforms/%s/contacts/vcard", test.IdString), body)
+			req.Header.Set("Content-Type", form.FormDataContentType())
+			w := httptest.NewRecorder()
+
+			// Mock request
+			r.ServeHTTP(w, req)
+
+			// Read response data
+			responseData, _ := io.ReadAll(w.Body)
+
+			// Check response status
+			require.Equal(t, test.StatusCode, w.Code)
+
+			// Handle empty responses
+			response := string(responseData)
+			if response == "" {
+				response = "{}"
+			}
+
+			// Check response body
+			require.JSONEq(t, test.Response, response)
+
+			// Check for any remaining expectations
+			// we make sure that all expectations were met
+			if err := mockSql.ExpectationsWereMet(); err != nil {
+				t.Fatalf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
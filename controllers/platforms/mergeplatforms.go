@@ -0,0 +1,77 @@
+package platforms
+
+import (
+	"database/sql"
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/webstradev/rsdb-backend/auth"
+	"github.com/webstradev/rsdb-backend/db"
+	"github.com/webstradev/rsdb-backend/utils"
+)
+
+type mergePlatformsInput struct {
+	Duplicates []int64 `json:"duplicates" binding:"required,min=1"`
+}
+
+func MergePlatforms(env *utils.Environment) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		user, err := auth.GetUserFromContext(c)
+		if err != nil {
+			log.Println(err)
+			c.AbortWithStatus(http.StatusInternalServerError)
+			return
+		}
+
+		// Get the surviving platform ID from URL
+		idString := c.Param("platformId")
+		id, err := strconv.ParseInt(idString, 10, 64)
+		if err != nil {
+			log.Println(err)
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Invalid ID"})
+			return
+		}
+
+		// Validate Input
+		input := mergePlatformsInput{}
+		err = c.ShouldBindJSON(&input)
+		if err != nil {
+			log.Println(err)
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		err = env.DB.MergePlatforms(id, input.Duplicates, user.UserID)
+		if err != nil {
+			if errors.Is(err, db.ErrInvalidMerge) {
+				c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+				return
+			}
+			if errors.Is(err, sql.ErrNoRows) {
+				c.AbortWithStatus(http.StatusNotFound)
+				return
+			}
+			log.Println(err)
+			c.AbortWithStatus(http.StatusInternalServerError)
+			return
+		}
+
+		// Let open frontends and the subscribed webhooks know the duplicates were merged into the platform
+		err = env.Changes.Publish(db.PLATFORM_ENTITY, db.CHANGE_UPDATED, id, user.UserID)
+		if err != nil {
+			log.Println(err)
+		}
+
+		for _, duplicate := range input.Duplicates {
+			err = env.Changes.Publish(db.PLATFORM_ENTITY, db.CHANGE_DELETED, duplicate, user.UserID)
+			if err != nil {
+				log.Println(err)
+			}
+		}
+
+		c.JSON(http.StatusOK, gin.H{"message": "Platforms merged successfully"})
+	}
+}
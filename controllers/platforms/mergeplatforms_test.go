@@ -0,0 +1,205 @@
+package platforms
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+	"github.com/webstradev/rsdb-backend/auth"
+	"github.com/webstradev/rsdb-backend/changes"
+	"github.com/webstradev/rsdb-backend/utils"
+	"github.com/webstradev/rsdb-backend/webhooks"
+)
+
+func TestMergePlatforms(t *testing.T) {
+	// Expectations for the revision that is stored for the surviving platform
+	expectSurvivorRevision := func(mock sqlmock.Sqlmock) {
+		mock.ExpectQuery("SELECT p.(.+) FROM platforms").WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(1, "Acme"))
+		mock.ExpectQuery("SELECT (.+) FROM categories").WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"platform_id", "category_id", "category"}))
+		mock.ExpectExec("INSERT INTO revisions").WithArgs("platform", 1, 1, sqlmock.AnyArg(), "platform", 1).WillReturnResult(sqlmock.NewResult(1, 1))
+	}
+
+	tests := []struct {
+		Name       string
+		IdString   string
+		User       auth.TokenData
+		MockDbCall func(sqlmock.Sqlmock)
+		StatusCode int
+		Body       string
+		Response   string
+	}{
+		{
+			"MergePlatforms - User Missing from Context",
+			"1",
+			auth.TokenData{},
+			nil,
+			http.StatusInternalServerError,
+			`{"duplicates":[2]}`,
+			`{}`,
+		},
+		{
+			"MergePlatforms - non int id",
+			"notanint",
+			auth.TokenData{UserID: 1},
+			nil,
+			http.StatusBadRequest,
+			`{"duplicates":[2]}`,
+			`{"error":"Invalid ID"}`,
+		},
+		{
+			"MergePlatforms - no duplicates",
+			"1",
+			auth.TokenData{UserID: 1},
+			nil,
+			http.StatusBadRequest,
+			`{"duplicates":[]}`,
+			`{"error":"Key: 'mergePlatformsInput.Duplicates' Error:Field validation for 'Duplicates' failed on the 'min' tag"}`,
+		},
+		{
+			"MergePlatforms - merge into itself",
+			"1",
+			auth.TokenData{UserID: 1},
+			nil,
+			http.StatusBadRequest,
+			`{"duplicates":[2, 1]}`,
+			`{"error":"a platform can not be merged into itself"}`,
+		},
+		{
+			"MergePlatforms - surviving platform not found",
+			"1",
+			auth.TokenData{UserID: 1},
+			func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT p.(.+) FROM platforms").WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"id"}))
+				mock.ExpectRollback()
+			},
+			http.StatusNotFound,
+			`{"duplicates":[2]}`,
+			`{}`,
+		},
+		{
+			"MergePlatforms - duplicate not found",
+			"1",
+			auth.TokenData{UserID: 1},
+			func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				expectSurvivorRevision(mock)
+				mock.ExpectExec("UPDATE platforms SET merged_into").WithArgs(1, 2, 3).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectRollback()
+			},
+			http.StatusNotFound,
+			`{"duplicates":[2, 3]}`,
+			`{}`,
+		},
+		{
+			"MergePlatforms - sql error on moving contacts",
+			"1",
+			auth.TokenData{UserID: 1},
+			func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				expectSurvivorRevision(mock)
+				mock.ExpectExec("UPDATE platforms SET merged_into").WithArgs(1, 2).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("UPDATE contacts SET platform_id").WithArgs(1, 2).WillReturnError(errors.New("test"))
+				mock.ExpectRollback()
+			},
+			http.StatusInternalServerError,
+			`{"duplicates":[2]}`,
+			`{}`,
+		},
+		{
+			"MergePlatforms - Valid Request",
+			"1",
+			auth.TokenData{UserID: 1},
+			func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				expectSurvivorRevision(mock)
+				mock.ExpectExec("UPDATE platforms SET merged_into").WithArgs(1, 2, 3).WillReturnResult(sqlmock.NewResult(0, 2))
+				mock.ExpectExec("UPDATE contacts SET platform_id").WithArgs(1, 2, 3).WillReturnResult(sqlmock.NewResult(0, 4))
+				mock.ExpectExec("UPDATE activities SET platform_id").WithArgs(1, 2, 3).WillReturnResult(sqlmock.NewResult(0, 2))
+				mock.ExpectExec("UPDATE deals SET platform_id").WithArgs(1, 2, 3).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("UPDATE tasks SET entity_id = \\? WHERE entity_type = 'platform'").WithArgs(1, 2, 3).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("UPDATE attachments SET entity_id = \\? WHERE entity_type = 'platform'").WithArgs(1, 2, 3).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("UPDATE comments SET entity_id = \\? WHERE entity_type = 'platform'").WithArgs(1, 2, 3).WillReturnResult(sqlmock.NewResult(0, 3))
+				mock.ExpectExec("INSERT IGNORE INTO favorites (.+) WHERE entity_type = 'platform'").WithArgs(1, 2, 3).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("INSERT IGNORE INTO platforms_articles").WithArgs(1, 2, 3).WillReturnResult(sqlmock.NewResult(0, 2))
+				mock.ExpectExec("INSERT IGNORE INTO platforms_projects").WithArgs(1, 2, 3).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("INSERT IGNORE INTO platforms_categories").WithArgs(1, 2, 3).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("DELETE FROM platforms_articles").WithArgs(2, 3).WillReturnResult(sqlmock.NewResult(0, 2))
+				mock.ExpectExec("DELETE FROM platforms_projects").WithArgs(2, 3).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("DELETE FROM platforms_categories").WithArgs(2, 3).WillReturnResult(sqlmock.NewResult(0, 2))
+				mock.ExpectExec("DELETE FROM favorites WHERE entity_type = 'platform'").WithArgs(2, 3).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+				mock.ExpectExec("INSERT INTO change_events").WithArgs("platform", 1, "updated", 1, 1).WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec("INSERT INTO webhook_deliveries").WithArgs("platform.updated", sqlmock.AnyArg(), sqlmock.AnyArg(), "platform.updated").WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec("INSERT INTO change_events").WithArgs("platform", 2, "deleted", 1, 2).WillReturnResult(sqlmock.NewResult(2, 1))
+				mock.ExpectExec("INSERT INTO webhook_deliveries").WithArgs("platform.deleted", sqlmock.AnyArg(), sqlmock.AnyArg(), "platform.deleted").WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec("INSERT INTO change_events").WithArgs("platform", 3, "deleted", 1, 3).WillReturnResult(sqlmock.NewResult(3, 1))
+				mock.ExpectExec("INSERT INTO webhook_deliveries").WithArgs("platform.deleted", sqlmock.AnyArg(), sqlmock.AnyArg(), "platform.deleted").WillReturnResult(sqlmock.NewResult(1, 1))
+			},
+			http.StatusOK,
+			`{"duplicates":[2, 3]}`,
+			`{"message":"Platforms merged successfully"}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			// Initilize test router, environemnt and mock database
+			r, mockDb, mockSql, env, err := utils.SetupTestEnvironment(test.MockDbCall)
+			// Close the mock database at the end of the test
+			defer mockDb.Close()
+
+			// Check for errors during setup
+			require.NoError(t, err)
+
+			// Merges are logged for open frontends and sent to the subscribed webhooks
+			env.Changes = changes.New(env.DB, webhooks.New(env.DB, http.DefaultClient))
+
+			// Register handler
+			r.POST("/api/v1/admin/platforms/:platformId/merge", func(c *gin.Context) {
+				// Add user to context if it exists
+				if test.User.UserID != 0 {
+					c.Set("user", test.User)
+				}
+
+				// Call handler
+				MergePlatforms(env)(c)
+			})
+
+			// Create httptest request
+			req, _ := http.NewRequest("POST", fmt.Sprintf("/api/v1/admin/platforms/%s/merge", test.IdString), strings.NewReader(test.Body))
+			w := httptest.NewRecorder()
+
+			// Mock request
+			r.ServeHTTP(w, req)
+
+			// Read response data
+			responseData, _ := io.ReadAll(w.Body)
+
+			// Check response status
+			require.Equal(t, test.StatusCode, w.Code)
+
+			// Handle empty responses
+			response := string(responseData)
+			if response == "" {
+				response = "{}"
+			}
+
+			// Check response body
+			require.JSONEq(t, test.Response, response)
+
+			// Check for any remaining expectations
+			// we make sure that all expectations were met
+			if err := mockSql.ExpectationsWereMet(); err != nil {
+				t.Fatalf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,102 @@
+package projects
+
+import (
+	"database/sql"
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/webstradev/rsdb-backend/auth"
+	"github.com/webstradev/rsdb-backend/db"
+	"github.com/webstradev/rsdb-backend/utils"
+)
+
+type changeStatusInput struct {
+	Status string `json:"status" binding:"required"`
+	// Left out to keep the current assignee, 0 to unassign the project
+	AssigneeId *int64 `json:"assigneeId"`
+}
+
+// ChangeStatus moves a project to another status of the workflow and/or assigns it to a user
+func ChangeStatus(env *utils.Environment) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		user, err := auth.GetUserFromContext(c)
+		if err != nil {
+			log.Println(err)
+			c.AbortWithStatus(http.StatusInternalServerError)
+			return
+		}
+
+		idString := c.Param("projectId")
+		id, err := strconv.ParseInt(idString, 10, 64)
+		if err != nil {
+			log.Println(err)
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Invalid ID"})
+			return
+		}
+
+		input := changeStatusInput{}
+		err = c.ShouldBindJSON(&input)
+		if err != nil {
+			log.Println(err)
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		if !env.Workflow.Valid(input.Status) {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Invalid status, use " + strings.Join(env.Workflow.Statuses, ", ")})
+			return
+		}
+
+		project, err := env.DB.GetProject(id)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				c.AbortWithStatus(http.StatusNotFound)
+				return
+			}
+			log.Println(err)
+			c.AbortWithStatus(http.StatusInternalServerError)
+			return
+		}
+
+		assigneeId := project.AssigneeId
+		if input.AssigneeId != nil {
+			assigneeId = sql.NullInt64{Int64: *input.AssigneeId, Valid: *input.AssigneeId != 0}
+		}
+
+		// The transition is checked against the status the project has once it is locked for the change
+		err = env.DB.ChangeProjectStatus(id, input.Status, assigneeId, user.UserID, env.Workflow.CanTransition)
+		if err != nil {
+			transitionErr := &db.StatusTransitionError{}
+			if errors.As(err, &transitionErr) {
+				c.AbortWithStatusJSON(http.StatusConflict, gin.H{
+					"error": transitionErr.Error(),
+					"next":  env.Workflow.Next(transitionErr.From),
+				})
+				return
+			}
+			if errors.Is(err, sql.ErrNoRows) {
+				c.AbortWithStatus(http.StatusNotFound)
+				return
+			}
+			if db.IsForeignKeyError(err) {
+				c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Assignee not found"})
+				return
+			}
+			log.Println(err)
+			c.AbortWithStatus(http.StatusInternalServerError)
+			return
+		}
+
+		// Let open frontends and the subscribed webhooks know the project changed status
+		err = env.Changes.Publish(db.PROJECT_ENTITY, db.CHANGE_UPDATED, id, user.UserID)
+		if err != nil {
+			log.Println(err)
+		}
+
+		c.JSON(http.StatusOK, gin.H{"message": "Project status updated successfully"})
+	}
+}
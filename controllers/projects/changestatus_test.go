@@ -0,0 +1,263 @@
+package projects
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gin-gonic/gin"
+	"github.com/go-sql-driver/mysql"
+	"github.com/stretchr/testify/require"
+	"github.com/webstradev/rsdb-backend/auth"
+	"github.com/webstradev/rsdb-backend/utils"
+)
+
+// Columns of a project with its status
+var projectStatusColumns = []string{"id", "title", "status", "status_changed_at", "assignee_id"}
+
+func TestChangeStatus(t *testing.T) {
+	expectProject := func(mock sqlmock.Sqlmock, status string, assigneeId any) {
+		rows := sqlmock.NewRows(projectStatusColumns).AddRow(1, "The Movie", status, nil, assigneeId)
+		mock.ExpectQuery("SELECT p.(.+) FROM projects p").WithArgs(1).WillReturnRows(rows)
+	}
+
+	expectLocked := func(mock sqlmock.Sqlmock, status string) {
+		mock.ExpectBegin()
+		mock.ExpectQuery("SELECT status FROM projects (.+) FOR UPDATE").WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"status"}).AddRow(status))
+	}
+
+	expectSnapshot := func(mock sqlmock.Sqlmock, status string, assigneeId any) {
+		expectLocked(mock, status)
+		expectProject(mock, status, assigneeId)
+		mock.ExpectQuery("SELECT (.+) FROM tags").WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"project_id", "tag_id", "tag"}))
+		mock.ExpectQuery("SELECT (.+) FROM platforms").WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"project_id", "platform_id", "platform_name"}))
+		mock.ExpectExec("INSERT INTO revisions").WillReturnResult(sqlmock.NewResult(1, 1))
+	}
+
+	tests := []struct {
+		Name       string
+		IdString   string
+		User       auth.TokenData
+		MockDbCall func(sqlmock.Sqlmock)
+		StatusCode int
+		Body       string
+		Response   string
+	}{
+		{
+			"ChangeStatus - User Missing from Context",
+			"1",
+			auth.TokenData{},
+			nil,
+			http.StatusInternalServerError,
+			`{}`,
+			`{}`,
+		},
+		{
+			"ChangeStatus - non int id",
+			"notanint",
+			auth.TokenData{UserID: 1},
+			nil,
+			http.StatusBadRequest,
+			`{}`,
+			`{"error":"Invalid ID"}`,
+		},
+		{
+			"ChangeStatus - missing status",
+			"1",
+			auth.TokenData{UserID: 1},
+			nil,
+			http.StatusBadRequest,
+			`{}`,
+			`{"error":"Key: 'changeStatusInput.Status' Error:Field validation for 'Status' failed on the 'required' tag"}`,
+		},
+		{
+			"ChangeStatus - unknown status",
+			"1",
+			auth.TokenData{UserID: 1},
+			nil,
+			http.StatusBadRequest,
+			`{"status":"archived"}`,
+			`{"error":"Invalid status, use development, production, sales, delivered"}`,
+		},
+		{
+			"ChangeStatus - project not found",
+			"1",
+			auth.TokenData{UserID: 1},
+			func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT p.(.+) FROM projects p").WithArgs(1).WillReturnRows(sqlmock.NewRows(projectStatusColumns))
+			},
+			http.StatusNotFound,
+			`{"status":"production"}`,
+			`{}`,
+		},
+		{
+			"ChangeStatus - transition not allowed",
+			"1",
+			auth.TokenData{UserID: 1},
+			func(mock sqlmock.Sqlmock) {
+				expectProject(mock, "development", nil)
+				expectLocked(mock, "development")
+				mock.ExpectRollback()
+			},
+			http.StatusConflict,
+			`{"status":"delivered"}`,
+			`{"error":"A project can not move from development to delivered","next":["production"]}`,
+		},
+		{
+			"ChangeStatus - moved by someone else in the meantime",
+			"1",
+			auth.TokenData{UserID: 1},
+			func(mock sqlmock.Sqlmock) {
+				expectProject(mock, "development", nil)
+				expectLocked(mock, "delivered")
+				mock.ExpectRollback()
+			},
+			http.StatusConflict,
+			`{"status":"production"}`,
+			`{"error":"A project can not move from delivered to production","next":["sales"]}`,
+		},
+		{
+			"ChangeStatus - deleted in the meantime",
+			"1",
+			auth.TokenData{UserID: 1},
+			func(mock sqlmock.Sqlmock) {
+				expectProject(mock, "development", nil)
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT status FROM projects (.+) FOR UPDATE").WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"status"}))
+				mock.ExpectRollback()
+			},
+			http.StatusNotFound,
+			`{"status":"production"}`,
+			`{}`,
+		},
+		{
+			"ChangeStatus - sql error on ChangeProjectStatus",
+			"1",
+			auth.TokenData{UserID: 1},
+			func(mock sqlmock.Sqlmock) {
+				expectProject(mock, "development", nil)
+				expectSnapshot(mock, "development", nil)
+				mock.ExpectExec("UPDATE projects").WillReturnError(errors.New("test"))
+				mock.ExpectRollback()
+			},
+			http.StatusInternalServerError,
+			`{"status":"production"}`,
+			`{}`,
+		},
+		{
+			"ChangeStatus - unknown assignee",
+			"1",
+			auth.TokenData{UserID: 1},
+			func(mock sqlmock.Sqlmock) {
+				expectProject(mock, "development", nil)
+				expectSnapshot(mock, "development", nil)
+				mock.ExpectExec("UPDATE projects").WillReturnError(&mysql.MySQLError{Number: 1452, Message: "Cannot add or update a child row: a foreign key constraint fails"})
+				mock.ExpectRollback()
+			},
+			http.StatusBadRequest,
+			`{"status":"production","assigneeId":9}`,
+			`{"error":"Assignee not found"}`,
+		},
+		{
+			"ChangeStatus - Valid Request",
+			"1",
+			auth.TokenData{UserID: 1},
+			func(mock sqlmock.Sqlmock) {
+				expectProject(mock, "development", 2)
+				expectSnapshot(mock, "development", 2)
+				mock.ExpectExec("UPDATE projects").WithArgs("production", "production", 3, 1).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("INSERT INTO project_status_changes").WithArgs(1, "development", "production", 3, 1).WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectCommit()
+			},
+			http.StatusOK,
+			`{"status":"production","assigneeId":3}`,
+			`{"message":"Project status updated successfully"}`,
+		},
+		{
+			"ChangeStatus - Valid Request keeping the status and assignee",
+			"1",
+			auth.TokenData{UserID: 1},
+			func(mock sqlmock.Sqlmock) {
+				expectProject(mock, "sales", 2)
+				expectSnapshot(mock, "sales", 2)
+				mock.ExpectExec("UPDATE projects").WithArgs("sales", "sales", 2, 1).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("INSERT INTO project_status_changes").WithArgs(1, "sales", "sales", 2, 1).WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectCommit()
+			},
+			http.StatusOK,
+			`{"status":"sales"}`,
+			`{"message":"Project status updated successfully"}`,
+		},
+		{
+			"ChangeStatus - Valid Request unassigning the project",
+			"1",
+			auth.TokenData{UserID: 1},
+			func(mock sqlmock.Sqlmock) {
+				expectProject(mock, "sales", 2)
+				expectSnapshot(mock, "sales", 2)
+				mock.ExpectExec("UPDATE projects").WithArgs("delivered", "delivered", nil, 1).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("INSERT INTO project_status_changes").WithArgs(1, "sales", "delivered", nil, 1).WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectCommit()
+			},
+			http.StatusOK,
+			`{"status":"delivered","assigneeId":0}`,
+			`{"message":"Project status updated successfully"}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			// Initilize test router, environemnt and mock database
+			r, mockDb, mockSql, env, err := utils.SetupTestEnvironment(test.MockDbCall)
+			// Close the mock database at the end of the test
+			defer mockDb.Close()
+
+			// Check for errors during setup
+			require.NoError(t, err)
+
+			// Register handler
+			r.PUT("/api/v1/projects/:projectId/status", func(c *gin.Context) {
+				// Add user to context if it exists
+				if test.User.UserID != 0 {
+					c.Set("user", test.User)
+				}
+
+				// Call handler
+				ChangeStatus(env)(c)
+			})
+
+			// Create httptest request
+			req, _ := http.NewRequest("PUT", fmt.Sprintf("/api/v1/projects/%s/status", test.IdString), strings.NewReader(test.Body))
+			w := httptest.NewRecorder()
+
+			// Mock request
+			r.ServeHTTP(w, req)
+
+			// Read response data
+			responseData, _ := io.ReadAll(w.Body)
+
+			// Check response status
+			require.Equal(t, test.StatusCode, w.Code)
+
+			// Handle empty responses
+			response := string(responseData)
+			if response == "" {
+				response = "{}"
+			}
+
+			// Check response body
+			require.JSONEq(t, test.Response, response)
+
+			// Check for any remaining expectations
+			// we make sure that all expectations were met
+			if err := mockSql.ExpectationsWereMet(); err != nil {
+				t.Fatalf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
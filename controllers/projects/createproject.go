@@ -1,11 +1,15 @@
 package projects
 
 import (
+	"database/sql"
+	"errors"
 	"log"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
+	"github.com/webstradev/rsdb-backend/auth"
 	"github.com/webstradev/rsdb-backend/db"
+	"github.com/webstradev/rsdb-backend/richtext"
 	"github.com/webstradev/rsdb-backend/utils"
 )
 
@@ -17,17 +21,32 @@ type createProjectInput struct {
 
 func CreateProject(env *utils.Environment) gin.HandlerFunc {
 	return func(c *gin.Context) {
+		user, err := auth.GetUserFromContext(c)
+		if err != nil {
+			log.Println(err)
+			c.AbortWithStatus(http.StatusInternalServerError)
+			return
+		}
+
 		input := createProjectInput{}
-		err := c.ShouldBindJSON(&input)
+		err = c.ShouldBindJSON(&input)
 		if err != nil {
 			log.Println(err)
 			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 			return
 		}
 
+		// New projects start at the beginning of the workflow
+		input.Project.Status = env.Workflow.Initial()
+		input.Project.CreatedBy = sql.NullInt64{Int64: user.UserID, Valid: true}
+
 		// Creat project
 		projectId, err := env.DB.InsertProject(input.Project)
 		if err != nil {
+			if errors.Is(err, richtext.ErrInvalidFormat) {
+				c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+				return
+			}
 			log.Println(err)
 			c.AbortWithStatus(http.StatusInternalServerError)
 			return
@@ -49,6 +68,12 @@ func CreateProject(env *utils.Environment) gin.HandlerFunc {
 			return
 		}
 
+		// Let open frontends and the subscribed webhooks know the project was created
+		err = env.Changes.Publish(db.PROJECT_ENTITY, db.CHANGE_CREATED, projectId, user.UserID)
+		if err != nil {
+			log.Println(err)
+		}
+
 		c.JSON(http.StatusOK, gin.H{"message": "Project created successfully"})
 	}
 }
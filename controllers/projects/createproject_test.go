@@ -9,7 +9,9 @@ import (
 	"testing"
 
 	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/require"
+	"github.com/webstradev/rsdb-backend/auth"
 	"github.com/webstradev/rsdb-backend/utils"
 )
 
@@ -60,7 +62,9 @@ func TestCreateProject(t *testing.T) {
 		{
 			"CreateProject - Valid Request",
 			func(mock sqlmock.Sqlmock) {
-				mock.ExpectExec("INSERT INTO projects").WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec("INSERT INTO projects").
+					WithArgs("test", "test", "test", sqlmock.AnyArg(), "test", "html", "test", "test", "development", 1).
+					WillReturnResult(sqlmock.NewResult(1, 1))
 				mock.ExpectExec("INSERT INTO platforms_projects").WillReturnResult(sqlmock.NewResult(1, 1))
 				mock.ExpectExec("INSERT INTO projects_tags").WillReturnResult(sqlmock.NewResult(1, 1))
 			},
@@ -68,6 +72,24 @@ func TestCreateProject(t *testing.T) {
 			`{"project":{"title":"test","description":"test","link":"test","date":{"Time":"2023-03-05T00:00:00Z","Valid":true},"body":"test"},"linkedPlatforms":[1,2,3],"tags":[1,2]}`,
 			`{"message":"Project created successfully"}`,
 		},
+		{
+			"CreateProject - invalid body format",
+			nil,
+			http.StatusBadRequest,
+			`{"project":{"title":"test","description":"test","link":"test","body":"test","bodyFormat":"rtf"}}`,
+			`{"error":"invalid body format, use markdown or html"}`,
+		},
+		{
+			"CreateProject - Valid Request with Markdown body",
+			func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec("INSERT INTO projects").
+					WithArgs("test", "test", "test", sqlmock.AnyArg(), "A *new* film <script>alert(1)</script>", "markdown", "<p>A <em>new</em> film </p>", "A new film", "development", 1).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+			},
+			http.StatusOK,
+			`{"project":{"title":"test","description":"test","link":"test","body":"A *new* film <script>alert(1)</script>","bodyFormat":"markdown"}}`,
+			`{"message":"Project created successfully"}`,
+		},
 	}
 
 	for _, test := range tests {
@@ -81,7 +103,10 @@ func TestCreateProject(t *testing.T) {
 			require.NoError(t, err)
 
 			// Register handler
-			r.POST("/api/v1/projects", CreateProject(env))
+			r.POST("/api/v1/projects", func(c *gin.Context) {
+				c.Set("user", auth.TokenData{UserID: 1})
+				CreateProject(env)(c)
+			})
 
 			// Create httptest request
 			req, _ := http.NewRequest("POST", "/api/v1/projects", strings.NewReader(test.Body))
@@ -6,6 +6,7 @@ import (
 	"strconv"
 
 	"github.com/gin-gonic/gin"
+	"github.com/webstradev/rsdb-backend/db"
 	"github.com/webstradev/rsdb-backend/utils"
 )
 
@@ -26,6 +27,12 @@ func DeleteProject(env *utils.Environment) gin.HandlerFunc {
 			return
 		}
 
+		// Let open frontends and the subscribed webhooks know the project was deleted
+		err = env.Changes.Publish(db.PROJECT_ENTITY, db.CHANGE_DELETED, id, 0)
+		if err != nil {
+			log.Println(err)
+		}
+
 		c.JSON(http.StatusOK, gin.H{"message": "Project deleted successfully"})
 	}
 }
@@ -1,17 +1,28 @@
 package projects
 
 import (
+	"database/sql"
+	"errors"
 	"log"
 	"net/http"
 	"strconv"
 
 	"github.com/gin-gonic/gin"
+	"github.com/webstradev/rsdb-backend/auth"
 	"github.com/webstradev/rsdb-backend/db"
+	"github.com/webstradev/rsdb-backend/richtext"
 	"github.com/webstradev/rsdb-backend/utils"
 )
 
 func EditProject(env *utils.Environment) gin.HandlerFunc {
 	return func(c *gin.Context) {
+		user, err := auth.GetUserFromContext(c)
+		if err != nil {
+			log.Println(err)
+			c.AbortWithStatus(http.StatusInternalServerError)
+			return
+		}
+
 		// Get platform ID from URL
 		idString := c.Param("projectId")
 		id, err := strconv.ParseInt(idString, 10, 64)
@@ -33,12 +44,31 @@ func EditProject(env *utils.Environment) gin.HandlerFunc {
 		input.ID = id
 
 		// edit article with tags and platforms
-		err = env.DB.EditProject(input)
+		err = env.DB.EditProject(input, user.UserID)
 		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				c.AbortWithStatus(http.StatusNotFound)
+				return
+			}
+			if errors.Is(err, richtext.ErrInvalidFormat) {
+				c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+				return
+			}
 			log.Println(err)
 			c.AbortWithStatus(http.StatusInternalServerError)
 			return
 		}
 
+		// Let the owners know their record was edited
+		err = env.Inbox.RecordEdited(db.PROJECT_ENTITY, id, user.UserID)
+		if err != nil {
+			log.Println(err)
+		}
+
+		// Let open frontends and the subscribed webhooks know the project was edited
+		err = env.Changes.Publish(db.PROJECT_ENTITY, db.CHANGE_UPDATED, id, user.UserID)
+		if err != nil {
+			log.Println(err)
+		}
 	}
 }
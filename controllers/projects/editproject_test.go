@@ -10,7 +10,9 @@ import (
 	"testing"
 
 	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/require"
+	"github.com/webstradev/rsdb-backend/auth"
 	"github.com/webstradev/rsdb-backend/utils"
 )
 
@@ -18,14 +20,25 @@ func TestEditProject(t *testing.T) {
 	tests := []struct {
 		Name       string
 		IdString   string
+		User       auth.TokenData
 		MockDbCall func(sqlmock.Sqlmock)
 		StatusCode int
 		Body       string
 		Response   string
 	}{
+		{
+			"EditProject - User Missing from Context",
+			"1",
+			auth.TokenData{},
+			nil,
+			http.StatusInternalServerError,
+			`{}`,
+			`{}`,
+		},
 		{
 			"EditProject - non int id",
 			"notanint",
+			auth.TokenData{UserID: 1},
 			nil,
 			http.StatusBadRequest,
 			`{}`,
@@ -34,16 +47,51 @@ func TestEditProject(t *testing.T) {
 		{
 			"EditProject - Bad json body",
 			"1",
+			auth.TokenData{UserID: 1},
 			nil,
 			http.StatusBadRequest,
 			`{badbody}`,
 			`{"error": "invalid character 'b' looking for beginning of object key string"}`,
 		},
+		{
+			"EditProject - project not found",
+			"1",
+			auth.TokenData{UserID: 1},
+			func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT p.(.+) FROM projects").WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"id"}))
+				mock.ExpectRollback()
+			},
+			http.StatusNotFound,
+			`{"title":"test","description":"test","link":"test","date":{"Time":"2023-03-05T00:00:00Z","Valid":true},"body":"test"}`,
+			`{}`,
+		},
+		{
+			"EditProject - sql error on revision insert",
+			"1",
+			auth.TokenData{UserID: 1},
+			func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT p.(.+) FROM projects").WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"id", "title"}).AddRow(1, "old"))
+				mock.ExpectQuery("SELECT (.+) FROM tags").WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"project_id", "tag_id", "tag"}))
+				mock.ExpectQuery("SELECT (.+) FROM platforms").WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"project_id", "platform_id", "platform_name"}))
+				mock.ExpectExec("INSERT INTO revisions").WillReturnError(errors.New("test"))
+				mock.ExpectRollback()
+			},
+			http.StatusInternalServerError,
+			`{"title":"test","description":"test","link":"test","date":{"Time":"2023-03-05T00:00:00Z","Valid":true},"body":"test"}`,
+			`{}`,
+		},
 		{
 			"EditProject - sql error on EditProject transaction",
 			"1",
+			auth.TokenData{UserID: 1},
 			func(mock sqlmock.Sqlmock) {
 				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT p.(.+) FROM projects").WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"id", "title"}).AddRow(1, "old"))
+				mock.ExpectQuery("SELECT (.+) FROM tags").WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"project_id", "tag_id", "tag"}))
+				mock.ExpectQuery("SELECT (.+) FROM platforms").WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"project_id", "platform_id", "platform_name"}))
+				mock.ExpectExec("INSERT INTO revisions").WithArgs("project", 1, 1, sqlmock.AnyArg(), "project", 1).WillReturnResult(sqlmock.NewResult(1, 1))
 				mock.ExpectExec("UPDATE projects SET").WillReturnError(errors.New("test"))
 				mock.ExpectRollback()
 			},
@@ -54,8 +102,13 @@ func TestEditProject(t *testing.T) {
 		{
 			"EditProject - Valid Request",
 			"1",
+			auth.TokenData{UserID: 1},
 			func(mock sqlmock.Sqlmock) {
 				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT p.(.+) FROM projects").WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"id", "title"}).AddRow(1, "old"))
+				mock.ExpectQuery("SELECT (.+) FROM tags").WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"project_id", "tag_id", "tag"}).AddRow(1, 2, "old"))
+				mock.ExpectQuery("SELECT (.+) FROM platforms").WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"project_id", "platform_id", "platform_name"}))
+				mock.ExpectExec("INSERT INTO revisions").WithArgs("project", 1, 1, sqlmock.AnyArg(), "project", 1).WillReturnResult(sqlmock.NewResult(1, 1))
 				mock.ExpectExec("UPDATE projects SET").WillReturnResult(sqlmock.NewResult(1, 1))
 				mock.ExpectExec("DELETE FROM projects_tags").WillReturnResult(sqlmock.NewResult(1, 1))
 				mock.ExpectExec("INSERT INTO projects_tags").WillReturnResult(sqlmock.NewResult(1, 1))
@@ -80,7 +133,15 @@ func TestEditProject(t *testing.T) {
 			require.NoError(t, err)
 
 			// Register handler
-			r.PUT("/api/v1/projects/:projectId", EditProject(env))
+			r.PUT("/api/v1/projects/:projectId", func(c *gin.Context) {
+				// Add user to context if it exists
+				if test.User.UserID != 0 {
+					c.Set("user", test.User)
+				}
+
+				// Call handler
+				EditProject(env)(c)
+			})
 
 			// Create httptest request
 			req, _ := http.NewRequest("PUT", fmt.Sprintf("/api/v1/projects/%s", test.IdString), strings.NewReader(test.Body))
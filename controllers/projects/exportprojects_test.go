@@ -0,0 +1,130 @@
+package projects
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+	"github.com/webstradev/rsdb-backend/auth"
+	"github.com/webstradev/rsdb-backend/utils"
+)
+
+func TestExportProjects(t *testing.T) {
+	tests := []struct {
+		Name        string
+		User        auth.TokenData
+		Query       string
+		MockDbCall  func(sqlmock.Sqlmock)
+		StatusCode  int
+		ContentType string
+		Response    string
+	}{
+		{
+			"ExportProjects - User Missing from Context",
+			auth.TokenData{},
+			"",
+			nil,
+			http.StatusInternalServerError,
+			"",
+			``,
+		},
+		{
+			"ExportProjects - Invalid format",
+			auth.TokenData{UserID: 1, Role: auth.UserRole},
+			"?format=pdf",
+			nil,
+			http.StatusBadRequest,
+			"application/json; charset=utf-8",
+			`{"error":"Invalid format"}`,
+		},
+		{
+			"ExportProjects - sql error on ExportProjects",
+			auth.TokenData{UserID: 1, Role: auth.UserRole},
+			"",
+			func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT (.+) FROM projects").WithArgs(false, "private").WillReturnError(errors.New("test"))
+			},
+			http.StatusInternalServerError,
+			"",
+			``,
+		},
+		{
+			"ExportProjects - CSV without private platforms",
+			auth.TokenData{UserID: 1, Role: auth.UserRole},
+			"?format=csv",
+			func(mock sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows([]string{"id", "title", "description", "link", "date", "project_tags", "project_platforms"}).
+					AddRow(1, "test", "test", "https://example.com", "2023-03-05", "drama,film", "Acme TV").
+					AddRow(2, "other", "", "", "", "", "")
+				mock.ExpectQuery("SELECT (.+) FROM projects").WithArgs(false, "private").WillReturnRows(rows)
+			},
+			http.StatusOK,
+			"text/csv; charset=utf-8",
+			"id,title,description,link,date,tags,platforms\n1,test,test,https://example.com,2023-03-05,\"drama,film\",Acme TV\n2,other,,,,,\n",
+		},
+		{
+			"ExportProjects - NDJSON for admins",
+			auth.TokenData{UserID: 1, Role: auth.AdminRole},
+			"?format=ndjson",
+			func(mock sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows([]string{"id", "title", "description", "link", "date", "project_tags", "project_platforms"}).
+					AddRow(1, "test", "test", "", nil, "", "Private TV")
+				mock.ExpectQuery("SELECT (.+) FROM projects").WithArgs(true, "private").WillReturnRows(rows)
+			},
+			http.StatusOK,
+			"application/x-ndjson",
+			`{"id":"1","title":"test","description":"test","link":"","date":"","tags":"","platforms":"Private TV"}` + "\n",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			// Initilize test router, environemnt and mock database
+			r, mockDb, mockSql, env, err := utils.SetupTestEnvironment(test.MockDbCall)
+			// Close the mock database at the end of the test
+			defer mockDb.Close()
+
+			// Check for errors during setup
+			require.NoError(t, err)
+
+			// Register handler
+			r.GET("/api/v1/projects/export", func(c *gin.Context) {
+				// Add user to context if it exists
+				if test.User.UserID != 0 {
+					c.Set("user", test.User)
+				}
+
+				// Call handler
+				ExportProjects(env)(c)
+			})
+
+			// Create httptest request
+			req, _ := http.NewRequest("GET", "/api/v1/projects/export"+test.Query, nil)
+			w := httptest.NewRecorder()
+
+			// Mock request
+			r.ServeHTTP(w, req)
+
+			// Read response data
+			responseData, _ := io.ReadAll(w.Body)
+
+			// Check response status and type
+			require.Equal(t, test.StatusCode, w.Code)
+			require.Equal(t, test.ContentType, w.Header().Get("Content-Type"))
+
+			// Check response body
+			require.Equal(t, test.Response, string(responseData))
+
+			// Check for any remaining expectations
+			// we make sure that all expectations were met
+			if err := mockSql.ExpectationsWereMet(); err != nil {
+				t.Fatalf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,50 @@
+package projects
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/webstradev/rsdb-backend/db"
+	"github.com/webstradev/rsdb-backend/utils"
+)
+
+type pipelineColumn struct {
+	Status   string               `json:"status"`
+	Count    int                  `json:"count"`
+	Projects []db.PipelineProject `json:"projects"`
+}
+
+// GetPipeline groups all projects by status in the order of the workflow, for a kanban board.
+// Projects with a status that is no longer part of the workflow are listed in extra columns at the end.
+func GetPipeline(env *utils.Environment) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		projects, err := env.DB.GetProjectPipeline()
+		if err != nil {
+			log.Println(err)
+			c.AbortWithStatus(http.StatusInternalServerError)
+			return
+		}
+
+		columns := []pipelineColumn{}
+		index := map[string]int{}
+		for _, status := range env.Workflow.Statuses {
+			index[status] = len(columns)
+			columns = append(columns, pipelineColumn{Status: status, Projects: []db.PipelineProject{}})
+		}
+
+		for _, project := range projects {
+			i, ok := index[project.Status]
+			if !ok {
+				i = len(columns)
+				index[project.Status] = i
+				columns = append(columns, pipelineColumn{Status: project.Status, Projects: []db.PipelineProject{}})
+			}
+
+			columns[i].Count++
+			columns[i].Projects = append(columns[i].Projects, project)
+		}
+
+		c.JSON(http.StatusOK, gin.H{"total": len(projects), "columns": columns})
+	}
+}
@@ -0,0 +1,118 @@
+package projects
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/require"
+	"github.com/webstradev/rsdb-backend/utils"
+)
+
+func TestGetPipeline(t *testing.T) {
+	changed := time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC)
+
+	pipelineColumns := []string{"id", "title", "status", "status_changed_at", "assignee_id", "assignee_email"}
+
+	tests := []struct {
+		Name       string
+		MockDbCall func(sqlmock.Sqlmock)
+		StatusCode int
+		Response   string
+	}{
+		{
+			"GetPipeline - sql error",
+			func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT (.+) FROM projects p").WillReturnError(errors.New("test"))
+			},
+			http.StatusInternalServerError,
+			`{}`,
+		},
+		{
+			"GetPipeline - no projects",
+			func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT (.+) FROM projects p").WillReturnRows(sqlmock.NewRows(pipelineColumns))
+			},
+			http.StatusOK,
+			`{"total":0,"columns":[
+				{"status":"development","count":0,"projects":[]},
+				{"status":"production","count":0,"projects":[]},
+				{"status":"sales","count":0,"projects":[]},
+				{"status":"delivered","count":0,"projects":[]}
+			]}`,
+		},
+		{
+			"GetPipeline - Valid Request",
+			func(mock sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows(pipelineColumns).
+					AddRow(1, "The Movie", "sales", changed, 2, "user@example.com").
+					AddRow(2, "The Sequel", "development", changed, nil, nil).
+					AddRow(3, "The Pilot", "archived", nil, nil, nil).
+					AddRow(4, "The Series", "sales", changed.Add(time.Hour), nil, nil)
+				mock.ExpectQuery("SELECT (.+) FROM projects p").WillReturnRows(rows)
+			},
+			http.StatusOK,
+			`{"total":4,"columns":[
+				{"status":"development","count":1,"projects":[
+					{"id":2,"title":"The Sequel","status":"development","statusChangedAt":{"Time":"2024-03-01T09:00:00Z","Valid":true},"assigneeId":{"Int64":0,"Valid":false},"assigneeEmail":{"String":"","Valid":false}}
+				]},
+				{"status":"production","count":0,"projects":[]},
+				{"status":"sales","count":2,"projects":[
+					{"id":1,"title":"The Movie","status":"sales","statusChangedAt":{"Time":"2024-03-01T09:00:00Z","Valid":true},"assigneeId":{"Int64":2,"Valid":true},"assigneeEmail":{"String":"user@example.com","Valid":true}},
+					{"id":4,"title":"The Series","status":"sales","statusChangedAt":{"Time":"2024-03-01T10:00:00Z","Valid":true},"assigneeId":{"Int64":0,"Valid":false},"assigneeEmail":{"String":"","Valid":false}}
+				]},
+				{"status":"delivered","count":0,"projects":[]},
+				{"status":"archived","count":1,"projects":[
+					{"id":3,"title":"The Pilot","status":"archived","statusChangedAt":{"Time":"0001-01-01T00:00:00Z","Valid":false},"assigneeId":{"Int64":0,"Valid":false},"assigneeEmail":{"String":"","Valid":false}}
+				]}
+			]}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			// Initilize test router, environemnt and mock database
+			r, mockDb, mockSql, env, err := utils.SetupTestEnvironment(test.MockDbCall)
+			// Close the mock database at the end of the test
+			defer mockDb.Close()
+
+			// Check for errors during setup
+			require.NoError(t, err)
+
+			// Register handler
+			r.GET("/api/v1/projects/pipeline", GetPipeline(env))
+
+			// Create httptest request
+			req, _ := http.NewRequest("GET", "/api/v1/projects/pipeline", nil)
+			w := httptest.NewRecorder()
+
+			// Mock request
+			r.ServeHTTP(w, req)
+
+			// Read response data
+			responseData, _ := io.ReadAll(w.Body)
+
+			// Check response status
+			require.Equal(t, test.StatusCode, w.Code)
+
+			// Handle empty responses
+			response := string(responseData)
+			if response == "" {
+				response = "{}"
+			}
+
+			// Check response body
+			require.JSONEq(t, test.Response, response)
+
+			// Check for any remaining expectations
+			// we make sure that all expectations were met
+			if err := mockSql.ExpectationsWereMet(); err != nil {
+				t.Fatalf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
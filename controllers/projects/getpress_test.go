@@ -0,0 +1,134 @@
+package projects
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/require"
+	"github.com/webstradev/rsdb-backend/utils"
+)
+
+func TestGetPress(t *testing.T) {
+	date := time.Date(2023, 5, 1, 0, 0, 0, 0, time.UTC)
+
+	pressColumns := []string{"project_id", "article_id", "title", "description", "link", "date"}
+
+	tests := []struct {
+		Name       string
+		IdString   string
+		MockDbCall func(sqlmock.Sqlmock)
+		StatusCode int
+		Response   string
+	}{
+		{
+			"GetPress - non int id",
+			"notanint",
+			nil,
+			http.StatusBadRequest,
+			`{"error":"Invalid ID"}`,
+		},
+		{
+			"GetPress - sql error on EntityExists",
+			"1",
+			func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT COUNT(.+) FROM projects").WithArgs(1).WillReturnError(errors.New("test"))
+			},
+			http.StatusInternalServerError,
+			`{}`,
+		},
+		{
+			"GetPress - project not found",
+			"1",
+			func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT COUNT(.+) FROM projects").WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+			},
+			http.StatusNotFound,
+			`{}`,
+		},
+		{
+			"GetPress - sql error on GetPressForProject",
+			"1",
+			func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT COUNT(.+) FROM projects").WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+				mock.ExpectQuery("SELECT ap.(.+)").WithArgs(1).WillReturnError(errors.New("test"))
+			},
+			http.StatusInternalServerError,
+			`{}`,
+		},
+		{
+			"GetPress - Valid Request without press",
+			"1",
+			func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT COUNT(.+) FROM projects").WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+				mock.ExpectQuery("SELECT ap.(.+)").WithArgs(1).WillReturnRows(sqlmock.NewRows(pressColumns))
+			},
+			http.StatusOK,
+			`[]`,
+		},
+		{
+			"GetPress - Valid Request",
+			"1",
+			func(mock sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows(pressColumns).
+					AddRow(1, 2, "review", "test", "https://example.com/review", date).
+					AddRow(1, 1, "interview", "test", "https://example.com/interview", nil)
+				mock.ExpectQuery("SELECT COUNT(.+) FROM projects").WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+				mock.ExpectQuery("SELECT ap.(.+)").WithArgs(1).WillReturnRows(rows)
+			},
+			http.StatusOK,
+			`[
+				{"id":2,"title":"review","description":"test","link":"https://example.com/review","date":{"Time":"2023-05-01T00:00:00Z","Valid":true}},
+				{"id":1,"title":"interview","description":"test","link":"https://example.com/interview","date":{"Time":"0001-01-01T00:00:00Z","Valid":false}}
+			]`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			// Initilize test router, environemnt and mock database
+			r, mockDb, mockSql, env, err := utils.SetupTestEnvironment(test.MockDbCall)
+			// Close the mock database at the end of the test
+			defer mockDb.Close()
+
+			// Check for errors during setup
+			require.NoError(t, err)
+
+			// Register handler
+			r.GET("/api/v1/projects/:projectId/press", GetPress(env))
+
+			// Create httptest request
+			req, _ := http.NewRequest("GET", fmt.Sprintf("/api/v1/projects/%s/press", test.IdString), nil)
+			w := httptest.NewRecorder()
+
+			// Mock request
+			r.ServeHTTP(w, req)
+
+			// Read response data
+			responseData, _ := io.ReadAll(w.Body)
+
+			// Check response status
+			require.Equal(t, test.StatusCode, w.Code)
+
+			// Handle empty responses
+			response := string(responseData)
+			if response == "" {
+				response = "{}"
+			}
+
+			// Check response body
+			require.JSONEq(t, test.Response, response)
+
+			// Check for any remaining expectations
+			// we make sure that all expectations were met
+			if err := mockSql.ExpectationsWereMet(); err != nil {
+				t.Fatalf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
@@ -47,6 +47,13 @@ func GetProject(env *utils.Environment) gin.HandlerFunc {
 			return
 		}
 
+		err = project.PopulatePress(env.DB)
+		if err != nil {
+			log.Println(err)
+			c.AbortWithStatus(http.StatusInternalServerError)
+			return
+		}
+
 		c.JSON(http.StatusOK, project)
 	}
 }
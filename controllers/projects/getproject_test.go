@@ -93,8 +93,8 @@ func TestGetProject(t *testing.T) {
 			"GetProject - Valid Request",
 			"1",
 			func(mock sqlmock.Sqlmock) {
-				rows := sqlmock.NewRows([]string{"id", "title", "description", "link", "date", "body"}).
-					AddRow(1, "test", "test", "test", sqlTimestamp, "test")
+				rows := sqlmock.NewRows([]string{"id", "title", "description", "link", "date", "body", "status", "status_changed_at", "assignee_id"}).
+					AddRow(1, "test", "test", "test", sqlTimestamp, "test", "production", sqlTimestamp, 2)
 				mock.ExpectQuery("SELECT p.(.+)").WithArgs(1).WillReturnRows(rows)
 
 				rows = sqlmock.NewRows([]string{"project_id", "tag_id", "tag"}).
@@ -104,9 +104,13 @@ func TestGetProject(t *testing.T) {
 				rows = sqlmock.NewRows([]string{"project_id", "platform_id", "platform_name"}).
 					AddRow(1, 1, "test")
 				mock.ExpectQuery("SELECT pp.(.+)").WithArgs(1).WillReturnRows(rows)
+
+				rows = sqlmock.NewRows([]string{"project_id", "article_id", "title", "description", "link", "date"}).
+					AddRow(1, 3, "review", "test", "test", sqlTimestamp)
+				mock.ExpectQuery("SELECT ap.(.+)").WithArgs(1).WillReturnRows(rows)
 			},
 			http.StatusOK,
-			`{"id":1,"createdAt":"0001-01-01T00:00:00Z","modifiedAt":"0001-01-01T00:00:00Z","deletedAt":{"Time":"0001-01-01T00:00:00Z","Valid":false},"title":"test","description":"test","link":"test","date":{"Time":"2023-01-01T00:00:00Z","Valid":true},"body":"test","tags":[{"id":1,"tag":"test"}],"platforms":[{"id":1,"platform":"test"}]}`,
+			`{"id":1,"createdAt":"0001-01-01T00:00:00Z","modifiedAt":"0001-01-01T00:00:00Z","deletedAt":{"Time":"0001-01-01T00:00:00Z","Valid":false},"title":"test","description":"test","link":"test","date":{"Time":"2023-01-01T00:00:00Z","Valid":true},"body":"test","createdBy":{"Int64":0,"Valid":false},"bodyFormat":"","bodyHtml":"","bodyText":"","status":"production","statusChangedAt":{"Time":"2023-01-01T00:00:00Z","Valid":true},"assigneeId":{"Int64":2,"Valid":true},"tags":[{"id":1,"tag":"test"}],"platforms":[{"id":1,"platform":"test"}],"press":[{"id":3,"title":"review","description":"test","link":"test","date":{"Time":"2023-01-01T00:00:00Z","Valid":true}}]}`,
 		},
 	}
 
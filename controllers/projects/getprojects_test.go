@@ -51,9 +51,9 @@ func TestGetProjects(t *testing.T) {
 			0,
 			2,
 			func(mock sqlmock.Sqlmock) {
-				rows := sqlmock.NewRows([]string{"id", "title", "description", "link", "date", "body"}).
-					AddRow(1, "test", "test", "test", sqlTimestamp, "test").
-					AddRow(2, "test", "test", "test", sqlTimestamp, "test")
+				rows := sqlmock.NewRows([]string{"id", "title", "description", "link", "date", "body", "comments_count"}).
+					AddRow(1, "test", "test", "test", sqlTimestamp, "test", 3).
+					AddRow(2, "test", "test", "test", sqlTimestamp, "test", 3)
 				mock.ExpectQuery("SELECT p.(.+) FROM projects").WithArgs(2, 0).WillReturnRows(rows)
 
 				mock.ExpectQuery("SELECT COUNT(.+) AS count FROM projects").WillReturnError(errors.New("test"))
@@ -66,34 +66,34 @@ func TestGetProjects(t *testing.T) {
 			0,
 			2,
 			func(mock sqlmock.Sqlmock) {
-				rows := sqlmock.NewRows([]string{"id", "title", "description", "link", "date", "body"}).
-					AddRow(1, "test", "test", "test", sqlTimestamp, "test").
-					AddRow(2, "test", "test", "test", sqlTimestamp, "test")
+				rows := sqlmock.NewRows([]string{"id", "title", "description", "link", "date", "body", "comments_count"}).
+					AddRow(1, "test", "test", "test", sqlTimestamp, "test", 3).
+					AddRow(2, "test", "test", "test", sqlTimestamp, "test", 3)
 				mock.ExpectQuery("SELECT p.(.+) FROM projects").WithArgs(2, 0).WillReturnRows(rows)
 
 				rows = sqlmock.NewRows([]string{"count"}).AddRow(10)
 				mock.ExpectQuery("SELECT COUNT(.+) AS count FROM projects").WillReturnRows(rows)
 			},
 			http.StatusOK,
-			`{"total":10,"projects":[{"id":1,"createdAt":"0001-01-01T00:00:00Z","modifiedAt":"0001-01-01T00:00:00Z","deletedAt":{"Time":"0001-01-01T00:00:00Z","Valid":false},"title":"test","description":"test","link":"test","date":{"Time":"2023-01-01T00:00:00Z","Valid":true},"body":"test","platforms":null,"tags":null,"tagString":""},{"id":2,"createdAt":"0001-01-01T00:00:00Z","modifiedAt":"0001-01-01T00:00:00Z","deletedAt":{"Time":"0001-01-01T00:00:00Z","Valid":false},"title":"test","description":"test","link":"test","date":{"Time":"2023-01-01T00:00:00Z","Valid":true},"body":"test","platforms":null,"tags":null,"tagString":""}]}`,
+			`{"total":10,"projects":[{"id":1,"createdAt":"0001-01-01T00:00:00Z","modifiedAt":"0001-01-01T00:00:00Z","deletedAt":{"Time":"0001-01-01T00:00:00Z","Valid":false},"title":"test","description":"test","link":"test","date":{"Time":"2023-01-01T00:00:00Z","Valid":true},"body":"test","createdBy":{"Int64":0,"Valid":false},"bodyFormat":"","bodyHtml":"","bodyText":"","status":"","statusChangedAt":{"Time":"0001-01-01T00:00:00Z","Valid":false},"assigneeId":{"Int64":0,"Valid":false},"platforms":null,"press":null,"tags":null,"tagString":"","commentsCount":3},{"id":2,"createdAt":"0001-01-01T00:00:00Z","modifiedAt":"0001-01-01T00:00:00Z","deletedAt":{"Time":"0001-01-01T00:00:00Z","Valid":false},"title":"test","description":"test","link":"test","date":{"Time":"2023-01-01T00:00:00Z","Valid":true},"body":"test","createdBy":{"Int64":0,"Valid":false},"bodyFormat":"","bodyHtml":"","bodyText":"","status":"","statusChangedAt":{"Time":"0001-01-01T00:00:00Z","Valid":false},"assigneeId":{"Int64":0,"Valid":false},"platforms":null,"press":null,"tags":null,"tagString":"","commentsCount":3}]}`,
 		},
 		{
 			"GetProjects - 4 projects from page 2",
 			1,
 			4,
 			func(mock sqlmock.Sqlmock) {
-				rows := sqlmock.NewRows([]string{"id", "title", "description", "link", "date", "body"}).
-					AddRow(3, "test", "test", "test", sqlTimestamp, "test").
-					AddRow(4, "test", "test", "test", sqlTimestamp, "test").
-					AddRow(5, "test", "test", "test", sqlTimestamp, "test").
-					AddRow(6, "test", "test", "test", sqlTimestamp, "test")
+				rows := sqlmock.NewRows([]string{"id", "title", "description", "link", "date", "body", "comments_count"}).
+					AddRow(3, "test", "test", "test", sqlTimestamp, "test", 3).
+					AddRow(4, "test", "test", "test", sqlTimestamp, "test", 3).
+					AddRow(5, "test", "test", "test", sqlTimestamp, "test", 3).
+					AddRow(6, "test", "test", "test", sqlTimestamp, "test", 3)
 				mock.ExpectQuery("SELECT p.(.+) FROM projects").WithArgs(4, 4).WillReturnRows(rows)
 
 				rows = sqlmock.NewRows([]string{"count"}).AddRow(10)
 				mock.ExpectQuery("SELECT COUNT(.+) AS count FROM projects").WillReturnRows(rows)
 			},
 			http.StatusOK,
-			`{"total":10,"projects":[{"id":3,"createdAt":"0001-01-01T00:00:00Z","modifiedAt":"0001-01-01T00:00:00Z","deletedAt":{"Time":"0001-01-01T00:00:00Z","Valid":false},"title":"test","description":"test","link":"test","date":{"Time":"2023-01-01T00:00:00Z","Valid":true},"body":"test","platforms":null,"tags":null,"tagString":""},{"id":4,"createdAt":"0001-01-01T00:00:00Z","modifiedAt":"0001-01-01T00:00:00Z","deletedAt":{"Time":"0001-01-01T00:00:00Z","Valid":false},"title":"test","description":"test","link":"test","date":{"Time":"2023-01-01T00:00:00Z","Valid":true},"body":"test","platforms":null,"tags":null,"tagString":""},{"id":5,"createdAt":"0001-01-01T00:00:00Z","modifiedAt":"0001-01-01T00:00:00Z","deletedAt":{"Time":"0001-01-01T00:00:00Z","Valid":false},"title":"test","description":"test","link":"test","date":{"Time":"2023-01-01T00:00:00Z","Valid":true},"body":"test","platforms":null,"tags":null,"tagString":""},{"id":6,"createdAt":"0001-01-01T00:00:00Z","modifiedAt":"0001-01-01T00:00:00Z","deletedAt":{"Time":"0001-01-01T00:00:00Z","Valid":false},"title":"test","description":"test","link":"test","date":{"Time":"2023-01-01T00:00:00Z","Valid":true},"body":"test","platforms":null,"tags":null,"tagString":""}]}`,
+			`{"total":10,"projects":[{"id":3,"createdAt":"0001-01-01T00:00:00Z","modifiedAt":"0001-01-01T00:00:00Z","deletedAt":{"Time":"0001-01-01T00:00:00Z","Valid":false},"title":"test","description":"test","link":"test","date":{"Time":"2023-01-01T00:00:00Z","Valid":true},"body":"test","createdBy":{"Int64":0,"Valid":false},"bodyFormat":"","bodyHtml":"","bodyText":"","status":"","statusChangedAt":{"Time":"0001-01-01T00:00:00Z","Valid":false},"assigneeId":{"Int64":0,"Valid":false},"platforms":null,"press":null,"tags":null,"tagString":"","commentsCount":3},{"id":4,"createdAt":"0001-01-01T00:00:00Z","modifiedAt":"0001-01-01T00:00:00Z","deletedAt":{"Time":"0001-01-01T00:00:00Z","Valid":false},"title":"test","description":"test","link":"test","date":{"Time":"2023-01-01T00:00:00Z","Valid":true},"body":"test","createdBy":{"Int64":0,"Valid":false},"bodyFormat":"","bodyHtml":"","bodyText":"","status":"","statusChangedAt":{"Time":"0001-01-01T00:00:00Z","Valid":false},"assigneeId":{"Int64":0,"Valid":false},"platforms":null,"press":null,"tags":null,"tagString":"","commentsCount":3},{"id":5,"createdAt":"0001-01-01T00:00:00Z","modifiedAt":"0001-01-01T00:00:00Z","deletedAt":{"Time":"0001-01-01T00:00:00Z","Valid":false},"title":"test","description":"test","link":"test","date":{"Time":"2023-01-01T00:00:00Z","Valid":true},"body":"test","createdBy":{"Int64":0,"Valid":false},"bodyFormat":"","bodyHtml":"","bodyText":"","status":"","statusChangedAt":{"Time":"0001-01-01T00:00:00Z","Valid":false},"assigneeId":{"Int64":0,"Valid":false},"platforms":null,"press":null,"tags":null,"tagString":"","commentsCount":3},{"id":6,"createdAt":"0001-01-01T00:00:00Z","modifiedAt":"0001-01-01T00:00:00Z","deletedAt":{"Time":"0001-01-01T00:00:00Z","Valid":false},"title":"test","description":"test","link":"test","date":{"Time":"2023-01-01T00:00:00Z","Valid":true},"body":"test","createdBy":{"Int64":0,"Valid":false},"bodyFormat":"","bodyHtml":"","bodyText":"","status":"","statusChangedAt":{"Time":"0001-01-01T00:00:00Z","Valid":false},"assigneeId":{"Int64":0,"Valid":false},"platforms":null,"press":null,"tags":null,"tagString":"","commentsCount":3}]}`,
 		},
 	}
 
@@ -0,0 +1,53 @@
+package projects
+
+import (
+	"database/sql"
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/webstradev/rsdb-backend/utils"
+)
+
+// GetStatus returns the status and assignee of a project, the statuses it can move to, when it entered each status
+// and its status history
+func GetStatus(env *utils.Environment) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		idString := c.Param("projectId")
+		id, err := strconv.ParseInt(idString, 10, 64)
+		if err != nil {
+			log.Println(err)
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Invalid ID"})
+			return
+		}
+
+		project, err := env.DB.GetProject(id)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				c.AbortWithStatus(http.StatusNotFound)
+				return
+			}
+			log.Println(err)
+			c.AbortWithStatus(http.StatusInternalServerError)
+			return
+		}
+
+		changes, err := env.DB.GetProjectStatusChanges(id)
+		if err != nil {
+			log.Println(err)
+			c.AbortWithStatus(http.StatusInternalServerError)
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"status":          project.Status,
+			"statusChangedAt": project.StatusChangedAt,
+			"assigneeId":      project.AssigneeId,
+			"next":            env.Workflow.Next(project.Status),
+			"timestamps":      project.StatusTimestamps(changes),
+			"history":         changes,
+		})
+	}
+}
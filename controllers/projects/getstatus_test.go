@@ -0,0 +1,137 @@
+package projects
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/require"
+	"github.com/webstradev/rsdb-backend/utils"
+)
+
+func TestGetStatus(t *testing.T) {
+	created := time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC)
+	production := time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC)
+	sales := time.Date(2024, 6, 1, 9, 0, 0, 0, time.UTC)
+
+	changeColumns := []string{"id", "created_at", "project_id", "from_status", "to_status", "assignee_id", "changed_by"}
+
+	tests := []struct {
+		Name       string
+		IdString   string
+		MockDbCall func(sqlmock.Sqlmock)
+		StatusCode int
+		Response   string
+	}{
+		{
+			"GetStatus - non int id",
+			"notanint",
+			nil,
+			http.StatusBadRequest,
+			`{"error":"Invalid ID"}`,
+		},
+		{
+			"GetStatus - project not found",
+			"1",
+			func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT p.(.+) FROM projects p").WithArgs(1).WillReturnRows(sqlmock.NewRows(projectStatusColumns))
+			},
+			http.StatusNotFound,
+			`{}`,
+		},
+		{
+			"GetStatus - sql error on GetProjectStatusChanges",
+			"1",
+			func(mock sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows(projectStatusColumns).AddRow(1, "The Movie", "development", created, nil)
+				mock.ExpectQuery("SELECT p.(.+) FROM projects p").WithArgs(1).WillReturnRows(rows)
+				mock.ExpectQuery("SELECT (.+) FROM project_status_changes").WithArgs(1).WillReturnError(errors.New("test"))
+			},
+			http.StatusInternalServerError,
+			`{}`,
+		},
+		{
+			"GetStatus - Valid Request without changes",
+			"1",
+			func(mock sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows(append(projectStatusColumns, "created_at")).AddRow(1, "The Movie", "development", created, nil, created)
+				mock.ExpectQuery("SELECT p.(.+) FROM projects p").WithArgs(1).WillReturnRows(rows)
+				mock.ExpectQuery("SELECT (.+) FROM project_status_changes").WithArgs(1).WillReturnRows(sqlmock.NewRows(changeColumns))
+			},
+			http.StatusOK,
+			`{"status":"development","statusChangedAt":{"Time":"2024-01-01T09:00:00Z","Valid":true},"assigneeId":{"Int64":0,"Valid":false},
+				"next":["production"],"timestamps":{"development":"2024-01-01T09:00:00Z"},"history":[]}`,
+		},
+		{
+			"GetStatus - Valid Request",
+			"1",
+			func(mock sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows(append(projectStatusColumns, "created_at")).AddRow(1, "The Movie", "sales", sales, 2, created)
+				mock.ExpectQuery("SELECT p.(.+) FROM projects p").WithArgs(1).WillReturnRows(rows)
+
+				changes := sqlmock.NewRows(changeColumns).
+					AddRow(1, production, 1, "development", "production", nil, 1).
+					AddRow(2, sales, 1, "production", "sales", 2, 1).
+					AddRow(3, sales.Add(time.Hour), 1, "sales", "sales", 2, 1)
+				mock.ExpectQuery("SELECT (.+) FROM project_status_changes").WithArgs(1).WillReturnRows(changes)
+			},
+			http.StatusOK,
+			`{"status":"sales","statusChangedAt":{"Time":"2024-06-01T09:00:00Z","Valid":true},"assigneeId":{"Int64":2,"Valid":true},
+				"next":["production","delivered"],
+				"timestamps":{"development":"2024-01-01T09:00:00Z","production":"2024-03-01T09:00:00Z","sales":"2024-06-01T09:00:00Z"},
+				"history":[
+					{"id":1,"createdAt":"2024-03-01T09:00:00Z","projectId":1,"fromStatus":{"String":"development","Valid":true},"toStatus":"production","assigneeId":{"Int64":0,"Valid":false},"changedBy":{"Int64":1,"Valid":true}},
+					{"id":2,"createdAt":"2024-06-01T09:00:00Z","projectId":1,"fromStatus":{"String":"production","Valid":true},"toStatus":"sales","assigneeId":{"Int64":2,"Valid":true},"changedBy":{"Int64":1,"Valid":true}},
+					{"id":3,"createdAt":"2024-06-01T10:00:00Z","projectId":1,"fromStatus":{"String":"sales","Valid":true},"toStatus":"sales","assigneeId":{"Int64":2,"Valid":true},"changedBy":{"Int64":1,"Valid":true}}
+				]}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			// Initilize test router, environemnt and mock database
+			r, mockDb, mockSql, env, err := utils.SetupTestEnvironment(test.MockDbCall)
+			// Close the mock database at the end of the test
+			defer mockDb.Close()
+
+			// Check for errors during setup
+			require.NoError(t, err)
+
+			// Register handler
+			r.GET("/api/v1/projects/:projectId/status", GetStatus(env))
+
+			// Create httptest request
+			req, _ := http.NewRequest("GET", fmt.Sprintf("/api/v1/projects/%s/status", test.IdString), nil)
+			w := httptest.NewRecorder()
+
+			// Mock request
+			r.ServeHTTP(w, req)
+
+			// Read response data
+			responseData, _ := io.ReadAll(w.Body)
+
+			// Check response status
+			require.Equal(t, test.StatusCode, w.Code)
+
+			// Handle empty responses
+			response := string(responseData)
+			if response == "" {
+				response = "{}"
+			}
+
+			// Check response body
+			require.JSONEq(t, test.Response, response)
+
+			// Check for any remaining expectations
+			// we make sure that all expectations were met
+			if err := mockSql.ExpectationsWereMet(); err != nil {
+				t.Fatalf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,70 @@
+package revisions
+
+import (
+	"database/sql"
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/webstradev/rsdb-backend/utils"
+)
+
+// DiffRevisions compares the revisions given by the from and to query parameters
+func DiffRevisions(env *utils.Environment, entityType, idParam string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		idString := c.Param(idParam)
+		id, err := strconv.ParseInt(idString, 10, 64)
+		if err != nil {
+			log.Println(err)
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Invalid ID"})
+			return
+		}
+
+		from, err := strconv.ParseInt(c.Query("from"), 10, 64)
+		if err != nil {
+			log.Println(err)
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Invalid from revision"})
+			return
+		}
+
+		to, err := strconv.ParseInt(c.Query("to"), 10, 64)
+		if err != nil {
+			log.Println(err)
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Invalid to revision"})
+			return
+		}
+
+		fromRevision, err := env.DB.GetRevision(entityType, id, from)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				c.AbortWithStatus(http.StatusNotFound)
+				return
+			}
+			log.Println(err)
+			c.AbortWithStatus(http.StatusInternalServerError)
+			return
+		}
+
+		toRevision, err := env.DB.GetRevision(entityType, id, to)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				c.AbortWithStatus(http.StatusNotFound)
+				return
+			}
+			log.Println(err)
+			c.AbortWithStatus(http.StatusInternalServerError)
+			return
+		}
+
+		changes, err := fromRevision.Diff(toRevision)
+		if err != nil {
+			log.Println(err)
+			c.AbortWithStatus(http.StatusInternalServerError)
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"from": from, "to": to, "changes": changes})
+	}
+}
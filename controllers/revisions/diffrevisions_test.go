@@ -0,0 +1,132 @@
+package revisions
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/require"
+	"github.com/webstradev/rsdb-backend/db"
+	"github.com/webstradev/rsdb-backend/utils"
+)
+
+func TestDiffRevisions(t *testing.T) {
+	tests := []struct {
+		Name       string
+		IdString   string
+		Query      string
+		MockDbCall func(sqlmock.Sqlmock)
+		StatusCode int
+		Response   string
+	}{
+		{
+			"DiffRevisions - non int id",
+			"notanint",
+			"from=1&to=2",
+			nil,
+			http.StatusBadRequest,
+			`{"error":"Invalid ID"}`,
+		},
+		{
+			"DiffRevisions - missing from",
+			"1",
+			"to=2",
+			nil,
+			http.StatusBadRequest,
+			`{"error":"Invalid from revision"}`,
+		},
+		{
+			"DiffRevisions - missing to",
+			"1",
+			"from=1",
+			nil,
+			http.StatusBadRequest,
+			`{"error":"Invalid to revision"}`,
+		},
+		{
+			"DiffRevisions - from revision not found",
+			"1",
+			"from=1&to=2",
+			func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT (.+) FROM revisions").WithArgs("article", 1, 1).WillReturnRows(sqlmock.NewRows([]string{"id"}))
+			},
+			http.StatusNotFound,
+			`{}`,
+		},
+		{
+			"DiffRevisions - sql error on to revision",
+			"1",
+			"from=1&to=2",
+			func(mock sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows([]string{"revision", "data"}).AddRow(1, `{}`)
+				mock.ExpectQuery("SELECT (.+) FROM revisions").WithArgs("article", 1, 1).WillReturnRows(rows)
+				mock.ExpectQuery("SELECT (.+) FROM revisions").WithArgs("article", 1, 2).WillReturnError(errors.New("test"))
+			},
+			http.StatusInternalServerError,
+			`{}`,
+		},
+		{
+			"DiffRevisions - Valid Request",
+			"1",
+			"from=1&to=2",
+			func(mock sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows([]string{"revision", "data"}).
+					AddRow(1, `{"id":1,"modifiedAt":"2023-01-01T00:00:00Z","title":"old","body":"same","tags":[{"id":1,"tag":"a"},{"id":2,"tag":"b"}]}`)
+				mock.ExpectQuery("SELECT (.+) FROM revisions").WithArgs("article", 1, 1).WillReturnRows(rows)
+
+				rows = sqlmock.NewRows([]string{"revision", "data"}).
+					AddRow(2, `{"id":1,"modifiedAt":"2023-02-01T00:00:00Z","title":"new","body":"same","tags":[{"id":2,"tag":"b"},{"id":3,"tag":"c"}]}`)
+				mock.ExpectQuery("SELECT (.+) FROM revisions").WithArgs("article", 1, 2).WillReturnRows(rows)
+			},
+			http.StatusOK,
+			`{"from":1,"to":2,"changes":[{"field":"tags","from":[{"id":1,"tag":"a"},{"id":2,"tag":"b"}],"to":[{"id":2,"tag":"b"},{"id":3,"tag":"c"}],"added":[{"id":3,"tag":"c"}],"removed":[{"id":1,"tag":"a"}]},{"field":"title","from":"old","to":"new"}]}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			// Initilize test router, environemnt and mock database
+			r, mockDb, mockSql, env, err := utils.SetupTestEnvironment(test.MockDbCall)
+			// Close the mock database at the end of the test
+			defer mockDb.Close()
+
+			// Check for errors during setup
+			require.NoError(t, err)
+
+			// Register handler
+			r.GET("/api/v1/articles/:articleId/revisions/diff", DiffRevisions(env, db.ARTICLE_ENTITY, "articleId"))
+
+			// Create httptest request
+			req, _ := http.NewRequest("GET", fmt.Sprintf("/api/v1/articles/%s/revisions/diff?%s", test.IdString, test.Query), nil)
+			w := httptest.NewRecorder()
+
+			// Mock request
+			r.ServeHTTP(w, req)
+
+			// Read response data
+			responseData, _ := io.ReadAll(w.Body)
+
+			// Check response status
+			require.Equal(t, test.StatusCode, w.Code)
+
+			// Handle empty responses
+			response := string(responseData)
+			if response == "" {
+				response = "{}"
+			}
+
+			// Check response body
+			require.JSONEq(t, test.Response, response)
+
+			// Check for any remaining expectations
+			// we make sure that all expectations were met
+			if err := mockSql.ExpectationsWereMet(); err != nil {
+				t.Fatalf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
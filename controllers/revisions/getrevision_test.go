@@ -0,0 +1,118 @@
+package revisions
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/require"
+	"github.com/webstradev/rsdb-backend/db"
+	"github.com/webstradev/rsdb-backend/utils"
+)
+
+func TestGetRevision(t *testing.T) {
+	tests := []struct {
+		Name           string
+		IdString       string
+		RevisionString string
+		MockDbCall     func(sqlmock.Sqlmock)
+		StatusCode     int
+		Response       string
+	}{
+		{
+			"GetRevision - non int id",
+			"notanint",
+			"1",
+			nil,
+			http.StatusBadRequest,
+			`{"error":"Invalid ID"}`,
+		},
+		{
+			"GetRevision - non int revision",
+			"1",
+			"notanint",
+			nil,
+			http.StatusBadRequest,
+			`{"error":"Invalid Revision"}`,
+		},
+		{
+			"GetRevision - revision not found",
+			"1",
+			"3",
+			func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT (.+) FROM revisions").WithArgs("project", 1, 3).WillReturnRows(sqlmock.NewRows([]string{"id"}))
+			},
+			http.StatusNotFound,
+			`{}`,
+		},
+		{
+			"GetRevision - sql error on GetRevision",
+			"1",
+			"3",
+			func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT (.+) FROM revisions").WithArgs("project", 1, 3).WillReturnError(errors.New("test"))
+			},
+			http.StatusInternalServerError,
+			`{}`,
+		},
+		{
+			"GetRevision - Valid Request",
+			"1",
+			"3",
+			func(mock sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows([]string{"id", "entity_type", "entity_id", "revision", "created_by", "data"}).
+					AddRow(7, "project", 1, 3, 1, `{"title":"old"}`)
+				mock.ExpectQuery("SELECT (.+) FROM revisions").WithArgs("project", 1, 3).WillReturnRows(rows)
+			},
+			http.StatusOK,
+			`{"revision":{"id":7,"createdAt":"0001-01-01T00:00:00Z","modifiedAt":"0001-01-01T00:00:00Z","deletedAt":{"Time":"0001-01-01T00:00:00Z","Valid":false},"entityType":"project","entityId":1,"revision":3,"createdBy":{"Int64":1,"Valid":true}},"data":{"title":"old"}}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			// Initilize test router, environemnt and mock database
+			r, mockDb, mockSql, env, err := utils.SetupTestEnvironment(test.MockDbCall)
+			// Close the mock database at the end of the test
+			defer mockDb.Close()
+
+			// Check for errors during setup
+			require.NoError(t, err)
+
+			// Register handler
+			r.GET("/api/v1/projects/:projectId/revisions/:revision", GetRevision(env, db.PROJECT_ENTITY, "projectId"))
+
+			// Create httptest request
+			req, _ := http.NewRequest("GET", fmt.Sprintf("/api/v1/projects/%s/revisions/%s", test.IdString, test.RevisionString), nil)
+			w := httptest.NewRecorder()
+
+			// Mock request
+			r.ServeHTTP(w, req)
+
+			// Read response data
+			responseData, _ := io.ReadAll(w.Body)
+
+			// Check response status
+			require.Equal(t, test.StatusCode, w.Code)
+
+			// Handle empty responses
+			response := string(responseData)
+			if response == "" {
+				response = "{}"
+			}
+
+			// Check response body
+			require.JSONEq(t, test.Response, response)
+
+			// Check for any remaining expectations
+			// we make sure that all expectations were met
+			if err := mockSql.ExpectationsWereMet(); err != nil {
+				t.Fatalf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,72 @@
+package revisions
+
+import (
+	"database/sql"
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/webstradev/rsdb-backend/auth"
+	"github.com/webstradev/rsdb-backend/db"
+	"github.com/webstradev/rsdb-backend/normalize"
+	"github.com/webstradev/rsdb-backend/utils"
+)
+
+func RestoreRevision(env *utils.Environment, entityType, idParam string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		user, err := auth.GetUserFromContext(c)
+		if err != nil {
+			log.Println(err)
+			c.AbortWithStatus(http.StatusInternalServerError)
+			return
+		}
+
+		idString := c.Param(idParam)
+		id, err := strconv.ParseInt(idString, 10, 64)
+		if err != nil {
+			log.Println(err)
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Invalid ID"})
+			return
+		}
+
+		revisionString := c.Param("revision")
+		revision, err := strconv.ParseInt(revisionString, 10, 64)
+		if err != nil {
+			log.Println(err)
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Invalid Revision"})
+			return
+		}
+
+		err = env.DB.RestoreRevision(entityType, id, revision, user.UserID)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				c.AbortWithStatus(http.StatusNotFound)
+				return
+			}
+			// Platforms can not be restored to a country that is not known anymore
+			if errors.Is(err, normalize.ErrInvalidCountry) {
+				c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+				return
+			}
+			log.Println(err)
+			c.AbortWithStatus(http.StatusInternalServerError)
+			return
+		}
+
+		// Let the owners know their record was edited
+		err = env.Inbox.RecordEdited(entityType, id, user.UserID)
+		if err != nil {
+			log.Println(err)
+		}
+
+		// Let open frontends and the subscribed webhooks know the record was restored
+		err = env.Changes.Publish(entityType, db.CHANGE_UPDATED, id, user.UserID)
+		if err != nil {
+			log.Println(err)
+		}
+
+		c.JSON(http.StatusOK, gin.H{"message": "Revision restored successfully"})
+	}
+}
@@ -0,0 +1,181 @@
+package revisions
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+	"github.com/webstradev/rsdb-backend/auth"
+	"github.com/webstradev/rsdb-backend/db"
+	"github.com/webstradev/rsdb-backend/utils"
+)
+
+func TestRestoreRevision(t *testing.T) {
+	tests := []struct {
+		Name           string
+		IdString       string
+		RevisionString string
+		User           auth.TokenData
+		MockDbCall     func(sqlmock.Sqlmock)
+		StatusCode     int
+		Response       string
+	}{
+		{
+			"RestoreRevision - User Missing from Context",
+			"1",
+			"1",
+			auth.TokenData{},
+			nil,
+			http.StatusInternalServerError,
+			`{}`,
+		},
+		{
+			"RestoreRevision - non int id",
+			"notanint",
+			"1",
+			auth.TokenData{UserID: 1},
+			nil,
+			http.StatusBadRequest,
+			`{"error":"Invalid ID"}`,
+		},
+		{
+			"RestoreRevision - non int revision",
+			"1",
+			"notanint",
+			auth.TokenData{UserID: 1},
+			nil,
+			http.StatusBadRequest,
+			`{"error":"Invalid Revision"}`,
+		},
+		{
+			"RestoreRevision - revision not found",
+			"1",
+			"1",
+			auth.TokenData{UserID: 1},
+			func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT (.+) FROM revisions").WithArgs("platform", 1, 1).WillReturnRows(sqlmock.NewRows([]string{"id"}))
+			},
+			http.StatusNotFound,
+			`{}`,
+		},
+		{
+			"RestoreRevision - sql error on EditPlatform",
+			"1",
+			"1",
+			auth.TokenData{UserID: 1},
+			func(mock sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows([]string{"revision", "data"}).AddRow(1, `{"name":"old","country":"NL","privacy":"private","categories":[{"id":2,"category":"Distributor"}]}`)
+				mock.ExpectQuery("SELECT (.+) FROM revisions").WithArgs("platform", 1, 1).WillReturnRows(rows)
+
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT id FROM platforms WHERE id = \\? AND deleted_at IS NULL FOR UPDATE").WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+				mock.ExpectQuery("SELECT p.(.+) FROM platforms").WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(1, "new"))
+				mock.ExpectQuery("SELECT (.+) FROM categories").WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"platform_id", "category_id", "category"}))
+				mock.ExpectExec("INSERT INTO revisions").WillReturnResult(sqlmock.NewResult(1, 1))
+
+				mock.ExpectExec("UPDATE platforms SET").WillReturnError(errors.New("test"))
+				mock.ExpectRollback()
+			},
+			http.StatusInternalServerError,
+			`{}`,
+		},
+		{
+			"RestoreRevision - unknown country",
+			"1",
+			"1",
+			auth.TokenData{UserID: 1},
+			func(mock sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows([]string{"revision", "data"}).AddRow(1, `{"name":"old","country":"Atlantis","privacy":"private","categories":[]}`)
+				mock.ExpectQuery("SELECT (.+) FROM revisions").WithArgs("platform", 1, 1).WillReturnRows(rows)
+
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT id FROM platforms WHERE id = \\? AND deleted_at IS NULL FOR UPDATE").WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+				mock.ExpectQuery("SELECT p.(.+) FROM platforms").WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"id", "name", "country"}).AddRow(1, "new", "NL"))
+				mock.ExpectQuery("SELECT (.+) FROM categories").WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"platform_id", "category_id", "category"}))
+				mock.ExpectRollback()
+			},
+			http.StatusBadRequest,
+			`{"error":"unknown country, use an ISO 3166-1 code or English country name"}`,
+		},
+		{
+			"RestoreRevision - Valid Request",
+			"1",
+			"1",
+			auth.TokenData{UserID: 1},
+			func(mock sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows([]string{"revision", "data"}).AddRow(1, `{"name":"old","country":"Netherlands","privacy":"private","categories":[{"id":2,"category":"Distributor"}]}`)
+				mock.ExpectQuery("SELECT (.+) FROM revisions").WithArgs("platform", 1, 1).WillReturnRows(rows)
+
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT id FROM platforms WHERE id = \\? AND deleted_at IS NULL FOR UPDATE").WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+				mock.ExpectQuery("SELECT p.(.+) FROM platforms").WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(1, "new"))
+				mock.ExpectQuery("SELECT (.+) FROM categories").WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"platform_id", "category_id", "category"}))
+				mock.ExpectExec("INSERT INTO revisions").WithArgs("platform", 1, 1, sqlmock.AnyArg(), "platform", 1).WillReturnResult(sqlmock.NewResult(1, 1))
+
+				mock.ExpectExec("UPDATE platforms SET").WithArgs("old", "", "NL", "", "", "", "private", 1).WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec("DELETE FROM platforms_categories").WithArgs(1).WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec("INSERT INTO platforms_categories").WithArgs(1, 2).WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectCommit()
+			},
+			http.StatusOK,
+			`{"message":"Revision restored successfully"}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			// Initilize test router, environemnt and mock database
+			r, mockDb, mockSql, env, err := utils.SetupTestEnvironment(test.MockDbCall)
+			// Close the mock database at the end of the test
+			defer mockDb.Close()
+
+			// Check for errors during setup
+			require.NoError(t, err)
+
+			// Register handler
+			r.POST("/api/v1/platforms/:platformId/revisions/:revision/restore", func(c *gin.Context) {
+				// Add user to context if it exists
+				if test.User.UserID != 0 {
+					c.Set("user", test.User)
+				}
+
+				// Call handler
+				RestoreRevision(env, db.PLATFORM_ENTITY, "platformId")(c)
+			})
+
+			// Create httptest request
+			req, _ := http.NewRequest("POST", fmt.Sprintf("/api/v1/platforms/%s/revisions/%s/restore", test.IdString, test.RevisionString), nil)
+			w := httptest.NewRecorder()
+
+			// Mock request
+			r.ServeHTTP(w, req)
+
+			// Read response data
+			responseData, _ := io.ReadAll(w.Body)
+
+			// Check response status
+			require.Equal(t, test.StatusCode, w.Code)
+
+			// Handle empty responses
+			response := string(responseData)
+			if response == "" {
+				response = "{}"
+			}
+
+			// Check response body
+			require.JSONEq(t, test.Response, response)
+
+			// Check for any remaining expectations
+			// we make sure that all expectations were met
+			if err := mockSql.ExpectationsWereMet(); err != nil {
+				t.Fatalf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,62 @@
+package searches
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/webstradev/rsdb-backend/auth"
+	"github.com/webstradev/rsdb-backend/db"
+	"github.com/webstradev/rsdb-backend/utils"
+)
+
+type searchInput struct {
+	Name   string `json:"name" binding:"required"`
+	Target string `json:"target" binding:"required"`
+	Query  string `json:"query"`
+	Shared bool   `json:"shared"`
+}
+
+// CreateSearch saves a search of the current user. The query is the query string of the list the search is for,
+// with its filters and order.
+func CreateSearch(env *utils.Environment) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		user, err := auth.GetUserFromContext(c)
+		if err != nil {
+			log.Println(err)
+			c.AbortWithStatus(http.StatusInternalServerError)
+			return
+		}
+
+		input := searchInput{}
+		err = c.ShouldBindJSON(&input)
+		if err != nil {
+			log.Println(err)
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		search := db.SavedSearch{
+			OwnerId: user.UserID,
+			Name:    input.Name,
+			Target:  input.Target,
+			Query:   input.Query,
+			Shared:  input.Shared,
+		}
+
+		err = search.Validate()
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		id, err := env.DB.InsertSavedSearch(search)
+		if err != nil {
+			log.Println(err)
+			c.AbortWithStatus(http.StatusInternalServerError)
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"id": id, "message": "Search saved successfully"})
+	}
+}
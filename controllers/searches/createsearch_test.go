@@ -0,0 +1,129 @@
+package searches
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+	"github.com/webstradev/rsdb-backend/auth"
+	"github.com/webstradev/rsdb-backend/utils"
+)
+
+func TestCreateSearch(t *testing.T) {
+	tests := []struct {
+		Name       string
+		User       auth.TokenData
+		MockDbCall func(sqlmock.Sqlmock)
+		StatusCode int
+		Body       string
+		Response   string
+	}{
+		{
+			"CreateSearch - User Missing from Context",
+			auth.TokenData{},
+			nil,
+			http.StatusInternalServerError,
+			`{}`,
+			`{}`,
+		},
+		{
+			"CreateSearch - missing name",
+			owner,
+			nil,
+			http.StatusBadRequest,
+			`{"target":"contacts","query":"country=NL"}`,
+			`{"error":"Key: 'searchInput.Name' Error:Field validation for 'Name' failed on the 'required' tag"}`,
+		},
+		{
+			"CreateSearch - invalid target",
+			owner,
+			nil,
+			http.StatusBadRequest,
+			`{"name":"Dutch editors","target":"people","query":"country=NL"}`,
+			`{"error":"invalid search target, use contacts"}`,
+		},
+		{
+			"CreateSearch - invalid query",
+			owner,
+			nil,
+			http.StatusBadRequest,
+			`{"name":"Dutch editors","target":"contacts","query":"category=news"}`,
+			`{"error":"Invalid category"}`,
+		},
+		{
+			"CreateSearch - sql error",
+			owner,
+			func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec("INSERT INTO saved_searches").WithArgs(2, "Dutch editors", "contacts", "country=NL&sort=-created", true).WillReturnError(errors.New("test"))
+			},
+			http.StatusInternalServerError,
+			`{"name":"Dutch editors","target":"contacts","query":"country=NL&sort=-created","shared":true}`,
+			`{}`,
+		},
+		{
+			"CreateSearch - Valid Request without pagination",
+			owner,
+			func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec("INSERT INTO saved_searches").WithArgs(2, "Dutch editors", "contacts", "country=NL&sort=-created", true).WillReturnResult(sqlmock.NewResult(1, 1))
+			},
+			http.StatusOK,
+			`{"name":"Dutch editors","target":"contacts","query":"page=2&pageSize=10&country=NL&sort=-created","shared":true}`,
+			`{"id":1,"message":"Search saved successfully"}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			// Initilize test router, environemnt and mock database
+			r, mockDb, mockSql, env, err := utils.SetupTestEnvironment(test.MockDbCall)
+			// Close the mock database at the end of the test
+			defer mockDb.Close()
+
+			// Check for errors during setup
+			require.NoError(t, err)
+
+			// Register handler
+			r.Use(func(c *gin.Context) {
+				// Add user to context if it exists
+				if test.User.UserID != 0 {
+					c.Set("user", test.User)
+				}
+			})
+			r.POST("/api/v1/searches", CreateSearch(env))
+
+			// Create httptest request
+			req, _ := http.NewRequest("POST", "/api/v1/searches", strings.NewReader(test.Body))
+			w := httptest.NewRecorder()
+
+			// Mock request
+			r.ServeHTTP(w, req)
+
+			// Read response data
+			responseData, _ := io.ReadAll(w.Body)
+
+			// Check response status
+			require.Equal(t, test.StatusCode, w.Code)
+
+			// Handle empty responses
+			response := string(responseData)
+			if response == "" {
+				response = "{}"
+			}
+
+			// Check response body
+			require.JSONEq(t, test.Response, response)
+
+			// Check for any remaining expectations
+			// we make sure that all expectations were met
+			if err := mockSql.ExpectationsWereMet(); err != nil {
+				t.Fatalf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
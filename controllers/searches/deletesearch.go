@@ -0,0 +1,41 @@
+package searches
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/webstradev/rsdb-backend/auth"
+	"github.com/webstradev/rsdb-backend/utils"
+)
+
+// DeleteSearch removes a saved search, only its owner can. Its subscribers are no longer notified.
+func DeleteSearch(env *utils.Environment) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		user, err := auth.GetUserFromContext(c)
+		if err != nil {
+			log.Println(err)
+			c.AbortWithStatus(http.StatusInternalServerError)
+			return
+		}
+
+		search, ok := visibleSearch(c, env, user)
+		if !ok {
+			return
+		}
+
+		if search.OwnerId != user.UserID {
+			c.AbortWithStatus(http.StatusForbidden)
+			return
+		}
+
+		err = env.DB.DeleteSavedSearch(search.ID)
+		if err != nil {
+			log.Println(err)
+			c.AbortWithStatus(http.StatusInternalServerError)
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"message": "Search deleted successfully"})
+	}
+}
@@ -0,0 +1,125 @@
+package searches
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+	"github.com/webstradev/rsdb-backend/auth"
+	"github.com/webstradev/rsdb-backend/utils"
+)
+
+func TestDeleteSearch(t *testing.T) {
+	tests := []struct {
+		Name       string
+		IdString   string
+		User       auth.TokenData
+		MockDbCall func(sqlmock.Sqlmock)
+		StatusCode int
+		Response   string
+	}{
+		{
+			"DeleteSearch - User Missing from Context",
+			"1",
+			auth.TokenData{},
+			nil,
+			http.StatusInternalServerError,
+			`{}`,
+		},
+		{
+			"DeleteSearch - non int id",
+			"notanint",
+			owner,
+			nil,
+			http.StatusBadRequest,
+			`{"error":"Invalid ID"}`,
+		},
+		{
+			"DeleteSearch - shared search of another user",
+			"1",
+			other,
+			func(mock sqlmock.Sqlmock) {
+				expectSearch(mock, 3, true)
+			},
+			http.StatusForbidden,
+			`{}`,
+		},
+		{
+			"DeleteSearch - sql error",
+			"1",
+			owner,
+			func(mock sqlmock.Sqlmock) {
+				expectSearch(mock, 2, false)
+				mock.ExpectExec("UPDATE saved_searches SET deleted_at").WithArgs(1).WillReturnError(errors.New("test"))
+			},
+			http.StatusInternalServerError,
+			`{}`,
+		},
+		{
+			"DeleteSearch - Valid Request",
+			"1",
+			owner,
+			func(mock sqlmock.Sqlmock) {
+				expectSearch(mock, 2, false)
+				mock.ExpectExec("UPDATE saved_searches SET deleted_at").WithArgs(1).WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			http.StatusOK,
+			`{"message":"Search deleted successfully"}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			// Initilize test router, environemnt and mock database
+			r, mockDb, mockSql, env, err := utils.SetupTestEnvironment(test.MockDbCall)
+			// Close the mock database at the end of the test
+			defer mockDb.Close()
+
+			// Check for errors during setup
+			require.NoError(t, err)
+
+			// Register handler
+			r.Use(func(c *gin.Context) {
+				// Add user to context if it exists
+				if test.User.UserID != 0 {
+					c.Set("user", test.User)
+				}
+			})
+			r.DELETE("/api/v1/searches/:searchId", DeleteSearch(env))
+
+			// Create httptest request
+			req, _ := http.NewRequest("DELETE", fmt.Sprintf("/api/v1/searches/%s", test.IdString), nil)
+			w := httptest.NewRecorder()
+
+			// Mock request
+			r.ServeHTTP(w, req)
+
+			// Read response data
+			responseData, _ := io.ReadAll(w.Body)
+
+			// Check response status
+			require.Equal(t, test.StatusCode, w.Code)
+
+			// Handle empty responses
+			response := string(responseData)
+			if response == "" {
+				response = "{}"
+			}
+
+			// Check response body
+			require.JSONEq(t, test.Response, response)
+
+			// Check for any remaining expectations
+			// we make sure that all expectations were met
+			if err := mockSql.ExpectationsWereMet(); err != nil {
+				t.Fatalf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,60 @@
+package searches
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/webstradev/rsdb-backend/auth"
+	"github.com/webstradev/rsdb-backend/utils"
+)
+
+// EditSearch changes a saved search, only its owner can
+func EditSearch(env *utils.Environment) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		user, err := auth.GetUserFromContext(c)
+		if err != nil {
+			log.Println(err)
+			c.AbortWithStatus(http.StatusInternalServerError)
+			return
+		}
+
+		input := searchInput{}
+		err = c.ShouldBindJSON(&input)
+		if err != nil {
+			log.Println(err)
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		search, ok := visibleSearch(c, env, user)
+		if !ok {
+			return
+		}
+
+		if search.OwnerId != user.UserID {
+			c.AbortWithStatus(http.StatusForbidden)
+			return
+		}
+
+		search.Name = input.Name
+		search.Target = input.Target
+		search.Query = input.Query
+		search.Shared = input.Shared
+
+		err = search.Validate()
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		err = env.DB.UpdateSavedSearch(search)
+		if err != nil {
+			log.Println(err)
+			c.AbortWithStatus(http.StatusInternalServerError)
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"message": "Search updated successfully"})
+	}
+}
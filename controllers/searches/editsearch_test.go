@@ -0,0 +1,152 @@
+package searches
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+	"github.com/webstradev/rsdb-backend/auth"
+	"github.com/webstradev/rsdb-backend/utils"
+)
+
+func TestEditSearch(t *testing.T) {
+	tests := []struct {
+		Name       string
+		IdString   string
+		User       auth.TokenData
+		MockDbCall func(sqlmock.Sqlmock)
+		StatusCode int
+		Body       string
+		Response   string
+	}{
+		{
+			"EditSearch - User Missing from Context",
+			"1",
+			auth.TokenData{},
+			nil,
+			http.StatusInternalServerError,
+			`{}`,
+			`{}`,
+		},
+		{
+			"EditSearch - missing name",
+			"1",
+			owner,
+			nil,
+			http.StatusBadRequest,
+			`{"target":"contacts"}`,
+			`{"error":"Key: 'searchInput.Name' Error:Field validation for 'Name' failed on the 'required' tag"}`,
+		},
+		{
+			"EditSearch - non int id",
+			"notanint",
+			owner,
+			nil,
+			http.StatusBadRequest,
+			`{"name":"Editors","target":"contacts"}`,
+			`{"error":"Invalid ID"}`,
+		},
+		{
+			"EditSearch - shared search of another user",
+			"1",
+			other,
+			func(mock sqlmock.Sqlmock) {
+				expectSearch(mock, 3, true)
+			},
+			http.StatusForbidden,
+			`{"name":"Editors","target":"contacts"}`,
+			`{}`,
+		},
+		{
+			"EditSearch - invalid sort",
+			"1",
+			owner,
+			func(mock sqlmock.Sqlmock) {
+				expectSearch(mock, 2, true)
+			},
+			http.StatusBadRequest,
+			`{"name":"Editors","target":"contacts","query":"sort=email"}`,
+			`{"error":"Invalid sort, use name, platform or created, prefixed with - for descending order"}`,
+		},
+		{
+			"EditSearch - sql error",
+			"1",
+			owner,
+			func(mock sqlmock.Sqlmock) {
+				expectSearch(mock, 2, true)
+				mock.ExpectExec("UPDATE saved_searches").WithArgs("Editors", "contacts", "title=editor", false, 1).WillReturnError(errors.New("test"))
+			},
+			http.StatusInternalServerError,
+			`{"name":"Editors","target":"contacts","query":"title=editor"}`,
+			`{}`,
+		},
+		{
+			"EditSearch - Valid Request",
+			"1",
+			owner,
+			func(mock sqlmock.Sqlmock) {
+				expectSearch(mock, 2, true)
+				mock.ExpectExec("UPDATE saved_searches").WithArgs("Editors", "contacts", "title=editor", false, 1).WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			http.StatusOK,
+			`{"name":"Editors","target":"contacts","query":"title=editor"}`,
+			`{"message":"Search updated successfully"}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			// Initilize test router, environemnt and mock database
+			r, mockDb, mockSql, env, err := utils.SetupTestEnvironment(test.MockDbCall)
+			// Close the mock database at the end of the test
+			defer mockDb.Close()
+
+			// Check for errors during setup
+			require.NoError(t, err)
+
+			// Register handler
+			r.Use(func(c *gin.Context) {
+				// Add user to context if it exists
+				if test.User.UserID != 0 {
+					c.Set("user", test.User)
+				}
+			})
+			r.PUT("/api/v1/searches/:searchId", EditSearch(env))
+
+			// Create httptest request
+			req, _ := http.NewRequest("PUT", fmt.Sprintf("/api/v1/searches/%s", test.IdString), strings.NewReader(test.Body))
+			w := httptest.NewRecorder()
+
+			// Mock request
+			r.ServeHTTP(w, req)
+
+			// Read response data
+			responseData, _ := io.ReadAll(w.Body)
+
+			// Check response status
+			require.Equal(t, test.StatusCode, w.Code)
+
+			// Handle empty responses
+			response := string(responseData)
+			if response == "" {
+				response = "{}"
+			}
+
+			// Check response body
+			require.JSONEq(t, test.Response, response)
+
+			// Check for any remaining expectations
+			// we make sure that all expectations were met
+			if err := mockSql.ExpectationsWereMet(); err != nil {
+				t.Fatalf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
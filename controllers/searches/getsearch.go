@@ -0,0 +1,82 @@
+package searches
+
+import (
+	"database/sql"
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/webstradev/rsdb-backend/auth"
+	"github.com/webstradev/rsdb-backend/db"
+	"github.com/webstradev/rsdb-backend/utils"
+)
+
+// GetSearches lists the saved searches of the current user and the ones shared by other users
+func GetSearches(env *utils.Environment) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		user, err := auth.GetUserFromContext(c)
+		if err != nil {
+			log.Println(err)
+			c.AbortWithStatus(http.StatusInternalServerError)
+			return
+		}
+
+		searches, err := env.DB.GetSavedSearches(user.UserID)
+		if err != nil {
+			log.Println(err)
+			c.AbortWithStatus(http.StatusInternalServerError)
+			return
+		}
+
+		c.JSON(http.StatusOK, searches)
+	}
+}
+
+func GetSearch(env *utils.Environment) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		user, err := auth.GetUserFromContext(c)
+		if err != nil {
+			log.Println(err)
+			c.AbortWithStatus(http.StatusInternalServerError)
+			return
+		}
+
+		search, ok := visibleSearch(c, env, user)
+		if !ok {
+			return
+		}
+
+		c.JSON(http.StatusOK, search)
+	}
+}
+
+// visibleSearch returns the saved search in the searchId URL parameter. It aborts the request unless the search
+// exists and is owned by or shared with the user, searches of others that aren't shared are not found.
+func visibleSearch(c *gin.Context, env *utils.Environment, user auth.TokenData) (db.SavedSearch, bool) {
+	id, err := strconv.ParseInt(c.Param("searchId"), 10, 64)
+	if err != nil {
+		log.Println(err)
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Invalid ID"})
+		return db.SavedSearch{}, false
+	}
+
+	search, err := env.DB.GetSavedSearch(id, user.UserID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			c.AbortWithStatus(http.StatusNotFound)
+			return search, false
+		}
+		log.Println(err)
+		c.AbortWithStatus(http.StatusInternalServerError)
+		return search, false
+	}
+
+	if search.OwnerId != user.UserID && !search.Shared {
+		c.AbortWithStatus(http.StatusNotFound)
+		return search, false
+	}
+
+	return search, true
+}
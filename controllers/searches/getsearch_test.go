@@ -0,0 +1,179 @@
+package searches
+
+import (
+	"database/sql"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+	"github.com/webstradev/rsdb-backend/auth"
+	"github.com/webstradev/rsdb-backend/utils"
+)
+
+var (
+	owner = auth.TokenData{UserID: 2, Role: auth.UserRole}
+	other = auth.TokenData{UserID: 3, Role: auth.UserRole}
+)
+
+var searchColumns = []string{"id", "owner_id", "name", "target", "query", "shared", "owner_email", "subscribed"}
+
+const search = `{"id":1,"createdAt":"0001-01-01T00:00:00Z","modifiedAt":"0001-01-01T00:00:00Z","deletedAt":{"Time":"0001-01-01T00:00:00Z","Valid":false},
+	"ownerId":2,"ownerEmail":"owner@example.com","name":"Dutch editors","target":"contacts","query":"country=NL&title=editor","shared":true,"subscribed":false}`
+
+// expectSearch expects the saved search with ID 1 of the owner to be read for the user
+func expectSearch(mock sqlmock.Sqlmock, userId int64, shared bool) {
+	rows := sqlmock.NewRows(searchColumns).AddRow(1, 2, "Dutch editors", "contacts", "country=NL&title=editor", shared, "owner@example.com", false)
+	mock.ExpectQuery("SELECT s.(.+) FROM saved_searches s").WithArgs(userId, 1).WillReturnRows(rows)
+}
+
+func TestGetSearch(t *testing.T) {
+	tests := []struct {
+		Name       string
+		Path       string
+		User       auth.TokenData
+		MockDbCall func(sqlmock.Sqlmock)
+		StatusCode int
+		Response   string
+	}{
+		{
+			"GetSearches - User Missing from Context",
+			"/api/v1/searches",
+			auth.TokenData{},
+			nil,
+			http.StatusInternalServerError,
+			`{}`,
+		},
+		{
+			"GetSearches - sql error",
+			"/api/v1/searches",
+			owner,
+			func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT s.(.+) FROM saved_searches s").WithArgs(2, 2).WillReturnError(errors.New("test"))
+			},
+			http.StatusInternalServerError,
+			`{}`,
+		},
+		{
+			"GetSearches - Valid Request",
+			"/api/v1/searches",
+			owner,
+			func(mock sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows(searchColumns).AddRow(1, 2, "Dutch editors", "contacts", "country=NL&title=editor", true, "owner@example.com", false)
+				mock.ExpectQuery("SELECT s.(.+) FROM saved_searches s (.+) \\(s.owner_id = \\? OR s.shared\\)").WithArgs(2, 2).WillReturnRows(rows)
+			},
+			http.StatusOK,
+			`[` + search + `]`,
+		},
+		{
+			"GetSearch - User Missing from Context",
+			"/api/v1/searches/1",
+			auth.TokenData{},
+			nil,
+			http.StatusInternalServerError,
+			`{}`,
+		},
+		{
+			"GetSearch - non int id",
+			"/api/v1/searches/notanint",
+			owner,
+			nil,
+			http.StatusBadRequest,
+			`{"error":"Invalid ID"}`,
+		},
+		{
+			"GetSearch - not found",
+			"/api/v1/searches/1",
+			owner,
+			func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT s.(.+) FROM saved_searches s").WithArgs(2, 1).WillReturnError(sql.ErrNoRows)
+			},
+			http.StatusNotFound,
+			`{}`,
+		},
+		{
+			"GetSearch - sql error",
+			"/api/v1/searches/1",
+			owner,
+			func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT s.(.+) FROM saved_searches s").WithArgs(2, 1).WillReturnError(errors.New("test"))
+			},
+			http.StatusInternalServerError,
+			`{}`,
+		},
+		{
+			"GetSearch - private search of another user",
+			"/api/v1/searches/1",
+			other,
+			func(mock sqlmock.Sqlmock) {
+				expectSearch(mock, 3, false)
+			},
+			http.StatusNotFound,
+			`{}`,
+		},
+		{
+			"GetSearch - shared search of another user",
+			"/api/v1/searches/1",
+			other,
+			func(mock sqlmock.Sqlmock) {
+				expectSearch(mock, 3, true)
+			},
+			http.StatusOK,
+			search,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			// Initilize test router, environemnt and mock database
+			r, mockDb, mockSql, env, err := utils.SetupTestEnvironment(test.MockDbCall)
+			// Close the mock database at the end of the test
+			defer mockDb.Close()
+
+			// Check for errors during setup
+			require.NoError(t, err)
+
+			// Register handlers
+			r.Use(func(c *gin.Context) {
+				// Add user to context if it exists
+				if test.User.UserID != 0 {
+					c.Set("user", test.User)
+				}
+			})
+			r.GET("/api/v1/searches", GetSearches(env))
+			r.GET("/api/v1/searches/:searchId", GetSearch(env))
+
+			// Create httptest request
+			req, _ := http.NewRequest("GET", test.Path, nil)
+			w := httptest.NewRecorder()
+
+			// Mock request
+			r.ServeHTTP(w, req)
+
+			// Read response data
+			responseData, _ := io.ReadAll(w.Body)
+
+			// Check response status
+			require.Equal(t, test.StatusCode, w.Code)
+
+			// Handle empty responses
+			response := string(responseData)
+			if response == "" {
+				response = "{}"
+			}
+
+			// Check response body
+			require.JSONEq(t, test.Response, response)
+
+			// Check for any remaining expectations
+			// we make sure that all expectations were met
+			if err := mockSql.ExpectationsWereMet(); err != nil {
+				t.Fatalf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,82 @@
+package searches
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/webstradev/rsdb-backend/auth"
+	"github.com/webstradev/rsdb-backend/db"
+	"github.com/webstradev/rsdb-backend/utils"
+)
+
+// RunSearch lists a page of the records that match a saved search, like the list the search is for
+func RunSearch(env *utils.Environment) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		user, err := auth.GetUserFromContext(c)
+		if err != nil {
+			log.Println(err)
+			c.AbortWithStatus(http.StatusInternalServerError)
+			return
+		}
+
+		page := c.MustGet("page").(int)
+		pageSize := c.MustGet("pageSize").(int)
+
+		search, ok := visibleSearch(c, env, user)
+		if !ok {
+			return
+		}
+
+		filter, err := search.ContactFilter()
+		if err != nil {
+			log.Println(err)
+			c.AbortWithStatus(http.StatusInternalServerError)
+			return
+		}
+
+		contacts, err := env.DB.GetContactsDirectory(filter, user.IsAdmin(), page, pageSize)
+		if err != nil {
+			log.Println(err)
+			c.AbortWithStatus(http.StatusInternalServerError)
+			return
+		}
+
+		count, err := env.DB.CountContactsDirectory(filter, user.IsAdmin())
+		if err != nil {
+			log.Println(err)
+			c.AbortWithStatus(http.StatusInternalServerError)
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"search": search, "total": count, "contacts": contacts})
+	}
+}
+
+// ExportSearch exports the records that match a saved search, like the export of the list the search is for
+func ExportSearch(env *utils.Environment) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		user, err := auth.GetUserFromContext(c)
+		if err != nil {
+			log.Println(err)
+			c.AbortWithStatus(http.StatusInternalServerError)
+			return
+		}
+
+		search, ok := visibleSearch(c, env, user)
+		if !ok {
+			return
+		}
+
+		filter, err := search.ContactFilter()
+		if err != nil {
+			log.Println(err)
+			c.AbortWithStatus(http.StatusInternalServerError)
+			return
+		}
+
+		utils.StreamExport(c, "contacts", db.ContactExportColumns, func(write func([]string) error) error {
+			return env.DB.ExportContacts(filter, user.IsAdmin(), write)
+		})
+	}
+}
@@ -0,0 +1,259 @@
+package searches
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+	"github.com/webstradev/gin-pagination/v2/pkg/pagination"
+	"github.com/webstradev/rsdb-backend/auth"
+	"github.com/webstradev/rsdb-backend/utils"
+)
+
+func TestRunSearch(t *testing.T) {
+	tests := []struct {
+		Name       string
+		Path       string
+		User       auth.TokenData
+		MockDbCall func(sqlmock.Sqlmock)
+		StatusCode int
+		Response   string
+	}{
+		{
+			"RunSearch - User Missing from Context",
+			"/api/v1/searches/1/results?page=0&pageSize=10",
+			auth.TokenData{},
+			nil,
+			http.StatusInternalServerError,
+			`{}`,
+		},
+		{
+			"RunSearch - non int id",
+			"/api/v1/searches/notanint/results?page=0&pageSize=10",
+			owner,
+			nil,
+			http.StatusBadRequest,
+			`{"error":"Invalid ID"}`,
+		},
+		{
+			"RunSearch - private search of another user",
+			"/api/v1/searches/1/results?page=0&pageSize=10",
+			other,
+			func(mock sqlmock.Sqlmock) {
+				expectSearch(mock, 3, false)
+			},
+			http.StatusNotFound,
+			`{}`,
+		},
+		{
+			"RunSearch - sql error on GetContactsDirectory",
+			"/api/v1/searches/1/results?page=0&pageSize=10",
+			owner,
+			func(mock sqlmock.Sqlmock) {
+				expectSearch(mock, 2, false)
+				mock.ExpectQuery("SELECT c.(.+) FROM contacts c").WithArgs("NL", "%editor%", "private", "private", 10, 0).WillReturnError(errors.New("test"))
+			},
+			http.StatusInternalServerError,
+			`{}`,
+		},
+		{
+			"RunSearch - sql error on CountContactsDirectory",
+			"/api/v1/searches/1/results?page=0&pageSize=10",
+			owner,
+			func(mock sqlmock.Sqlmock) {
+				expectSearch(mock, 2, false)
+				mock.ExpectQuery("SELECT c.(.+) FROM contacts c").WithArgs("NL", "%editor%", "private", "private", 10, 0).WillReturnRows(sqlmock.NewRows([]string{"id"}))
+				mock.ExpectQuery("SELECT COUNT(.+) AS count FROM contacts c").WithArgs("NL", "%editor%", "private", "private").WillReturnError(errors.New("test"))
+			},
+			http.StatusInternalServerError,
+			`{}`,
+		},
+		{
+			"RunSearch - Valid Request",
+			"/api/v1/searches/1/results?page=0&pageSize=10",
+			other,
+			func(mock sqlmock.Sqlmock) {
+				expectSearch(mock, 3, true)
+				mock.ExpectQuery("SELECT c.(.+) FROM contacts c").WithArgs("NL", "%editor%", "private", "private", 10, 0).WillReturnRows(sqlmock.NewRows([]string{"id"}))
+				mock.ExpectQuery("SELECT COUNT(.+) AS count FROM contacts c").WithArgs("NL", "%editor%", "private", "private").WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+			},
+			http.StatusOK,
+			`{"search":` + search + `,"total":0,"contacts":[]}`,
+		},
+		{
+			"RunSearch - Valid Request including private contacts for admins",
+			"/api/v1/searches/1/results?page=0&pageSize=10",
+			auth.TokenData{UserID: 4, Role: auth.AdminRole},
+			func(mock sqlmock.Sqlmock) {
+				expectSearch(mock, 4, true)
+				mock.ExpectQuery("SELECT c.(.+) FROM contacts c").WithArgs("NL", "%editor%", 10, 0).WillReturnRows(sqlmock.NewRows([]string{"id"}))
+				mock.ExpectQuery("SELECT COUNT(.+) AS count FROM contacts c").WithArgs("NL", "%editor%").WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+			},
+			http.StatusOK,
+			`{"search":` + search + `,"total":0,"contacts":[]}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			// Initilize test router, environemnt and mock database
+			r, mockDb, mockSql, env, err := utils.SetupTestEnvironment(test.MockDbCall)
+			// Close the mock database at the end of the test
+			defer mockDb.Close()
+
+			// Check for errors during setup
+			require.NoError(t, err)
+
+			// Register handler
+			r.GET("/api/v1/searches/:searchId/results",
+				pagination.New(
+					pagination.WithSizeText("pageSize"),
+					pagination.WithMinPageSize(1),
+					pagination.WithMaxPageSize(100),
+				),
+				func(c *gin.Context) {
+					// Add user to context if it exists
+					if test.User.UserID != 0 {
+						c.Set("user", test.User)
+					}
+
+					// Call handler
+					RunSearch(env)(c)
+				},
+			)
+
+			// Create httptest request
+			req, _ := http.NewRequest("GET", test.Path, nil)
+			w := httptest.NewRecorder()
+
+			// Mock request
+			r.ServeHTTP(w, req)
+
+			// Read response data
+			responseData, _ := io.ReadAll(w.Body)
+
+			// Check response status
+			require.Equal(t, test.StatusCode, w.Code)
+
+			// Handle empty responses
+			response := string(responseData)
+			if response == "" {
+				response = "{}"
+			}
+
+			// Check response body
+			require.JSONEq(t, test.Response, response)
+
+			// Check for any remaining expectations
+			// we make sure that all expectations were met
+			if err := mockSql.ExpectationsWereMet(); err != nil {
+				t.Fatalf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func TestExportSearch(t *testing.T) {
+	tests := []struct {
+		Name        string
+		User        auth.TokenData
+		MockDbCall  func(sqlmock.Sqlmock)
+		StatusCode  int
+		ContentType string
+		Response    string
+	}{
+		{
+			"ExportSearch - User Missing from Context",
+			auth.TokenData{},
+			nil,
+			http.StatusInternalServerError,
+			"",
+			``,
+		},
+		{
+			"ExportSearch - private search of another user",
+			other,
+			func(mock sqlmock.Sqlmock) {
+				expectSearch(mock, 3, false)
+			},
+			http.StatusNotFound,
+			"",
+			``,
+		},
+		{
+			"ExportSearch - sql error on ExportContacts",
+			owner,
+			func(mock sqlmock.Sqlmock) {
+				expectSearch(mock, 2, false)
+				mock.ExpectQuery("SELECT (.+) FROM contacts c").WithArgs("NL", "%editor%", "private", "private").WillReturnError(errors.New("test"))
+			},
+			http.StatusInternalServerError,
+			"",
+			``,
+		},
+		{
+			"ExportSearch - CSV without private contacts",
+			other,
+			func(mock sqlmock.Sqlmock) {
+				expectSearch(mock, 3, true)
+				rows := sqlmock.NewRows([]string{"id", "name", "title", "email", "phone", "phone2", "address", "source", "privacy", "notes", "platform_id", "platform_name", "platform_country", "platform_categories"}).
+					AddRow(1, "John", "Editor", "john@example.com", "", "", "", "", "public", "", 2, "Acme TV", "NL", "news")
+				mock.ExpectQuery("SELECT (.+) FROM contacts c").WithArgs("NL", "%editor%", "private", "private").WillReturnRows(rows)
+			},
+			http.StatusOK,
+			"text/csv; charset=utf-8",
+			"id,name,title,email,phone,phone2,address,source,privacy,notes,platformId,platform,country,categories\n1,John,Editor,john@example.com,,,,,public,,2,Acme TV,NL,news\n",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			// Initilize test router, environemnt and mock database
+			r, mockDb, mockSql, env, err := utils.SetupTestEnvironment(test.MockDbCall)
+			// Close the mock database at the end of the test
+			defer mockDb.Close()
+
+			// Check for errors during setup
+			require.NoError(t, err)
+
+			// Register handler
+			r.GET("/api/v1/searches/:searchId/export", func(c *gin.Context) {
+				// Add user to context if it exists
+				if test.User.UserID != 0 {
+					c.Set("user", test.User)
+				}
+
+				// Call handler
+				ExportSearch(env)(c)
+			})
+
+			// Create httptest request
+			req, _ := http.NewRequest("GET", "/api/v1/searches/1/export", nil)
+			w := httptest.NewRecorder()
+
+			// Mock request
+			r.ServeHTTP(w, req)
+
+			// Read response data
+			responseData, _ := io.ReadAll(w.Body)
+
+			// Check response status and type
+			require.Equal(t, test.StatusCode, w.Code)
+			require.Equal(t, test.ContentType, w.Header().Get("Content-Type"))
+
+			// Check response body
+			require.Equal(t, test.Response, string(responseData))
+
+			// Check for any remaining expectations
+			// we make sure that all expectations were met
+			if err := mockSql.ExpectationsWereMet(); err != nil {
+				t.Fatalf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
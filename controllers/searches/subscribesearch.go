@@ -0,0 +1,62 @@
+package searches
+
+import (
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/webstradev/rsdb-backend/auth"
+	"github.com/webstradev/rsdb-backend/utils"
+)
+
+// SubscribeToSearch subscribes the current user to a weekly notification of the new matches of a saved search
+func SubscribeToSearch(env *utils.Environment) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		user, err := auth.GetUserFromContext(c)
+		if err != nil {
+			log.Println(err)
+			c.AbortWithStatus(http.StatusInternalServerError)
+			return
+		}
+
+		search, ok := visibleSearch(c, env, user)
+		if !ok {
+			return
+		}
+
+		err = env.DB.SubscribeToSearch(search.ID, user.UserID, time.Now())
+		if err != nil {
+			log.Println(err)
+			c.AbortWithStatus(http.StatusInternalServerError)
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"message": "Subscribed successfully"})
+	}
+}
+
+func UnsubscribeFromSearch(env *utils.Environment) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		user, err := auth.GetUserFromContext(c)
+		if err != nil {
+			log.Println(err)
+			c.AbortWithStatus(http.StatusInternalServerError)
+			return
+		}
+
+		search, ok := visibleSearch(c, env, user)
+		if !ok {
+			return
+		}
+
+		err = env.DB.UnsubscribeFromSearch(search.ID, user.UserID)
+		if err != nil {
+			log.Println(err)
+			c.AbortWithStatus(http.StatusInternalServerError)
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"message": "Unsubscribed successfully"})
+	}
+}
@@ -0,0 +1,147 @@
+package searches
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+	"github.com/webstradev/rsdb-backend/auth"
+	"github.com/webstradev/rsdb-backend/utils"
+)
+
+func TestSubscribeToSearch(t *testing.T) {
+	tests := []struct {
+		Name       string
+		Method     string
+		User       auth.TokenData
+		MockDbCall func(sqlmock.Sqlmock)
+		StatusCode int
+		Response   string
+	}{
+		{
+			"SubscribeToSearch - User Missing from Context",
+			"PUT",
+			auth.TokenData{},
+			nil,
+			http.StatusInternalServerError,
+			`{}`,
+		},
+		{
+			"SubscribeToSearch - private search of another user",
+			"PUT",
+			other,
+			func(mock sqlmock.Sqlmock) {
+				expectSearch(mock, 3, false)
+			},
+			http.StatusNotFound,
+			`{}`,
+		},
+		{
+			"SubscribeToSearch - sql error",
+			"PUT",
+			other,
+			func(mock sqlmock.Sqlmock) {
+				expectSearch(mock, 3, true)
+				mock.ExpectExec("INSERT IGNORE INTO saved_searches_subscriptions").WithArgs(1, 3, sqlmock.AnyArg()).WillReturnError(errors.New("test"))
+			},
+			http.StatusInternalServerError,
+			`{}`,
+		},
+		{
+			"SubscribeToSearch - Valid Request",
+			"PUT",
+			other,
+			func(mock sqlmock.Sqlmock) {
+				expectSearch(mock, 3, true)
+				mock.ExpectExec("INSERT IGNORE INTO saved_searches_subscriptions").WithArgs(1, 3, sqlmock.AnyArg()).WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			http.StatusOK,
+			`{"message":"Subscribed successfully"}`,
+		},
+		{
+			"UnsubscribeFromSearch - User Missing from Context",
+			"DELETE",
+			auth.TokenData{},
+			nil,
+			http.StatusInternalServerError,
+			`{}`,
+		},
+		{
+			"UnsubscribeFromSearch - sql error",
+			"DELETE",
+			other,
+			func(mock sqlmock.Sqlmock) {
+				expectSearch(mock, 3, true)
+				mock.ExpectExec("DELETE FROM saved_searches_subscriptions").WithArgs(1, 3).WillReturnError(errors.New("test"))
+			},
+			http.StatusInternalServerError,
+			`{}`,
+		},
+		{
+			"UnsubscribeFromSearch - Valid Request",
+			"DELETE",
+			other,
+			func(mock sqlmock.Sqlmock) {
+				expectSearch(mock, 3, true)
+				mock.ExpectExec("DELETE FROM saved_searches_subscriptions").WithArgs(1, 3).WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			http.StatusOK,
+			`{"message":"Unsubscribed successfully"}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			// Initilize test router, environemnt and mock database
+			r, mockDb, mockSql, env, err := utils.SetupTestEnvironment(test.MockDbCall)
+			// Close the mock database at the end of the test
+			defer mockDb.Close()
+
+			// Check for errors during setup
+			require.NoError(t, err)
+
+			// Register handlers
+			r.Use(func(c *gin.Context) {
+				// Add user to context if it exists
+				if test.User.UserID != 0 {
+					c.Set("user", test.User)
+				}
+			})
+			r.PUT("/api/v1/searches/:searchId/subscription", SubscribeToSearch(env))
+			r.DELETE("/api/v1/searches/:searchId/subscription", UnsubscribeFromSearch(env))
+
+			// Create httptest request
+			req, _ := http.NewRequest(test.Method, "/api/v1/searches/1/subscription", nil)
+			w := httptest.NewRecorder()
+
+			// Mock request
+			r.ServeHTTP(w, req)
+
+			// Read response data
+			responseData, _ := io.ReadAll(w.Body)
+
+			// Check response status
+			require.Equal(t, test.StatusCode, w.Code)
+
+			// Handle empty responses
+			response := string(responseData)
+			if response == "" {
+				response = "{}"
+			}
+
+			// Check response body
+			require.JSONEq(t, test.Response, response)
+
+			// Check for any remaining expectations
+			// we make sure that all expectations were met
+			if err := mockSql.ExpectationsWereMet(); err != nil {
+				t.Fatalf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
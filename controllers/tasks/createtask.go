@@ -0,0 +1,131 @@
+package tasks
+
+import (
+	"database/sql"
+	"errors"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/webstradev/rsdb-backend/auth"
+	"github.com/webstradev/rsdb-backend/db"
+	"github.com/webstradev/rsdb-backend/utils"
+)
+
+type taskInput struct {
+	Title      string `json:"title" binding:"required"`
+	Notes      string `json:"notes"`
+	DueDate    string `json:"dueDate" binding:"required"`
+	AssigneeId int64  `json:"assigneeId"`
+	EntityType string `json:"entityType"`
+	EntityId   int64  `json:"entityId"`
+	Status     string `json:"status"`
+}
+
+// task converts the input, tasks are assigned to the given user and open unless stated otherwise. Edits replace the
+// assignee with the current one when it is not given.
+func (input taskInput) task(userId int64) (db.Task, error) {
+	dueDate, err := time.Parse(time.DateOnly, input.DueDate)
+	if err != nil {
+		return db.Task{}, errors.New("Invalid due date, use YYYY-MM-DD")
+	}
+
+	task := db.Task{
+		Title:      input.Title,
+		Notes:      input.Notes,
+		DueDate:    dueDate,
+		AssigneeId: input.AssigneeId,
+		EntityType: sql.NullString{String: input.EntityType, Valid: input.EntityType != ""},
+		EntityId:   sql.NullInt64{Int64: input.EntityId, Valid: input.EntityId != 0},
+		Status:     input.Status,
+	}
+
+	if task.AssigneeId == 0 {
+		task.AssigneeId = userId
+	}
+	if task.Status == "" {
+		task.Status = db.TASK_OPEN
+	}
+
+	return task, task.Validate()
+}
+
+// checkLinkedEntity makes sure the entity a task is linked to exists, it returns the status code to abort with otherwise
+func checkLinkedEntity(env *utils.Environment, task db.Task) (int, error) {
+	if !task.EntityType.Valid {
+		return 0, nil
+	}
+
+	exists, err := env.DB.EntityExists(task.EntityType.String, task.EntityId.Int64)
+	if errors.Is(err, db.ErrInvalidEntityType) {
+		return http.StatusBadRequest, err
+	}
+	if err != nil {
+		return http.StatusInternalServerError, err
+	}
+	if !exists {
+		return http.StatusBadRequest, errors.New("Linked " + task.EntityType.String + " not found")
+	}
+
+	return 0, nil
+}
+
+func CreateTask(env *utils.Environment) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		user, err := auth.GetUserFromContext(c)
+		if err != nil {
+			log.Println(err)
+			c.AbortWithStatus(http.StatusInternalServerError)
+			return
+		}
+
+		input := taskInput{}
+		err = c.ShouldBindJSON(&input)
+		if err != nil {
+			log.Println(err)
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		task, err := input.task(user.UserID)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		status, err := checkLinkedEntity(env, task)
+		if status == http.StatusInternalServerError {
+			log.Println(err)
+			c.AbortWithStatus(status)
+			return
+		}
+		if err != nil {
+			c.AbortWithStatusJSON(status, gin.H{"error": err.Error()})
+			return
+		}
+
+		task.CreatedBy = sql.NullInt64{Int64: user.UserID, Valid: true}
+
+		id, err := env.DB.InsertTask(task)
+		if err != nil {
+			// The assignee is the only reference that comes from the input
+			if db.IsForeignKeyError(err) {
+				c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Assignee not found"})
+				return
+			}
+			log.Println(err)
+			c.AbortWithStatus(http.StatusInternalServerError)
+			return
+		}
+
+		// Let the assignee know, users assigning tasks to themselves are not notified
+		task.ID = id
+		err = env.Inbox.TaskAssigned(task, user.UserID)
+		if err != nil {
+			log.Println(err)
+		}
+
+		c.JSON(http.StatusOK, gin.H{"id": id, "message": "Task created successfully"})
+	}
+}
@@ -0,0 +1,203 @@
+package tasks
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gin-gonic/gin"
+	"github.com/go-sql-driver/mysql"
+	"github.com/stretchr/testify/require"
+	"github.com/webstradev/rsdb-backend/auth"
+	"github.com/webstradev/rsdb-backend/inbox"
+	"github.com/webstradev/rsdb-backend/utils"
+)
+
+func TestCreateTask(t *testing.T) {
+	dueDate := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		Name       string
+		User       auth.TokenData
+		MockDbCall func(sqlmock.Sqlmock)
+		StatusCode int
+		Body       string
+		Response   string
+	}{
+		{
+			"CreateTask - User Missing from Context",
+			auth.TokenData{},
+			nil,
+			http.StatusInternalServerError,
+			`{}`,
+			`{}`,
+		},
+		{
+			"CreateTask - missing required fields",
+			auth.TokenData{UserID: 1},
+			nil,
+			http.StatusBadRequest,
+			`{}`,
+			`{"error":"Key: 'taskInput.Title' Error:Field validation for 'Title' failed on the 'required' tag\nKey: 'taskInput.DueDate' Error:Field validation for 'DueDate' failed on the 'required' tag"}`,
+		},
+		{
+			"CreateTask - invalid due date",
+			auth.TokenData{UserID: 1},
+			nil,
+			http.StatusBadRequest,
+			`{"title":"Call Jane","dueDate":"01-05-2024"}`,
+			`{"error":"Invalid due date, use YYYY-MM-DD"}`,
+		},
+		{
+			"CreateTask - invalid status",
+			auth.TokenData{UserID: 1},
+			nil,
+			http.StatusBadRequest,
+			`{"title":"Call Jane","dueDate":"2024-05-01","status":"later"}`,
+			`{"error":"invalid task status, use open, in_progress, done or cancelled"}`,
+		},
+		{
+			"CreateTask - entity type without id",
+			auth.TokenData{UserID: 1},
+			nil,
+			http.StatusBadRequest,
+			`{"title":"Call Jane","dueDate":"2024-05-01","entityType":"contact"}`,
+			`{"error":"a linked entity needs both an entity type and id"}`,
+		},
+		{
+			"CreateTask - invalid entity type",
+			auth.TokenData{UserID: 1},
+			nil,
+			http.StatusBadRequest,
+			`{"title":"Call Jane","dueDate":"2024-05-01","entityType":"user","entityId":2}`,
+			`{"error":"invalid entity type, use article, project, platform or contact"}`,
+		},
+		{
+			"CreateTask - linked entity not found",
+			auth.TokenData{UserID: 1},
+			func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT COUNT(.+) FROM contacts").WithArgs(3).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+			},
+			http.StatusBadRequest,
+			`{"title":"Call Jane","dueDate":"2024-05-01","entityType":"contact","entityId":3}`,
+			`{"error":"Linked contact not found"}`,
+		},
+		{
+			"CreateTask - sql error on EntityExists",
+			auth.TokenData{UserID: 1},
+			func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT COUNT(.+) FROM contacts").WithArgs(3).WillReturnError(errors.New("test"))
+			},
+			http.StatusInternalServerError,
+			`{"title":"Call Jane","dueDate":"2024-05-01","entityType":"contact","entityId":3}`,
+			`{}`,
+		},
+		{
+			"CreateTask - sql error on InsertTask",
+			auth.TokenData{UserID: 1},
+			func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec("INSERT INTO tasks").WillReturnError(errors.New("test"))
+			},
+			http.StatusInternalServerError,
+			`{"title":"Call Jane","dueDate":"2024-05-01"}`,
+			`{}`,
+		},
+		{
+			"CreateTask - unknown assignee",
+			auth.TokenData{UserID: 1},
+			func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec("INSERT INTO tasks").WillReturnError(&mysql.MySQLError{Number: 1452, Message: "Cannot add or update a child row: a foreign key constraint fails"})
+			},
+			http.StatusBadRequest,
+			`{"title":"Call Jane","dueDate":"2024-05-01","assigneeId":9}`,
+			`{"error":"Assignee not found"}`,
+		},
+		{
+			"CreateTask - Valid Request assigned to the current user",
+			auth.TokenData{UserID: 1},
+			func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec("INSERT INTO tasks").
+					WithArgs("Call Jane", "", dueDate, 1, nil, nil, "open", "open", 1).
+					WillReturnResult(sqlmock.NewResult(4, 1))
+			},
+			http.StatusOK,
+			`{"title":"Call Jane","dueDate":"2024-05-01"}`,
+			`{"id":4,"message":"Task created successfully"}`,
+		},
+		{
+			"CreateTask - Valid Request linked to a contact",
+			auth.TokenData{UserID: 1},
+			func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT COUNT(.+) FROM contacts").WithArgs(3).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+				mock.ExpectExec("INSERT INTO tasks").
+					WithArgs("Call Jane", "About the launch", dueDate, 2, "contact", 3, "in_progress", "in_progress", 1).
+					WillReturnResult(sqlmock.NewResult(5, 1))
+				mock.ExpectExec("INSERT INTO notifications").
+					WithArgs(2, "task_assigned", "task", 5, 1, `You were assigned the task "Call Jane"`).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+			},
+			http.StatusOK,
+			`{"title":"Call Jane","notes":"About the launch","dueDate":"2024-05-01","assigneeId":2,"entityType":"contact","entityId":3,"status":"in_progress"}`,
+			`{"id":5,"message":"Task created successfully"}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			// Initilize test router, environemnt and mock database
+			r, mockDb, mockSql, env, err := utils.SetupTestEnvironment(test.MockDbCall)
+			// Close the mock database at the end of the test
+			defer mockDb.Close()
+
+			// Check for errors during setup
+			require.NoError(t, err)
+
+			// Assignees are notified in their inbox
+			env.Inbox = inbox.New(env.DB)
+
+			// Register handler
+			r.POST("/api/v1/tasks", func(c *gin.Context) {
+				// Add user to context if it exists
+				if test.User.UserID != 0 {
+					c.Set("user", test.User)
+				}
+
+				// Call handler
+				CreateTask(env)(c)
+			})
+
+			// Create httptest request
+			req, _ := http.NewRequest("POST", "/api/v1/tasks", strings.NewReader(test.Body))
+			w := httptest.NewRecorder()
+
+			// Mock request
+			r.ServeHTTP(w, req)
+
+			// Read response data
+			responseData, _ := io.ReadAll(w.Body)
+
+			// Check response status
+			require.Equal(t, test.StatusCode, w.Code)
+
+			// Handle empty responses
+			response := string(responseData)
+			if response == "" {
+				response = "{}"
+			}
+
+			// Check response body
+			require.JSONEq(t, test.Response, response)
+
+			// Check for any remaining expectations
+			// we make sure that all expectations were met
+			if err := mockSql.ExpectationsWereMet(); err != nil {
+				t.Fatalf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
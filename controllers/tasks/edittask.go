@@ -0,0 +1,98 @@
+package tasks
+
+import (
+	"database/sql"
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/webstradev/rsdb-backend/auth"
+	"github.com/webstradev/rsdb-backend/db"
+	"github.com/webstradev/rsdb-backend/utils"
+)
+
+func EditTask(env *utils.Environment) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		user, err := auth.GetUserFromContext(c)
+		if err != nil {
+			log.Println(err)
+			c.AbortWithStatus(http.StatusInternalServerError)
+			return
+		}
+
+		idString := c.Param("taskId")
+		id, err := strconv.ParseInt(idString, 10, 64)
+		if err != nil {
+			log.Println(err)
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Invalid ID"})
+			return
+		}
+
+		input := taskInput{}
+		err = c.ShouldBindJSON(&input)
+		if err != nil {
+			log.Println(err)
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		task, err := input.task(user.UserID)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		task.ID = id
+
+		// Make sure the task exists
+		previous, err := env.DB.GetTask(id)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				c.AbortWithStatus(http.StatusNotFound)
+				return
+			}
+			log.Println(err)
+			c.AbortWithStatus(http.StatusInternalServerError)
+			return
+		}
+
+		// Editing a task without an assignee keeps it with the current assignee instead of taking it over
+		if input.AssigneeId == 0 {
+			task.AssigneeId = previous.AssigneeId
+		}
+
+		status, err := checkLinkedEntity(env, task)
+		if status == http.StatusInternalServerError {
+			log.Println(err)
+			c.AbortWithStatus(status)
+			return
+		}
+		if err != nil {
+			c.AbortWithStatusJSON(status, gin.H{"error": err.Error()})
+			return
+		}
+
+		err = env.DB.UpdateTask(task)
+		if err != nil {
+			// The assignee is the only reference that comes from the input
+			if db.IsForeignKeyError(err) {
+				c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Assignee not found"})
+				return
+			}
+			log.Println(err)
+			c.AbortWithStatus(http.StatusInternalServerError)
+			return
+		}
+
+		// Let the new assignee know when the task was reassigned
+		if previous.AssigneeId != task.AssigneeId {
+			err = env.Inbox.TaskAssigned(task, user.UserID)
+			if err != nil {
+				log.Println(err)
+			}
+		}
+
+		c.JSON(http.StatusOK, gin.H{"message": "Task updated successfully"})
+	}
+}
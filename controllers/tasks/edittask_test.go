@@ -0,0 +1,217 @@
+package tasks
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gin-gonic/gin"
+	"github.com/go-sql-driver/mysql"
+	"github.com/stretchr/testify/require"
+	"github.com/webstradev/rsdb-backend/auth"
+	"github.com/webstradev/rsdb-backend/inbox"
+	"github.com/webstradev/rsdb-backend/utils"
+)
+
+// Columns of the task query
+var taskColumns = []string{"id", "title", "notes", "due_date", "assignee_id", "entity_type", "entity_id", "status", "completed_at", "created_by", "assignee_email"}
+
+func TestEditTask(t *testing.T) {
+	dueDate := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+
+	expectExisting := func(mock sqlmock.Sqlmock) {
+		rows := sqlmock.NewRows(taskColumns).AddRow(1, "Call Jane", "", dueDate, 1, nil, nil, "open", nil, 1, "user@example.com")
+		mock.ExpectQuery("SELECT t.(.+) FROM tasks t").WithArgs(1).WillReturnRows(rows)
+	}
+
+	tests := []struct {
+		Name       string
+		IdString   string
+		User       auth.TokenData
+		MockDbCall func(sqlmock.Sqlmock)
+		StatusCode int
+		Body       string
+		Response   string
+	}{
+		{
+			"EditTask - User Missing from Context",
+			"1",
+			auth.TokenData{},
+			nil,
+			http.StatusInternalServerError,
+			`{}`,
+			`{}`,
+		},
+		{
+			"EditTask - non int id",
+			"notanint",
+			auth.TokenData{UserID: 1},
+			nil,
+			http.StatusBadRequest,
+			`{}`,
+			`{"error":"Invalid ID"}`,
+		},
+		{
+			"EditTask - invalid status",
+			"1",
+			auth.TokenData{UserID: 1},
+			nil,
+			http.StatusBadRequest,
+			`{"title":"Call Jane","dueDate":"2024-05-01","status":"later"}`,
+			`{"error":"invalid task status, use open, in_progress, done or cancelled"}`,
+		},
+		{
+			"EditTask - task not found",
+			"1",
+			auth.TokenData{UserID: 1},
+			func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT t.(.+) FROM tasks t").WithArgs(1).WillReturnRows(sqlmock.NewRows(taskColumns))
+			},
+			http.StatusNotFound,
+			`{"title":"Call Jane","dueDate":"2024-05-01","status":"done"}`,
+			`{}`,
+		},
+		{
+			"EditTask - linked entity not found",
+			"1",
+			auth.TokenData{UserID: 1},
+			func(mock sqlmock.Sqlmock) {
+				expectExisting(mock)
+				mock.ExpectQuery("SELECT COUNT(.+) FROM platforms").WithArgs(2).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+			},
+			http.StatusBadRequest,
+			`{"title":"Call Jane","dueDate":"2024-05-01","entityType":"platform","entityId":2}`,
+			`{"error":"Linked platform not found"}`,
+		},
+		{
+			"EditTask - sql error on UpdateTask",
+			"1",
+			auth.TokenData{UserID: 1},
+			func(mock sqlmock.Sqlmock) {
+				expectExisting(mock)
+				mock.ExpectExec("UPDATE tasks").WillReturnError(errors.New("test"))
+			},
+			http.StatusInternalServerError,
+			`{"title":"Call Jane","dueDate":"2024-05-01","status":"done"}`,
+			`{}`,
+		},
+		{
+			"EditTask - unknown assignee",
+			"1",
+			auth.TokenData{UserID: 1},
+			func(mock sqlmock.Sqlmock) {
+				expectExisting(mock)
+				mock.ExpectExec("UPDATE tasks").WillReturnError(&mysql.MySQLError{Number: 1452, Message: "Cannot add or update a child row: a foreign key constraint fails"})
+			},
+			http.StatusBadRequest,
+			`{"title":"Call Jane","dueDate":"2024-05-01","assigneeId":9}`,
+			`{"error":"Assignee not found"}`,
+		},
+		{
+			"EditTask - Valid Request",
+			"1",
+			auth.TokenData{UserID: 1},
+			func(mock sqlmock.Sqlmock) {
+				expectExisting(mock)
+				mock.ExpectExec("UPDATE tasks").
+					WithArgs("Call Jane", "Left a message", dueDate, 1, nil, nil, "done", "done", 1).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			http.StatusOK,
+			`{"title":"Call Jane","notes":"Left a message","dueDate":"2024-05-01","status":"done"}`,
+			`{"message":"Task updated successfully"}`,
+		},
+		{
+			"EditTask - Valid Request keeps the assignee",
+			"1",
+			auth.TokenData{UserID: 1},
+			func(mock sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows(taskColumns).AddRow(1, "Call Jane", "", dueDate, 3, nil, nil, "open", nil, 1, "other@example.com")
+				mock.ExpectQuery("SELECT t.(.+) FROM tasks t").WithArgs(1).WillReturnRows(rows)
+				mock.ExpectExec("UPDATE tasks").
+					WithArgs("Call Jane", "", dueDate, 3, nil, nil, "open", "open", 1).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			http.StatusOK,
+			`{"title":"Call Jane","dueDate":"2024-05-01"}`,
+			`{"message":"Task updated successfully"}`,
+		},
+		{
+			"EditTask - Valid Request reassigned",
+			"1",
+			auth.TokenData{UserID: 1},
+			func(mock sqlmock.Sqlmock) {
+				expectExisting(mock)
+				mock.ExpectExec("UPDATE tasks").
+					WithArgs("Call Jane", "", dueDate, 2, nil, nil, "open", "open", 1).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("INSERT INTO notifications").
+					WithArgs(2, "task_assigned", "task", 1, 1, `You were assigned the task "Call Jane"`).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+			},
+			http.StatusOK,
+			`{"title":"Call Jane","dueDate":"2024-05-01","assigneeId":2}`,
+			`{"message":"Task updated successfully"}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			// Initilize test router, environemnt and mock database
+			r, mockDb, mockSql, env, err := utils.SetupTestEnvironment(test.MockDbCall)
+			// Close the mock database at the end of the test
+			defer mockDb.Close()
+
+			// Check for errors during setup
+			require.NoError(t, err)
+
+			// Assignees are notified in their inbox
+			env.Inbox = inbox.New(env.DB)
+
+			// Register handler
+			r.PUT("/api/v1/tasks/:taskId", func(c *gin.Context) {
+				// Add user to context if it exists
+				if test.User.UserID != 0 {
+					c.Set("user", test.User)
+				}
+
+				// Call handler
+				EditTask(env)(c)
+			})
+
+			// Create httptest request
+			req, _ := http.NewRequest("PUT", fmt.Sprintf("/api/v1/tasks/%s", test.IdString), strings.NewReader(test.Body))
+			w := httptest.NewRecorder()
+
+			// Mock request
+			r.ServeHTTP(w, req)
+
+			// Read response data
+			responseData, _ := io.ReadAll(w.Body)
+
+			// Check response status
+			require.Equal(t, test.StatusCode, w.Code)
+
+			// Handle empty responses
+			response := string(responseData)
+			if response == "" {
+				response = "{}"
+			}
+
+			// Check response body
+			require.JSONEq(t, test.Response, response)
+
+			// Check for any remaining expectations
+			// we make sure that all expectations were met
+			if err := mockSql.ExpectationsWereMet(); err != nil {
+				t.Fatalf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
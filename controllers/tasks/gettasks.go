@@ -0,0 +1,57 @@
+package tasks
+
+import (
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/webstradev/rsdb-backend/auth"
+	"github.com/webstradev/rsdb-backend/db"
+	"github.com/webstradev/rsdb-backend/utils"
+)
+
+// GetMyTasks lists the tasks assigned to the current user, by default the ones that still need to be done
+func GetMyTasks(env *utils.Environment) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		user, err := auth.GetUserFromContext(c)
+		if err != nil {
+			log.Println(err)
+			c.AbortWithStatus(http.StatusInternalServerError)
+			return
+		}
+
+		status := c.Query("status")
+		if status != "" {
+			filter := db.Task{Status: status}
+			err = filter.Validate()
+			if err != nil {
+				c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+				return
+			}
+		}
+
+		tasks, err := env.DB.GetTasksForAssignee(user.UserID, status)
+		if err != nil {
+			log.Println(err)
+			c.AbortWithStatus(http.StatusInternalServerError)
+			return
+		}
+
+		c.JSON(http.StatusOK, tasks)
+	}
+}
+
+// GetOverdueTasks lists the tasks of all users that are past their due date and not done yet
+func GetOverdueTasks(env *utils.Environment) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		tasks, err := env.DB.GetOverdueTasks(time.Now())
+		if err != nil {
+			log.Println(err)
+			c.AbortWithStatus(http.StatusInternalServerError)
+			return
+		}
+
+		c.JSON(http.StatusOK, tasks)
+	}
+}
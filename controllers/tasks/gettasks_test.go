@@ -0,0 +1,152 @@
+package tasks
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+	"github.com/webstradev/rsdb-backend/auth"
+	"github.com/webstradev/rsdb-backend/utils"
+)
+
+func TestGetTasks(t *testing.T) {
+	dueDate := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	task := `{"id":1,"createdAt":"0001-01-01T00:00:00Z","modifiedAt":"0001-01-01T00:00:00Z","deletedAt":{"Time":"0001-01-01T00:00:00Z","Valid":false},
+		"title":"Call Jane","notes":"","dueDate":"2024-05-01T00:00:00Z","assigneeId":1,"assigneeEmail":"user@example.com",
+		"entityType":{"String":"","Valid":false},"entityId":{"Int64":0,"Valid":false},"status":"open",
+		"completedAt":{"Time":"0001-01-01T00:00:00Z","Valid":false},"createdBy":{"Int64":1,"Valid":true}}`
+
+	tests := []struct {
+		Name       string
+		Path       string
+		User       auth.TokenData
+		MockDbCall func(sqlmock.Sqlmock)
+		StatusCode int
+		Response   string
+	}{
+		{
+			"GetMyTasks - User Missing from Context",
+			"/api/v1/tasks/mine",
+			auth.TokenData{},
+			nil,
+			http.StatusInternalServerError,
+			`{}`,
+		},
+		{
+			"GetMyTasks - invalid status",
+			"/api/v1/tasks/mine?status=later",
+			auth.TokenData{UserID: 1},
+			nil,
+			http.StatusBadRequest,
+			`{"error":"invalid task status, use open, in_progress, done or cancelled"}`,
+		},
+		{
+			"GetMyTasks - sql error",
+			"/api/v1/tasks/mine",
+			auth.TokenData{UserID: 1},
+			func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT t.(.+) FROM tasks t").WithArgs(1).WillReturnError(errors.New("test"))
+			},
+			http.StatusInternalServerError,
+			`{}`,
+		},
+		{
+			"GetMyTasks - open tasks",
+			"/api/v1/tasks/mine",
+			auth.TokenData{UserID: 1},
+			func(mock sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows(taskColumns).AddRow(1, "Call Jane", "", dueDate, 1, nil, nil, "open", nil, 1, "user@example.com")
+				mock.ExpectQuery("SELECT t.(.+) FROM tasks t (.+) t.status IN \\('open', 'in_progress'\\)").WithArgs(1).WillReturnRows(rows)
+			},
+			http.StatusOK,
+			`[` + task + `]`,
+		},
+		{
+			"GetMyTasks - done tasks",
+			"/api/v1/tasks/mine?status=done",
+			auth.TokenData{UserID: 1},
+			func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT t.(.+) FROM tasks t").WithArgs(1, "done").WillReturnRows(sqlmock.NewRows(taskColumns))
+			},
+			http.StatusOK,
+			`[]`,
+		},
+		{
+			"GetOverdueTasks - sql error",
+			"/api/v1/tasks/overdue",
+			auth.TokenData{UserID: 1},
+			func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT t.(.+) FROM tasks t").WithArgs(time.Now().Format(time.DateOnly)).WillReturnError(errors.New("test"))
+			},
+			http.StatusInternalServerError,
+			`{}`,
+		},
+		{
+			"GetOverdueTasks - Valid Request",
+			"/api/v1/tasks/overdue",
+			auth.TokenData{UserID: 1},
+			func(mock sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows(taskColumns).AddRow(1, "Call Jane", "", dueDate, 1, nil, nil, "open", nil, 1, "user@example.com")
+				mock.ExpectQuery("SELECT t.(.+) FROM tasks t (.+) AND t.due_date < ?").WithArgs(time.Now().Format(time.DateOnly)).WillReturnRows(rows)
+			},
+			http.StatusOK,
+			`[` + task + `]`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			// Initilize test router, environemnt and mock database
+			r, mockDb, mockSql, env, err := utils.SetupTestEnvironment(test.MockDbCall)
+			// Close the mock database at the end of the test
+			defer mockDb.Close()
+
+			// Check for errors during setup
+			require.NoError(t, err)
+
+			// Register handlers
+			r.Use(func(c *gin.Context) {
+				// Add user to context if it exists
+				if test.User.UserID != 0 {
+					c.Set("user", test.User)
+				}
+			})
+			r.GET("/api/v1/tasks/mine", GetMyTasks(env))
+			r.GET("/api/v1/tasks/overdue", GetOverdueTasks(env))
+
+			// Create httptest request
+			req, _ := http.NewRequest("GET", test.Path, nil)
+			w := httptest.NewRecorder()
+
+			// Mock request
+			r.ServeHTTP(w, req)
+
+			// Read response data
+			responseData, _ := io.ReadAll(w.Body)
+
+			// Check response status
+			require.Equal(t, test.StatusCode, w.Code)
+
+			// Handle empty responses
+			response := string(responseData)
+			if response == "" {
+				response = "{}"
+			}
+
+			// Check response body
+			require.JSONEq(t, test.Response, response)
+
+			// Check for any remaining expectations
+			// we make sure that all expectations were met
+			if err := mockSql.ExpectationsWereMet(); err != nil {
+				t.Fatalf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
@@ -95,6 +95,27 @@ func Register(env *utils.Environment) gin.HandlerFunc {
 			return
 		}
 
+		// Let the user that created the token know their invite was accepted
+		err = notifyInviter(env, hashedToken, input.Email)
+		if err != nil {
+			log.Println(err)
+		}
+
 		c.Status(http.StatusAccepted)
 	}
 }
+
+// notifyInviter sends the user that created the registration token a notification about the user that registered
+func notifyInviter(env *utils.Environment, hashedToken, email string) error {
+	invitedBy, err := env.DB.GetTokenCreator(hashedToken)
+	if err != nil || !invitedBy.Valid {
+		return err
+	}
+
+	user, err := env.DB.GetUserWithEmail(email)
+	if err != nil {
+		return err
+	}
+
+	return env.Inbox.InviteAccepted(invitedBy.Int64, *user)
+}
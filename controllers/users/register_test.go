@@ -11,6 +11,7 @@ import (
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/stretchr/testify/require"
+	"github.com/webstradev/rsdb-backend/inbox"
 	"github.com/webstradev/rsdb-backend/mocks"
 	"github.com/webstradev/rsdb-backend/utils"
 )
@@ -151,6 +152,23 @@ func TestRegister(t *testing.T) {
 				mock.ExpectQuery("SELECT COUNT(.+) FROM users").WillReturnRows(sqlmock.NewRows([]string{"COUNT(*)"}).AddRow(0))
 				mock.ExpectExec("UPDATE users_tokens").WillReturnResult(sqlmock.NewResult(1, 1))
 				mock.ExpectExec("INSERT INTO users").WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectQuery("SELECT created_by FROM users_tokens").WillReturnRows(sqlmock.NewRows([]string{"created_by"}).AddRow(nil))
+			},
+			http.StatusAccepted,
+			`{"email": "test","password":"test"}`,
+			`{}`,
+		},
+		{
+			"Register - Valid Request, inviter notified",
+			"validtoken",
+			func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT COUNT(.+) FROM users_tokens").WillReturnRows(sqlmock.NewRows([]string{"COUNT(*)"}).AddRow(1))
+				mock.ExpectQuery("SELECT COUNT(.+) FROM users").WillReturnRows(sqlmock.NewRows([]string{"COUNT(*)"}).AddRow(0))
+				mock.ExpectExec("UPDATE users_tokens").WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec("INSERT INTO users").WillReturnResult(sqlmock.NewResult(5, 1))
+				mock.ExpectQuery("SELECT created_by FROM users_tokens").WillReturnRows(sqlmock.NewRows([]string{"created_by"}).AddRow(2))
+				mock.ExpectQuery("SELECT (.+) FROM users WHERE email = ?").WithArgs("test").WillReturnRows(sqlmock.NewRows([]string{"id", "email", "role"}).AddRow(5, "test", "user"))
+				mock.ExpectExec("INSERT INTO notifications").WithArgs(2, "invite_accepted", nil, nil, 5, "test accepted your invite").WillReturnResult(sqlmock.NewResult(1, 1))
 			},
 			http.StatusAccepted,
 			`{"email": "test","password":"test"}`,
@@ -166,6 +184,7 @@ func TestRegister(t *testing.T) {
 			defer mockDb.Close()
 
 			env.AuthService = mocks.NewMockAuthService()
+			env.Inbox = inbox.New(env.DB)
 
 			// Check for errors during setup
 			require.NoError(t, err)
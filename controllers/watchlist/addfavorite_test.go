@@ -0,0 +1,155 @@
+package watchlist
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+	"github.com/webstradev/rsdb-backend/auth"
+	"github.com/webstradev/rsdb-backend/db"
+	"github.com/webstradev/rsdb-backend/utils"
+)
+
+var (
+	admin = auth.TokenData{UserID: 1, Role: auth.AdminRole}
+	user  = auth.TokenData{UserID: 2, Role: auth.UserRole}
+)
+
+func TestAddFavorite(t *testing.T) {
+	tests := []struct {
+		Name       string
+		IdString   string
+		User       auth.TokenData
+		MockDbCall func(sqlmock.Sqlmock)
+		StatusCode int
+		Response   string
+	}{
+		{
+			"AddFavorite - User Missing from Context",
+			"3",
+			auth.TokenData{},
+			nil,
+			http.StatusInternalServerError,
+			`{}`,
+		},
+		{
+			"AddFavorite - non int id",
+			"notanint",
+			user,
+			nil,
+			http.StatusBadRequest,
+			`{"error":"Invalid ID"}`,
+		},
+		{
+			"AddFavorite - platform not found",
+			"3",
+			user,
+			func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT privacy = (.+) FROM platforms").WithArgs(db.PRIVACY_PRIVATE, 3).WillReturnError(sql.ErrNoRows)
+			},
+			http.StatusNotFound,
+			`{"error":"Record not found"}`,
+		},
+		{
+			"AddFavorite - private platform",
+			"3",
+			user,
+			func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT privacy = (.+) FROM platforms").WithArgs(db.PRIVACY_PRIVATE, 3).WillReturnRows(sqlmock.NewRows([]string{"private"}).AddRow(true))
+			},
+			http.StatusForbidden,
+			`{}`,
+		},
+		{
+			"AddFavorite - sql error",
+			"3",
+			user,
+			func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT privacy = (.+) FROM platforms").WithArgs(db.PRIVACY_PRIVATE, 3).WillReturnRows(sqlmock.NewRows([]string{"private"}).AddRow(false))
+				mock.ExpectExec("INSERT IGNORE INTO favorites").WithArgs(2, "platform", 3).WillReturnError(errors.New("test"))
+			},
+			http.StatusInternalServerError,
+			`{}`,
+		},
+		{
+			"AddFavorite - Valid Request",
+			"3",
+			user,
+			func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT privacy = (.+) FROM platforms").WithArgs(db.PRIVACY_PRIVATE, 3).WillReturnRows(sqlmock.NewRows([]string{"private"}).AddRow(false))
+				mock.ExpectExec("INSERT IGNORE INTO favorites").WithArgs(2, "platform", 3).WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			http.StatusOK,
+			`{"message":"Added to watchlist successfully"}`,
+		},
+		{
+			"AddFavorite - Valid Request, private platform as admin",
+			"3",
+			admin,
+			func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT privacy = (.+) FROM platforms").WithArgs(db.PRIVACY_PRIVATE, 3).WillReturnRows(sqlmock.NewRows([]string{"private"}).AddRow(true))
+				mock.ExpectExec("INSERT IGNORE INTO favorites").WithArgs(1, "platform", 3).WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+			http.StatusOK,
+			`{"message":"Added to watchlist successfully"}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			// Initilize test router, environemnt and mock database
+			r, mockDb, mockSql, env, err := utils.SetupTestEnvironment(test.MockDbCall)
+			// Close the mock database at the end of the test
+			defer mockDb.Close()
+
+			// Check for errors during setup
+			require.NoError(t, err)
+
+			// Register handler
+			r.PUT("/api/v1/platforms/:platformId/favorite", func(c *gin.Context) {
+				// Add user to context if it exists
+				if test.User.UserID != 0 {
+					c.Set("user", test.User)
+				}
+
+				// Call handler
+				AddFavorite(env, db.PLATFORM_ENTITY, "platformId")(c)
+			})
+
+			// Create httptest request
+			req, _ := http.NewRequest("PUT", fmt.Sprintf("/api/v1/platforms/%s/favorite", test.IdString), nil)
+			w := httptest.NewRecorder()
+
+			// Mock request
+			r.ServeHTTP(w, req)
+
+			// Read response data
+			responseData, _ := io.ReadAll(w.Body)
+
+			// Check response status
+			require.Equal(t, test.StatusCode, w.Code)
+
+			// Handle empty responses
+			response := string(responseData)
+			if response == "" {
+				response = "{}"
+			}
+
+			// Check response body
+			require.JSONEq(t, test.Response, response)
+
+			// Check for any remaining expectations
+			// we make sure that all expectations were met
+			if err := mockSql.ExpectationsWereMet(); err != nil {
+				t.Fatalf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,84 @@
+package watchlist
+
+import (
+	"database/sql"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/webstradev/rsdb-backend/auth"
+	"github.com/webstradev/rsdb-backend/utils"
+)
+
+// GetWatchlist lists the platforms, contacts and projects on the watchlist of the current user
+func GetWatchlist(env *utils.Environment) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		user, err := auth.GetUserFromContext(c)
+		if err != nil {
+			log.Println(err)
+			c.AbortWithStatus(http.StatusInternalServerError)
+			return
+		}
+
+		favorites, err := env.DB.GetFavorites(user.UserID, user.IsAdmin())
+		if err != nil {
+			log.Println(err)
+			c.AbortWithStatus(http.StatusInternalServerError)
+			return
+		}
+
+		c.JSON(http.StatusOK, favorites)
+	}
+}
+
+// GetWatchlistChanges lists the watched records that changed since the last visit of the current user and records
+// this visit. With ?since= (an RFC 3339 time) the changes since then are listed instead and the visit isn't recorded.
+func GetWatchlistChanges(env *utils.Environment) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		user, err := auth.GetUserFromContext(c)
+		if err != nil {
+			log.Println(err)
+			c.AbortWithStatus(http.StatusInternalServerError)
+			return
+		}
+
+		now := time.Now()
+		since := sql.NullTime{}
+		lastVisit := c.Query("since") == ""
+
+		if lastVisit {
+			since, err = env.DB.GetWatchlistVisit(user.UserID)
+			if err != nil {
+				log.Println(err)
+				c.AbortWithStatus(http.StatusInternalServerError)
+				return
+			}
+		} else {
+			since.Time, err = time.Parse(time.RFC3339, c.Query("since"))
+			if err != nil {
+				c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Invalid since, use an RFC 3339 time"})
+				return
+			}
+			since.Valid = true
+		}
+
+		changes, err := env.DB.GetWatchlistChanges(user.UserID, since.Time, user.IsAdmin())
+		if err != nil {
+			log.Println(err)
+			c.AbortWithStatus(http.StatusInternalServerError)
+			return
+		}
+
+		if lastVisit {
+			err = env.DB.SaveWatchlistVisit(user.UserID, now)
+			if err != nil {
+				log.Println(err)
+				c.AbortWithStatus(http.StatusInternalServerError)
+				return
+			}
+		}
+
+		c.JSON(http.StatusOK, gin.H{"since": since, "changes": changes})
+	}
+}
@@ -0,0 +1,214 @@
+package watchlist
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+	"github.com/webstradev/rsdb-backend/auth"
+	"github.com/webstradev/rsdb-backend/utils"
+)
+
+var favoriteColumns = []string{"entity_type", "entity_id", "name", "modified_at", "deleted_at", "favorited_at"}
+
+func TestGetWatchlist(t *testing.T) {
+	favorited := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	modified := time.Date(2024, 5, 3, 10, 0, 0, 0, time.UTC)
+	visited := time.Date(2024, 5, 2, 10, 0, 0, 0, time.UTC)
+
+	favorite := `{"entityType":"platform","entityId":3,"name":"Variety","modifiedAt":"2024-05-03T10:00:00Z",
+		"deletedAt":{"Time":"0001-01-01T00:00:00Z","Valid":false},"favoritedAt":"2024-05-01T10:00:00Z"}`
+	change := `{"entityType":"project","entityId":4,"name":"The Film","modifiedAt":"2024-05-03T10:00:00Z",
+		"deletedAt":{"Time":"0001-01-01T00:00:00Z","Valid":false},"favoritedAt":"2024-05-01T10:00:00Z","newComments":2}`
+
+	tests := []struct {
+		Name       string
+		Path       string
+		User       auth.TokenData
+		MockDbCall func(sqlmock.Sqlmock)
+		StatusCode int
+		Response   string
+	}{
+		{
+			"GetWatchlist - User Missing from Context",
+			"/api/v1/watchlist",
+			auth.TokenData{},
+			nil,
+			http.StatusInternalServerError,
+			`{}`,
+		},
+		{
+			"GetWatchlist - sql error",
+			"/api/v1/watchlist",
+			user,
+			func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT w.(.+) FROM favorites f").WithArgs(2, "private", 2, "private", "private", 2).WillReturnError(errors.New("test"))
+			},
+			http.StatusInternalServerError,
+			`{}`,
+		},
+		{
+			"GetWatchlist - Valid Request",
+			"/api/v1/watchlist",
+			user,
+			func(mock sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows(favoriteColumns).AddRow("platform", 3, "Variety", modified, nil, favorited)
+				mock.ExpectQuery("SELECT w.(.+) FROM favorites f (.+) WHERE w.deleted_at IS NULL").WithArgs(2, "private", 2, "private", "private", 2).WillReturnRows(rows)
+			},
+			http.StatusOK,
+			`[` + favorite + `]`,
+		},
+		{
+			"GetWatchlist - Valid Request as admin includes private records",
+			"/api/v1/watchlist",
+			admin,
+			func(mock sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows(favoriteColumns).AddRow("platform", 3, "Variety", modified, nil, favorited)
+				mock.ExpectQuery("SELECT w.(.+) FROM favorites f (.+) WHERE w.deleted_at IS NULL").WithArgs(1, 1, 1).WillReturnRows(rows)
+			},
+			http.StatusOK,
+			`[` + favorite + `]`,
+		},
+		{
+			"GetWatchlistChanges - User Missing from Context",
+			"/api/v1/watchlist/changes",
+			auth.TokenData{},
+			nil,
+			http.StatusInternalServerError,
+			`{}`,
+		},
+		{
+			"GetWatchlistChanges - invalid since",
+			"/api/v1/watchlist/changes?since=yesterday",
+			user,
+			nil,
+			http.StatusBadRequest,
+			`{"error":"Invalid since, use an RFC 3339 time"}`,
+		},
+		{
+			"GetWatchlistChanges - sql error on GetWatchlistVisit",
+			"/api/v1/watchlist/changes",
+			user,
+			func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT MAX(.+) FROM watchlist_visits").WithArgs(2).WillReturnError(errors.New("test"))
+			},
+			http.StatusInternalServerError,
+			`{}`,
+		},
+		{
+			"GetWatchlistChanges - sql error on GetWatchlistChanges",
+			"/api/v1/watchlist/changes",
+			user,
+			func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT MAX(.+) FROM watchlist_visits").WithArgs(2).WillReturnRows(sqlmock.NewRows([]string{"visited_at"}).AddRow(visited))
+				mock.ExpectQuery("SELECT ch.(.+) FROM favorites f").WithArgs(visited, 2, "private", 2, "private", "private", 2, visited).WillReturnError(errors.New("test"))
+			},
+			http.StatusInternalServerError,
+			`{}`,
+		},
+		{
+			"GetWatchlistChanges - sql error on SaveWatchlistVisit",
+			"/api/v1/watchlist/changes",
+			user,
+			func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT MAX(.+) FROM watchlist_visits").WithArgs(2).WillReturnRows(sqlmock.NewRows([]string{"visited_at"}).AddRow(visited))
+				mock.ExpectQuery("SELECT ch.(.+) FROM favorites f").WithArgs(visited, 2, "private", 2, "private", "private", 2, visited).WillReturnRows(sqlmock.NewRows(append(favoriteColumns, "new_comments")))
+				mock.ExpectExec("INSERT INTO watchlist_visits").WithArgs(2, sqlmock.AnyArg()).WillReturnError(errors.New("test"))
+			},
+			http.StatusInternalServerError,
+			`{}`,
+		},
+		{
+			"GetWatchlistChanges - Valid Request since last visit",
+			"/api/v1/watchlist/changes",
+			user,
+			func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT MAX(.+) FROM watchlist_visits").WithArgs(2).WillReturnRows(sqlmock.NewRows([]string{"visited_at"}).AddRow(visited))
+				rows := sqlmock.NewRows(append(favoriteColumns, "new_comments")).AddRow("project", 4, "The Film", modified, nil, favorited, 2)
+				mock.ExpectQuery("SELECT ch.(.+) FROM favorites f").WithArgs(visited, 2, "private", 2, "private", "private", 2, visited).WillReturnRows(rows)
+				mock.ExpectExec("INSERT INTO watchlist_visits").WithArgs(2, sqlmock.AnyArg()).WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			http.StatusOK,
+			`{"since":{"Time":"2024-05-02T10:00:00Z","Valid":true},"changes":[` + change + `]}`,
+		},
+		{
+			"GetWatchlistChanges - Valid Request first visit",
+			"/api/v1/watchlist/changes",
+			user,
+			func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT MAX(.+) FROM watchlist_visits").WithArgs(2).WillReturnRows(sqlmock.NewRows([]string{"visited_at"}).AddRow(nil))
+				mock.ExpectQuery("SELECT ch.(.+) FROM favorites f").WithArgs(time.Time{}, 2, "private", 2, "private", "private", 2, time.Time{}).WillReturnRows(sqlmock.NewRows(append(favoriteColumns, "new_comments")))
+				mock.ExpectExec("INSERT INTO watchlist_visits").WithArgs(2, sqlmock.AnyArg()).WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			http.StatusOK,
+			`{"since":{"Time":"0001-01-01T00:00:00Z","Valid":false},"changes":[]}`,
+		},
+		{
+			"GetWatchlistChanges - Valid Request with since",
+			"/api/v1/watchlist/changes?since=2024-05-02T10:00:00Z",
+			user,
+			func(mock sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows(append(favoriteColumns, "new_comments")).AddRow("project", 4, "The Film", modified, nil, favorited, 2)
+				mock.ExpectQuery("SELECT ch.(.+) FROM favorites f").WithArgs(visited, 2, "private", 2, "private", "private", 2, visited).WillReturnRows(rows)
+			},
+			http.StatusOK,
+			`{"since":{"Time":"2024-05-02T10:00:00Z","Valid":true},"changes":[` + change + `]}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			// Initilize test router, environemnt and mock database
+			r, mockDb, mockSql, env, err := utils.SetupTestEnvironment(test.MockDbCall)
+			// Close the mock database at the end of the test
+			defer mockDb.Close()
+
+			// Check for errors during setup
+			require.NoError(t, err)
+
+			// Register handlers
+			r.Use(func(c *gin.Context) {
+				// Add user to context if it exists
+				if test.User.UserID != 0 {
+					c.Set("user", test.User)
+				}
+			})
+			r.GET("/api/v1/watchlist", GetWatchlist(env))
+			r.GET("/api/v1/watchlist/changes", GetWatchlistChanges(env))
+
+			// Create httptest request
+			req, _ := http.NewRequest("GET", test.Path, nil)
+			w := httptest.NewRecorder()
+
+			// Mock request
+			r.ServeHTTP(w, req)
+
+			// Read response data
+			responseData, _ := io.ReadAll(w.Body)
+
+			// Check response status
+			require.Equal(t, test.StatusCode, w.Code)
+
+			// Handle empty responses
+			response := string(responseData)
+			if response == "" {
+				response = "{}"
+			}
+
+			// Check response body
+			require.JSONEq(t, test.Response, response)
+
+			// Check for any remaining expectations
+			// we make sure that all expectations were met
+			if err := mockSql.ExpectationsWereMet(); err != nil {
+				t.Fatalf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
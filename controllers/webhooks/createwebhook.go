@@ -0,0 +1,63 @@
+package webhooks
+
+import (
+	"database/sql"
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/webstradev/rsdb-backend/auth"
+	"github.com/webstradev/rsdb-backend/db"
+	"github.com/webstradev/rsdb-backend/utils"
+)
+
+type webhookInput struct {
+	Url    string   `json:"url" binding:"required"`
+	Events []string `json:"events"`
+	Active bool     `json:"active"`
+}
+
+// CreateWebhook creates a webhook with a new secret. The secret is only returned here, receivers use it to check the
+// signature of the requests.
+func CreateWebhook(env *utils.Environment) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		user, err := auth.GetUserFromContext(c)
+		if err != nil {
+			log.Println(err)
+			c.AbortWithStatus(http.StatusInternalServerError)
+			return
+		}
+
+		// Validate Input (webhooks are active unless stated otherwise)
+		input := webhookInput{Active: true}
+		err = c.ShouldBindJSON(&input)
+		if err != nil {
+			log.Println(err)
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		webhook := db.Webhook{
+			Url:       input.Url,
+			Events:    input.Events,
+			Active:    input.Active,
+			Secret:    env.UUID.Generate(),
+			CreatedBy: sql.NullInt64{Int64: user.UserID, Valid: true},
+		}
+
+		err = webhook.Validate()
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		id, err := env.DB.InsertWebhook(webhook)
+		if err != nil {
+			log.Println(err)
+			c.AbortWithStatus(http.StatusInternalServerError)
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"id": id, "secret": webhook.Secret, "message": "Webhook created successfully"})
+	}
+}
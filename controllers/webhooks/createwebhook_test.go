@@ -0,0 +1,162 @@
+package webhooks
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+	"github.com/webstradev/rsdb-backend/auth"
+	"github.com/webstradev/rsdb-backend/mocks"
+	"github.com/webstradev/rsdb-backend/utils"
+)
+
+func TestCreateWebhook(t *testing.T) {
+	tests := []struct {
+		Name       string
+		User       auth.TokenData
+		MockDbCall func(sqlmock.Sqlmock)
+		StatusCode int
+		Body       string
+		Response   string
+	}{
+		{
+			"CreateWebhook - User Missing from Context",
+			auth.TokenData{},
+			nil,
+			http.StatusInternalServerError,
+			`{}`,
+			`{}`,
+		},
+		{
+			"CreateWebhook - missing url",
+			auth.TokenData{UserID: 1, Role: auth.AdminRole},
+			nil,
+			http.StatusBadRequest,
+			`{"events":["platform.created"]}`,
+			`{"error":"Key: 'webhookInput.Url' Error:Field validation for 'Url' failed on the 'required' tag"}`,
+		},
+		{
+			"CreateWebhook - invalid url",
+			auth.TokenData{UserID: 1, Role: auth.AdminRole},
+			nil,
+			http.StatusBadRequest,
+			`{"url":"ftp://example.com","events":["platform.created"]}`,
+			`{"error":"invalid URL, use an http or https URL"}`,
+		},
+		{
+			"CreateWebhook - without events",
+			auth.TokenData{UserID: 1, Role: auth.AdminRole},
+			nil,
+			http.StatusBadRequest,
+			`{"url":"https://example.com/hooks"}`,
+			`{"error":"a webhook needs at least one event"}`,
+		},
+		{
+			"CreateWebhook - invalid event",
+			auth.TokenData{UserID: 1, Role: auth.AdminRole},
+			nil,
+			http.StatusBadRequest,
+			`{"url":"https://example.com/hooks","events":["task.created"]}`,
+			`{"error":"invalid event, use platform, contact, article or project followed by .created, .updated or .deleted"}`,
+		},
+		{
+			"CreateWebhook - sql error",
+			auth.TokenData{UserID: 1, Role: auth.AdminRole},
+			func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec("INSERT INTO webhooks").WithArgs("https://example.com/hooks", "mock-uuid", true, 1).WillReturnError(errors.New("test"))
+				mock.ExpectRollback()
+			},
+			http.StatusInternalServerError,
+			`{"url":"https://example.com/hooks","events":["platform.created"]}`,
+			`{}`,
+		},
+		{
+			"CreateWebhook - Valid Request",
+			auth.TokenData{UserID: 1, Role: auth.AdminRole},
+			func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec("INSERT INTO webhooks").WithArgs("https://example.com/hooks", "mock-uuid", true, 1).WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec("INSERT IGNORE INTO webhooks_events").WithArgs(1, "platform.created").WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("INSERT IGNORE INTO webhooks_events").WithArgs(1, "platform.deleted").WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+			http.StatusOK,
+			`{"url":"https://example.com/hooks","events":["platform.created","platform.deleted"]}`,
+			`{"id":1,"secret":"mock-uuid","message":"Webhook created successfully"}`,
+		},
+		{
+			"CreateWebhook - Valid Request inactive",
+			auth.TokenData{UserID: 1, Role: auth.AdminRole},
+			func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec("INSERT INTO webhooks").WithArgs("https://example.com/hooks", "mock-uuid", false, 1).WillReturnResult(sqlmock.NewResult(2, 1))
+				mock.ExpectExec("INSERT IGNORE INTO webhooks_events").WithArgs(2, "project.updated").WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+			http.StatusOK,
+			`{"url":"https://example.com/hooks","events":["project.updated"],"active":false}`,
+			`{"id":2,"secret":"mock-uuid","message":"Webhook created successfully"}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			// Initilize test router, environemnt and mock database
+			r, mockDb, mockSql, env, err := utils.SetupTestEnvironment(test.MockDbCall)
+			// Close the mock database at the end of the test
+			defer mockDb.Close()
+
+			// Check for errors during setup
+			require.NoError(t, err)
+
+			// Secrets are generated as UUIDs
+			env.UUID = mocks.NewMockUUIDService()
+
+			// Register handler
+			r.POST("/api/v1/admin/webhooks", func(c *gin.Context) {
+				// Add user to context if it exists
+				if test.User.UserID != 0 {
+					c.Set("user", test.User)
+				}
+
+				// Call handler
+				CreateWebhook(env)(c)
+			})
+
+			// Create httptest request
+			req, _ := http.NewRequest("POST", "/api/v1/admin/webhooks", strings.NewReader(test.Body))
+			w := httptest.NewRecorder()
+
+			// Mock request
+			r.ServeHTTP(w, req)
+
+			// Read response data
+			responseData, _ := io.ReadAll(w.Body)
+
+			// Check response status
+			require.Equal(t, test.StatusCode, w.Code)
+
+			// Handle empty responses
+			response := string(responseData)
+			if response == "" {
+				response = "{}"
+			}
+
+			// Check response body
+			require.JSONEq(t, test.Response, response)
+
+			// Check for any remaining expectations
+			// we make sure that all expectations were met
+			if err := mockSql.ExpectationsWereMet(); err != nil {
+				t.Fatalf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,133 @@
+package webhooks
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/require"
+	"github.com/webstradev/rsdb-backend/utils"
+)
+
+func TestEditWebhook(t *testing.T) {
+	tests := []struct {
+		Name       string
+		IdString   string
+		MockDbCall func(sqlmock.Sqlmock)
+		StatusCode int
+		Body       string
+		Response   string
+	}{
+		{
+			"EditWebhook - non int id",
+			"notanint",
+			nil,
+			http.StatusBadRequest,
+			`{"url":"https://example.com/hooks","events":["platform.created"]}`,
+			`{"error":"Invalid ID"}`,
+		},
+		{
+			"EditWebhook - missing url",
+			"1",
+			nil,
+			http.StatusBadRequest,
+			`{"events":["platform.created"]}`,
+			`{"error":"Key: 'webhookInput.Url' Error:Field validation for 'Url' failed on the 'required' tag"}`,
+		},
+		{
+			"EditWebhook - not found",
+			"1",
+			func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT \\* FROM webhooks WHERE id = \\?").WithArgs(1).WillReturnError(sql.ErrNoRows)
+			},
+			http.StatusNotFound,
+			`{"url":"https://example.com/hooks","events":["platform.created"]}`,
+			`{}`,
+		},
+		{
+			"EditWebhook - invalid event",
+			"1",
+			expectWebhook,
+			http.StatusBadRequest,
+			`{"url":"https://example.com/hooks","events":["platform.merged"]}`,
+			`{"error":"invalid event, use platform, contact, article or project followed by .created, .updated or .deleted"}`,
+		},
+		{
+			"EditWebhook - sql error",
+			"1",
+			func(mock sqlmock.Sqlmock) {
+				expectWebhook(mock)
+				mock.ExpectBegin()
+				mock.ExpectExec("UPDATE webhooks SET url").WithArgs("https://example.com/rsdb", false, 1).WillReturnError(errors.New("test"))
+				mock.ExpectRollback()
+			},
+			http.StatusInternalServerError,
+			`{"url":"https://example.com/rsdb","events":["contact.updated"],"active":false}`,
+			`{}`,
+		},
+		{
+			"EditWebhook - Valid Request",
+			"1",
+			func(mock sqlmock.Sqlmock) {
+				expectWebhook(mock)
+				mock.ExpectBegin()
+				mock.ExpectExec("UPDATE webhooks SET url").WithArgs("https://example.com/rsdb", false, 1).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("DELETE FROM webhooks_events").WithArgs(1).WillReturnResult(sqlmock.NewResult(0, 2))
+				mock.ExpectExec("INSERT IGNORE INTO webhooks_events").WithArgs(1, "contact.updated").WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+			http.StatusOK,
+			`{"url":"https://example.com/rsdb","events":["contact.updated"],"active":false}`,
+			`{"message":"Webhook updated successfully"}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			// Initilize test router, environemnt and mock database
+			r, mockDb, mockSql, env, err := utils.SetupTestEnvironment(test.MockDbCall)
+			// Close the mock database at the end of the test
+			defer mockDb.Close()
+
+			// Check for errors during setup
+			require.NoError(t, err)
+
+			// Register handler
+			r.PUT("/api/v1/admin/webhooks/:webhookId", EditWebhook(env))
+
+			// Create httptest request
+			req, _ := http.NewRequest("PUT", fmt.Sprintf("/api/v1/admin/webhooks/%s", test.IdString), strings.NewReader(test.Body))
+			w := httptest.NewRecorder()
+
+			// Mock request
+			r.ServeHTTP(w, req)
+
+			// Read response data
+			responseData, _ := io.ReadAll(w.Body)
+
+			// Check response status
+			require.Equal(t, test.StatusCode, w.Code)
+
+			// Handle empty responses
+			response := string(responseData)
+			if response == "" {
+				response = "{}"
+			}
+
+			// Check response body
+			require.JSONEq(t, test.Response, response)
+
+			// Check for any remaining expectations
+			// we make sure that all expectations were met
+			if err := mockSql.ExpectationsWereMet(); err != nil {
+				t.Fatalf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
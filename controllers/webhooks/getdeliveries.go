@@ -0,0 +1,106 @@
+package webhooks
+
+import (
+	"database/sql"
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/webstradev/rsdb-backend/db"
+	"github.com/webstradev/rsdb-backend/utils"
+)
+
+// GetWebhookDeliveries lists a page of the log of deliveries of a webhook, the latest first
+func GetWebhookDeliveries(env *utils.Environment) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		page := c.MustGet("page").(int)
+		pageSize := c.MustGet("pageSize").(int)
+
+		idString := c.Param("webhookId")
+		id, err := strconv.ParseInt(idString, 10, 64)
+		if err != nil {
+			log.Println(err)
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Invalid ID"})
+			return
+		}
+
+		// Make sure the webhook exists
+		_, err = env.DB.GetWebhook(id)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				c.AbortWithStatus(http.StatusNotFound)
+				return
+			}
+			log.Println(err)
+			c.AbortWithStatus(http.StatusInternalServerError)
+			return
+		}
+
+		deliveries, err := env.DB.GetWebhookDeliveries(id, page, pageSize)
+		if err != nil {
+			log.Println(err)
+			c.AbortWithStatus(http.StatusInternalServerError)
+			return
+		}
+
+		count, err := env.DB.CountWebhookDeliveries(id)
+		if err != nil {
+			log.Println(err)
+			c.AbortWithStatus(http.StatusInternalServerError)
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"total": count, "deliveries": deliveries})
+	}
+}
+
+func GetWebhookDelivery(env *utils.Environment) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		delivery, ok := webhookDelivery(c, env)
+		if !ok {
+			return
+		}
+
+		c.JSON(http.StatusOK, delivery)
+	}
+}
+
+// webhookDelivery returns the delivery of the webhook in the URL. It aborts the request and returns false when either
+// does not exist.
+func webhookDelivery(c *gin.Context, env *utils.Environment) (db.WebhookDelivery, bool) {
+	webhookIdString := c.Param("webhookId")
+	webhookId, err := strconv.ParseInt(webhookIdString, 10, 64)
+	if err != nil {
+		log.Println(err)
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Invalid ID"})
+		return db.WebhookDelivery{}, false
+	}
+
+	idString := c.Param("deliveryId")
+	id, err := strconv.ParseInt(idString, 10, 64)
+	if err != nil {
+		log.Println(err)
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Invalid Delivery ID"})
+		return db.WebhookDelivery{}, false
+	}
+
+	delivery, err := env.DB.GetWebhookDelivery(id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			c.AbortWithStatus(http.StatusNotFound)
+			return db.WebhookDelivery{}, false
+		}
+		log.Println(err)
+		c.AbortWithStatus(http.StatusInternalServerError)
+		return db.WebhookDelivery{}, false
+	}
+
+	if delivery.WebhookId != webhookId {
+		c.AbortWithStatus(http.StatusNotFound)
+		return db.WebhookDelivery{}, false
+	}
+
+	return delivery, true
+}
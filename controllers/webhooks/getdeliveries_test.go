@@ -0,0 +1,173 @@
+package webhooks
+
+import (
+	"database/sql"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/require"
+	"github.com/webstradev/gin-pagination/v2/pkg/pagination"
+	"github.com/webstradev/rsdb-backend/utils"
+)
+
+var deliveryColumns = []string{"id", "webhook_id", "event", "payload", "status", "attempts", "response_status", "last_error"}
+
+const delivery = `{"id":3,"createdAt":"0001-01-01T00:00:00Z","modifiedAt":"0001-01-01T00:00:00Z","deletedAt":{"Time":"0001-01-01T00:00:00Z","Valid":false},
+	"webhookId":1,"event":"platform.created","payload":"{\"event\":\"platform.created\"}","status":"pending","attempts":2,
+	"nextAttemptAt":{"Time":"0001-01-01T00:00:00Z","Valid":false},"lastAttemptAt":{"Time":"0001-01-01T00:00:00Z","Valid":false},
+	"responseStatus":{"Int64":500,"Valid":true},"lastError":"webhook responded with status 500","redeliveryOf":{"Int64":0,"Valid":false}}`
+
+// expectDelivery expects delivery 3 of the given webhook to be read
+func expectDelivery(mock sqlmock.Sqlmock, webhookId int64) {
+	rows := sqlmock.NewRows(deliveryColumns).AddRow(3, webhookId, "platform.created", `{"event":"platform.created"}`, "pending", 2, 500, "webhook responded with status 500")
+	mock.ExpectQuery("SELECT \\* FROM webhook_deliveries WHERE id = \\?").WithArgs(3).WillReturnRows(rows)
+}
+
+func TestGetWebhookDeliveries(t *testing.T) {
+	tests := []struct {
+		Name       string
+		Path       string
+		MockDbCall func(sqlmock.Sqlmock)
+		StatusCode int
+		Response   string
+	}{
+		{
+			"GetWebhookDeliveries - non int id",
+			"/api/v1/admin/webhooks/notanint/deliveries?page=0&pageSize=10",
+			nil,
+			http.StatusBadRequest,
+			`{"error":"Invalid ID"}`,
+		},
+		{
+			"GetWebhookDeliveries - webhook not found",
+			"/api/v1/admin/webhooks/1/deliveries?page=0&pageSize=10",
+			func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT \\* FROM webhooks WHERE id = \\?").WithArgs(1).WillReturnError(sql.ErrNoRows)
+			},
+			http.StatusNotFound,
+			`{}`,
+		},
+		{
+			"GetWebhookDeliveries - sql error on GetWebhookDeliveries",
+			"/api/v1/admin/webhooks/1/deliveries?page=0&pageSize=10",
+			func(mock sqlmock.Sqlmock) {
+				expectWebhook(mock)
+				mock.ExpectQuery("SELECT \\* FROM webhook_deliveries WHERE webhook_id = \\?").WithArgs(1, 10, 0).WillReturnError(errors.New("test"))
+			},
+			http.StatusInternalServerError,
+			`{}`,
+		},
+		{
+			"GetWebhookDeliveries - sql error on CountWebhookDeliveries",
+			"/api/v1/admin/webhooks/1/deliveries?page=0&pageSize=10",
+			func(mock sqlmock.Sqlmock) {
+				expectWebhook(mock)
+				mock.ExpectQuery("SELECT \\* FROM webhook_deliveries WHERE webhook_id = \\?").WithArgs(1, 10, 0).WillReturnRows(sqlmock.NewRows(deliveryColumns))
+				mock.ExpectQuery("SELECT COUNT(.+) FROM webhook_deliveries").WithArgs(1).WillReturnError(errors.New("test"))
+			},
+			http.StatusInternalServerError,
+			`{}`,
+		},
+		{
+			"GetWebhookDeliveries - Valid Request",
+			"/api/v1/admin/webhooks/1/deliveries?page=1&pageSize=1",
+			func(mock sqlmock.Sqlmock) {
+				expectWebhook(mock)
+				rows := sqlmock.NewRows(deliveryColumns).AddRow(3, 1, "platform.created", `{"event":"platform.created"}`, "pending", 2, 500, "webhook responded with status 500")
+				mock.ExpectQuery("SELECT \\* FROM webhook_deliveries WHERE webhook_id = \\?").WithArgs(1, 1, 1).WillReturnRows(rows)
+				mock.ExpectQuery("SELECT COUNT(.+) FROM webhook_deliveries").WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
+			},
+			http.StatusOK,
+			`{"total":2,"deliveries":[` + delivery + `]}`,
+		},
+		{
+			"GetWebhookDelivery - non int delivery id",
+			"/api/v1/admin/webhooks/1/deliveries/notanint",
+			nil,
+			http.StatusBadRequest,
+			`{"error":"Invalid Delivery ID"}`,
+		},
+		{
+			"GetWebhookDelivery - not found",
+			"/api/v1/admin/webhooks/1/deliveries/3",
+			func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT \\* FROM webhook_deliveries WHERE id = \\?").WithArgs(3).WillReturnError(sql.ErrNoRows)
+			},
+			http.StatusNotFound,
+			`{}`,
+		},
+		{
+			"GetWebhookDelivery - delivery of another webhook",
+			"/api/v1/admin/webhooks/1/deliveries/3",
+			func(mock sqlmock.Sqlmock) {
+				expectDelivery(mock, 2)
+			},
+			http.StatusNotFound,
+			`{}`,
+		},
+		{
+			"GetWebhookDelivery - Valid Request",
+			"/api/v1/admin/webhooks/1/deliveries/3",
+			func(mock sqlmock.Sqlmock) {
+				expectDelivery(mock, 1)
+			},
+			http.StatusOK,
+			delivery,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			// Initilize test router, environemnt and mock database
+			r, mockDb, mockSql, env, err := utils.SetupTestEnvironment(test.MockDbCall)
+			// Close the mock database at the end of the test
+			defer mockDb.Close()
+
+			// Check for errors during setup
+			require.NoError(t, err)
+
+			// Register handlers
+			r.GET("/api/v1/admin/webhooks/:webhookId/deliveries",
+				pagination.New(
+					pagination.WithSizeText("pageSize"),
+					pagination.WithMinPageSize(1),
+					pagination.WithMaxPageSize(100),
+				),
+				GetWebhookDeliveries(env),
+			)
+			r.GET("/api/v1/admin/webhooks/:webhookId/deliveries/:deliveryId", GetWebhookDelivery(env))
+
+			// Create httptest request
+			req, _ := http.NewRequest("GET", test.Path, nil)
+			w := httptest.NewRecorder()
+
+			// Mock request
+			r.ServeHTTP(w, req)
+
+			// Read response data
+			responseData, _ := io.ReadAll(w.Body)
+
+			// Check response status
+			require.Equal(t, test.StatusCode, w.Code)
+
+			// Handle empty responses
+			response := string(responseData)
+			if response == "" {
+				response = "{}"
+			}
+
+			// Check response body
+			require.JSONEq(t, test.Response, response)
+
+			// Check for any remaining expectations
+			// we make sure that all expectations were met
+			if err := mockSql.ExpectationsWereMet(); err != nil {
+				t.Fatalf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
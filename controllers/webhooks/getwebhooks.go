@@ -0,0 +1,58 @@
+package webhooks
+
+import (
+	"database/sql"
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/webstradev/rsdb-backend/db"
+	"github.com/webstradev/rsdb-backend/utils"
+)
+
+func GetWebhooks(env *utils.Environment) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		webhooks, err := env.DB.GetWebhooks()
+		if err != nil {
+			log.Println(err)
+			c.AbortWithStatus(http.StatusInternalServerError)
+			return
+		}
+
+		c.JSON(http.StatusOK, webhooks)
+	}
+}
+
+func GetWebhook(env *utils.Environment) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		idString := c.Param("webhookId")
+		id, err := strconv.ParseInt(idString, 10, 64)
+		if err != nil {
+			log.Println(err)
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Invalid ID"})
+			return
+		}
+
+		webhook, err := env.DB.GetWebhook(id)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				c.AbortWithStatus(http.StatusNotFound)
+				return
+			}
+			log.Println(err)
+			c.AbortWithStatus(http.StatusInternalServerError)
+			return
+		}
+
+		c.JSON(http.StatusOK, webhook)
+	}
+}
+
+// GetWebhookEvents lists the events webhooks can subscribe to
+func GetWebhookEvents(env *utils.Environment) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.JSON(http.StatusOK, db.WebhookEvents)
+	}
+}
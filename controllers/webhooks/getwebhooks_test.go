@@ -0,0 +1,165 @@
+package webhooks
+
+import (
+	"database/sql"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/require"
+	"github.com/webstradev/rsdb-backend/utils"
+)
+
+var webhookColumns = []string{"id", "url", "secret", "active", "created_by"}
+
+const webhook = `{"id":1,"createdAt":"0001-01-01T00:00:00Z","modifiedAt":"0001-01-01T00:00:00Z","deletedAt":{"Time":"0001-01-01T00:00:00Z","Valid":false},
+	"url":"https://example.com/hooks","active":true,"createdBy":{"Int64":1,"Valid":true},"events":["platform.created","platform.deleted"]}`
+
+// expectWebhook expects webhook 1 to be read with its events
+func expectWebhook(mock sqlmock.Sqlmock) {
+	mock.ExpectQuery("SELECT \\* FROM webhooks WHERE id = \\?").WithArgs(1).WillReturnRows(sqlmock.NewRows(webhookColumns).AddRow(1, "https://example.com/hooks", "secret", true, 1))
+	events := sqlmock.NewRows([]string{"webhook_id", "event"}).AddRow(1, "platform.created").AddRow(1, "platform.deleted")
+	mock.ExpectQuery("SELECT webhook_id, event FROM webhooks_events").WithArgs(1).WillReturnRows(events)
+}
+
+func TestGetWebhooks(t *testing.T) {
+	tests := []struct {
+		Name       string
+		Path       string
+		MockDbCall func(sqlmock.Sqlmock)
+		StatusCode int
+		Response   string
+	}{
+		{
+			"GetWebhooks - sql error",
+			"/api/v1/admin/webhooks",
+			func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT \\* FROM webhooks").WillReturnError(errors.New("test"))
+			},
+			http.StatusInternalServerError,
+			`{}`,
+		},
+		{
+			"GetWebhooks - sql error on events",
+			"/api/v1/admin/webhooks",
+			func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT \\* FROM webhooks").WillReturnRows(sqlmock.NewRows(webhookColumns).AddRow(1, "https://example.com/hooks", "secret", true, 1))
+				mock.ExpectQuery("SELECT webhook_id, event FROM webhooks_events").WithArgs(1).WillReturnError(errors.New("test"))
+			},
+			http.StatusInternalServerError,
+			`{}`,
+		},
+		{
+			"GetWebhooks - no webhooks",
+			"/api/v1/admin/webhooks",
+			func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT \\* FROM webhooks").WillReturnRows(sqlmock.NewRows(webhookColumns))
+			},
+			http.StatusOK,
+			`[]`,
+		},
+		{
+			"GetWebhooks - Valid Request",
+			"/api/v1/admin/webhooks",
+			func(mock sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows(webhookColumns).
+					AddRow(1, "https://example.com/hooks", "secret", true, 1).
+					AddRow(2, "https://example.org/hooks", "other", false, nil)
+				mock.ExpectQuery("SELECT \\* FROM webhooks").WillReturnRows(rows)
+				events := sqlmock.NewRows([]string{"webhook_id", "event"}).AddRow(1, "platform.created").AddRow(1, "platform.deleted")
+				mock.ExpectQuery("SELECT webhook_id, event FROM webhooks_events").WithArgs(1, 2).WillReturnRows(events)
+			},
+			http.StatusOK,
+			`[` + webhook + `,{"id":2,"createdAt":"0001-01-01T00:00:00Z","modifiedAt":"0001-01-01T00:00:00Z","deletedAt":{"Time":"0001-01-01T00:00:00Z","Valid":false},
+			"url":"https://example.org/hooks","active":false,"createdBy":{"Int64":0,"Valid":false},"events":[]}]`,
+		},
+		{
+			"GetWebhook - non int id",
+			"/api/v1/admin/webhooks/notanint",
+			nil,
+			http.StatusBadRequest,
+			`{"error":"Invalid ID"}`,
+		},
+		{
+			"GetWebhook - not found",
+			"/api/v1/admin/webhooks/1",
+			func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT \\* FROM webhooks WHERE id = \\?").WithArgs(1).WillReturnError(sql.ErrNoRows)
+			},
+			http.StatusNotFound,
+			`{}`,
+		},
+		{
+			"GetWebhook - sql error",
+			"/api/v1/admin/webhooks/1",
+			func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT \\* FROM webhooks WHERE id = \\?").WithArgs(1).WillReturnError(errors.New("test"))
+			},
+			http.StatusInternalServerError,
+			`{}`,
+		},
+		{
+			"GetWebhook - Valid Request",
+			"/api/v1/admin/webhooks/1",
+			expectWebhook,
+			http.StatusOK,
+			webhook,
+		},
+		{
+			"GetWebhookEvents - Valid Request",
+			"/api/v1/admin/webhooks/events",
+			nil,
+			http.StatusOK,
+			`["platform.created","platform.updated","platform.deleted","contact.created","contact.updated","contact.deleted",
+			"article.created","article.updated","article.deleted","project.created","project.updated","project.deleted"]`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			// Initilize test router, environemnt and mock database
+			r, mockDb, mockSql, env, err := utils.SetupTestEnvironment(test.MockDbCall)
+			// Close the mock database at the end of the test
+			defer mockDb.Close()
+
+			// Check for errors during setup
+			require.NoError(t, err)
+
+			// Register handlers
+			r.GET("/api/v1/admin/webhooks", GetWebhooks(env))
+			r.GET("/api/v1/admin/webhooks/events", GetWebhookEvents(env))
+			r.GET("/api/v1/admin/webhooks/:webhookId", GetWebhook(env))
+
+			// Create httptest request
+			req, _ := http.NewRequest("GET", test.Path, nil)
+			w := httptest.NewRecorder()
+
+			// Mock request
+			r.ServeHTTP(w, req)
+
+			// Read response data
+			responseData, _ := io.ReadAll(w.Body)
+
+			// Check response status
+			require.Equal(t, test.StatusCode, w.Code)
+
+			// Handle empty responses
+			response := string(responseData)
+			if response == "" {
+				response = "{}"
+			}
+
+			// Check response body
+			require.JSONEq(t, test.Response, response)
+
+			// Check for any remaining expectations
+			// we make sure that all expectations were met
+			if err := mockSql.ExpectationsWereMet(); err != nil {
+				t.Fatalf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
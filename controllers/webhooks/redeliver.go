@@ -0,0 +1,35 @@
+package webhooks
+
+import (
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/webstradev/rsdb-backend/utils"
+)
+
+// RedeliverWebhookDelivery queues a new delivery of the payload of an earlier delivery, which is logged as its own
+// delivery
+func RedeliverWebhookDelivery(env *utils.Environment) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		delivery, ok := webhookDelivery(c, env)
+		if !ok {
+			return
+		}
+
+		id, err := env.DB.RedeliverWebhookDelivery(delivery.ID, time.Now())
+		if err != nil {
+			log.Println(err)
+			c.AbortWithStatus(http.StatusInternalServerError)
+			return
+		}
+
+		// Send it now instead of at the next poll
+		if env.Webhooks != nil {
+			env.Webhooks.Wake()
+		}
+
+		c.JSON(http.StatusOK, gin.H{"id": id, "message": "Delivery queued successfully"})
+	}
+}
@@ -0,0 +1,107 @@
+package webhooks
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/require"
+	"github.com/webstradev/rsdb-backend/utils"
+	"github.com/webstradev/rsdb-backend/webhooks"
+)
+
+func TestRedeliverWebhookDelivery(t *testing.T) {
+	tests := []struct {
+		Name       string
+		Path       string
+		MockDbCall func(sqlmock.Sqlmock)
+		StatusCode int
+		Response   string
+	}{
+		{
+			"RedeliverWebhookDelivery - non int id",
+			"/api/v1/admin/webhooks/notanint/deliveries/3/redeliver",
+			nil,
+			http.StatusBadRequest,
+			`{"error":"Invalid ID"}`,
+		},
+		{
+			"RedeliverWebhookDelivery - delivery of another webhook",
+			"/api/v1/admin/webhooks/1/deliveries/3/redeliver",
+			func(mock sqlmock.Sqlmock) {
+				expectDelivery(mock, 2)
+			},
+			http.StatusNotFound,
+			`{}`,
+		},
+		{
+			"RedeliverWebhookDelivery - sql error",
+			"/api/v1/admin/webhooks/1/deliveries/3/redeliver",
+			func(mock sqlmock.Sqlmock) {
+				expectDelivery(mock, 1)
+				mock.ExpectExec("INSERT INTO webhook_deliveries (.+) redelivery_of").WithArgs(sqlmock.AnyArg(), 3).WillReturnError(errors.New("test"))
+			},
+			http.StatusInternalServerError,
+			`{}`,
+		},
+		{
+			"RedeliverWebhookDelivery - Valid Request",
+			"/api/v1/admin/webhooks/1/deliveries/3/redeliver",
+			func(mock sqlmock.Sqlmock) {
+				expectDelivery(mock, 1)
+				mock.ExpectExec("INSERT INTO webhook_deliveries (.+) redelivery_of").WithArgs(sqlmock.AnyArg(), 3).WillReturnResult(sqlmock.NewResult(4, 1))
+			},
+			http.StatusOK,
+			`{"id":4,"message":"Delivery queued successfully"}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			// Initilize test router, environemnt and mock database
+			r, mockDb, mockSql, env, err := utils.SetupTestEnvironment(test.MockDbCall)
+			// Close the mock database at the end of the test
+			defer mockDb.Close()
+
+			// Check for errors during setup
+			require.NoError(t, err)
+
+			// The dispatcher is woken up to send the redelivery
+			env.Webhooks = webhooks.New(env.DB, http.DefaultClient)
+
+			// Register handler
+			r.POST("/api/v1/admin/webhooks/:webhookId/deliveries/:deliveryId/redeliver", RedeliverWebhookDelivery(env))
+
+			// Create httptest request
+			req, _ := http.NewRequest("POST", test.Path, nil)
+			w := httptest.NewRecorder()
+
+			// Mock request
+			r.ServeHTTP(w, req)
+
+			// Read response data
+			responseData, _ := io.ReadAll(w.Body)
+
+			// Check response status
+			require.Equal(t, test.StatusCode, w.Code)
+
+			// Handle empty responses
+			response := string(responseData)
+			if response == "" {
+				response = "{}"
+			}
+
+			// Check response body
+			require.JSONEq(t, test.Response, response)
+
+			// Check for any remaining expectations
+			// we make sure that all expectations were met
+			if err := mockSql.ExpectationsWereMet(); err != nil {
+				t.Fatalf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
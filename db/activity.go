@@ -0,0 +1,291 @@
+package db
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// Types of interactions that can be logged
+var ActivityTypes = []string{"call", "meeting", "email", "other"}
+
+var (
+	ErrInvalidActivityType = errors.New("invalid activity type, use call, meeting, email or other")
+	ErrInvalidActivity     = errors.New("an activity needs a platform or at least one contact")
+)
+
+// An activity is a call, meeting or email with one or more contacts, logged by users
+type Activity struct {
+	Model
+	Type         string            `json:"type" db:"type"`
+	Date         time.Time         `json:"date" db:"date"`
+	Summary      string            `json:"summary" db:"summary"`
+	PlatformId   sql.NullInt64     `json:"platformId" db:"platform_id"`
+	PlatformName sql.NullString    `json:"platformName" db:"platform_name"`
+	ProjectId    sql.NullInt64     `json:"projectId" db:"project_id"`
+	ProjectTitle sql.NullString    `json:"projectTitle" db:"project_title"`
+	CreatedBy    sql.NullInt64     `json:"createdBy" db:"created_by"`
+	Contacts     []ActivityContact `json:"contacts"`
+	Users        []ActivityUser    `json:"users"`
+}
+
+type ActivityContact struct {
+	ActivityId int64  `json:"-" db:"activity_id"`
+	ContactId  int64  `json:"contactId" db:"contact_id"`
+	Name       string `json:"name" db:"name"`
+	PlatformId int64  `json:"platformId" db:"platform_id"`
+}
+
+type ActivityUser struct {
+	ActivityId int64  `json:"-" db:"activity_id"`
+	UserId     int64  `json:"userId" db:"user_id"`
+	Email      string `json:"email" db:"email"`
+}
+
+// Activities that count as contact, planned meetings in the future do not
+const pastActivity = "a.deleted_at IS NULL AND a.date <= CURRENT_TIMESTAMP()"
+
+// Date of the latest activity with the contact (aliased c), used as last_contacted column
+const contactLastContacted = `(
+		SELECT MAX(a.date) FROM activities a JOIN activities_contacts ac ON ac.activity_id = a.id
+		WHERE ac.contact_id = c.id AND ` + pastActivity + `
+	)`
+
+// Date of the latest activity with the platform (aliased p) or any of its contacts, used as last_contacted column
+const platformLastContacted = `(
+		SELECT MAX(a.date) FROM activities a
+		WHERE ` + pastActivity + ` AND (a.platform_id = p.id OR EXISTS (
+			SELECT 1 FROM activities_contacts ac JOIN contacts pc ON pc.id = ac.contact_id
+			WHERE ac.activity_id = a.id AND pc.platform_id = p.id
+		))
+	)`
+
+const activityQuery = `
+	SELECT
+		a.*,
+		p.name AS platform_name,
+		pr.title AS project_title
+	FROM
+		activities a
+	LEFT JOIN
+		platforms p ON p.id = a.platform_id
+	LEFT JOIN
+		projects pr ON pr.id = a.project_id
+	WHERE a.deleted_at IS NULL AND `
+
+// Validate checks the type of the activity and that it is linked to a platform or contact
+func (a *Activity) Validate(contactIds []int64) error {
+	valid := false
+	for _, t := range ActivityTypes {
+		valid = valid || a.Type == t
+	}
+	if !valid {
+		return ErrInvalidActivityType
+	}
+
+	if !a.PlatformId.Valid && len(contactIds) == 0 {
+		return ErrInvalidActivity
+	}
+
+	return nil
+}
+
+func (db *Database) GetActivity(id int64) (Activity, error) {
+	activity := Activity{}
+
+	err := db.querier.Get(&activity, activityQuery+"a.id = ?", id)
+	if err != nil {
+		return activity, err
+	}
+
+	activities := []Activity{activity}
+	err = populateParticipants(db.querier, activities)
+	return activities[0], err
+}
+
+// GetContactActivities is the timeline of a contact, the latest activity first
+func (db *Database) GetContactActivities(contactId int64) ([]Activity, error) {
+	activities := []Activity{}
+
+	err := db.querier.Select(&activities, activityQuery+`
+	a.id IN (SELECT activity_id FROM activities_contacts WHERE contact_id = ?)
+	ORDER BY a.date DESC, a.id DESC`, contactId)
+	if err != nil {
+		return nil, err
+	}
+
+	err = populateParticipants(db.querier, activities)
+	return activities, err
+}
+
+// GetPlatformActivities is the timeline of a platform, including the activities with any of its contacts
+func (db *Database) GetPlatformActivities(platformId int64) ([]Activity, error) {
+	activities := []Activity{}
+
+	err := db.querier.Select(&activities, activityQuery+`
+	(a.platform_id = ? OR a.id IN (
+		SELECT ac.activity_id FROM activities_contacts ac JOIN contacts c ON c.id = ac.contact_id WHERE c.platform_id = ?
+	))
+	ORDER BY a.date DESC, a.id DESC`, platformId, platformId)
+	if err != nil {
+		return nil, err
+	}
+
+	err = populateParticipants(db.querier, activities)
+	return activities, err
+}
+
+// populateParticipants loads the contacts and users of all activities with one query each
+func populateParticipants(q sqlx.Queryer, activities []Activity) error {
+	if len(activities) == 0 {
+		return nil
+	}
+
+	ids := make([]int64, len(activities))
+	index := map[int64]int{}
+	for i := range activities {
+		ids[i] = activities[i].ID
+		index[activities[i].ID] = i
+		activities[i].Contacts = []ActivityContact{}
+		activities[i].Users = []ActivityUser{}
+	}
+
+	query, args, err := sqlx.In(`
+	SELECT ac.activity_id, c.id AS contact_id, c.name, c.platform_id
+	FROM activities_contacts ac
+	JOIN contacts c ON c.id = ac.contact_id
+	WHERE ac.activity_id IN (?)
+	ORDER BY c.name`, ids)
+	if err != nil {
+		return err
+	}
+
+	contacts := []ActivityContact{}
+	err = sqlx.Select(q, &contacts, query, args...)
+	if err != nil {
+		return err
+	}
+
+	for _, contact := range contacts {
+		i := index[contact.ActivityId]
+		activities[i].Contacts = append(activities[i].Contacts, contact)
+	}
+
+	query, args, err = sqlx.In(`
+	SELECT au.activity_id, u.id AS user_id, u.email
+	FROM activities_users au
+	JOIN users u ON u.id = au.user_id
+	WHERE au.activity_id IN (?)
+	ORDER BY u.email`, ids)
+	if err != nil {
+		return err
+	}
+
+	users := []ActivityUser{}
+	err = sqlx.Select(q, &users, query, args...)
+	if err != nil {
+		return err
+	}
+
+	for _, user := range users {
+		i := index[user.ActivityId]
+		activities[i].Users = append(activities[i].Users, user)
+	}
+
+	return nil
+}
+
+// InsertActivity stores the activity and its participants in a single transaction
+func (db *Database) InsertActivity(activity Activity, contactIds, userIds []int64) (int64, error) {
+	tx, err := db.querier.Beginx()
+	if err != nil {
+		return 0, err
+	}
+
+	result, err := tx.NamedExec(`
+		INSERT INTO activities
+			(type, date, summary, platform_id, project_id, created_by)
+		VALUES
+			(:type, :date, :summary, :platform_id, :project_id, :created_by)`, activity)
+	if err != nil {
+		tx.Rollback()
+		return 0, err
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		tx.Rollback()
+		return 0, err
+	}
+
+	err = insertParticipants(tx, id, contactIds, userIds)
+	if err != nil {
+		tx.Rollback()
+		return 0, err
+	}
+
+	return id, tx.Commit()
+}
+
+// UpdateActivity stores the changes to the activity and replaces its participants in a single transaction
+func (db *Database) UpdateActivity(activity Activity, contactIds, userIds []int64) error {
+	tx, err := db.querier.Beginx()
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.NamedExec(`
+		UPDATE activities
+		SET
+			type = :type,
+			date = :date,
+			summary = :summary,
+			platform_id = :platform_id,
+			project_id = :project_id
+		WHERE id = :id`, activity)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	for _, table := range []string{"activities_contacts", "activities_users"} {
+		_, err = tx.Exec("DELETE FROM "+table+" WHERE activity_id = ?", activity.ID)
+		if err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	err = insertParticipants(tx, activity.ID, contactIds, userIds)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func insertParticipants(tx *sqlx.Tx, activityId int64, contactIds, userIds []int64) error {
+	for _, contactId := range contactIds {
+		_, err := tx.Exec("INSERT IGNORE INTO activities_contacts (activity_id, contact_id) VALUES (?, ?)", activityId, contactId)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, userId := range userIds {
+		_, err := tx.Exec("INSERT IGNORE INTO activities_users (activity_id, user_id) VALUES (?, ?)", activityId, userId)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (db *Database) DeleteActivity(id int64) error {
+	_, err := db.querier.Exec("UPDATE activities SET deleted_at = CURRENT_TIMESTAMP() WHERE id = ?", id)
+	return err
+}
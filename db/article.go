@@ -14,14 +14,21 @@ type Article struct {
 	Description string            `json:"description" db:"description"`
 	Link        string            `json:"link" db:"link"`
 	Date        sql.NullTime      `json:"date" db:"date"`
+	Image       string            `json:"image" db:"image"`
 	Body        string            `json:"body" db:"body"`
+	BodyFormat  string            `json:"bodyFormat" db:"body_format"`
+	BodyHTML    string            `json:"bodyHtml" db:"body_html"`
+	BodyText    string            `json:"bodyText" db:"body_text"`
 	Tags        []ArticleTag      `json:"tags"`
 	Platforms   []ArticlePlatform `json:"platforms"`
+	Projects    []ArticleProject  `json:"projects"`
+	CreatedBy   sql.NullInt64     `json:"createdBy" db:"created_by"`
 }
 
 type ArticleWithTagString struct {
 	Article
-	TagString string `json:"tagString" db:"article_tags"`
+	TagString     string `json:"tagString" db:"article_tags"`
+	CommentsCount int    `json:"commentsCount" db:"comments_count"`
 }
 
 func (db *Database) CountArticles() (int, error) {
@@ -50,6 +57,16 @@ func (a *Article) PlatformIds() []int64 {
 	return ids
 }
 
+func (a *Article) ProjectIds() []int64 {
+	ids := []int64{}
+
+	for _, project := range a.Projects {
+		ids = append(ids, project.ProjectId)
+	}
+
+	return ids
+}
+
 func (a *Article) PopulateTags(db *Database) error {
 	tags, err := db.GetArticleTags(a.ID)
 	if err != nil {
@@ -72,10 +89,25 @@ func (a *Article) PopulatePlatforms(db *Database) error {
 	return nil
 }
 
+func (a *Article) PopulateProjects(db *Database) error {
+	projects, err := db.GetProjectsForArticle(a.ID)
+	if err != nil {
+		return err
+	}
+
+	a.Projects = projects
+
+	return nil
+}
+
 func (db *Database) GetArticle(id int64) (Article, error) {
+	return getArticle(db.querier, id)
+}
+
+func getArticle(q sqlx.Queryer, id int64) (Article, error) {
 	article := Article{}
 
-	err := db.querier.Get(&article, "SELECT a.* FROM articles a WHERE a.id = ? AND a.deleted_at IS NULL", id)
+	err := sqlx.Get(q, &article, "SELECT a.* FROM articles a WHERE a.id = ? AND a.deleted_at IS NULL", id)
 	return article, err
 }
 
@@ -85,7 +117,8 @@ func (db *Database) GetArticles(page, pageSize int) ([]ArticleWithTagString, err
 	err := db.querier.Select(&articles, `
 	SELECT 
 		a.*, 
-		COALESCE(GROUP_CONCAT(DISTINCT t.tag), '') AS article_tags
+		COALESCE(GROUP_CONCAT(DISTINCT t.tag), '') AS article_tags,
+		`+articleCommentsCount+` AS comments_count
 	FROM 
 		articles a 
 	LEFT JOIN 
@@ -104,9 +137,14 @@ func (db *Database) GetArticles(page, pageSize int) ([]ArticleWithTagString, err
 }
 
 func (db *Database) InsertArticle(article Article) (int64, error) {
+	err := article.RenderBody()
+	if err != nil {
+		return 0, err
+	}
+
 	result, err := db.querier.NamedExec(`
-	INSERT INTO articles (title, description, link, date, body)
-	VALUES (:title, :description, :link, :date, :body)`, article)
+	INSERT INTO articles (title, description, link, date, image, body, body_format, body_html, body_text, created_by)
+	VALUES (:title, :description, :link, :date, :image, :body, :body_format, :body_html, :body_text, :created_by)`, article)
 	if err != nil {
 		log.Println(err)
 		return 0, err
@@ -121,6 +159,19 @@ func (db *Database) InsertArticle(article Article) (int64, error) {
 	return id, nil
 }
 
+// FillArticleMetadata sets the title, description, date and image of an article where they are still empty,
+// fields a user filled in the meantime are left alone
+func (db *Database) FillArticleMetadata(article Article) error {
+	_, err := db.querier.NamedExec(`
+	UPDATE articles SET
+		title = IF(title = '', :title, title),
+		description = IF(description = '', :description, description),
+		date = COALESCE(date, :date),
+		image = IF(image = '', :image, image)
+	WHERE id = :id AND deleted_at IS NULL`, article)
+	return err
+}
+
 func (db *Database) InsertArticleTags(articleId int64, tags []int64) error {
 	// If there are no categories, we're done
 	if len(tags) == 0 {
@@ -229,14 +280,34 @@ func (db *Database) InsertArticlePlatformsTx(tx *sqlx.Tx, articleID int64, platf
 	return nil
 }
 
-func (db *Database) EditArticle(article Article) error {
+// EditArticle stores a revision of the current article (including its tags, platforms and projects)
+// and then applies the edit, all within the same transaction
+func (db *Database) EditArticle(article Article, editedBy int64) error {
+	err := article.RenderBody()
+	if err != nil {
+		return err
+	}
+
 	tx, err := db.querier.Beginx()
 	if err != nil {
 		return err
 	}
 
+	current, err := snapshotArticle(tx, article.ID)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	err = insertRevision(tx, ARTICLE_ENTITY, article.ID, editedBy, current)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
 	_, err = tx.NamedExec(`
-    UPDATE articles SET title = :title, description = :description, link = :link, date = :date, body = :body
+    UPDATE articles SET title = :title, description = :description, link = :link, date = :date, image = :image,
+        body = :body, body_format = :body_format, body_html = :body_html, body_text = :body_text
     WHERE id = :id`, article)
 	if err != nil {
 		tx.Rollback()
@@ -267,6 +338,21 @@ func (db *Database) EditArticle(article Article) error {
 		return err
 	}
 
+	// Without project data (e.g. revisions stored before articles were linked to projects) the links are kept
+	if article.Projects != nil {
+		_, err = tx.Exec("DELETE FROM articles_projects WHERE article_id = ?", article.ID)
+		if err != nil {
+			tx.Rollback()
+			return err
+		}
+
+		err = db.InsertArticleProjectsTx(tx, article.ID, article.ProjectIds())
+		if err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
 	err = tx.Commit()
 	if err != nil {
 		return err
@@ -0,0 +1,115 @@
+package db
+
+import (
+	"database/sql"
+	"strings"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// A project an article writes about
+type ArticleProject struct {
+	ArticleId    int64  `json:"-" db:"article_id"`
+	ProjectId    int64  `json:"id" db:"project_id"`
+	ProjectTitle string `json:"project" db:"project_title"`
+}
+
+// An article about a project, the press coverage of the project
+type ProjectArticle struct {
+	ProjectId   int64        `json:"-" db:"project_id"`
+	ArticleId   int64        `json:"id" db:"article_id"`
+	Title       string       `json:"title" db:"title"`
+	Description string       `json:"description" db:"description"`
+	Link        string       `json:"link" db:"link"`
+	Date        sql.NullTime `json:"date" db:"date"`
+}
+
+func (db *Database) GetProjectsForArticle(id int64) ([]ArticleProject, error) {
+	return selectProjectsForArticle(db.querier, id)
+}
+
+func selectProjectsForArticle(q sqlx.Queryer, id int64) ([]ArticleProject, error) {
+	projects := []ArticleProject{}
+
+	err := sqlx.Select(q, &projects, `
+	SELECT
+		ap.*,
+		p.title as project_title
+	FROM
+		projects p
+	LEFT JOIN
+		articles_projects ap ON ap.project_id = p.id
+	WHERE
+		ap.article_id = ? AND p.deleted_at IS NULL`, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return projects, nil
+}
+
+// GetPressForProject lists the articles about a project, the latest first and articles without a date last
+func (db *Database) GetPressForProject(id int64) ([]ProjectArticle, error) {
+	press := []ProjectArticle{}
+
+	err := db.querier.Select(&press, `
+	SELECT
+		ap.*,
+		a.title, a.description, a.link, a.date
+	FROM
+		articles a
+	LEFT JOIN
+		articles_projects ap ON ap.article_id = a.id
+	WHERE
+		ap.project_id = ? AND a.deleted_at IS NULL
+	ORDER BY a.date IS NULL, a.date DESC, a.id DESC`, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return press, nil
+}
+
+func (db *Database) InsertArticleProjects(articleId int64, projects []int64) error {
+	// If there are no projects, we're done
+	if len(projects) == 0 {
+		return nil
+	}
+
+	// Build a query and args
+	query := `INSERT INTO articles_projects (project_id, article_id) VALUES `
+	args := []any{}
+
+	for _, projectId := range projects {
+		// Add placeholders for each project
+		query += "(?, ?),"
+
+		// Add bind arguments for each project
+		args = append(args, projectId, articleId)
+	}
+
+	// Remove the last comma
+	query = strings.TrimRight(query, ",")
+
+	_, err := db.querier.Exec(query, args...)
+	return err
+}
+
+func (db *Database) InsertArticleProjectsTx(tx *sqlx.Tx, articleID int64, projects []int64) error {
+	if len(projects) == 0 {
+		return nil
+	}
+
+	// Build the query and arguments
+	query := "INSERT INTO articles_projects (project_id, article_id) VALUES "
+	args := make([]interface{}, 0, len(projects)*2)
+	for _, projectID := range projects {
+		query += "(?, ?),"
+		args = append(args, projectID, articleID)
+	}
+	query = strings.TrimRight(query, ",")
+
+	// Execute the query
+	_, err := tx.Exec(query, args...)
+	return err
+}
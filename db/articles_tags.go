@@ -1,5 +1,7 @@
 package db
 
+import "github.com/jmoiron/sqlx"
+
 type ArticleTag struct {
 	ArticleId int64  `json:"-" db:"article_id"`
 	TagId     int64  `json:"id" db:"tag_id"`
@@ -7,9 +9,13 @@ type ArticleTag struct {
 }
 
 func (db *Database) GetArticleTags(id int64) ([]ArticleTag, error) {
+	return selectArticleTags(db.querier, id)
+}
+
+func selectArticleTags(q sqlx.Queryer, id int64) ([]ArticleTag, error) {
 	tags := []ArticleTag{}
 
-	err := db.querier.Select(&tags, `
+	err := sqlx.Select(q, &tags, `
 	SELECT 
 		at.*,
 		t.tag
@@ -0,0 +1,75 @@
+package db
+
+import "database/sql"
+
+// A file attached to a platform, contact, article or project, the file itself is kept in storage under StorageKey
+type Attachment struct {
+	Model
+	EntityType  string        `json:"entityType" db:"entity_type"`
+	EntityId    int64         `json:"entityId" db:"entity_id"`
+	Filename    string        `json:"filename" db:"filename"`
+	ContentType string        `json:"contentType" db:"content_type"`
+	Size        int64         `json:"size" db:"size"`
+	Checksum    string        `json:"checksum" db:"checksum"` // SHA-256 of the file, hex encoded
+	StorageKey  string        `json:"-" db:"storage_key"`
+	CreatedBy   sql.NullInt64 `json:"createdBy" db:"created_by"`
+}
+
+func (db *Database) GetAttachment(id int64) (Attachment, error) {
+	attachment := Attachment{}
+
+	err := db.querier.Get(&attachment, "SELECT * FROM attachments WHERE id = ? AND deleted_at IS NULL", id)
+	return attachment, err
+}
+
+// GetAttachments lists the attachments of a record, the latest first
+func (db *Database) GetAttachments(entityType string, entityId int64) ([]Attachment, error) {
+	attachments := []Attachment{}
+
+	err := db.querier.Select(&attachments, `
+	SELECT * FROM attachments
+	WHERE entity_type = ? AND entity_id = ? AND deleted_at IS NULL
+	ORDER BY created_at DESC, id DESC`, entityType, entityId)
+	return attachments, err
+}
+
+func (db *Database) InsertAttachment(attachment Attachment) (int64, error) {
+	result, err := db.querier.NamedExec(`
+	INSERT INTO attachments (entity_type, entity_id, filename, content_type, size, checksum, storage_key, created_by)
+	VALUES (:entity_type, :entity_id, :filename, :content_type, :size, :checksum, :storage_key, :created_by)`, attachment)
+	if err != nil {
+		return 0, err
+	}
+
+	return result.LastInsertId()
+}
+
+// DeleteAttachment soft deletes the attachment, the file is kept in storage
+func (db *Database) DeleteAttachment(id int64) error {
+	_, err := db.querier.Exec("UPDATE attachments SET deleted_at = CURRENT_TIMESTAMP() WHERE id = ?", id)
+	return err
+}
+
+// RecordIsPrivate reports whether a record is private, contacts are private when their platform is.
+// It returns sql.ErrNoRows when the record does not exist or has been deleted.
+func (db *Database) RecordIsPrivate(entityType string, id int64) (bool, error) {
+	var private bool
+	var err error
+
+	switch entityType {
+	case ARTICLE_ENTITY, PROJECT_ENTITY:
+		err = db.querier.Get(&private, "SELECT FALSE FROM "+entityTables[entityType]+" WHERE id = ? AND deleted_at IS NULL", id)
+	case PLATFORM_ENTITY:
+		err = db.querier.Get(&private, "SELECT privacy = ? FROM platforms WHERE id = ? AND deleted_at IS NULL", PRIVACY_PRIVATE, id)
+	case CONTACT_ENTITY:
+		err = db.querier.Get(&private, `
+		SELECT c.privacy = ? OR p.privacy = ?
+		FROM contacts c
+		JOIN platforms p ON p.id = c.platform_id
+		WHERE c.id = ? AND c.deleted_at IS NULL`, PRIVACY_PRIVATE, PRIVACY_PRIVATE, id)
+	default:
+		err = ErrInvalidEntityType
+	}
+
+	return private, err
+}
@@ -0,0 +1,100 @@
+package db
+
+import (
+	"github.com/jmoiron/sqlx"
+	"github.com/webstradev/rsdb-backend/richtext"
+)
+
+// RenderBody sanitizes the body of the article and renders its HTML and plain text
+func (a *Article) RenderBody() error {
+	body, err := richtext.Render(a.BodyFormat, a.Body)
+	if err != nil {
+		return err
+	}
+
+	a.BodyFormat, a.Body, a.BodyHTML, a.BodyText = body.Format, body.Source, body.HTML, body.Text
+	return nil
+}
+
+// RenderBody sanitizes the body of the project and renders its HTML and plain text
+func (p *Project) RenderBody() error {
+	body, err := richtext.Render(p.BodyFormat, p.Body)
+	if err != nil {
+		return err
+	}
+
+	p.BodyFormat, p.Body, p.BodyHTML, p.BodyText = body.Format, body.Source, body.HTML, body.Text
+	return nil
+}
+
+type SanitizationReport struct {
+	ArticlesUpdated int `json:"articlesUpdated"`
+	ProjectsUpdated int `json:"projectsUpdated"`
+}
+
+// SanitizeExisting sanitizes and renders the bodies of the articles and projects that were stored before bodies were
+// sanitized on write, in a single transaction. With dryRun the transaction is rolled back.
+func (db *Database) SanitizeExisting(dryRun bool) (*SanitizationReport, error) {
+	report := &SanitizationReport{}
+
+	tx, err := db.querier.Beginx()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, table := range []struct {
+		name    string
+		updated *int
+	}{
+		{"articles", &report.ArticlesUpdated},
+		{"projects", &report.ProjectsUpdated},
+	} {
+		*table.updated, err = sanitizeBodies(tx, table.name)
+		if err != nil {
+			tx.Rollback()
+			return nil, err
+		}
+	}
+
+	if dryRun {
+		return report, tx.Rollback()
+	}
+
+	return report, tx.Commit()
+}
+
+func sanitizeBodies(tx *sqlx.Tx, table string) (int, error) {
+	rows := []struct {
+		ID         int64  `db:"id"`
+		Body       string `db:"body"`
+		BodyFormat string `db:"body_format"`
+		BodyHTML   string `db:"body_html"`
+		BodyText   string `db:"body_text"`
+	}{}
+
+	err := tx.Select(&rows, "SELECT id, body, body_format, body_html, body_text FROM "+table+" WHERE deleted_at IS NULL FOR UPDATE")
+	if err != nil {
+		return 0, err
+	}
+
+	updated := 0
+	for _, row := range rows {
+		body, err := richtext.Render(row.BodyFormat, row.Body)
+		if err != nil {
+			return updated, err
+		}
+
+		if body.Format == row.BodyFormat && body.Source == row.Body && body.HTML == row.BodyHTML && body.Text == row.BodyText {
+			continue
+		}
+
+		_, err = tx.Exec("UPDATE "+table+" SET body = ?, body_format = ?, body_html = ?, body_text = ? WHERE id = ?",
+			body.Source, body.Format, body.HTML, body.Text, row.ID)
+		if err != nil {
+			return updated, err
+		}
+		updated++
+	}
+
+	return updated, nil
+}
@@ -0,0 +1,76 @@
+package db
+
+import (
+	"database/sql"
+	"time"
+)
+
+// Actions on records that are logged as change events
+const (
+	CHANGE_CREATED = "created"
+	CHANGE_UPDATED = "updated"
+	CHANGE_DELETED = "deleted"
+)
+
+// A change to a platform, contact, article or project. Private is set when the record was private at the time, those
+// changes are only shown to admins.
+type ChangeEvent struct {
+	ID         int64         `json:"id" db:"id"`
+	CreatedAt  time.Time     `json:"createdAt" db:"created_at"`
+	EntityType string        `json:"entityType" db:"entity_type"`
+	EntityId   int64         `json:"entityId" db:"entity_id"`
+	Action     string        `json:"action" db:"action"`
+	ActorId    sql.NullInt64 `json:"actorId" db:"actor_id"`
+	Private    bool          `json:"-" db:"private"`
+}
+
+// recordPrivacy selects whether the record with the ID given as argument is private, also when it has been deleted.
+// Contacts are private when their platform is.
+var recordPrivacy = map[string]string{
+	PLATFORM_ENTITY: "SELECT privacy = '" + PRIVACY_PRIVATE + "' FROM platforms WHERE id = ?",
+	CONTACT_ENTITY: `SELECT c.privacy = '` + PRIVACY_PRIVATE + `' OR p.privacy = '` + PRIVACY_PRIVATE + `'
+		FROM contacts c
+		JOIN platforms p ON p.id = c.platform_id
+		WHERE c.id = ?`,
+	ARTICLE_ENTITY: "SELECT FALSE FROM articles WHERE id = ?",
+	PROJECT_ENTITY: "SELECT FALSE FROM projects WHERE id = ?",
+}
+
+// InsertChangeEvent adds a change to the event log, whether it is private is taken from the record. Changes to records
+// that can not be found are kept private.
+func (db *Database) InsertChangeEvent(event ChangeEvent) (int64, error) {
+	privacy, ok := recordPrivacy[event.EntityType]
+	if !ok {
+		return 0, ErrInvalidEntityType
+	}
+
+	result, err := db.querier.Exec(`
+	INSERT INTO change_events (entity_type, entity_id, action, actor_id, private)
+	VALUES (?, ?, ?, ?, COALESCE(( `+privacy+` ), TRUE))`, event.EntityType, event.EntityId, event.Action, event.ActorId, event.EntityId)
+	if err != nil {
+		return 0, err
+	}
+
+	return result.LastInsertId()
+}
+
+// GetChangeEventsAfter lists the change events that came after the given one, the oldest first. Private changes are
+// left out unless includePrivate is set.
+func (db *Database) GetChangeEventsAfter(afterId int64, includePrivate bool, limit int) ([]ChangeEvent, error) {
+	events := []ChangeEvent{}
+
+	err := db.querier.Select(&events, `
+	SELECT * FROM change_events
+	WHERE id > ? AND (? OR NOT private)
+	ORDER BY id
+	LIMIT ?`, afterId, includePrivate, limit)
+	return events, err
+}
+
+// LatestChangeEventId returns the ID of the latest change event, or 0 when there are none
+func (db *Database) LatestChangeEventId() (int64, error) {
+	var id int64
+
+	err := db.querier.Get(&id, "SELECT COALESCE(MAX(id), 0) FROM change_events")
+	return id, err
+}
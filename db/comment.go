@@ -0,0 +1,218 @@
+package db
+
+import (
+	"database/sql"
+	"errors"
+
+	"github.com/jmoiron/sqlx"
+)
+
+var ErrInvalidParentComment = errors.New("invalid parent comment, replies must be to a comment on the same record")
+
+// A comment on a platform, contact, article or project, replies to another comment have its ID as ParentId
+type Comment struct {
+	Model
+	EntityType  string        `json:"entityType" db:"entity_type"`
+	EntityId    int64         `json:"entityId" db:"entity_id"`
+	ParentId    sql.NullInt64 `json:"parentId" db:"parent_id"`
+	AuthorId    sql.NullInt64 `json:"authorId" db:"author_id"`
+	AuthorEmail string        `json:"authorEmail" db:"author_email"`
+	Body        string        `json:"body" db:"body"`
+	EditedAt    sql.NullTime  `json:"editedAt" db:"edited_at"`
+	Replies     []Comment     `json:"replies"`
+}
+
+// Number of comments on a record, for use in the list queries of each record type
+const (
+	platformCommentsCount = `(SELECT COUNT(*) FROM comments cm WHERE cm.entity_type = '` + PLATFORM_ENTITY + `' AND cm.entity_id = p.id AND cm.deleted_at IS NULL)`
+	contactCommentsCount  = `(SELECT COUNT(*) FROM comments cm WHERE cm.entity_type = '` + CONTACT_ENTITY + `' AND cm.entity_id = c.id AND cm.deleted_at IS NULL)`
+	articleCommentsCount  = `(SELECT COUNT(*) FROM comments cm WHERE cm.entity_type = '` + ARTICLE_ENTITY + `' AND cm.entity_id = a.id AND cm.deleted_at IS NULL)`
+	projectCommentsCount  = `(SELECT COUNT(*) FROM comments cm WHERE cm.entity_type = '` + PROJECT_ENTITY + `' AND cm.entity_id = p.id AND cm.deleted_at IS NULL)`
+)
+
+const commentQuery = `
+	SELECT cm.*, COALESCE(u.email, '') AS author_email
+	FROM comments cm
+	LEFT JOIN users u ON u.id = cm.author_id`
+
+func (db *Database) GetComment(id int64) (Comment, error) {
+	comment := Comment{}
+
+	err := db.querier.Get(&comment, commentQuery+" WHERE cm.id = ? AND cm.deleted_at IS NULL", id)
+	return comment, err
+}
+
+// GetComments returns the threads of comments on a record, oldest first, with the replies nested in their comment.
+// Deleted comments are left out unless they have replies, in which case they are kept without body and author.
+func (db *Database) GetComments(entityType string, entityId int64) ([]Comment, error) {
+	comments := []Comment{}
+
+	err := db.querier.Select(&comments, commentQuery+`
+	WHERE cm.entity_type = ? AND cm.entity_id = ?
+	ORDER BY cm.created_at, cm.id`, entityType, entityId)
+	if err != nil {
+		return nil, err
+	}
+
+	return commentThreads(comments), nil
+}
+
+// commentThreads nests the replies in their parent comment, the comments are in the order they were created
+func commentThreads(comments []Comment) []Comment {
+	replies := map[int64][]Comment{}
+	for _, comment := range comments {
+		if comment.ParentId.Valid {
+			replies[comment.ParentId.Int64] = append(replies[comment.ParentId.Int64], comment)
+		}
+	}
+
+	var build func(comment Comment) (Comment, bool)
+	build = func(comment Comment) (Comment, bool) {
+		comment.Replies = []Comment{}
+		for _, reply := range replies[comment.ID] {
+			if reply, ok := build(reply); ok {
+				comment.Replies = append(comment.Replies, reply)
+			}
+		}
+
+		if comment.DeletedAt.Valid {
+			if len(comment.Replies) == 0 {
+				return comment, false
+			}
+			comment.Body = ""
+			comment.AuthorId = sql.NullInt64{}
+			comment.AuthorEmail = ""
+		}
+
+		return comment, true
+	}
+
+	threads := []Comment{}
+	for _, comment := range comments {
+		if comment.ParentId.Valid {
+			continue
+		}
+		if thread, ok := build(comment); ok {
+			threads = append(threads, thread)
+		}
+	}
+
+	return threads
+}
+
+// InsertComment creates a comment and records the users mentioned in it.
+// Replies must be to a comment on the same record, otherwise ErrInvalidParentComment is returned.
+func (db *Database) InsertComment(comment Comment, mentionedIds []int64) (int64, error) {
+	tx, err := db.querier.Beginx()
+	if err != nil {
+		return 0, err
+	}
+
+	if comment.ParentId.Valid {
+		var count int
+		err = tx.Get(&count, "SELECT COUNT(*) FROM comments WHERE id = ? AND entity_type = ? AND entity_id = ? AND deleted_at IS NULL", comment.ParentId, comment.EntityType, comment.EntityId)
+		if err != nil {
+			tx.Rollback()
+			return 0, err
+		}
+
+		if count == 0 {
+			tx.Rollback()
+			return 0, ErrInvalidParentComment
+		}
+	}
+
+	result, err := tx.NamedExec(`
+	INSERT INTO comments (entity_type, entity_id, parent_id, author_id, body)
+	VALUES (:entity_type, :entity_id, :parent_id, :author_id, :body)`, comment)
+	if err != nil {
+		tx.Rollback()
+		return 0, err
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		tx.Rollback()
+		return 0, err
+	}
+
+	err = insertCommentMentions(tx, id, mentionedIds)
+	if err != nil {
+		tx.Rollback()
+		return 0, err
+	}
+
+	return id, tx.Commit()
+}
+
+// EditComment changes the body of a comment and replaces the users mentioned in it
+func (db *Database) EditComment(id int64, body string, mentionedIds []int64) error {
+	tx, err := db.querier.Beginx()
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec("UPDATE comments SET body = ?, edited_at = CURRENT_TIMESTAMP() WHERE id = ?", body, id)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	_, err = tx.Exec("DELETE FROM comments_mentions WHERE comment_id = ?", id)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	err = insertCommentMentions(tx, id, mentionedIds)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func insertCommentMentions(tx *sqlx.Tx, commentId int64, userIds []int64) error {
+	for _, userId := range userIds {
+		_, err := tx.Exec("INSERT INTO comments_mentions (comment_id, user_id) VALUES (?, ?)", commentId, userId)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// GetCommentMentions returns the IDs of the users mentioned in a comment
+func (db *Database) GetCommentMentions(commentId int64) ([]int64, error) {
+	userIds := []int64{}
+
+	err := db.querier.Select(&userIds, "SELECT user_id FROM comments_mentions WHERE comment_id = ? ORDER BY user_id", commentId)
+	return userIds, err
+}
+
+// DeleteComment soft deletes the comment, its replies are kept
+func (db *Database) DeleteComment(id int64) error {
+	_, err := db.querier.Exec("UPDATE comments SET deleted_at = CURRENT_TIMESTAMP() WHERE id = ?", id)
+	return err
+}
+
+// GetMentionableUsers returns the users whose email address or the part of it before the @ is one of the handles
+func (db *Database) GetMentionableUsers(handles []string) ([]User, error) {
+	users := []User{}
+	if len(handles) == 0 {
+		return users, nil
+	}
+
+	query, args, err := sqlx.In(`
+	SELECT * FROM users
+	WHERE deleted_at IS NULL AND (email IN (?) OR SUBSTRING_INDEX(email, '@', 1) IN (?))
+	ORDER BY id`, handles, handles)
+	if err != nil {
+		return nil, err
+	}
+
+	err = db.querier.Select(&users, db.querier.Rebind(query), args...)
+	return users, err
+}
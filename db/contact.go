@@ -1,17 +1,59 @@
 package db
 
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/webstradev/rsdb-backend/normalize"
+)
+
+// A contact is a person's employment at a platform, the same person can be a contact at several platforms over time
 type Contact struct {
 	Model
-	Name       string `json:"name" db:"name" binding:"required"`
-	Title      string `json:"title" db:"title"`
-	Email      string `json:"email" db:"email"`
-	Phone      string `json:"phone" db:"phone"`
-	Phone2     string `json:"phone2" db:"phone2"`
-	Address    string `json:"address" db:"address"`
-	Notes      string `json:"notes" db:"notes"`
-	Source     string `json:"source" db:"source"`
-	Privacy    string `json:"privacy" db:"privacy"`
-	PlatformId int64  `json:"platformId" db:"platform_id"`
+	Name       string       `json:"name" db:"name" binding:"required"`
+	Title      string       `json:"title" db:"title"`
+	Email      string       `json:"email" db:"email"`
+	Phone      string       `json:"phone" db:"phone"`
+	Phone2     string       `json:"phone2" db:"phone2"`
+	Address    string       `json:"address" db:"address"`
+	Notes      string       `json:"notes" db:"notes"`
+	Source     string       `json:"source" db:"source"`
+	Privacy    string       `json:"privacy" db:"privacy"`
+	PlatformId int64        `json:"platformId" db:"platform_id"`
+	PersonId   int64        `json:"personId" db:"person_id"`
+	StartDate  sql.NullTime `json:"startDate" db:"start_date"`
+	EndDate    sql.NullTime `json:"endDate" db:"end_date"`
+	Current    bool         `json:"current" db:"current"`
+	// Date of the latest activity with the contact, only set when listing contacts
+	LastContacted *time.Time `json:"lastContacted,omitempty" db:"last_contacted"`
+	// Number of comments on the contact, only set when listing contacts
+	CommentsCount *int          `json:"commentsCount,omitempty" db:"comments_count"`
+	CreatedBy     sql.NullInt64 `json:"createdBy" db:"created_by"`
+}
+
+// Normalize validates the email address and phone numbers of the contact and stores them in a uniform format.
+// Phone numbers without country code are read as numbers from the given country (usually the platform's).
+func (c *Contact) Normalize(country string) error {
+	var err error
+
+	c.Email, err = normalize.ValidEmail(c.Email)
+	if err != nil {
+		return fmt.Errorf("email: %w", err)
+	}
+
+	c.Phone, err = normalize.E164(c.Phone, country)
+	if err != nil {
+		return fmt.Errorf("phone: %w", err)
+	}
+
+	c.Phone2, err = normalize.E164(c.Phone2, country)
+	if err != nil {
+		return fmt.Errorf("phone2: %w", err)
+	}
+
+	return nil
 }
 
 func (db *Database) CountContacts() (int, error) {
@@ -22,12 +64,19 @@ func (db *Database) CountContacts() (int, error) {
 
 func (db *Database) GetContactsForPlatform(platformId int64) ([]Contact, error) {
 	contacts := []Contact{}
-	err := db.querier.Select(&contacts, "SELECT * FROM contacts WHERE platform_id = ? AND deleted_at IS NULL", platformId)
+	err := db.querier.Select(&contacts, "SELECT c.*, "+contactLastContacted+" AS last_contacted, "+contactCommentsCount+" AS comments_count FROM contacts c WHERE c.platform_id = ? AND c.deleted_at IS NULL", platformId)
 	return contacts, err
 }
 
-func (db *Database) EditContact(contact Contact) error {
-	_, err := db.querier.NamedExec(`
+// EditContact updates the contact and renames the person it belongs to in the same transaction. Whether the contact is
+// a current employment is left unchanged when current is not valid.
+func (db *Database) EditContact(contact Contact, current sql.NullBool) error {
+	tx, err := db.querier.Beginx()
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.NamedExec(`
 		UPDATE contacts 
 		SET 
 			name = :name, 
@@ -38,18 +87,88 @@ func (db *Database) EditContact(contact Contact) error {
 			address = :address, 
 			notes = :notes, 
 			source = :source, 
-			privacy = :privacy 
-		WHERE id = :id AND platform_id = :platform_id`, contact)
-	return err
+			privacy = :privacy,
+			person_id = COALESCE(NULLIF(:person_id, 0), person_id),
+			start_date = :start_date,
+			end_date = :end_date,
+			current = COALESCE(:edited_current, current)
+		WHERE id = :id AND platform_id = :platform_id`, struct {
+		Contact
+		EditedCurrent sql.NullBool `db:"edited_current"`
+	}{contact, current})
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	_, err = tx.Exec(`
+	UPDATE people pe
+	JOIN contacts c ON c.person_id = pe.id
+	SET pe.name = c.name
+	WHERE c.id = ? AND c.platform_id = ?`, contact.ID, contact.PlatformId)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
 }
 
-func (db *Database) InsertContact(contact Contact) error {
-	_, err := db.querier.NamedExec(`
+// InsertContact creates a contact, when the contact isn't linked to an existing person a new person is created for it
+func (db *Database) InsertContact(contact Contact) (int64, error) {
+	tx, err := db.querier.Beginx()
+	if err != nil {
+		return 0, err
+	}
+
+	id, err := insertContact(tx, contact)
+	if err != nil {
+		tx.Rollback()
+		return 0, err
+	}
+
+	return id, tx.Commit()
+}
+
+// InsertContacts creates all contacts in a single transaction and returns their IDs
+func (db *Database) InsertContacts(contacts []Contact) ([]int64, error) {
+	tx, err := db.querier.Beginx()
+	if err != nil {
+		return nil, err
+	}
+
+	ids := []int64{}
+	for _, contact := range contacts {
+		id, err := insertContact(tx, contact)
+		if err != nil {
+			tx.Rollback()
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+
+	return ids, tx.Commit()
+}
+
+func insertContact(tx *sqlx.Tx, contact Contact) (int64, error) {
+	var err error
+	if contact.PersonId == 0 {
+		contact.PersonId, err = insertPerson(tx, Person{Name: contact.Name})
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	result, err := tx.NamedExec(`
 		INSERT INTO contacts 
-			(name, title, email, phone, phone2, address, notes, source, privacy, platform_id) 
+			(name, title, email, phone, phone2, address, notes, source, privacy, platform_id, person_id, start_date, end_date, current, created_by) 
 		VALUES 
-			(:name, :title, :email, :phone, :phone2, :address, :notes, :source, :privacy, :platform_id)`, contact)
-	return err
+			(:name, :title, :email, :phone, :phone2, :address, :notes, :source, :privacy, :platform_id, :person_id, :start_date, :end_date, :current, :created_by)`, contact)
+	if err != nil {
+		return 0, err
+	}
+
+	return result.LastInsertId()
 }
 
 func (db *Database) DeleteContact(id, platformId int64) error {
@@ -0,0 +1,241 @@
+package db
+
+import (
+	"errors"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/webstradev/rsdb-backend/normalize"
+)
+
+// ContactFilter narrows down the contacts directory, empty fields are ignored
+type ContactFilter struct {
+	Search     string
+	PlatformId int64
+	Country    string
+	Category   int64
+	Title      string
+	Privacy    string
+	// Order of the contacts, one of the keys of contactSorts
+	Sort string
+	// Only contacts created after this time, used to find new matches of saved searches
+	CreatedAfter time.Time
+}
+
+// Orders the contacts directory can be sorted in, by name when no order is given
+var contactSorts = map[string]string{
+	"":          "c.name, c.id",
+	"name":      "c.name, c.id",
+	"-name":     "c.name DESC, c.id DESC",
+	"platform":  "p.name, c.name, c.id",
+	"-platform": "p.name DESC, c.name DESC, c.id DESC",
+	"created":   "c.created_at, c.id",
+	"-created":  "c.created_at DESC, c.id DESC",
+}
+
+var errInvalidContactSort = errors.New("Invalid sort, use name, platform or created, prefixed with - for descending order")
+
+// ParseContactFilter reads the filters and order of the contacts directory from query string values
+func ParseContactFilter(values url.Values) (ContactFilter, error) {
+	filter := ContactFilter{
+		Search:  values.Get("search"),
+		Country: values.Get("country"),
+		Title:   values.Get("title"),
+		Privacy: values.Get("privacy"),
+		Sort:    values.Get("sort"),
+	}
+
+	// Countries are stored as ISO codes, names are only matched as given when they are unknown
+	if country, err := normalize.Country(filter.Country); err == nil {
+		filter.Country = country
+	}
+
+	if platformString := values.Get("platform"); platformString != "" {
+		id, err := strconv.ParseInt(platformString, 10, 64)
+		if err != nil {
+			return filter, errors.New("Invalid platform")
+		}
+		filter.PlatformId = id
+	}
+
+	if categoryString := values.Get("category"); categoryString != "" {
+		id, err := strconv.ParseInt(categoryString, 10, 64)
+		if err != nil {
+			return filter, errors.New("Invalid category")
+		}
+		filter.Category = id
+	}
+
+	if _, ok := contactSorts[filter.Sort]; !ok {
+		return filter, errInvalidContactSort
+	}
+
+	return filter, nil
+}
+
+// orderBy returns the order of the filter, the platform is available as p and the contact as c
+func (f ContactFilter) orderBy() string {
+	if order, ok := contactSorts[f.Sort]; ok {
+		return order
+	}
+	return contactSorts[""]
+}
+
+type DirectoryContact struct {
+	Contact
+	PlatformName    string `json:"platformName" db:"platform_name"`
+	PlatformCountry string `json:"platformCountry" db:"platform_country"`
+	CategoryString  string `json:"categoryString" db:"platform_categories"`
+}
+
+// where builds the conditions for the filter, the platform is available as p and the contact as c
+func (f ContactFilter) where() (string, []any) {
+	conditions := []string{"c.deleted_at IS NULL", "p.deleted_at IS NULL"}
+	args := []any{}
+
+	if f.Search != "" {
+		search := likePattern(f.Search)
+		conditions = append(conditions, "(c.name LIKE ? OR c.email LIKE ? OR c.title LIKE ? OR p.name LIKE ?)")
+		args = append(args, search, search, search, search)
+	}
+
+	if f.PlatformId != 0 {
+		conditions = append(conditions, "p.id = ?")
+		args = append(args, f.PlatformId)
+	}
+
+	if f.Country != "" {
+		conditions = append(conditions, "p.country = ?")
+		args = append(args, f.Country)
+	}
+
+	if f.Category != 0 {
+		conditions = append(conditions, "EXISTS (SELECT 1 FROM platforms_categories fpc WHERE fpc.platform_id = p.id AND fpc.category_id = ?)")
+		args = append(args, f.Category)
+	}
+
+	if f.Title != "" {
+		conditions = append(conditions, "c.title LIKE ?")
+		args = append(args, likePattern(f.Title))
+	}
+
+	if f.Privacy != "" {
+		conditions = append(conditions, "c.privacy = ?")
+		args = append(args, f.Privacy)
+	}
+
+	if !f.CreatedAfter.IsZero() {
+		conditions = append(conditions, "c.created_at > ?")
+		args = append(args, f.CreatedAfter)
+	}
+
+	return strings.Join(conditions, " AND "), args
+}
+
+// visibleWhere builds the conditions for the filter like where, leaving out private contacts and the contacts of private
+// platforms unless includePrivate is set
+func (f ContactFilter) visibleWhere(includePrivate bool) (string, []any) {
+	where, args := f.where()
+	if !includePrivate {
+		where += " AND c.privacy <> ? AND p.privacy <> ?"
+		args = append(args, PRIVACY_PRIVATE, PRIVACY_PRIVATE)
+	}
+	return where, args
+}
+
+// likePattern matches the value anywhere in a column, wildcards in the value itself are matched literally
+func likePattern(value string) string {
+	value = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(value)
+	return "%" + value + "%"
+}
+
+const directoryContactQuery = `
+	SELECT
+		c.*,
+		p.name AS platform_name,
+		p.country AS platform_country,
+		COALESCE(GROUP_CONCAT(DISTINCT ca.category), '') AS platform_categories,
+		` + contactLastContacted + ` AS last_contacted,
+		` + contactCommentsCount + ` AS comments_count
+	FROM
+		contacts c
+	JOIN
+		platforms p ON p.id = c.platform_id
+	LEFT JOIN
+		platforms_categories pc ON pc.platform_id = p.id
+	LEFT JOIN
+		categories ca ON ca.id = pc.category_id
+	WHERE `
+
+// GetContactsDirectory lists the contacts of all platforms, including the name, country and categories of their platform.
+// Private contacts and the contacts of private platforms are only listed when includePrivate is set.
+func (db *Database) GetContactsDirectory(filter ContactFilter, includePrivate bool, page, pageSize int) ([]DirectoryContact, error) {
+	contacts := []DirectoryContact{}
+
+	where, args := filter.visibleWhere(includePrivate)
+	args = append(args, pageSize, page*pageSize)
+
+	err := db.querier.Select(&contacts, directoryContactQuery+where+`
+	GROUP BY c.id
+	ORDER BY `+filter.orderBy()+`
+	LIMIT ? OFFSET ?`, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	return contacts, nil
+}
+
+func (db *Database) GetDirectoryContact(id int64) (DirectoryContact, error) {
+	contact := DirectoryContact{}
+
+	err := db.querier.Get(&contact, directoryContactQuery+`c.id = ? AND c.deleted_at IS NULL AND p.deleted_at IS NULL
+	GROUP BY c.id`, id)
+	return contact, err
+}
+
+// StreamContacts passes every contact of the directory that matches the filter to fn, without holding all of them in memory
+func (db *Database) StreamContacts(filter ContactFilter, includePrivate bool, fn func(DirectoryContact) error) error {
+	where, args := filter.visibleWhere(includePrivate)
+
+	rows, err := db.querier.Queryx(directoryContactQuery+where+`
+	GROUP BY c.id
+	ORDER BY `+filter.orderBy(), args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		contact := DirectoryContact{}
+		err = rows.StructScan(&contact)
+		if err != nil {
+			return err
+		}
+
+		err = fn(contact)
+		if err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
+}
+
+func (db *Database) CountContactsDirectory(filter ContactFilter, includePrivate bool) (int, error) {
+	var count int
+
+	where, args := filter.visibleWhere(includePrivate)
+
+	err := db.querier.Get(&count, `
+	SELECT
+		COUNT(*) AS count
+	FROM
+		contacts c
+	JOIN
+		platforms p ON p.id = c.platform_id
+	WHERE `+where, args...)
+	return count, err
+}
@@ -0,0 +1,337 @@
+package db
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"sort"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/webstradev/rsdb-backend/normalize"
+)
+
+const (
+	MERGE_KEEP_SURVIVOR  = "survivor"
+	MERGE_KEEP_DUPLICATE = "duplicate"
+	MERGE_COMBINED       = "combined"
+)
+
+var (
+	ErrInvalidContactMerge      = errors.New("a contact can not be merged into itself")
+	ErrInvalidContactPreference = errors.New("invalid field preference, use a contact field with either survivor or duplicate")
+)
+
+// Contact fields that are taken from either the surviving or the duplicate contact when merging
+var contactMergeFields = []struct {
+	name  string
+	value func(*Contact) *string
+}{
+	{"name", func(c *Contact) *string { return &c.Name }},
+	{"title", func(c *Contact) *string { return &c.Title }},
+	{"email", func(c *Contact) *string { return &c.Email }},
+	{"phone", func(c *Contact) *string { return &c.Phone }},
+	{"phone2", func(c *Contact) *string { return &c.Phone2 }},
+	{"address", func(c *Contact) *string { return &c.Address }},
+	{"source", func(c *Contact) *string { return &c.Source }},
+	{"privacy", func(c *Contact) *string { return &c.Privacy }},
+}
+
+type DuplicateContact struct {
+	ID           int64  `json:"id" db:"id"`
+	Name         string `json:"name" db:"name"`
+	Title        string `json:"title" db:"title"`
+	Email        string `json:"email" db:"email"`
+	Phone        string `json:"phone" db:"phone"`
+	Phone2       string `json:"phone2" db:"phone2"`
+	PlatformId   int64  `json:"platformId" db:"platform_id"`
+	PlatformName string `json:"platformName" db:"platform_name"`
+}
+
+type ContactDuplicateGroup struct {
+	Match    string             `json:"match"`
+	Key      string             `json:"key"`
+	Contacts []DuplicateContact `json:"contacts"`
+}
+
+type ContactMerge struct {
+	Model
+	ContactId       int64           `json:"contactId" db:"contact_id"`
+	MergedContactId int64           `json:"mergedContactId" db:"merged_contact_id"`
+	MergedBy        sql.NullInt64   `json:"mergedBy" db:"merged_by"`
+	Fields          json.RawMessage `json:"fields" db:"fields"`
+	MergedContact   json.RawMessage `json:"mergedContact" db:"merged_contact"`
+}
+
+func (db *Database) GetContactsForDuplicateCheck() ([]DuplicateContact, error) {
+	contacts := []DuplicateContact{}
+
+	err := db.querier.Select(&contacts, `
+	SELECT
+		c.id, c.name, c.title, c.email, c.phone, c.phone2, c.platform_id,
+		p.name AS platform_name
+	FROM
+		contacts c
+	JOIN
+		platforms p ON p.id = c.platform_id
+	WHERE c.deleted_at IS NULL AND p.deleted_at IS NULL
+	ORDER BY c.id`)
+	return contacts, err
+}
+
+// FindDuplicateContacts groups contacts that share a normalized email address, phone number or name
+func FindDuplicateContacts(contacts []DuplicateContact) []ContactDuplicateGroup {
+	groups := map[string]*ContactDuplicateGroup{}
+
+	add := func(match, key string, contact DuplicateContact) {
+		if key == "" {
+			return
+		}
+
+		group, ok := groups[match+":"+key]
+		if !ok {
+			group = &ContactDuplicateGroup{Match: match, Key: key}
+			groups[match+":"+key] = group
+		}
+
+		// A contact can have the same number as phone and phone2
+		for _, existing := range group.Contacts {
+			if existing.ID == contact.ID {
+				return
+			}
+		}
+
+		group.Contacts = append(group.Contacts, contact)
+	}
+
+	for _, contact := range contacts {
+		add("email", normalize.Email(contact.Email), contact)
+		add("phone", normalize.PhoneKey(contact.Phone), contact)
+		add("phone", normalize.PhoneKey(contact.Phone2), contact)
+		add("name", normalize.PersonName(contact.Name), contact)
+	}
+
+	duplicates := []ContactDuplicateGroup{}
+	for _, group := range groups {
+		if len(group.Contacts) > 1 {
+			duplicates = append(duplicates, *group)
+		}
+	}
+
+	sort.Slice(duplicates, func(i, j int) bool {
+		if duplicates[i].Match != duplicates[j].Match {
+			return duplicates[i].Match < duplicates[j].Match
+		}
+		return duplicates[i].Key < duplicates[j].Key
+	})
+
+	return duplicates
+}
+
+// MergeContactFields combines two contacts into one. By default a field of the surviving contact wins unless it is empty,
+// this can be overridden per field with the prefer map. Notes are combined unless a preference is given for them.
+// The returned map lists which contact won for every field.
+func MergeContactFields(survivor, duplicate Contact, prefer map[string]string) (Contact, map[string]string, error) {
+	merged := survivor
+	fields := map[string]string{}
+
+	for field, keep := range prefer {
+		if keep != MERGE_KEEP_SURVIVOR && keep != MERGE_KEEP_DUPLICATE {
+			return merged, nil, ErrInvalidContactPreference
+		}
+
+		known := field == "notes"
+		for _, f := range contactMergeFields {
+			known = known || f.name == field
+		}
+		if !known {
+			return merged, nil, ErrInvalidContactPreference
+		}
+	}
+
+	for _, f := range contactMergeFields {
+		keep, ok := prefer[f.name]
+		if !ok {
+			keep = MERGE_KEEP_SURVIVOR
+			if *f.value(&survivor) == "" && *f.value(&duplicate) != "" {
+				keep = MERGE_KEEP_DUPLICATE
+			}
+		}
+
+		if keep == MERGE_KEEP_DUPLICATE {
+			*f.value(&merged) = *f.value(&duplicate)
+		}
+		fields[f.name] = keep
+	}
+
+	keep, ok := prefer["notes"]
+	switch {
+	case ok && keep == MERGE_KEEP_DUPLICATE:
+		merged.Notes = duplicate.Notes
+	case ok:
+		merged.Notes = survivor.Notes
+	case duplicate.Notes == "" || duplicate.Notes == survivor.Notes:
+		keep = MERGE_KEEP_SURVIVOR
+	case survivor.Notes == "":
+		keep = MERGE_KEEP_DUPLICATE
+		merged.Notes = duplicate.Notes
+	default:
+		keep = MERGE_COMBINED
+		merged.Notes = survivor.Notes + "\n\n" + duplicate.Notes
+	}
+	fields["notes"] = keep
+
+	return merged, fields, nil
+}
+
+// MergeContacts merges the duplicate contact into the survivor, moves its activities, tasks, attachments, comments and
+// favorites onto the survivor, soft-deletes the duplicate and stores an audit of which fields won in a single transaction
+func (db *Database) MergeContacts(survivorId, duplicateId int64, prefer map[string]string, mergedBy int64) (*ContactMerge, error) {
+	if survivorId == duplicateId {
+		return nil, ErrInvalidContactMerge
+	}
+
+	tx, err := db.querier.Beginx()
+	if err != nil {
+		return nil, err
+	}
+
+	survivor, err := getContactForUpdate(tx, survivorId)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	duplicate, err := getContactForUpdate(tx, duplicateId)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	merged, fields, err := MergeContactFields(survivor, duplicate, prefer)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	_, err = tx.NamedExec(`
+		UPDATE contacts
+		SET
+			name = :name,
+			title = :title,
+			email = :email,
+			phone = :phone,
+			phone2 = :phone2,
+			address = :address,
+			notes = :notes,
+			source = :source,
+			privacy = :privacy
+		WHERE id = :id`, merged)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	// The person of the survivor is known by the merged name as well
+	_, err = tx.Exec(`
+	UPDATE people pe
+	JOIN contacts c ON c.person_id = pe.id
+	SET pe.name = c.name
+	WHERE c.id = ?`, survivorId)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	_, err = tx.Exec("UPDATE contacts SET deleted_at = CURRENT_TIMESTAMP() WHERE id = ?", duplicateId)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	// Move everything that is linked to the duplicate onto the survivor, the activities with the duplicate become
+	// part of the timeline of the survivor
+	statements := []string{
+		"UPDATE tasks SET entity_id = ? WHERE entity_type = '" + CONTACT_ENTITY + "' AND entity_id = ?",
+		"UPDATE attachments SET entity_id = ? WHERE entity_type = '" + CONTACT_ENTITY + "' AND entity_id = ?",
+		"UPDATE comments SET entity_id = ? WHERE entity_type = '" + CONTACT_ENTITY + "' AND entity_id = ?",
+		"INSERT IGNORE INTO favorites (user_id, entity_type, entity_id, created_at) SELECT user_id, entity_type, ?, created_at FROM favorites WHERE entity_type = '" + CONTACT_ENTITY + "' AND entity_id = ?",
+		"INSERT IGNORE INTO activities_contacts (activity_id, contact_id) SELECT activity_id, ? FROM activities_contacts WHERE contact_id = ?",
+	}
+
+	for _, statement := range statements {
+		_, err = tx.Exec(statement, survivorId, duplicateId)
+		if err != nil {
+			tx.Rollback()
+			return nil, err
+		}
+	}
+
+	// Remove the links and favorites of the duplicate now that they have been copied
+	deletes := []string{
+		"DELETE FROM favorites WHERE entity_type = '" + CONTACT_ENTITY + "' AND entity_id = ?",
+		"DELETE FROM activities_contacts WHERE contact_id = ?",
+	}
+
+	for _, statement := range deletes {
+		_, err = tx.Exec(statement, duplicateId)
+		if err != nil {
+			tx.Rollback()
+			return nil, err
+		}
+	}
+
+	audit := ContactMerge{
+		ContactId:       survivorId,
+		MergedContactId: duplicateId,
+		MergedBy:        sql.NullInt64{Int64: mergedBy, Valid: mergedBy != 0},
+	}
+
+	audit.Fields, err = json.Marshal(fields)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	audit.MergedContact, err = json.Marshal(duplicate)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	result, err := tx.NamedExec(`
+		INSERT INTO contact_merges
+			(contact_id, merged_contact_id, merged_by, fields, merged_contact)
+		VALUES
+			(:contact_id, :merged_contact_id, :merged_by, :fields, :merged_contact)`, audit)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	audit.ID, err = result.LastInsertId()
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, err
+	}
+
+	return &audit, nil
+}
+
+func (db *Database) GetContactMerges(contactId int64) ([]ContactMerge, error) {
+	merges := []ContactMerge{}
+
+	err := db.querier.Select(&merges, "SELECT * FROM contact_merges WHERE contact_id = ? AND deleted_at IS NULL ORDER BY created_at DESC", contactId)
+	return merges, err
+}
+
+func getContactForUpdate(tx *sqlx.Tx, id int64) (Contact, error) {
+	contact := Contact{}
+
+	err := tx.Get(&contact, "SELECT * FROM contacts WHERE id = ? AND deleted_at IS NULL FOR UPDATE", id)
+	return contact, err
+}
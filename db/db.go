@@ -1,11 +1,16 @@
 package db
 
 import (
-	_ "github.com/go-sql-driver/mysql"
+	"errors"
+
+	"github.com/go-sql-driver/mysql"
 	"github.com/jmoiron/sqlx"
 	"github.com/webstradev/rsdb-backend/migrations"
 )
 
+// Error number of MySQL and MariaDB for a row that references a row that doesn't exist
+const ER_NO_REFERENCED_ROW = 1452
+
 type Database struct {
 	querier  *sqlx.DB
 	migrator *migrations.Sqlx
@@ -37,3 +42,9 @@ func (db *Database) Migrate() error {
 	err := db.migrator.Migrate(db.querier.DB, "mysql")
 	return err
 }
+
+// IsForeignKeyError reports whether the error is caused by a reference to a row that doesn't exist, e.g. an unknown user
+func IsForeignKeyError(err error) bool {
+	mysqlErr := &mysql.MySQLError{}
+	return errors.As(err, &mysqlErr) && mysqlErr.Number == ER_NO_REFERENCED_ROW
+}
@@ -0,0 +1,364 @@
+package db
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"golang.org/x/text/currency"
+)
+
+const (
+	DEAL_DRAFT       = "draft"
+	DEAL_NEGOTIATING = "negotiating"
+	DEAL_SIGNED      = "signed"
+	DEAL_CANCELLED   = "cancelled"
+)
+
+// Media a project can be licensed for
+var DealMedia = []string{"svod", "avod", "tvod", "est", "tv", "theatrical", "home_video", "airline"}
+
+var (
+	ErrInvalidDealStatus = errors.New("invalid deal status, use draft, negotiating, signed or cancelled")
+	ErrInvalidDealMedia  = errors.New("invalid media, use svod, avod, tvod, est, tv, theatrical, home_video or airline")
+	ErrInvalidDealRights = errors.New("a deal needs at least one territory and one media")
+	ErrInvalidDealWindow = errors.New("the window of a deal can not end before it starts")
+	ErrInvalidCurrency   = errors.New("invalid currency, use an ISO 4217 code")
+	ErrDealFeeCurrency   = errors.New("a fee needs a currency")
+)
+
+// A deal licenses a project to a platform for a set of territories and media during a window
+type Deal struct {
+	Model
+	ProjectId    int64         `json:"projectId" db:"project_id"`
+	ProjectTitle string        `json:"projectTitle" db:"project_title"`
+	PlatformId   int64         `json:"platformId" db:"platform_id"`
+	PlatformName string        `json:"platformName" db:"platform_name"`
+	Exclusive    bool          `json:"exclusive" db:"exclusive"`
+	WindowStart  time.Time     `json:"windowStart" db:"window_start"`
+	WindowEnd    time.Time     `json:"windowEnd" db:"window_end"`
+	Fee          sql.NullInt64 `json:"fee" db:"fee"` // In the minor unit of the currency (e.g. cents)
+	Currency     string        `json:"currency" db:"currency"`
+	Status       string        `json:"status" db:"status"`
+	CreatedBy    sql.NullInt64 `json:"createdBy" db:"created_by"`
+	Territories  []string      `json:"territories"`
+	Media        []string      `json:"media"`
+}
+
+const dealQuery = `
+	SELECT
+		d.*,
+		pr.title AS project_title,
+		p.name AS platform_name
+	FROM
+		deals d
+	JOIN
+		projects pr ON pr.id = d.project_id
+	JOIN
+		platforms p ON p.id = d.platform_id
+	WHERE d.deleted_at IS NULL AND `
+
+// Validate checks the status, rights, window and fee of the deal, territories are expected to be ISO codes already
+func (d *Deal) Validate() error {
+	switch d.Status {
+	case DEAL_DRAFT, DEAL_NEGOTIATING, DEAL_SIGNED, DEAL_CANCELLED:
+	default:
+		return ErrInvalidDealStatus
+	}
+
+	if len(d.Territories) == 0 || len(d.Media) == 0 {
+		return ErrInvalidDealRights
+	}
+
+	for _, media := range d.Media {
+		valid := false
+		for _, m := range DealMedia {
+			valid = valid || media == m
+		}
+		if !valid {
+			return ErrInvalidDealMedia
+		}
+	}
+
+	if d.WindowEnd.Before(d.WindowStart) {
+		return ErrInvalidDealWindow
+	}
+
+	if d.Currency != "" {
+		unit, err := currency.ParseISO(d.Currency)
+		if err != nil {
+			return ErrInvalidCurrency
+		}
+		d.Currency = unit.String()
+	}
+
+	if d.Fee.Valid && d.Currency == "" {
+		return ErrDealFeeCurrency
+	}
+
+	return nil
+}
+
+func (db *Database) GetDeal(id int64) (Deal, error) {
+	deal := Deal{}
+
+	err := db.querier.Get(&deal, dealQuery+"d.id = ?", id)
+	if err != nil {
+		return deal, err
+	}
+
+	deals := []Deal{deal}
+	err = populateRights(db.querier, deals)
+	return deals[0], err
+}
+
+// GetProjectDeals lists the deals of a project, ordered by their window
+func (db *Database) GetProjectDeals(projectId int64) ([]Deal, error) {
+	return selectDeals(db.querier, "d.project_id = ? ORDER BY d.window_start, d.id", projectId)
+}
+
+// GetPlatformDeals lists the deals a platform is the licensee of, ordered by their window
+func (db *Database) GetPlatformDeals(platformId int64) ([]Deal, error) {
+	return selectDeals(db.querier, "d.platform_id = ? ORDER BY d.window_start, d.id", platformId)
+}
+
+// DealConflictError is returned when a deal can not be stored because other deals block it
+type DealConflictError struct {
+	Conflicts []Deal
+}
+
+func (e *DealConflictError) Error() string {
+	return "the deal overlaps deals that block it in territory, media and time"
+}
+
+// checkConflicts locks the project of the deal until the end of the transaction, so deals of a project are stored
+// one at a time, and returns a DealConflictError when other deals block it (see selectBlockingDeals). Cancelled deals
+// never conflict.
+func checkConflicts(tx *sqlx.Tx, deal Deal) error {
+	var projectId int64
+	err := tx.Get(&projectId, "SELECT id FROM projects WHERE id = ? FOR UPDATE", deal.ProjectId)
+	if err != nil {
+		return err
+	}
+
+	if deal.Status == DEAL_CANCELLED {
+		return nil
+	}
+
+	conflicts, err := selectBlockingDeals(tx, deal)
+	if err != nil {
+		return err
+	}
+
+	if len(conflicts) > 0 {
+		return &DealConflictError{Conflicts: conflicts}
+	}
+
+	return nil
+}
+
+// GetBlockingDeals lists the deals of a project that block a deal for the given territories, media and window, the
+// same deals that keep such a deal from being stored
+func (db *Database) GetBlockingDeals(projectId int64, territories, media []string, from, to time.Time, exclusive bool) ([]Deal, error) {
+	deal := Deal{ProjectId: projectId, Territories: territories, Media: media, WindowStart: from, WindowEnd: to, Exclusive: exclusive}
+	return selectBlockingDeals(db.querier, deal)
+}
+
+// selectBlockingDeals lists the overlapping deals that block the deal. Exclusive deals block any deal, and an
+// exclusive deal is blocked by deals that are not exclusive as well.
+func selectBlockingDeals(q sqlx.Queryer, deal Deal) ([]Deal, error) {
+	return selectOverlappingDeals(q, deal, !deal.Exclusive)
+}
+
+// selectOverlappingDeals lists the deals (other than the given one) that are not cancelled and overlap the deal
+// in territory, media and time, ordered by their window
+func selectOverlappingDeals(q sqlx.Queryer, deal Deal, exclusiveOnly bool) ([]Deal, error) {
+	where := "d.project_id = ? AND d.id <> ? AND d.status <> ?"
+	if exclusiveOnly {
+		where += " AND d.exclusive = 1"
+	}
+
+	query, args, err := sqlx.In(where+`
+	AND d.window_start <= ? AND d.window_end >= ?
+	AND EXISTS (SELECT 1 FROM deals_territories dt WHERE dt.deal_id = d.id AND dt.territory IN (?))
+	AND EXISTS (SELECT 1 FROM deals_media dm WHERE dm.deal_id = d.id AND dm.media IN (?))
+	ORDER BY d.window_start, d.id`,
+		deal.ProjectId, deal.ID, DEAL_CANCELLED,
+		deal.WindowEnd.Format(time.DateOnly), deal.WindowStart.Format(time.DateOnly),
+		deal.Territories, deal.Media)
+	if err != nil {
+		return nil, err
+	}
+
+	return selectDeals(q, query, args...)
+}
+
+func selectDeals(q sqlx.Queryer, where string, args ...any) ([]Deal, error) {
+	deals := []Deal{}
+
+	err := sqlx.Select(q, &deals, dealQuery+where, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	err = populateRights(q, deals)
+	return deals, err
+}
+
+// populateRights loads the territories and media of all deals with one query each
+func populateRights(q sqlx.Queryer, deals []Deal) error {
+	if len(deals) == 0 {
+		return nil
+	}
+
+	ids := make([]int64, len(deals))
+	index := map[int64]int{}
+	for i := range deals {
+		ids[i] = deals[i].ID
+		index[deals[i].ID] = i
+		deals[i].Territories = []string{}
+		deals[i].Media = []string{}
+	}
+
+	rights := []struct {
+		DealId int64  `db:"deal_id"`
+		Kind   string `db:"kind"`
+		Value  string `db:"value"`
+	}{}
+
+	query, args, err := sqlx.In(`
+	SELECT deal_id, 'territory' AS kind, territory AS value FROM deals_territories WHERE deal_id IN (?)
+	UNION ALL
+	SELECT deal_id, 'media' AS kind, media AS value FROM deals_media WHERE deal_id IN (?)
+	ORDER BY deal_id, kind, value`, ids, ids)
+	if err != nil {
+		return err
+	}
+
+	err = sqlx.Select(q, &rights, query, args...)
+	if err != nil {
+		return err
+	}
+
+	for _, right := range rights {
+		i := index[right.DealId]
+		if right.Kind == "territory" {
+			deals[i].Territories = append(deals[i].Territories, right.Value)
+		} else {
+			deals[i].Media = append(deals[i].Media, right.Value)
+		}
+	}
+
+	return nil
+}
+
+// InsertDeal stores the deal with its territories and media in a single transaction. It returns a
+// DealConflictError when the deal overlaps an exclusive deal.
+func (db *Database) InsertDeal(deal Deal) (int64, error) {
+	tx, err := db.querier.Beginx()
+	if err != nil {
+		return 0, err
+	}
+
+	err = checkConflicts(tx, deal)
+	if err != nil {
+		tx.Rollback()
+		return 0, err
+	}
+
+	result, err := tx.NamedExec(`
+		INSERT INTO deals
+			(project_id, platform_id, exclusive, window_start, window_end, fee, currency, status, created_by)
+		VALUES
+			(:project_id, :platform_id, :exclusive, :window_start, :window_end, :fee, :currency, :status, :created_by)`, deal)
+	if err != nil {
+		tx.Rollback()
+		return 0, err
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		tx.Rollback()
+		return 0, err
+	}
+
+	err = insertRights(tx, id, deal.Territories, deal.Media)
+	if err != nil {
+		tx.Rollback()
+		return 0, err
+	}
+
+	return id, tx.Commit()
+}
+
+// UpdateDeal stores the changes to the deal and replaces its territories and media in a single transaction. It
+// returns a DealConflictError when the deal overlaps an exclusive deal.
+func (db *Database) UpdateDeal(deal Deal) error {
+	tx, err := db.querier.Beginx()
+	if err != nil {
+		return err
+	}
+
+	err = checkConflicts(tx, deal)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	_, err = tx.NamedExec(`
+		UPDATE deals
+		SET
+			project_id = :project_id,
+			platform_id = :platform_id,
+			exclusive = :exclusive,
+			window_start = :window_start,
+			window_end = :window_end,
+			fee = :fee,
+			currency = :currency,
+			status = :status
+		WHERE id = :id`, deal)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	for _, table := range []string{"deals_territories", "deals_media"} {
+		_, err = tx.Exec("DELETE FROM "+table+" WHERE deal_id = ?", deal.ID)
+		if err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	err = insertRights(tx, deal.ID, deal.Territories, deal.Media)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func insertRights(tx *sqlx.Tx, dealId int64, territories, media []string) error {
+	for _, territory := range territories {
+		_, err := tx.Exec("INSERT IGNORE INTO deals_territories (deal_id, territory) VALUES (?, ?)", dealId, territory)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, m := range media {
+		_, err := tx.Exec("INSERT IGNORE INTO deals_media (deal_id, media) VALUES (?, ?)", dealId, m)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (db *Database) DeleteDeal(id int64) error {
+	_, err := db.querier.Exec("UPDATE deals SET deleted_at = CURRENT_TIMESTAMP() WHERE id = ?", id)
+	return err
+}
@@ -0,0 +1,148 @@
+package db
+
+import (
+	"database/sql"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// Platforms and contacts with this privacy are only exported for admins
+const PRIVACY_PRIVATE = "private"
+
+// Columns of the exports, in the same order as the values passed to the write function
+var (
+	PlatformExportColumns = []string{"id", "name", "website", "country", "source", "privacy", "categories", "notes", "comment"}
+	ContactExportColumns  = []string{"id", "name", "title", "email", "phone", "phone2", "address", "source", "privacy", "notes", "platformId", "platform", "country", "categories"}
+	ArticleExportColumns  = []string{"id", "title", "description", "link", "date", "tags", "platforms"}
+	ProjectExportColumns  = []string{"id", "title", "description", "link", "date", "tags", "platforms"}
+)
+
+// exportRows passes every row of the query to write as soon as it is read, so exports are never held in memory as a whole
+func exportRows(q sqlx.Queryer, write func([]string) error, query string, args ...any) error {
+	rows, err := q.Query(query, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return err
+	}
+
+	values := make([]sql.NullString, len(columns))
+	pointers := make([]any, len(columns))
+	for i := range values {
+		pointers[i] = &values[i]
+	}
+
+	for rows.Next() {
+		err = rows.Scan(pointers...)
+		if err != nil {
+			return err
+		}
+
+		record := make([]string, len(values))
+		for i, value := range values {
+			record[i] = value.String
+		}
+
+		err = write(record)
+		if err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
+}
+
+func (db *Database) ExportPlatforms(includePrivate bool, write func([]string) error) error {
+	args := []any{}
+	where := "p.deleted_at IS NULL"
+	if !includePrivate {
+		where += " AND p.privacy <> ?"
+		args = append(args, PRIVACY_PRIVATE)
+	}
+
+	return exportRows(db.querier, write, `
+	SELECT
+		p.id, p.name, p.website, p.country, p.source, p.privacy,
+		COALESCE(GROUP_CONCAT(DISTINCT ca.category), '') AS platform_categories,
+		p.notes, p.comment
+	FROM
+		platforms p
+	LEFT JOIN
+		platforms_categories pc ON pc.platform_id = p.id
+	LEFT JOIN
+		categories ca ON ca.id = pc.category_id
+	WHERE `+where+`
+	GROUP BY p.id
+	ORDER BY p.id`, args...)
+}
+
+func (db *Database) ExportContacts(filter ContactFilter, includePrivate bool, write func([]string) error) error {
+	where, args := filter.visibleWhere(includePrivate)
+
+	return exportRows(db.querier, write, `
+	SELECT
+		c.id, c.name, c.title, c.email, c.phone, c.phone2, c.address, c.source, c.privacy, c.notes,
+		p.id AS platform_id,
+		p.name AS platform_name,
+		p.country AS platform_country,
+		COALESCE(GROUP_CONCAT(DISTINCT ca.category), '') AS platform_categories
+	FROM
+		contacts c
+	JOIN
+		platforms p ON p.id = c.platform_id
+	LEFT JOIN
+		platforms_categories pc ON pc.platform_id = p.id
+	LEFT JOIN
+		categories ca ON ca.id = pc.category_id
+	WHERE `+where+`
+	GROUP BY c.id
+	ORDER BY `+filter.orderBy(), args...)
+}
+
+func (db *Database) ExportArticles(includePrivate bool, write func([]string) error) error {
+	return exportRows(db.querier, write, `
+	SELECT
+		a.id, a.title, a.description, a.link,
+		COALESCE(DATE_FORMAT(a.date, '%Y-%m-%d'), '') AS date,
+		COALESCE(GROUP_CONCAT(DISTINCT t.tag), '') AS article_tags,
+		COALESCE(GROUP_CONCAT(DISTINCT p.name), '') AS article_platforms
+	FROM
+		articles a
+	LEFT JOIN
+		articles_tags at ON at.article_id = a.id
+	LEFT JOIN
+		tags t ON t.id = at.tag_id
+	LEFT JOIN
+		platforms_articles pa ON pa.article_id = a.id
+	LEFT JOIN
+		platforms p ON p.id = pa.platform_id AND p.deleted_at IS NULL AND (? OR p.privacy <> ?)
+	WHERE a.deleted_at IS NULL
+	GROUP BY a.id
+	ORDER BY a.id`, includePrivate, PRIVACY_PRIVATE)
+}
+
+func (db *Database) ExportProjects(includePrivate bool, write func([]string) error) error {
+	return exportRows(db.querier, write, `
+	SELECT
+		pr.id, pr.title, pr.description, pr.link,
+		COALESCE(DATE_FORMAT(pr.date, '%Y-%m-%d'), '') AS date,
+		COALESCE(GROUP_CONCAT(DISTINCT t.tag), '') AS project_tags,
+		COALESCE(GROUP_CONCAT(DISTINCT p.name), '') AS project_platforms
+	FROM
+		projects pr
+	LEFT JOIN
+		projects_tags pt ON pt.project_id = pr.id
+	LEFT JOIN
+		tags t ON t.id = pt.tag_id
+	LEFT JOIN
+		platforms_projects pp ON pp.project_id = pr.id
+	LEFT JOIN
+		platforms p ON p.id = pp.platform_id AND p.deleted_at IS NULL AND (? OR p.privacy <> ?)
+	WHERE pr.deleted_at IS NULL
+	GROUP BY pr.id
+	ORDER BY pr.id`, includePrivate, PRIVACY_PRIVATE)
+}
@@ -0,0 +1,142 @@
+package db
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+)
+
+var ErrInvalidFavoriteType = errors.New("invalid favorite type, use platform, contact or project")
+
+// Entity types users can add to their watchlist
+var favoriteEntityTypes = map[string]bool{
+	PLATFORM_ENTITY: true,
+	CONTACT_ENTITY:  true,
+	PROJECT_ENTITY:  true,
+}
+
+// A record on the watchlist of a user
+type Favorite struct {
+	EntityType  string       `json:"entityType" db:"entity_type"`
+	EntityId    int64        `json:"entityId" db:"entity_id"`
+	Name        string       `json:"name" db:"name"`
+	ModifiedAt  time.Time    `json:"modifiedAt" db:"modified_at"`
+	DeletedAt   sql.NullTime `json:"deletedAt" db:"deleted_at"`
+	FavoritedAt time.Time    `json:"favoritedAt" db:"favorited_at"`
+}
+
+// A watched record that changed, NewComments is the number of comments that were placed on it since
+type WatchlistChange struct {
+	Favorite
+	NewComments int `json:"newComments" db:"new_comments"`
+}
+
+// watchlistQuery selects the records on the watchlist of a user, including deleted ones. Private platforms and
+// contacts, and contacts of private platforms, are left out unless includePrivate is set.
+func watchlistQuery(userId int64, includePrivate bool) (string, []any) {
+	platformWhere, contactWhere := "", ""
+	platformArgs, contactArgs := []any{userId}, []any{userId}
+	if !includePrivate {
+		platformWhere = " AND p.privacy <> ?"
+		platformArgs = append(platformArgs, PRIVACY_PRIVATE)
+		contactWhere = " AND c.privacy <> ? AND cp.privacy <> ?"
+		contactArgs = append(contactArgs, PRIVACY_PRIVATE, PRIVACY_PRIVATE)
+	}
+
+	query := `
+	SELECT f.entity_type, f.entity_id, p.name, p.modified_at, p.deleted_at, f.created_at AS favorited_at
+	FROM favorites f
+	JOIN platforms p ON p.id = f.entity_id
+	WHERE f.user_id = ? AND f.entity_type = '` + PLATFORM_ENTITY + `'` + platformWhere + `
+	UNION ALL
+	SELECT f.entity_type, f.entity_id, c.name, c.modified_at, c.deleted_at, f.created_at AS favorited_at
+	FROM favorites f
+	JOIN contacts c ON c.id = f.entity_id
+	JOIN platforms cp ON cp.id = c.platform_id
+	WHERE f.user_id = ? AND f.entity_type = '` + CONTACT_ENTITY + `'` + contactWhere + `
+	UNION ALL
+	SELECT f.entity_type, f.entity_id, p.title AS name, p.modified_at, p.deleted_at, f.created_at AS favorited_at
+	FROM favorites f
+	JOIN projects p ON p.id = f.entity_id
+	WHERE f.user_id = ? AND f.entity_type = '` + PROJECT_ENTITY + `'`
+
+	args := append(append(platformArgs, contactArgs...), userId)
+	return query, args
+}
+
+// platformIsFavorite tells whether the platform is on the watchlist of the user given as argument, for use in the
+// platform list
+const platformIsFavorite = `EXISTS (SELECT 1 FROM favorites f WHERE f.user_id = ? AND f.entity_type = '` + PLATFORM_ENTITY + `' AND f.entity_id = p.id)`
+
+// GetFavorites lists the records on the watchlist of a user, the latest added first. Deleted records are left out,
+// and so are private records unless includePrivate is set.
+func (db *Database) GetFavorites(userId int64, includePrivate bool) ([]Favorite, error) {
+	favorites := []Favorite{}
+
+	watchlist, args := watchlistQuery(userId, includePrivate)
+	err := db.querier.Select(&favorites, `
+	SELECT w.* FROM (`+watchlist+`) w
+	WHERE w.deleted_at IS NULL
+	ORDER BY w.favorited_at DESC, w.entity_type, w.entity_id`, args...)
+	return favorites, err
+}
+
+// IsFavorite reports whether the record is on the watchlist of the user
+func (db *Database) IsFavorite(userId int64, entityType string, entityId int64) (bool, error) {
+	var count int
+
+	err := db.querier.Get(&count, "SELECT COUNT(*) FROM favorites WHERE user_id = ? AND entity_type = ? AND entity_id = ?", userId, entityType, entityId)
+	return count > 0, err
+}
+
+// AddFavorite adds a record to the watchlist of a user, adding a record that is already on it changes nothing
+func (db *Database) AddFavorite(userId int64, entityType string, entityId int64) error {
+	if !favoriteEntityTypes[entityType] {
+		return ErrInvalidFavoriteType
+	}
+
+	_, err := db.querier.Exec("INSERT IGNORE INTO favorites (user_id, entity_type, entity_id) VALUES (?, ?, ?)", userId, entityType, entityId)
+	return err
+}
+
+func (db *Database) RemoveFavorite(userId int64, entityType string, entityId int64) error {
+	_, err := db.querier.Exec("DELETE FROM favorites WHERE user_id = ? AND entity_type = ? AND entity_id = ?", userId, entityType, entityId)
+	return err
+}
+
+// GetWatchlistChanges lists the records on the watchlist of a user that were changed, deleted or commented on after
+// the given time, the latest changed first. Changes from before a record was added to the watchlist are left out, and
+// so are private records unless includePrivate is set.
+func (db *Database) GetWatchlistChanges(userId int64, since time.Time, includePrivate bool) ([]WatchlistChange, error) {
+	changes := []WatchlistChange{}
+
+	watchlist, args := watchlistQuery(userId, includePrivate)
+	args = append(append([]any{since}, args...), since)
+	err := db.querier.Select(&changes, `
+	SELECT ch.* FROM (
+		SELECT w.*, (
+			SELECT COUNT(*) FROM comments cm
+			WHERE cm.entity_type = w.entity_type AND cm.entity_id = w.entity_id AND cm.deleted_at IS NULL
+			AND cm.created_at > GREATEST(?, w.favorited_at)
+		) AS new_comments
+		FROM (`+watchlist+`) w
+	) ch
+	WHERE ch.modified_at > GREATEST(?, ch.favorited_at) OR ch.new_comments > 0
+	ORDER BY ch.modified_at DESC, ch.entity_type, ch.entity_id`, args...)
+	return changes, err
+}
+
+// GetWatchlistVisit returns when the user last looked at the changes to their watchlist, if they ever did
+func (db *Database) GetWatchlistVisit(userId int64) (sql.NullTime, error) {
+	var visitedAt sql.NullTime
+
+	err := db.querier.Get(&visitedAt, "SELECT MAX(visited_at) FROM watchlist_visits WHERE user_id = ?", userId)
+	return visitedAt, err
+}
+
+func (db *Database) SaveWatchlistVisit(userId int64, visitedAt time.Time) error {
+	_, err := db.querier.Exec(`
+	INSERT INTO watchlist_visits (user_id, visited_at) VALUES (?, ?)
+	ON DUPLICATE KEY UPDATE visited_at = VALUES(visited_at)`, userId, visitedAt)
+	return err
+}
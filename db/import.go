@@ -0,0 +1,465 @@
+package db
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/webstradev/rsdb-backend/normalize"
+)
+
+const (
+	IMPORT_PLATFORM_CREATE   = "create"
+	IMPORT_PLATFORM_EXISTING = "existing"
+	IMPORT_PLATFORM_FILE     = "file"
+)
+
+var (
+	ErrInvalidImportMapping = errors.New("invalid column mapping")
+	ErrInvalidImport        = errors.New("the import contains invalid rows")
+)
+
+// Platform and contact fields a spreadsheet column can be mapped to
+var importFields = map[string]func(*importRow, string){
+	"platform.name":       func(r *importRow, v string) { r.platform.Name = v },
+	"platform.website":    func(r *importRow, v string) { r.platform.Website = v },
+	"platform.country":    func(r *importRow, v string) { r.platform.Country = v },
+	"platform.source":     func(r *importRow, v string) { r.platform.Source = v },
+	"platform.notes":      func(r *importRow, v string) { r.platform.Notes = v },
+	"platform.comment":    func(r *importRow, v string) { r.platform.Comment = v },
+	"platform.privacy":    func(r *importRow, v string) { r.platform.Privacy = v },
+	"platform.categories": func(r *importRow, v string) { r.categoryNames = splitImportList(v) },
+	"contact.name":        func(r *importRow, v string) { r.contact.Name = v },
+	"contact.title":       func(r *importRow, v string) { r.contact.Title = v },
+	"contact.email":       func(r *importRow, v string) { r.contact.Email = v },
+	"contact.phone":       func(r *importRow, v string) { r.contact.Phone = v },
+	"contact.phone2":      func(r *importRow, v string) { r.contact.Phone2 = v },
+	"contact.address":     func(r *importRow, v string) { r.contact.Address = v },
+	"contact.notes":       func(r *importRow, v string) { r.contact.Notes = v },
+	"contact.source":      func(r *importRow, v string) { r.contact.Source = v },
+	"contact.privacy":     func(r *importRow, v string) { r.contact.Privacy = v },
+}
+
+type ImportDuplicate struct {
+	Type    string   `json:"type"`
+	ID      int64    `json:"id,omitempty"`
+	Row     int      `json:"row,omitempty"`
+	Name    string   `json:"name"`
+	Score   float64  `json:"score,omitempty"`
+	Reasons []string `json:"reasons"`
+}
+
+type ImportRowResult struct {
+	Row         int               `json:"row"`
+	Platform    string            `json:"platform"`
+	PlatformId  int64             `json:"platformId,omitempty"`
+	PlatformRow int               `json:"platformRow,omitempty"`
+	Contact     bool              `json:"contact"`
+	ContactId   int64             `json:"contactId,omitempty"`
+	Errors      []string          `json:"errors"`
+	Duplicates  []ImportDuplicate `json:"duplicates"`
+}
+
+// ImportReport describes what an import does (or did) for every row of the file
+type ImportReport struct {
+	Valid            bool              `json:"valid"`
+	Rows             int               `json:"rows"`
+	PlatformsCreated int               `json:"platformsCreated"`
+	ContactsCreated  int               `json:"contactsCreated"`
+	Results          []ImportRowResult `json:"results"`
+
+	// The parsed rows, in the same order as the results
+	rows []importRow
+}
+
+type Import struct {
+	Model
+	CreatedBy        sql.NullInt64   `json:"createdBy" db:"created_by"`
+	Filename         string          `json:"filename" db:"filename"`
+	PlatformsCreated int             `json:"platformsCreated" db:"platforms_created"`
+	ContactsCreated  int             `json:"contactsCreated" db:"contacts_created"`
+	Report           json.RawMessage `json:"report" db:"report"`
+}
+
+type importRow struct {
+	platform      Platform
+	categoryNames []string
+	categories    []int64
+	contact       Contact
+	hasContact    bool
+	// Identifies the platform within the file so rows for the same platform share it
+	key string
+}
+
+func splitImportList(value string) []string {
+	items := []string{}
+	for _, item := range strings.FieldsFunc(value, func(r rune) bool { return r == ',' || r == ';' }) {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// importColumns resolves the mapping of column headers to fields into a field per column index.
+// Without a mapping, columns named after a field (e.g. "platform.name") are used.
+func importColumns(header []string, mapping map[string]string) (map[int]string, error) {
+	columns := map[int]string{}
+
+	if len(mapping) == 0 {
+		for i, column := range header {
+			field := strings.ToLower(strings.TrimSpace(column))
+			if _, ok := importFields[field]; ok {
+				columns[i] = field
+			}
+		}
+	}
+
+	for column, field := range mapping {
+		if _, ok := importFields[field]; !ok {
+			return nil, fmt.Errorf("%w: unknown field %q", ErrInvalidImportMapping, field)
+		}
+
+		index := -1
+		for i, name := range header {
+			if strings.TrimSpace(name) == column {
+				index = i
+			}
+		}
+		if index == -1 {
+			return nil, fmt.Errorf("%w: column %q not found", ErrInvalidImportMapping, column)
+		}
+
+		columns[index] = field
+	}
+
+	for _, field := range columns {
+		if field == "platform.name" {
+			return columns, nil
+		}
+	}
+
+	return nil, fmt.Errorf("%w: platform.name is not mapped", ErrInvalidImportMapping)
+}
+
+// PlanImport validates the rows of a spreadsheet (the first row being the header) and matches them
+// against existing platforms and contacts. Nothing is written, the report can be passed to RunImport.
+func (db *Database) PlanImport(rows [][]string, mapping map[string]string) (*ImportReport, error) {
+	if len(rows) == 0 {
+		return nil, fmt.Errorf("%w: the file is empty", ErrInvalidImportMapping)
+	}
+
+	columns, err := importColumns(rows[0], mapping)
+	if err != nil {
+		return nil, err
+	}
+
+	categories := []Category{}
+	err = db.querier.Select(&categories, "SELECT * FROM categories WHERE deleted_at IS NULL")
+	if err != nil {
+		return nil, err
+	}
+
+	platforms, err := db.GetPlatformsForDuplicateCheck()
+	if err != nil {
+		return nil, err
+	}
+
+	contacts, err := db.GetContactsForDuplicateCheck()
+	if err != nil {
+		return nil, err
+	}
+
+	return planImport(rows, columns, categories, platforms, contacts), nil
+}
+
+func planImport(rows [][]string, columns map[int]string, categories []Category, platforms []DuplicatePlatform, contacts []DuplicateContact) *ImportReport {
+	report := &ImportReport{Valid: true, Results: []ImportRowResult{}}
+
+	categoryIds := map[string]int64{}
+	for _, category := range categories {
+		categoryIds[strings.ToLower(category.Category)] = category.ID
+	}
+
+	existingPlatforms := make([]normalizedPlatform, 0, len(platforms))
+	for _, platform := range platforms {
+		existingPlatforms = append(existingPlatforms, normalizePlatform(platform))
+	}
+
+	existingContacts := map[string][]DuplicateContact{}
+	for _, contact := range contacts {
+		for _, key := range contactImportKeys(contact.Email, contact.Phone, contact.Phone2) {
+			existingContacts[key] = append(existingContacts[key], contact)
+		}
+	}
+
+	// Platforms and contacts seen earlier in the file, by key
+	filePlatforms := map[string]int{}
+	fileContacts := map[string]int{}
+
+	for i, cells := range rows[1:] {
+		// Row numbers as shown in a spreadsheet, the header is row 1
+		rowNumber := i + 2
+
+		row := importRow{}
+		empty := true
+		for index, field := range columns {
+			if index >= len(cells) {
+				continue
+			}
+
+			value := strings.TrimSpace(cells[index])
+			if value == "" {
+				continue
+			}
+
+			empty = false
+			importFields[field](&row, value)
+			if strings.HasPrefix(field, "contact.") {
+				row.hasContact = true
+			}
+		}
+
+		if empty {
+			continue
+		}
+
+		result := ImportRowResult{Row: rowNumber, Contact: row.hasContact, Errors: []string{}, Duplicates: []ImportDuplicate{}}
+
+		// Validation
+		if row.platform.Name == "" {
+			result.Errors = append(result.Errors, "platform.name is required")
+		}
+		if utf8.RuneCountInString(row.platform.Name) > 128 {
+			result.Errors = append(result.Errors, "platform.name can not be longer than 128 characters")
+		}
+		if utf8.RuneCountInString(row.platform.Website) > 512 {
+			result.Errors = append(result.Errors, "platform.website can not be longer than 512 characters")
+		}
+		country, err := normalize.Country(row.platform.Country)
+		if err != nil {
+			result.Errors = append(result.Errors, "platform.country: "+err.Error())
+		}
+		row.platform.Country = country
+		if row.hasContact && row.contact.Name == "" {
+			result.Errors = append(result.Errors, "contact.name is required")
+		}
+
+		for _, name := range row.categoryNames {
+			id, ok := categoryIds[strings.ToLower(name)]
+			if !ok {
+				result.Errors = append(result.Errors, fmt.Sprintf("unknown category %q", name))
+				continue
+			}
+			row.categories = append(row.categories, id)
+		}
+
+		if row.platform.Privacy == "" {
+			row.platform.Privacy = PRIVACY_PRIVATE
+		}
+		row.contact.Current = true
+
+		// Resolve the platform, either from an earlier row, an existing platform or a new one
+		platform := normalizePlatform(DuplicatePlatform{Name: row.platform.Name, Website: row.platform.Website, Country: row.platform.Country})
+		row.key = platform.domain
+		if row.key == "" {
+			row.key = platform.name + "|" + platform.country
+		}
+
+		if first, ok := filePlatforms[row.key]; ok {
+			result.Platform = IMPORT_PLATFORM_FILE
+			result.PlatformRow = first
+		} else {
+			result.Platform = IMPORT_PLATFORM_CREATE
+
+			for _, existing := range existingPlatforms {
+				score, reasons := comparePlatforms(platform, existing, DEFAULT_DUPLICATE_THRESHOLD)
+				if len(reasons) == 0 {
+					continue
+				}
+
+				// An exact match is used instead of creating the platform again
+				if score == 1 && result.Platform == IMPORT_PLATFORM_CREATE {
+					result.Platform = IMPORT_PLATFORM_EXISTING
+					result.PlatformId = existing.platform.ID
+					if country == "" {
+						// Platforms that have not been normalized yet still have a country name
+						country, _ = normalize.Country(existing.platform.Country)
+					}
+					continue
+				}
+
+				result.Duplicates = append(result.Duplicates, ImportDuplicate{
+					Type:    "platform",
+					ID:      existing.platform.ID,
+					Name:    existing.platform.Name,
+					Score:   score,
+					Reasons: reasons,
+				})
+			}
+
+			if result.Platform == IMPORT_PLATFORM_CREATE {
+				filePlatforms[row.key] = rowNumber
+			}
+		}
+
+		// Contacts with the same email address or phone number
+		if row.hasContact {
+			err = row.contact.Normalize(country)
+			if err != nil {
+				result.Errors = append(result.Errors, "contact."+err.Error())
+			}
+
+			matched := map[int64]bool{}
+			for _, key := range contactImportKeys(row.contact.Email, row.contact.Phone, row.contact.Phone2) {
+				reason := strings.SplitN(key, ":", 2)[0]
+
+				for _, contact := range existingContacts[key] {
+					if matched[contact.ID] {
+						continue
+					}
+					matched[contact.ID] = true
+
+					result.Duplicates = append(result.Duplicates, ImportDuplicate{
+						Type:    "contact",
+						ID:      contact.ID,
+						Name:    contact.Name,
+						Reasons: []string{reason},
+					})
+				}
+
+				if first, ok := fileContacts[key]; ok {
+					result.Duplicates = append(result.Duplicates, ImportDuplicate{
+						Type:    "contact",
+						Row:     first,
+						Name:    row.contact.Name,
+						Reasons: []string{reason},
+					})
+				} else {
+					fileContacts[key] = rowNumber
+				}
+			}
+		}
+
+		if len(result.Errors) > 0 {
+			report.Valid = false
+		} else {
+			if result.Platform == IMPORT_PLATFORM_CREATE {
+				report.PlatformsCreated++
+			}
+			if row.hasContact {
+				report.ContactsCreated++
+			}
+		}
+
+		report.Rows++
+		report.Results = append(report.Results, result)
+		report.rows = append(report.rows, row)
+	}
+
+	return report
+}
+
+func contactImportKeys(email, phone, phone2 string) []string {
+	keys := []string{}
+
+	if key := normalize.Email(email); key != "" {
+		keys = append(keys, "email:"+key)
+	}
+
+	for _, number := range []string{phone, phone2} {
+		if key := normalize.PhoneKey(number); key != "" {
+			keys = append(keys, "phone:"+key)
+		}
+	}
+
+	return keys
+}
+
+// RunImport creates the platforms and contacts of a planned import in a single transaction
+// and stores the report, the id of the stored report is returned
+func (db *Database) RunImport(report *ImportReport, filename string, createdBy int64) (int64, error) {
+	if !report.Valid {
+		return 0, ErrInvalidImport
+	}
+
+	tx, err := db.querier.Beginx()
+	if err != nil {
+		return 0, err
+	}
+
+	id, err := runImport(tx, report, filename, createdBy)
+	if err != nil {
+		tx.Rollback()
+		return 0, err
+	}
+
+	return id, tx.Commit()
+}
+
+func runImport(tx *sqlx.Tx, report *ImportReport, filename string, createdBy int64) (int64, error) {
+	created := map[string]int64{}
+
+	for i := range report.rows {
+		row := &report.rows[i]
+		result := &report.Results[i]
+
+		switch result.Platform {
+		case IMPORT_PLATFORM_CREATE:
+			p := row.platform
+			id, err := insertPlatform(tx, p.Name, p.Website, p.Country, p.Source, p.Notes, p.Comment, p.Privacy, createdBy)
+			if err != nil {
+				return 0, err
+			}
+			created[row.key] = id
+			result.PlatformId = id
+		case IMPORT_PLATFORM_FILE:
+			result.PlatformId = created[row.key]
+		}
+
+		for _, category := range row.categories {
+			_, err := tx.Exec("INSERT IGNORE INTO platforms_categories (platform_id, category_id) VALUES (?, ?)", result.PlatformId, category)
+			if err != nil {
+				return 0, err
+			}
+		}
+
+		if row.hasContact {
+			row.contact.PlatformId = result.PlatformId
+			row.contact.CreatedBy = sql.NullInt64{Int64: createdBy, Valid: createdBy != 0}
+			contactId, err := insertContact(tx, row.contact)
+			if err != nil {
+				return 0, err
+			}
+			result.ContactId = contactId
+		}
+	}
+
+	data, err := json.Marshal(report)
+	if err != nil {
+		return 0, err
+	}
+
+	result, err := tx.Exec(`
+		INSERT INTO imports
+			(created_by, filename, platforms_created, contacts_created, report)
+		VALUES
+			(?, ?, ?, ?, ?)`,
+		sql.NullInt64{Int64: createdBy, Valid: createdBy != 0}, filename, report.PlatformsCreated, report.ContactsCreated, string(data))
+	if err != nil {
+		return 0, err
+	}
+
+	return result.LastInsertId()
+}
+
+func (db *Database) GetImport(id int64) (Import, error) {
+	imp := Import{}
+
+	err := db.querier.Get(&imp, "SELECT * FROM imports WHERE id = ? AND deleted_at IS NULL", id)
+	return imp, err
+}
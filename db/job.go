@@ -0,0 +1,14 @@
+package db
+
+import "time"
+
+// ClaimJobRun records that the named job runs for the given date, it returns false when it already ran (e.g. on another server)
+func (db *Database) ClaimJobRun(name string, date time.Time) (bool, error) {
+	result, err := db.querier.Exec("INSERT IGNORE INTO job_runs (name, run_date) VALUES (?, ?)", name, date.Format(time.DateOnly))
+	if err != nil {
+		return false, err
+	}
+
+	claimed, err := result.RowsAffected()
+	return claimed > 0, err
+}
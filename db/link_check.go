@@ -0,0 +1,100 @@
+package db
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+)
+
+var ErrInvalidLinkEntityType = errors.New("invalid entity type, use article, project or platform")
+
+// A link of an article, project or platform
+type Link struct {
+	EntityType string `json:"entityType" db:"entity_type"`
+	EntityId   int64  `json:"entityId" db:"entity_id"`
+	Name       string `json:"name" db:"name"`
+	URL        string `json:"url" db:"url"`
+}
+
+// The result of the last check of a link, a link is broken when it could not be fetched or returned an error status
+type LinkCheck struct {
+	Link
+	StatusCode  sql.NullInt64 `json:"statusCode" db:"status_code"`
+	RedirectURL string        `json:"redirectUrl" db:"redirect_url"`
+	Error       string        `json:"error" db:"error"`
+	Broken      bool          `json:"broken" db:"broken"`
+	CheckedAt   time.Time     `json:"checkedAt" db:"checked_at"`
+}
+
+// The links of all articles, projects and platforms that have one
+const linksQuery = `
+	SELECT ? AS entity_type, id AS entity_id, title AS name, link AS url FROM articles WHERE deleted_at IS NULL AND link <> ''
+	UNION ALL
+	SELECT ?, id, title, link FROM projects WHERE deleted_at IS NULL AND link <> ''
+	UNION ALL
+	SELECT ?, id, name, website FROM platforms WHERE deleted_at IS NULL AND website <> ''`
+
+var linksQueryArgs = []any{ARTICLE_ENTITY, PROJECT_ENTITY, PLATFORM_ENTITY}
+
+func (db *Database) GetLinks() ([]Link, error) {
+	links := []Link{}
+
+	err := db.querier.Select(&links, linksQuery+" ORDER BY entity_type, entity_id", linksQueryArgs...)
+	return links, err
+}
+
+// SaveLinkChecks stores the results of a check, replacing the previous result for the same records
+func (db *Database) SaveLinkChecks(checks []LinkCheck) error {
+	for _, check := range checks {
+		_, err := db.querier.NamedExec(`
+		INSERT INTO link_checks (entity_type, entity_id, url, status_code, redirect_url, error, broken, checked_at)
+		VALUES (:entity_type, :entity_id, :url, :status_code, :redirect_url, :error, :broken, :checked_at)
+		ON DUPLICATE KEY UPDATE
+			url = VALUES(url),
+			status_code = VALUES(status_code),
+			redirect_url = VALUES(redirect_url),
+			error = VALUES(error),
+			broken = VALUES(broken),
+			checked_at = VALUES(checked_at)`, check)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// GetBrokenLinks lists the links that were broken when they were last checked, optionally of one entity type.
+// Links that were changed since are left out.
+func (db *Database) GetBrokenLinks(entityType string) ([]LinkCheck, error) {
+	checks := []LinkCheck{}
+
+	switch entityType {
+	case "", ARTICLE_ENTITY, PROJECT_ENTITY, PLATFORM_ENTITY:
+	default:
+		return nil, ErrInvalidLinkEntityType
+	}
+
+	args := append([]any{}, linksQueryArgs...)
+	where := "lc.broken = 1"
+	if entityType != "" {
+		where += " AND l.entity_type = ?"
+		args = append(args, entityType)
+	}
+
+	err := db.querier.Select(&checks, `
+	SELECT
+		l.*,
+		lc.status_code,
+		lc.redirect_url,
+		lc.error,
+		lc.broken,
+		lc.checked_at
+	FROM
+		(`+linksQuery+`) l
+	JOIN
+		link_checks lc ON lc.entity_type = l.entity_type AND lc.entity_id = l.entity_id AND lc.url = l.url
+	WHERE `+where+`
+	ORDER BY l.entity_type, l.name, l.entity_id`, args...)
+	return checks, err
+}
@@ -2,6 +2,7 @@ package db
 
 import (
 	"database/sql"
+	"errors"
 	"time"
 )
 
@@ -15,3 +16,33 @@ type ModelLite struct {
 	ModifiedAt time.Time    `json:"modifiedAt" db:"modified_at"`
 	DeletedAt  sql.NullTime `json:"deletedAt" db:"deleted_at"`
 }
+
+// Entity types used to refer to records across tables (e.g. revisions)
+const (
+	ARTICLE_ENTITY  = "article"
+	PROJECT_ENTITY  = "project"
+	PLATFORM_ENTITY = "platform"
+	CONTACT_ENTITY  = "contact"
+)
+
+var ErrInvalidEntityType = errors.New("invalid entity type, use article, project, platform or contact")
+
+// Tables of the entity types
+var entityTables = map[string]string{
+	ARTICLE_ENTITY:  "articles",
+	PROJECT_ENTITY:  "projects",
+	PLATFORM_ENTITY: "platforms",
+	CONTACT_ENTITY:  "contacts",
+}
+
+// EntityExists reports whether the entity of the given type exists and has not been deleted
+func (db *Database) EntityExists(entityType string, id int64) (bool, error) {
+	table, ok := entityTables[entityType]
+	if !ok {
+		return false, ErrInvalidEntityType
+	}
+
+	var count int
+	err := db.querier.Get(&count, "SELECT COUNT(*) FROM "+table+" WHERE id = ? AND deleted_at IS NULL", id)
+	return count > 0, err
+}
@@ -0,0 +1,144 @@
+package db
+
+import (
+	"github.com/jmoiron/sqlx"
+	"github.com/webstradev/rsdb-backend/normalize"
+)
+
+// A value that could not be normalized and was left as it is
+type NormalizationIssue struct {
+	Entity string `json:"entity"`
+	ID     int64  `json:"id"`
+	Field  string `json:"field"`
+	Value  string `json:"value"`
+	Error  string `json:"error"`
+}
+
+type NormalizationReport struct {
+	PlatformsUpdated int                  `json:"platformsUpdated"`
+	ContactsUpdated  int                  `json:"contactsUpdated"`
+	Issues           []NormalizationIssue `json:"issues"`
+}
+
+// NormalizeExisting stores the countries of existing platforms as ISO codes and the email addresses and phone numbers
+// of existing contacts in the same format as new ones in a single transaction. Values that can not be normalized are
+// left unchanged and listed in the report. With dryRun the transaction is rolled back.
+func (db *Database) NormalizeExisting(dryRun bool) (*NormalizationReport, error) {
+	report := &NormalizationReport{Issues: []NormalizationIssue{}}
+
+	tx, err := db.querier.Beginx()
+	if err != nil {
+		return nil, err
+	}
+
+	// Platforms go first so the phone numbers of contacts are read with the normalized country
+	err = normalizePlatforms(tx, report)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	err = normalizeContacts(tx, report)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	if dryRun {
+		return report, tx.Rollback()
+	}
+
+	return report, tx.Commit()
+}
+
+func normalizePlatforms(tx *sqlx.Tx, report *NormalizationReport) error {
+	platforms := []struct {
+		ID      int64  `db:"id"`
+		Country string `db:"country"`
+	}{}
+
+	err := tx.Select(&platforms, "SELECT id, country FROM platforms WHERE deleted_at IS NULL FOR UPDATE")
+	if err != nil {
+		return err
+	}
+
+	for _, platform := range platforms {
+		country, err := normalize.Country(platform.Country)
+		if err != nil {
+			report.Issues = append(report.Issues, NormalizationIssue{"platform", platform.ID, "country", platform.Country, err.Error()})
+			continue
+		}
+
+		if country == platform.Country {
+			continue
+		}
+
+		_, err = tx.Exec("UPDATE platforms SET country = ? WHERE id = ?", country, platform.ID)
+		if err != nil {
+			return err
+		}
+		report.PlatformsUpdated++
+	}
+
+	return nil
+}
+
+func normalizeContacts(tx *sqlx.Tx, report *NormalizationReport) error {
+	contacts := []struct {
+		ID      int64  `db:"id"`
+		Email   string `db:"email"`
+		Phone   string `db:"phone"`
+		Phone2  string `db:"phone2"`
+		Country string `db:"country"`
+	}{}
+
+	err := tx.Select(&contacts, `
+	SELECT
+		c.id, c.email, c.phone, c.phone2, p.country
+	FROM
+		contacts c
+	JOIN
+		platforms p ON p.id = c.platform_id
+	WHERE c.deleted_at IS NULL
+	FOR UPDATE`)
+	if err != nil {
+		return err
+	}
+
+	for _, contact := range contacts {
+		fields := []struct {
+			name  string
+			value string
+			fn    func(string) (string, error)
+		}{
+			{"email", contact.Email, normalize.ValidEmail},
+			{"phone", contact.Phone, func(v string) (string, error) { return normalize.E164(v, contact.Country) }},
+			{"phone2", contact.Phone2, func(v string) (string, error) { return normalize.E164(v, contact.Country) }},
+		}
+
+		values := make([]string, len(fields))
+		changed := false
+		for i, field := range fields {
+			value, err := field.fn(field.value)
+			if err != nil {
+				report.Issues = append(report.Issues, NormalizationIssue{"contact", contact.ID, field.name, field.value, err.Error()})
+				value = field.value
+			}
+
+			values[i] = value
+			changed = changed || value != field.value
+		}
+
+		if !changed {
+			continue
+		}
+
+		_, err = tx.Exec("UPDATE contacts SET email = ?, phone = ?, phone2 = ? WHERE id = ?", values[0], values[1], values[2], contact.ID)
+		if err != nil {
+			return err
+		}
+		report.ContactsUpdated++
+	}
+
+	return nil
+}
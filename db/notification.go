@@ -0,0 +1,138 @@
+package db
+
+import "database/sql"
+
+// Kinds of events users are notified of
+const (
+	NOTIFICATION_MENTION         = "mention"
+	NOTIFICATION_RECORD_EDITED   = "record_edited"
+	NOTIFICATION_TASK_ASSIGNED   = "task_assigned"
+	NOTIFICATION_INVITE_ACCEPTED = "invite_accepted"
+	NOTIFICATION_SEARCH_MATCHES  = "search_matches"
+)
+
+// Tasks and saved searches are not one of the record types, notifications refer to them with their own entity types
+const (
+	TASK_ENTITY   = "task"
+	SEARCH_ENTITY = "search"
+)
+
+// A notification in the inbox of a user, the entity is the record (or task) it is about and the actor the user that
+// caused it
+type Notification struct {
+	Model
+	UserId     int64          `json:"userId" db:"user_id"`
+	Kind       string         `json:"kind" db:"kind"`
+	EntityType sql.NullString `json:"entityType" db:"entity_type"`
+	EntityId   sql.NullInt64  `json:"entityId" db:"entity_id"`
+	ActorId    sql.NullInt64  `json:"actorId" db:"actor_id"`
+	ActorEmail string         `json:"actorEmail" db:"actor_email"`
+	Message    string         `json:"message" db:"message"`
+	ReadAt     sql.NullTime   `json:"readAt" db:"read_at"`
+}
+
+const notificationQuery = `
+	SELECT n.*, COALESCE(u.email, '') AS actor_email
+	FROM notifications n
+	LEFT JOIN users u ON u.id = n.actor_id`
+
+// unreadCondition limits the notifications to unread ones when unreadOnly is set
+func unreadCondition(unreadOnly bool) string {
+	if unreadOnly {
+		return " AND n.read_at IS NULL"
+	}
+	return ""
+}
+
+func (db *Database) GetNotification(id int64) (Notification, error) {
+	notification := Notification{}
+
+	err := db.querier.Get(&notification, notificationQuery+" WHERE n.id = ? AND n.deleted_at IS NULL", id)
+	return notification, err
+}
+
+// GetNotifications lists the notifications of a user, the latest first
+func (db *Database) GetNotifications(userId int64, unreadOnly bool, page, pageSize int) ([]Notification, error) {
+	notifications := []Notification{}
+
+	err := db.querier.Select(&notifications, notificationQuery+`
+	WHERE n.user_id = ? AND n.deleted_at IS NULL`+unreadCondition(unreadOnly)+`
+	ORDER BY n.id DESC
+	LIMIT ? OFFSET ?`, userId, pageSize, page*pageSize)
+	return notifications, err
+}
+
+func (db *Database) CountNotifications(userId int64, unreadOnly bool) (int, error) {
+	var count int
+
+	err := db.querier.Get(&count, `
+	SELECT COUNT(*) FROM notifications n
+	WHERE n.user_id = ? AND n.deleted_at IS NULL`+unreadCondition(unreadOnly), userId)
+	return count, err
+}
+
+// GetNotificationsAfter lists the notifications of a user that came after the given one, the oldest first
+func (db *Database) GetNotificationsAfter(userId, afterId int64, limit int) ([]Notification, error) {
+	notifications := []Notification{}
+
+	err := db.querier.Select(&notifications, notificationQuery+`
+	WHERE n.user_id = ? AND n.id > ? AND n.deleted_at IS NULL
+	ORDER BY n.id
+	LIMIT ?`, userId, afterId, limit)
+	return notifications, err
+}
+
+// LatestNotificationId returns the ID of the latest notification of a user, or 0 when there are none
+func (db *Database) LatestNotificationId(userId int64) (int64, error) {
+	var id int64
+
+	err := db.querier.Get(&id, "SELECT COALESCE(MAX(id), 0) FROM notifications WHERE user_id = ?", userId)
+	return id, err
+}
+
+func (db *Database) InsertNotification(notification Notification) (int64, error) {
+	result, err := db.querier.NamedExec(`
+	INSERT INTO notifications (user_id, kind, entity_type, entity_id, actor_id, message)
+	VALUES (:user_id, :kind, :entity_type, :entity_id, :actor_id, :message)`, notification)
+	if err != nil {
+		return 0, err
+	}
+
+	return result.LastInsertId()
+}
+
+func (db *Database) MarkNotificationRead(id int64) error {
+	_, err := db.querier.Exec("UPDATE notifications SET read_at = CURRENT_TIMESTAMP() WHERE id = ? AND read_at IS NULL", id)
+	return err
+}
+
+// MarkAllNotificationsRead marks the unread notifications of a user as read and returns how many there were
+func (db *Database) MarkAllNotificationsRead(userId int64) (int64, error) {
+	result, err := db.querier.Exec("UPDATE notifications SET read_at = CURRENT_TIMESTAMP() WHERE user_id = ? AND read_at IS NULL AND deleted_at IS NULL", userId)
+	if err != nil {
+		return 0, err
+	}
+
+	return result.RowsAffected()
+}
+
+// GetRecordOwners returns the IDs of the users that own a record: the user that created it and, for projects, the
+// user it is assigned to
+func (db *Database) GetRecordOwners(entityType string, id int64) ([]int64, error) {
+	table, ok := entityTables[entityType]
+	if !ok {
+		return nil, ErrInvalidEntityType
+	}
+
+	owners := []int64{}
+	query := "SELECT created_by FROM " + table + " WHERE id = ? AND created_by IS NOT NULL"
+	args := []any{id}
+
+	if entityType == PROJECT_ENTITY {
+		query += " UNION SELECT assignee_id FROM projects WHERE id = ? AND assignee_id IS NOT NULL"
+		args = append(args, id)
+	}
+
+	err := db.querier.Select(&owners, query, args...)
+	return owners, err
+}
@@ -0,0 +1,82 @@
+package db
+
+import (
+	"database/sql"
+
+	"github.com/jmoiron/sqlx"
+)
+
+type Person struct {
+	Model
+	Name  string `json:"name" db:"name"`
+	Notes string `json:"notes" db:"notes"`
+}
+
+// Employment is a contact as seen from the person or platform side
+type Employment struct {
+	ContactId    int64        `json:"contactId" db:"contact_id"`
+	PersonId     int64        `json:"personId" db:"person_id"`
+	PersonName   string       `json:"personName" db:"person_name"`
+	PlatformId   int64        `json:"platformId" db:"platform_id"`
+	PlatformName string       `json:"platformName" db:"platform_name"`
+	Title        string       `json:"title" db:"title"`
+	StartDate    sql.NullTime `json:"startDate" db:"start_date"`
+	EndDate      sql.NullTime `json:"endDate" db:"end_date"`
+	Current      bool         `json:"current" db:"current"`
+}
+
+const employmentQuery = `
+	SELECT
+		c.id AS contact_id,
+		c.person_id,
+		pe.name AS person_name,
+		c.platform_id,
+		p.name AS platform_name,
+		c.title,
+		c.start_date,
+		c.end_date,
+		c.current
+	FROM
+		contacts c
+	JOIN
+		people pe ON pe.id = c.person_id
+	JOIN
+		platforms p ON p.id = c.platform_id
+	WHERE
+		c.deleted_at IS NULL AND pe.deleted_at IS NULL AND p.deleted_at IS NULL`
+
+func insertPerson(tx *sqlx.Tx, person Person) (int64, error) {
+	result, err := tx.NamedExec("INSERT INTO people (name, notes) VALUES (:name, :notes)", person)
+	if err != nil {
+		return 0, err
+	}
+
+	return result.LastInsertId()
+}
+
+func (db *Database) GetPerson(id int64) (Person, error) {
+	person := Person{}
+
+	err := db.querier.Get(&person, "SELECT * FROM people WHERE id = ? AND deleted_at IS NULL", id)
+	return person, err
+}
+
+// GetCareer lists all employments of a person, current ones first and then most recent first
+func (db *Database) GetCareer(personId int64) ([]Employment, error) {
+	employments := []Employment{}
+
+	err := db.querier.Select(&employments, employmentQuery+`
+		AND c.person_id = ?
+	ORDER BY c.current DESC, c.start_date DESC, c.id DESC`, personId)
+	return employments, err
+}
+
+// GetStaff lists the current and former contacts of a platform
+func (db *Database) GetStaff(platformId int64) ([]Employment, error) {
+	employments := []Employment{}
+
+	err := db.querier.Select(&employments, employmentQuery+`
+		AND c.platform_id = ?
+	ORDER BY c.current DESC, pe.name`, platformId)
+	return employments, err
+}
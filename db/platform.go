@@ -1,8 +1,13 @@
 package db
 
 import (
+	"database/sql"
 	"log"
 	"strings"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/webstradev/rsdb-backend/normalize"
 )
 
 type Platform struct {
@@ -18,11 +23,16 @@ type Platform struct {
 	ArticlesCount int                `json:"articlesCount" db:"articles_count"`
 	ProjectsCount int                `json:"projectsCount" db:"projects_count"`
 	Privacy       string             `json:"privacy" db:"privacy"`
+	MergedInto    sql.NullInt64      `json:"-" db:"merged_into"`
+	LastContacted *time.Time         `json:"lastContacted,omitempty" db:"last_contacted"`
+	CreatedBy     sql.NullInt64      `json:"createdBy" db:"created_by"`
+	IsFavorite    bool               `json:"isFavorite" db:"is_favorite"`
 }
 
 type PlatformWithCategoryString struct {
 	Platform
 	CategoryString string `json:"categoryString" db:"platform_categories"`
+	CommentsCount  int    `json:"commentsCount" db:"comments_count"`
 }
 
 func (p *Platform) PopulateCategories(db *Database) error {
@@ -36,7 +46,18 @@ func (p *Platform) PopulateCategories(db *Database) error {
 	return nil
 }
 
-func (db *Database) GetPlatforms(page, pageSize int) ([]PlatformWithCategoryString, error) {
+func (p *Platform) CategoryIds() []int64 {
+	ids := []int64{}
+
+	for _, category := range p.Categories {
+		ids = append(ids, category.CategoryID)
+	}
+
+	return ids
+}
+
+// GetPlatforms lists a page of platforms, with whether they are on the watchlist of the user
+func (db *Database) GetPlatforms(userId int64, page, pageSize int) ([]PlatformWithCategoryString, error) {
 	platforms := []PlatformWithCategoryString{}
 
 	err := db.querier.Select(&platforms, `
@@ -45,7 +66,10 @@ func (db *Database) GetPlatforms(page, pageSize int) ([]PlatformWithCategoryStri
 		COUNT(DISTINCT c.id) as contacts_count,
 		COUNT(DISTINCT pa.article_id) as articles_count,
 		COUNT(DISTINCT pp.project_id) as projects_count,
-		COALESCE(GROUP_CONCAT(DISTINCT ca.category), '') AS platform_categories
+		COALESCE(GROUP_CONCAT(DISTINCT ca.category), '') AS platform_categories,
+		`+platformLastContacted+` AS last_contacted,
+		`+platformCommentsCount+` AS comments_count,
+		`+platformIsFavorite+` AS is_favorite
 	FROM 
 		platforms p 
 	LEFT JOIN 
@@ -60,7 +84,7 @@ func (db *Database) GetPlatforms(page, pageSize int) ([]PlatformWithCategoryStri
 		categories ca ON ca.id = pc.category_id
 	WHERE p.deleted_at IS NULL
 	GROUP BY p.id
-	LIMIT ? OFFSET ?`, pageSize, (page)*pageSize)
+	LIMIT ? OFFSET ?`, userId, pageSize, (page)*pageSize)
 	if err != nil {
 		log.Println(err)
 		return nil, err
@@ -77,7 +101,8 @@ func (db *Database) GetPlatform(id int64) (*Platform, error) {
 		p.* , 
 		COUNT(DISTINCT c.id) as contacts_count,
 		COUNT(DISTINCT pa.article_id) as articles_count,
-		COUNT(DISTINCT pp.project_id) as projects_count
+		COUNT(DISTINCT pp.project_id) as projects_count,
+		`+platformLastContacted+` AS last_contacted
 	FROM 
 		platforms p 
 	LEFT JOIN 
@@ -94,15 +119,27 @@ func (db *Database) GetPlatform(id int64) (*Platform, error) {
 	return &platform, nil
 }
 
+// GetPlatformCountry returns the ISO 3166-1 country code of a platform
+func (db *Database) GetPlatformCountry(id int64) (string, error) {
+	var country string
+	err := db.querier.Get(&country, "SELECT country FROM platforms WHERE id = ? AND deleted_at IS NULL", id)
+	return country, err
+}
+
 func (db *Database) CountPlatforms() (int, error) {
 	var count int
 	err := db.querier.Get(&count, "SELECT COUNT(*) AS count FROM platforms WHERE deleted_at IS NULL")
 	return count, err
 }
 
-func (db *Database) CreatePlatform(name, website, country, source, notes, comment, privacy string) (int64, error) {
+func (db *Database) CreatePlatform(name, website, country, source, notes, comment, privacy string, createdBy int64) (int64, error) {
+	return insertPlatform(db.querier, name, website, country, source, notes, comment, privacy, createdBy)
+}
+
+func insertPlatform(e sqlx.Execer, name, website, country, source, notes, comment, privacy string, createdBy int64) (int64, error) {
 	// Create the platform
-	result, err := db.querier.Exec(`INSERT INTO platforms (name, website, country, source, notes, comment, privacy) VALUES (?, ?, ?, ?, ?, ?, ?)`, name, website, country, source, notes, comment, privacy)
+	result, err := e.Exec(`INSERT INTO platforms (name, website, country, source, notes, comment, privacy, created_by) VALUES (?, ?, ?, ?, ?, ?, ?, ?)`,
+		name, website, country, source, notes, comment, privacy, sql.NullInt64{Int64: createdBy, Valid: createdBy != 0})
 	if err != nil {
 		return -1, err
 	}
@@ -115,16 +152,71 @@ func (db *Database) CreatePlatform(name, website, country, source, notes, commen
 	return id, nil
 }
 
-func (db *Database) EditPlatform(name, website, country, source, notes, comment, privacy string, id int64) error {
-	// Update basic platform information
-	_, err := db.querier.Exec(`UPDATE platforms SET name = ?, website = ?, country = ?, source = ?, notes = ?, comment = ?, privacy = ? WHERE id = ?`, name, website, country, source, notes, comment, privacy, id)
+// EditPlatform stores a revision of the current platform (including its categories) and then applies the edit, all
+// within the same transaction. The platform is locked first so concurrent edits are applied one after the other.
+// A changed country that is not a known country returns normalize.ErrInvalidCountry.
+func (db *Database) EditPlatform(platform Platform, editedBy int64) error {
+	tx, err := db.querier.Beginx()
 	if err != nil {
 		return err
 	}
-	return nil
+
+	var id int64
+	err = tx.Get(&id, "SELECT id FROM platforms WHERE id = ? AND deleted_at IS NULL FOR UPDATE", platform.ID)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	current, err := snapshotPlatform(tx, platform.ID)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	// The country is stored as ISO 3166-1 code. A legacy value that can not be read is kept as long as it is not
+	// changed, so those platforms stay editable until cmd/normalize is run.
+	country, err := normalize.Country(platform.Country)
+	if err == nil {
+		platform.Country = country
+	} else if platform.Country != current.Country {
+		tx.Rollback()
+		return err
+	}
+
+	err = insertRevision(tx, PLATFORM_ENTITY, platform.ID, editedBy, current)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	_, err = tx.Exec(`UPDATE platforms SET name = ?, website = ?, country = ?, source = ?, notes = ?, comment = ?, privacy = ? WHERE id = ?`,
+		platform.Name, platform.Website, platform.Country, platform.Source, platform.Notes, platform.Comment, platform.Privacy, platform.ID)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	_, err = tx.Exec("DELETE FROM platforms_categories WHERE platform_id = ?", platform.ID)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	err = insertPlatformCategories(tx, platform.ID, platform.CategoryIds())
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
 }
 
 func (db *Database) InsertPlatformCategories(platformId int64, categories []int64) error {
+	return insertPlatformCategories(db.querier, platformId, categories)
+}
+
+func insertPlatformCategories(e sqlx.Execer, platformId int64, categories []int64) error {
 	// If there are no categories, we're done
 	if len(categories) == 0 {
 		return nil
@@ -145,7 +237,7 @@ func (db *Database) InsertPlatformCategories(platformId int64, categories []int6
 	// Remove the last comma
 	query = strings.TrimRight(query, ",")
 
-	_, err := db.querier.Exec(query, args...)
+	_, err := e.Exec(query, args...)
 	if err != nil {
 		return err
 	}
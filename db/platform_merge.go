@@ -0,0 +1,267 @@
+package db
+
+import (
+	"database/sql"
+	"errors"
+	"sort"
+	"strings"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/webstradev/rsdb-backend/normalize"
+)
+
+const DEFAULT_DUPLICATE_THRESHOLD = 0.85
+
+var ErrInvalidMerge = errors.New("a platform can not be merged into itself")
+
+type DuplicatePlatform struct {
+	ID      int64  `json:"id" db:"id"`
+	Name    string `json:"name" db:"name"`
+	Website string `json:"website" db:"website"`
+	Country string `json:"country" db:"country"`
+	Source  string `json:"source" db:"source"`
+}
+
+type PlatformDuplicateCandidate struct {
+	Platform  DuplicatePlatform `json:"platform"`
+	Duplicate DuplicatePlatform `json:"duplicate"`
+	Score     float64           `json:"score"`
+	Reasons   []string          `json:"reasons"`
+}
+
+func (db *Database) GetPlatformsForDuplicateCheck() ([]DuplicatePlatform, error) {
+	platforms := []DuplicatePlatform{}
+
+	err := db.querier.Select(&platforms, "SELECT id, name, website, country, source FROM platforms WHERE deleted_at IS NULL ORDER BY id")
+	return platforms, err
+}
+
+// GetPossibleDuplicatePlatforms lists the platforms that can have a duplicate, those with a website or with a country
+// that another platform is in as well
+func (db *Database) GetPossibleDuplicatePlatforms() ([]DuplicatePlatform, error) {
+	platforms := []DuplicatePlatform{}
+
+	err := db.querier.Select(&platforms, `
+	SELECT p.id, p.name, p.website, p.country, p.source
+	FROM platforms p
+	WHERE p.deleted_at IS NULL AND (
+		p.website <> '' OR
+		(p.country <> '' AND EXISTS (SELECT 1 FROM platforms o WHERE o.country = p.country AND o.id <> p.id AND o.deleted_at IS NULL))
+	)
+	ORDER BY p.id`)
+	return platforms, err
+}
+
+type normalizedPlatform struct {
+	platform DuplicatePlatform
+	domain   string
+	name     string
+	country  string
+}
+
+func normalizePlatform(p DuplicatePlatform) normalizedPlatform {
+	return normalizedPlatform{
+		platform: p,
+		domain:   normalize.Domain(p.Website),
+		name:     normalize.Name(p.Name),
+		country:  strings.ToLower(strings.TrimSpace(p.Country)),
+	}
+}
+
+// comparePlatforms scores how likely two platforms are the same, a score of 0 means they don't match
+func comparePlatforms(a, b normalizedPlatform, threshold float64) (float64, []string) {
+	reasons := []string{}
+	score := 0.0
+
+	if a.domain != "" && a.domain == b.domain {
+		reasons = append(reasons, "website")
+		score = 1
+	}
+
+	if a.country != "" && a.country == b.country && a.name != "" && b.name != "" {
+		similarity := normalize.Similarity(a.name, b.name)
+		if similarity >= threshold {
+			reasons = append(reasons, "name", "country")
+			score = max(score, similarity)
+		}
+	}
+
+	return score, reasons
+}
+
+// FindDuplicatePlatforms pairs up platforms that share a website domain,
+// or that are in the same country and have a name similarity of at least the threshold
+func FindDuplicatePlatforms(platforms []DuplicatePlatform, threshold float64) []PlatformDuplicateCandidate {
+	entries := make([]normalizedPlatform, 0, len(platforms))
+	byDomain := map[string][]int{}
+	byCountry := map[string][]int{}
+	for i, p := range platforms {
+		entry := normalizePlatform(p)
+		entries = append(entries, entry)
+
+		if entry.domain != "" {
+			byDomain[entry.domain] = append(byDomain[entry.domain], i)
+		}
+		if entry.country != "" && entry.name != "" {
+			byCountry[entry.country] = append(byCountry[entry.country], i)
+		}
+	}
+
+	// Only platforms with the same domain or in the same country can match
+	pairs := map[[2]int]bool{}
+	for _, group := range byDomain {
+		for a := 0; a < len(group); a++ {
+			for b := a + 1; b < len(group); b++ {
+				pairs[[2]int{group[a], group[b]}] = true
+			}
+		}
+	}
+
+	for _, group := range byCountry {
+		// A name can only be similar enough to names that are not much longer, so the comparisons stop at those
+		length := func(i int) int { return len([]rune(entries[i].name)) }
+		sort.SliceStable(group, func(a, b int) bool { return length(group[a]) < length(group[b]) })
+
+		for a := 0; a < len(group); a++ {
+			for b := a + 1; b < len(group) && float64(length(group[a])) >= threshold*float64(length(group[b])); b++ {
+				pairs[[2]int{min(group[a], group[b]), max(group[a], group[b])}] = true
+			}
+		}
+	}
+
+	candidates := []PlatformDuplicateCandidate{}
+	for pair := range pairs {
+		i, j := pair[0], pair[1]
+		score, reasons := comparePlatforms(entries[i], entries[j], threshold)
+		if len(reasons) == 0 {
+			continue
+		}
+
+		candidates = append(candidates, PlatformDuplicateCandidate{
+			Platform:  entries[i].platform,
+			Duplicate: entries[j].platform,
+			Score:     score,
+			Reasons:   reasons,
+		})
+	}
+
+	// Most likely duplicates first, in the order of the platforms
+	sort.Slice(candidates, func(i, j int) bool {
+		if candidates[i].Score != candidates[j].Score {
+			return candidates[i].Score > candidates[j].Score
+		}
+		if candidates[i].Platform.ID != candidates[j].Platform.ID {
+			return candidates[i].Platform.ID < candidates[j].Platform.ID
+		}
+		return candidates[i].Duplicate.ID < candidates[j].Duplicate.ID
+	})
+
+	return candidates
+}
+
+// MergePlatforms moves contacts, activities, deals, tasks, attachments, comments, favorites, article/project links and
+// categories of the duplicates onto the surviving platform and soft-deletes the duplicates. Everything happens in a
+// single transaction, and the surviving platform's state before the merge is kept as a revision.
+func (db *Database) MergePlatforms(survivorId int64, duplicateIds []int64, mergedBy int64) error {
+	// Remove repeated ids so the number of merged platforms can be verified
+	unique := map[int64]bool{}
+	for _, id := range duplicateIds {
+		if id == survivorId {
+			return ErrInvalidMerge
+		}
+		unique[id] = true
+	}
+
+	tx, err := db.querier.Beginx()
+	if err != nil {
+		return err
+	}
+
+	survivor, err := snapshotPlatform(tx, survivorId)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	err = insertRevision(tx, PLATFORM_ENTITY, survivorId, mergedBy, survivor)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	// Soft-delete the duplicates first, if any of them doesn't exist (anymore) the merge is aborted
+	query, args, err := sqlx.In("UPDATE platforms SET merged_into = ?, deleted_at = CURRENT_TIMESTAMP() WHERE id IN (?) AND deleted_at IS NULL", survivorId, duplicateIds)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	result, err := tx.Exec(query, args...)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	merged, err := result.RowsAffected()
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if merged != int64(len(unique)) {
+		tx.Rollback()
+		return sql.ErrNoRows
+	}
+
+	// Move everything that is linked to the duplicates onto the survivor
+	statements := []string{
+		"UPDATE contacts SET platform_id = ? WHERE platform_id IN (?)",
+		"UPDATE activities SET platform_id = ? WHERE platform_id IN (?)",
+		"UPDATE deals SET platform_id = ? WHERE platform_id IN (?)",
+		"UPDATE tasks SET entity_id = ? WHERE entity_type = '" + PLATFORM_ENTITY + "' AND entity_id IN (?)",
+		"UPDATE attachments SET entity_id = ? WHERE entity_type = '" + PLATFORM_ENTITY + "' AND entity_id IN (?)",
+		"UPDATE comments SET entity_id = ? WHERE entity_type = '" + PLATFORM_ENTITY + "' AND entity_id IN (?)",
+		"INSERT IGNORE INTO favorites (user_id, entity_type, entity_id, created_at) SELECT user_id, entity_type, ?, created_at FROM favorites WHERE entity_type = '" + PLATFORM_ENTITY + "' AND entity_id IN (?)",
+		"INSERT IGNORE INTO platforms_articles (platform_id, article_id) SELECT ?, article_id FROM platforms_articles WHERE platform_id IN (?)",
+		"INSERT IGNORE INTO platforms_projects (platform_id, project_id) SELECT ?, project_id FROM platforms_projects WHERE platform_id IN (?)",
+		"INSERT IGNORE INTO platforms_categories (platform_id, category_id) SELECT ?, category_id FROM platforms_categories WHERE platform_id IN (?)",
+	}
+
+	for _, statement := range statements {
+		query, args, err := sqlx.In(statement, survivorId, duplicateIds)
+		if err != nil {
+			tx.Rollback()
+			return err
+		}
+
+		_, err = tx.Exec(query, args...)
+		if err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	// Remove the links and favorites of the duplicates now that they have been copied
+	deletes := []string{
+		"DELETE FROM platforms_articles WHERE platform_id IN (?)",
+		"DELETE FROM platforms_projects WHERE platform_id IN (?)",
+		"DELETE FROM platforms_categories WHERE platform_id IN (?)",
+		"DELETE FROM favorites WHERE entity_type = '" + PLATFORM_ENTITY + "' AND entity_id IN (?)",
+	}
+
+	for _, statement := range deletes {
+		query, args, err := sqlx.In(statement, duplicateIds)
+		if err != nil {
+			tx.Rollback()
+			return err
+		}
+
+		_, err = tx.Exec(query, args...)
+		if err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
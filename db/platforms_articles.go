@@ -1,5 +1,7 @@
 package db
 
+import "github.com/jmoiron/sqlx"
+
 type ArticlePlatform struct {
 	ArticleId    int64  `json:"-" db:"article_id"`
 	PlatformId   int64  `json:"id" db:"platform_id"`
@@ -7,9 +9,13 @@ type ArticlePlatform struct {
 }
 
 func (db *Database) GetPlatformsForArticle(id int64) ([]ArticlePlatform, error) {
+	return selectPlatformsForArticle(db.querier, id)
+}
+
+func selectPlatformsForArticle(q sqlx.Queryer, id int64) ([]ArticlePlatform, error) {
 	platforms := []ArticlePlatform{}
 
-	err := db.querier.Select(&platforms, `
+	err := sqlx.Select(q, &platforms, `
 	SELECT 
 		pa.*,
 		p.name as platform_name
@@ -1,5 +1,7 @@
 package db
 
+import "github.com/jmoiron/sqlx"
+
 type PlatformCategory struct {
 	PlatformID int64  `json:"-" db:"platform_id"`
 	CategoryID int64  `json:"id" db:"category_id"`
@@ -7,9 +9,13 @@ type PlatformCategory struct {
 }
 
 func (db *Database) GetPlatformCategories(id int64) ([]PlatformCategory, error) {
+	return selectPlatformCategories(db.querier, id)
+}
+
+func selectPlatformCategories(q sqlx.Queryer, id int64) ([]PlatformCategory, error) {
 	categories := []PlatformCategory{}
 
-	err := db.querier.Select(&categories, `
+	err := sqlx.Select(q, &categories, `
 	SELECT 
 		pc.*,
 		c.category
@@ -1,5 +1,7 @@
 package db
 
+import "github.com/jmoiron/sqlx"
+
 type ProjectPlatform struct {
 	ProjectId    int64  `json:"-" db:"project_id"`
 	PlatformId   int64  `json:"id" db:"platform_id"`
@@ -7,9 +9,13 @@ type ProjectPlatform struct {
 }
 
 func (db *Database) GetPlatformsForProject(id int64) ([]ProjectPlatform, error) {
+	return selectPlatformsForProject(db.querier, id)
+}
+
+func selectPlatformsForProject(q sqlx.Queryer, id int64) ([]ProjectPlatform, error) {
 	platforms := []ProjectPlatform{}
 
-	err := db.querier.Select(&platforms, `
+	err := sqlx.Select(q, &platforms, `
 	SELECT 
 		pp.*,
 		p.name as platform_name
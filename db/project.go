@@ -15,13 +15,23 @@ type Project struct {
 	Link        string            `json:"link" db:"link"`
 	Date        sql.NullTime      `json:"date" db:"date"`
 	Body        string            `json:"body" db:"body"`
+	BodyFormat  string            `json:"bodyFormat" db:"body_format"`
+	BodyHTML    string            `json:"bodyHtml" db:"body_html"`
+	BodyText    string            `json:"bodyText" db:"body_text"`
 	Tags        []ProjectTag      `json:"tags" db:"tags"`
 	Platforms   []ProjectPlatform `json:"platforms" db:"platforms"`
+	Press       []ProjectArticle  `json:"press" db:"press"`
+	// The status is changed through the workflow only, edits leave it as it is
+	Status          string        `json:"status" db:"status"`
+	StatusChangedAt sql.NullTime  `json:"statusChangedAt" db:"status_changed_at"`
+	AssigneeId      sql.NullInt64 `json:"assigneeId" db:"assignee_id"`
+	CreatedBy       sql.NullInt64 `json:"createdBy" db:"created_by"`
 }
 
 type ProjectWithTagString struct {
 	Project
-	TagString string `json:"tagString" db:"project_tags"`
+	TagString     string `json:"tagString" db:"project_tags"`
+	CommentsCount int    `json:"commentsCount" db:"comments_count"`
 }
 
 func (db *Database) CountProjects() (int, error) {
@@ -72,10 +82,25 @@ func (p *Project) PopulatePlatforms(db *Database) error {
 	return nil
 }
 
+func (p *Project) PopulatePress(db *Database) error {
+	press, err := db.GetPressForProject(p.ID)
+	if err != nil {
+		return err
+	}
+
+	p.Press = press
+
+	return nil
+}
+
 func (db *Database) GetProject(id int64) (Project, error) {
+	return getProject(db.querier, id)
+}
+
+func getProject(q sqlx.Queryer, id int64) (Project, error) {
 	project := Project{}
 
-	err := db.querier.Get(&project, "SELECT p.* FROM projects p WHERE p.id = ? AND p.deleted_at IS NULL", id)
+	err := sqlx.Get(q, &project, "SELECT p.* FROM projects p WHERE p.id = ? AND p.deleted_at IS NULL", id)
 	return project, err
 }
 
@@ -85,7 +110,8 @@ func (db *Database) GetProjects(page, pageSize int) ([]ProjectWithTagString, err
 	err := db.querier.Select(&projects, `
 	SELECT 
 		p.*, 
-		COALESCE(GROUP_CONCAT(DISTINCT t.tag), '') AS project_tags
+		COALESCE(GROUP_CONCAT(DISTINCT t.tag), '') AS project_tags,
+		`+projectCommentsCount+` AS comments_count
 	FROM 
 		projects p
 	LEFT JOIN 
@@ -104,9 +130,14 @@ func (db *Database) GetProjects(page, pageSize int) ([]ProjectWithTagString, err
 }
 
 func (db *Database) InsertProject(project Project) (int64, error) {
+	err := project.RenderBody()
+	if err != nil {
+		return 0, err
+	}
+
 	result, err := db.querier.NamedExec(`
-	INSERT INTO projects (title, description, link, date, body)
-	VALUES (:title, :description, :link, :date, :body)`, project)
+	INSERT INTO projects (title, description, link, date, body, body_format, body_html, body_text, status, status_changed_at, created_by)
+	VALUES (:title, :description, :link, :date, :body, :body_format, :body_html, :body_text, :status, CURRENT_TIMESTAMP(), :created_by)`, project)
 	if err != nil {
 		log.Println(err)
 		return 0, err
@@ -229,14 +260,34 @@ func (db *Database) InsertProjectPlatformsTx(tx *sqlx.Tx, projectID int64, platf
 	return nil
 }
 
-func (db *Database) EditProject(project Project) error {
+// EditProject stores a revision of the current project (including its tags and platforms)
+// and then applies the edit, all within the same transaction
+func (db *Database) EditProject(project Project, editedBy int64) error {
+	err := project.RenderBody()
+	if err != nil {
+		return err
+	}
+
 	tx, err := db.querier.Beginx()
 	if err != nil {
 		return err
 	}
 
+	current, err := snapshotProject(tx, project.ID)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	err = insertRevision(tx, PROJECT_ENTITY, project.ID, editedBy, current)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
 	_, err = tx.NamedExec(`
-    UPDATE projects SET title = :title, description = :description, link = :link, date = :date, body = :body
+    UPDATE projects SET title = :title, description = :description, link = :link, date = :date,
+        body = :body, body_format = :body_format, body_html = :body_html, body_text = :body_text
     WHERE id = :id`, project)
 	if err != nil {
 		tx.Rollback()
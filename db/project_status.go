@@ -0,0 +1,202 @@
+package db
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// StatusTransitionError is returned when the workflow does not allow the project to move from its current status
+type StatusTransitionError struct {
+	From string
+	To   string
+}
+
+func (e *StatusTransitionError) Error() string {
+	return fmt.Sprintf("A project can not move from %s to %s", e.From, e.To)
+}
+
+// A change of the status or assignee of a project
+type ProjectStatusChange struct {
+	ID         int64          `json:"id" db:"id"`
+	CreatedAt  time.Time      `json:"createdAt" db:"created_at"`
+	ProjectId  int64          `json:"projectId" db:"project_id"`
+	FromStatus sql.NullString `json:"fromStatus" db:"from_status"`
+	ToStatus   string         `json:"toStatus" db:"to_status"`
+	AssigneeId sql.NullInt64  `json:"assigneeId" db:"assignee_id"`
+	ChangedBy  sql.NullInt64  `json:"changedBy" db:"changed_by"`
+}
+
+// A project as shown on the pipeline board
+type PipelineProject struct {
+	ID              int64          `json:"id" db:"id"`
+	Title           string         `json:"title" db:"title"`
+	Status          string         `json:"status" db:"status"`
+	StatusChangedAt sql.NullTime   `json:"statusChangedAt" db:"status_changed_at"`
+	AssigneeId      sql.NullInt64  `json:"assigneeId" db:"assignee_id"`
+	AssigneeEmail   sql.NullString `json:"assigneeEmail" db:"assignee_email"`
+}
+
+// StatusTimestamps returns when the project last entered each of the statuses it has been in,
+// the status it was created with was entered when the project was created
+func (p *Project) StatusTimestamps(changes []ProjectStatusChange) map[string]time.Time {
+	timestamps := map[string]time.Time{p.Status: p.CreatedAt}
+	if len(changes) > 0 {
+		timestamps = map[string]time.Time{}
+		if changes[0].FromStatus.Valid {
+			timestamps[changes[0].FromStatus.String] = p.CreatedAt
+		}
+	}
+
+	for _, change := range changes {
+		if change.FromStatus.String != change.ToStatus {
+			timestamps[change.ToStatus] = change.CreatedAt
+		}
+	}
+
+	return timestamps
+}
+
+// ChangeProjectStatus moves the project to the given status and assignee. A revision of the current project is stored
+// and the change is recorded in the status history, all within the same transaction. The project is locked while its
+// current status is checked with canTransition, a *StatusTransitionError is returned when the move is not allowed.
+func (db *Database) ChangeProjectStatus(id int64, status string, assigneeId sql.NullInt64, changedBy int64, canTransition func(from, to string) bool) error {
+	tx, err := db.querier.Beginx()
+	if err != nil {
+		return err
+	}
+
+	var from string
+	err = tx.Get(&from, "SELECT status FROM projects WHERE id = ? AND deleted_at IS NULL FOR UPDATE", id)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if from != status && !canTransition(from, status) {
+		tx.Rollback()
+		return &StatusTransitionError{From: from, To: status}
+	}
+
+	current, err := snapshotProject(tx, id)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	err = insertRevision(tx, PROJECT_ENTITY, id, changedBy, current)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	_, err = tx.Exec(`
+	UPDATE projects
+	SET
+		status_changed_at = IF(status = ?, status_changed_at, CURRENT_TIMESTAMP()),
+		status = ?,
+		assignee_id = ?
+	WHERE id = ?`, status, status, assigneeId, id)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	_, err = tx.Exec(`
+	INSERT INTO project_status_changes (project_id, from_status, to_status, assignee_id, changed_by)
+	VALUES (?, ?, ?, ?, ?)`, id, current.Status, status, assigneeId, sql.NullInt64{Int64: changedBy, Valid: changedBy != 0})
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// GetUnknownProjectStatuses lists the statuses projects have that are not one of the given statuses
+func (db *Database) GetUnknownProjectStatuses(statuses []string) ([]string, error) {
+	unknown := []string{}
+
+	query, args, err := sqlx.In("SELECT DISTINCT status FROM projects WHERE status NOT IN (?) ORDER BY status", statuses)
+	if err != nil {
+		return unknown, err
+	}
+
+	err = db.querier.Select(&unknown, query, args...)
+	return unknown, err
+}
+
+// ResetUnknownProjectStatuses moves the projects with a status that is not one of the given statuses to the initial
+// status, e.g. projects that existed before the workflow or were left in a status the workflow no longer has. Every
+// move is recorded in the status history within the same transaction. It returns the number of projects that were moved.
+func (db *Database) ResetUnknownProjectStatuses(statuses []string, initial string, changedBy int64) (int64, error) {
+	tx, err := db.querier.Beginx()
+	if err != nil {
+		return 0, err
+	}
+
+	query, args, err := sqlx.In(`
+	INSERT INTO project_status_changes (project_id, from_status, to_status, assignee_id, changed_by)
+	SELECT id, status, ?, assignee_id, ? FROM projects WHERE status NOT IN (?)`, initial, sql.NullInt64{Int64: changedBy, Valid: changedBy != 0}, statuses)
+	if err != nil {
+		tx.Rollback()
+		return 0, err
+	}
+
+	_, err = tx.Exec(query, args...)
+	if err != nil {
+		tx.Rollback()
+		return 0, err
+	}
+
+	query, args, err = sqlx.In(`
+	UPDATE projects
+	SET status = ?, status_changed_at = CURRENT_TIMESTAMP()
+	WHERE status NOT IN (?)`, initial, statuses)
+	if err != nil {
+		tx.Rollback()
+		return 0, err
+	}
+
+	result, err := tx.Exec(query, args...)
+	if err != nil {
+		tx.Rollback()
+		return 0, err
+	}
+
+	reset, err := result.RowsAffected()
+	if err != nil {
+		tx.Rollback()
+		return 0, err
+	}
+
+	return reset, tx.Commit()
+}
+
+// GetProjectStatusChanges lists the status history of a project, the oldest change first
+func (db *Database) GetProjectStatusChanges(projectId int64) ([]ProjectStatusChange, error) {
+	changes := []ProjectStatusChange{}
+
+	err := db.querier.Select(&changes, `
+	SELECT * FROM project_status_changes WHERE project_id = ? ORDER BY created_at, id`, projectId)
+	return changes, err
+}
+
+// GetProjectPipeline lists all projects with their status and assignee, the longest in their status first
+func (db *Database) GetProjectPipeline() ([]PipelineProject, error) {
+	projects := []PipelineProject{}
+
+	err := db.querier.Select(&projects, `
+	SELECT
+		p.id, p.title, p.status, p.status_changed_at, p.assignee_id,
+		u.email AS assignee_email
+	FROM
+		projects p
+	LEFT JOIN
+		users u ON u.id = p.assignee_id
+	WHERE p.deleted_at IS NULL
+	ORDER BY p.status_changed_at, p.id`)
+	return projects, err
+}
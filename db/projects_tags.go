@@ -1,5 +1,7 @@
 package db
 
+import "github.com/jmoiron/sqlx"
+
 type ProjectTag struct {
 	ProjectId int64  `json:"-" db:"project_id"`
 	TagId     int64  `json:"id" db:"tag_id"`
@@ -7,9 +9,13 @@ type ProjectTag struct {
 }
 
 func (db *Database) GetProjectTags(id int64) ([]ProjectTag, error) {
+	return selectProjectTags(db.querier, id)
+}
+
+func selectProjectTags(q sqlx.Queryer, id int64) ([]ProjectTag, error) {
 	tags := []ProjectTag{}
 
-	err := db.querier.Select(&tags, `
+	err := sqlx.Select(q, &tags, `
 	SELECT 
 		pt.*,
 		t.tag
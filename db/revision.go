@@ -0,0 +1,251 @@
+package db
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+
+	"github.com/jmoiron/sqlx"
+)
+
+type Revision struct {
+	Model
+	EntityType string        `json:"entityType" db:"entity_type"`
+	EntityId   int64         `json:"entityId" db:"entity_id"`
+	Revision   int64         `json:"revision" db:"revision"`
+	CreatedBy  sql.NullInt64 `json:"createdBy" db:"created_by"`
+	Data       string        `json:"-" db:"data"`
+}
+
+type RevisionChange struct {
+	Field   string `json:"field"`
+	From    any    `json:"from"`
+	To      any    `json:"to"`
+	Added   []any  `json:"added,omitempty"`
+	Removed []any  `json:"removed,omitempty"`
+}
+
+// Fields that change on every edit and are therefore left out of diffs
+var revisionIgnoredFields = map[string]bool{
+	"id":         true,
+	"createdAt":  true,
+	"modifiedAt": true,
+	"deletedAt":  true,
+	"bodyHtml":   true,
+	"bodyText":   true,
+	"isFavorite": true,
+}
+
+func snapshotArticle(q sqlx.Queryer, id int64) (Article, error) {
+	article, err := getArticle(q, id)
+	if err != nil {
+		return article, err
+	}
+
+	article.Tags, err = selectArticleTags(q, id)
+	if err != nil {
+		return article, err
+	}
+
+	article.Platforms, err = selectPlatformsForArticle(q, id)
+	if err != nil {
+		return article, err
+	}
+
+	article.Projects, err = selectProjectsForArticle(q, id)
+	return article, err
+}
+
+func snapshotProject(q sqlx.Queryer, id int64) (Project, error) {
+	project, err := getProject(q, id)
+	if err != nil {
+		return project, err
+	}
+
+	project.Tags, err = selectProjectTags(q, id)
+	if err != nil {
+		return project, err
+	}
+
+	project.Platforms, err = selectPlatformsForProject(q, id)
+	return project, err
+}
+
+func snapshotPlatform(q sqlx.Queryer, id int64) (Platform, error) {
+	platform := Platform{}
+
+	err := sqlx.Get(q, &platform, "SELECT p.* FROM platforms p WHERE p.id = ? AND p.deleted_at IS NULL", id)
+	if err != nil {
+		return platform, err
+	}
+
+	platform.Categories, err = selectPlatformCategories(q, id)
+	return platform, err
+}
+
+// insertRevision stores the snapshot as the next revision number for the given entity
+func insertRevision(e sqlx.Execer, entityType string, entityId, createdBy int64, snapshot any) error {
+	data, err := json.Marshal(snapshot)
+	if err != nil {
+		return err
+	}
+
+	_, err = e.Exec(`
+	INSERT INTO revisions (entity_type, entity_id, revision, created_by, data)
+	SELECT ?, ?, COALESCE(MAX(revision), 0) + 1, ?, ?
+	FROM revisions
+	WHERE entity_type = ? AND entity_id = ?`,
+		entityType, entityId, sql.NullInt64{Int64: createdBy, Valid: createdBy != 0}, string(data), entityType, entityId)
+	return err
+}
+
+func (db *Database) GetRevisions(entityType string, entityId int64) ([]Revision, error) {
+	revisions := []Revision{}
+
+	err := db.querier.Select(&revisions, `
+	SELECT
+		id, created_at, modified_at, deleted_at, entity_type, entity_id, revision, created_by
+	FROM
+		revisions
+	WHERE
+		entity_type = ? AND entity_id = ? AND deleted_at IS NULL
+	ORDER BY revision DESC`, entityType, entityId)
+	return revisions, err
+}
+
+func (db *Database) GetRevision(entityType string, entityId, revision int64) (Revision, error) {
+	rev := Revision{}
+
+	err := db.querier.Get(&rev, `
+	SELECT
+		*
+	FROM
+		revisions
+	WHERE
+		entity_type = ? AND entity_id = ? AND revision = ? AND deleted_at IS NULL`, entityType, entityId, revision)
+	return rev, err
+}
+
+// RestoreRevision applies the snapshot stored in a revision to the current record.
+// Restoring goes through the regular edit path, so the state being replaced is itself kept as a new revision.
+func (db *Database) RestoreRevision(entityType string, entityId, revision, restoredBy int64) error {
+	rev, err := db.GetRevision(entityType, entityId, revision)
+	if err != nil {
+		return err
+	}
+
+	switch entityType {
+	case ARTICLE_ENTITY:
+		article := Article{}
+		err = json.Unmarshal([]byte(rev.Data), &article)
+		if err != nil {
+			return err
+		}
+		article.ID = entityId
+
+		return db.EditArticle(article, restoredBy)
+	case PROJECT_ENTITY:
+		project := Project{}
+		err = json.Unmarshal([]byte(rev.Data), &project)
+		if err != nil {
+			return err
+		}
+		project.ID = entityId
+
+		return db.EditProject(project, restoredBy)
+	case PLATFORM_ENTITY:
+		platform := Platform{}
+		err = json.Unmarshal([]byte(rev.Data), &platform)
+		if err != nil {
+			return err
+		}
+		platform.ID = entityId
+
+		return db.EditPlatform(platform, restoredBy)
+	}
+
+	return fmt.Errorf("unknown entity type %q", entityType)
+}
+
+// Diff lists the fields that changed between two revisions of the same record.
+// For linked records (tags, platforms, categories) the added and removed entries are listed as well.
+func (r *Revision) Diff(other Revision) ([]RevisionChange, error) {
+	from := map[string]any{}
+	err := json.Unmarshal([]byte(r.Data), &from)
+	if err != nil {
+		return nil, err
+	}
+
+	to := map[string]any{}
+	err = json.Unmarshal([]byte(other.Data), &to)
+	if err != nil {
+		return nil, err
+	}
+
+	// Collect all fields in a stable order
+	fields := []string{}
+	for field := range from {
+		fields = append(fields, field)
+	}
+	for field := range to {
+		if _, ok := from[field]; !ok {
+			fields = append(fields, field)
+		}
+	}
+	sort.Strings(fields)
+
+	changes := []RevisionChange{}
+	for _, field := range fields {
+		if revisionIgnoredFields[field] || reflect.DeepEqual(from[field], to[field]) {
+			continue
+		}
+
+		change := RevisionChange{Field: field, From: from[field], To: to[field]}
+		change.Added, change.Removed = diffLinks(from[field], to[field])
+
+		changes = append(changes, change)
+	}
+
+	return changes, nil
+}
+
+// diffLinks compares two lists of linked records by their id
+func diffLinks(from, to any) ([]any, []any) {
+	fromList, fromOk := from.([]any)
+	toList, toOk := to.([]any)
+	if !fromOk && !toOk {
+		return nil, nil
+	}
+
+	linkId := func(link any) string {
+		if m, ok := link.(map[string]any); ok {
+			return fmt.Sprint(m["id"])
+		}
+		return fmt.Sprint(link)
+	}
+
+	fromIds := map[string]bool{}
+	for _, link := range fromList {
+		fromIds[linkId(link)] = true
+	}
+
+	toIds := map[string]bool{}
+	added := []any{}
+	for _, link := range toList {
+		toIds[linkId(link)] = true
+		if !fromIds[linkId(link)] {
+			added = append(added, link)
+		}
+	}
+
+	removed := []any{}
+	for _, link := range fromList {
+		if !toIds[linkId(link)] {
+			removed = append(removed, link)
+		}
+	}
+
+	return added, removed
+}
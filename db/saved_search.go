@@ -0,0 +1,153 @@
+package db
+
+import (
+	"errors"
+	"net/url"
+	"time"
+)
+
+// Lists that searches can be saved for
+const SEARCH_TARGET_CONTACTS = "contacts"
+
+var ErrInvalidSearchTarget = errors.New("invalid search target, use contacts")
+
+// A named combination of filters and order of a list, only the owner can change it. Shared searches are visible to
+// every user, others only to their owner.
+type SavedSearch struct {
+	Model
+	OwnerId    int64  `json:"ownerId" db:"owner_id"`
+	OwnerEmail string `json:"ownerEmail" db:"owner_email"`
+	Name       string `json:"name" db:"name"`
+	Target     string `json:"target" db:"target"`
+	Query      string `json:"query" db:"query"`
+	Shared     bool   `json:"shared" db:"shared"`
+	Subscribed bool   `json:"subscribed" db:"subscribed"`
+}
+
+// A user subscribed to the new matches of a saved search, NotifiedAt is when they were last notified
+type SearchSubscription struct {
+	SavedSearch
+	UserId     int64     `db:"user_id"`
+	UserRole   string    `db:"user_role"`
+	NotifiedAt time.Time `db:"notified_at"`
+}
+
+// Validate checks that the query is valid for the target of the search. Pagination is not part of a saved search, so
+// it is removed from the query.
+func (s *SavedSearch) Validate() error {
+	values, err := url.ParseQuery(s.Query)
+	if err != nil {
+		return errors.New("invalid query, use the query string of the list")
+	}
+
+	switch s.Target {
+	case SEARCH_TARGET_CONTACTS:
+		_, err = ParseContactFilter(values)
+	default:
+		err = ErrInvalidSearchTarget
+	}
+	if err != nil {
+		return err
+	}
+
+	values.Del("page")
+	values.Del("pageSize")
+	s.Query = values.Encode()
+
+	return nil
+}
+
+// ContactFilter returns the filter of a search of the contacts directory
+func (s SavedSearch) ContactFilter() (ContactFilter, error) {
+	if s.Target != SEARCH_TARGET_CONTACTS {
+		return ContactFilter{}, ErrInvalidSearchTarget
+	}
+
+	values, err := url.ParseQuery(s.Query)
+	if err != nil {
+		return ContactFilter{}, err
+	}
+
+	return ParseContactFilter(values)
+}
+
+// savedSearchQuery selects saved searches with whether the user given as first argument is subscribed to them
+const savedSearchQuery = `
+	SELECT
+		s.*,
+		COALESCE(u.email, '') AS owner_email,
+		EXISTS (SELECT 1 FROM saved_searches_subscriptions ss WHERE ss.saved_search_id = s.id AND ss.user_id = ?) AS subscribed
+	FROM saved_searches s
+	LEFT JOIN users u ON u.id = s.owner_id`
+
+// GetSavedSearch returns a saved search, with whether the user is subscribed to it
+func (db *Database) GetSavedSearch(id, userId int64) (SavedSearch, error) {
+	search := SavedSearch{}
+
+	err := db.querier.Get(&search, savedSearchQuery+" WHERE s.id = ? AND s.deleted_at IS NULL", userId, id)
+	return search, err
+}
+
+// GetSavedSearches lists the searches of the user and the ones shared by others, by name
+func (db *Database) GetSavedSearches(userId int64) ([]SavedSearch, error) {
+	searches := []SavedSearch{}
+
+	err := db.querier.Select(&searches, savedSearchQuery+`
+	WHERE s.deleted_at IS NULL AND (s.owner_id = ? OR s.shared)
+	ORDER BY s.name, s.id`, userId, userId)
+	return searches, err
+}
+
+func (db *Database) InsertSavedSearch(search SavedSearch) (int64, error) {
+	result, err := db.querier.NamedExec(`
+	INSERT INTO saved_searches (owner_id, name, target, query, shared)
+	VALUES (:owner_id, :name, :target, :query, :shared)`, search)
+	if err != nil {
+		return 0, err
+	}
+
+	return result.LastInsertId()
+}
+
+func (db *Database) UpdateSavedSearch(search SavedSearch) error {
+	_, err := db.querier.NamedExec("UPDATE saved_searches SET name = :name, target = :target, query = :query, shared = :shared WHERE id = :id", search)
+	return err
+}
+
+func (db *Database) DeleteSavedSearch(id int64) error {
+	_, err := db.querier.Exec("UPDATE saved_searches SET deleted_at = CURRENT_TIMESTAMP() WHERE id = ?", id)
+	return err
+}
+
+// SubscribeToSearch notifies the user of matches of the search that are new after the given time, subscribing again
+// changes nothing
+func (db *Database) SubscribeToSearch(searchId, userId int64, since time.Time) error {
+	_, err := db.querier.Exec("INSERT IGNORE INTO saved_searches_subscriptions (saved_search_id, user_id, notified_at) VALUES (?, ?, ?)", searchId, userId, since)
+	return err
+}
+
+func (db *Database) UnsubscribeFromSearch(searchId, userId int64) error {
+	_, err := db.querier.Exec("DELETE FROM saved_searches_subscriptions WHERE saved_search_id = ? AND user_id = ?", searchId, userId)
+	return err
+}
+
+// GetDueSearchSubscriptions lists the subscriptions that were last notified at or before the given time. Subscriptions
+// to searches that are deleted or no longer shared with the subscriber are left out.
+func (db *Database) GetDueSearchSubscriptions(notifiedBefore time.Time) ([]SearchSubscription, error) {
+	subscriptions := []SearchSubscription{}
+
+	err := db.querier.Select(&subscriptions, `
+	SELECT s.*, ss.user_id, u.role AS user_role, ss.notified_at
+	FROM saved_searches_subscriptions ss
+	JOIN saved_searches s ON s.id = ss.saved_search_id
+	JOIN users u ON u.id = ss.user_id
+	WHERE ss.notified_at <= ? AND s.deleted_at IS NULL AND (s.shared OR s.owner_id = ss.user_id)
+	ORDER BY ss.saved_search_id, ss.user_id`, notifiedBefore)
+	return subscriptions, err
+}
+
+// SaveSearchNotified records when the subscriber of a search was notified of its new matches
+func (db *Database) SaveSearchNotified(searchId, userId int64, notifiedAt time.Time) error {
+	_, err := db.querier.Exec("UPDATE saved_searches_subscriptions SET notified_at = ? WHERE saved_search_id = ? AND user_id = ?", notifiedAt, searchId, userId)
+	return err
+}
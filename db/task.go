@@ -0,0 +1,137 @@
+package db
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+)
+
+const (
+	TASK_OPEN        = "open"
+	TASK_IN_PROGRESS = "in_progress"
+	TASK_DONE        = "done"
+	TASK_CANCELLED   = "cancelled"
+)
+
+var (
+	ErrInvalidTaskStatus = errors.New("invalid task status, use open, in_progress, done or cancelled")
+	ErrInvalidTaskEntity = errors.New("a linked entity needs both an entity type and id")
+)
+
+// A follow-up task assigned to a user, optionally linked to a platform, contact, article or project
+type Task struct {
+	Model
+	Title         string         `json:"title" db:"title"`
+	Notes         string         `json:"notes" db:"notes"`
+	DueDate       time.Time      `json:"dueDate" db:"due_date"`
+	AssigneeId    int64          `json:"assigneeId" db:"assignee_id"`
+	AssigneeEmail string         `json:"assigneeEmail" db:"assignee_email"`
+	EntityType    sql.NullString `json:"entityType" db:"entity_type"`
+	EntityId      sql.NullInt64  `json:"entityId" db:"entity_id"`
+	Status        string         `json:"status" db:"status"`
+	CompletedAt   sql.NullTime   `json:"completedAt" db:"completed_at"`
+	CreatedBy     sql.NullInt64  `json:"createdBy" db:"created_by"`
+}
+
+const taskQuery = `
+	SELECT
+		t.*,
+		u.email AS assignee_email
+	FROM
+		tasks t
+	JOIN
+		users u ON u.id = t.assignee_id
+	WHERE t.deleted_at IS NULL AND `
+
+// Tasks with these statuses still need to be done
+const openTaskStatuses = "t.status IN ('" + TASK_OPEN + "', '" + TASK_IN_PROGRESS + "')"
+
+func (t *Task) Validate() error {
+	switch t.Status {
+	case TASK_OPEN, TASK_IN_PROGRESS, TASK_DONE, TASK_CANCELLED:
+	default:
+		return ErrInvalidTaskStatus
+	}
+
+	if t.EntityType.Valid != t.EntityId.Valid {
+		return ErrInvalidTaskEntity
+	}
+
+	return nil
+}
+
+func (db *Database) GetTask(id int64) (Task, error) {
+	task := Task{}
+	err := db.querier.Get(&task, taskQuery+"t.id = ?", id)
+	return task, err
+}
+
+// GetTasksForAssignee lists the tasks of a user with the given status, or all tasks that still need to be done without a status
+func (db *Database) GetTasksForAssignee(assigneeId int64, status string) ([]Task, error) {
+	tasks := []Task{}
+
+	where := "t.assignee_id = ? AND " + openTaskStatuses
+	args := []any{assigneeId}
+	if status != "" {
+		where = "t.assignee_id = ? AND t.status = ?"
+		args = append(args, status)
+	}
+
+	err := db.querier.Select(&tasks, taskQuery+where+" ORDER BY t.due_date, t.id", args...)
+	return tasks, err
+}
+
+// GetOverdueTasks lists the tasks of all users that should have been done before the given date
+func (db *Database) GetOverdueTasks(date time.Time) ([]Task, error) {
+	tasks := []Task{}
+
+	err := db.querier.Select(&tasks, taskQuery+openTaskStatuses+`
+	AND t.due_date < ?
+	ORDER BY t.due_date, t.id`, date.Format(time.DateOnly))
+	return tasks, err
+}
+
+// GetTasksDueBy lists the tasks of all users that need to be done on or before the given date, grouped by assignee
+func (db *Database) GetTasksDueBy(date time.Time) ([]Task, error) {
+	tasks := []Task{}
+
+	err := db.querier.Select(&tasks, taskQuery+openTaskStatuses+`
+	AND t.due_date <= ?
+	ORDER BY t.assignee_id, t.due_date, t.id`, date.Format(time.DateOnly))
+	return tasks, err
+}
+
+func (db *Database) InsertTask(task Task) (int64, error) {
+	result, err := db.querier.NamedExec(`
+		INSERT INTO tasks
+			(title, notes, due_date, assignee_id, entity_type, entity_id, status, completed_at, created_by)
+		VALUES
+			(:title, :notes, :due_date, :assignee_id, :entity_type, :entity_id, :status, IF(:status = 'done', CURRENT_TIMESTAMP(), NULL), :created_by)`, task)
+	if err != nil {
+		return 0, err
+	}
+
+	return result.LastInsertId()
+}
+
+// UpdateTask stores the changes to a task, the completion date is set when it is first marked as done
+func (db *Database) UpdateTask(task Task) error {
+	_, err := db.querier.NamedExec(`
+		UPDATE tasks
+		SET
+			title = :title,
+			notes = :notes,
+			due_date = :due_date,
+			assignee_id = :assignee_id,
+			entity_type = :entity_type,
+			entity_id = :entity_id,
+			status = :status,
+			completed_at = IF(:status = 'done', COALESCE(completed_at, CURRENT_TIMESTAMP()), NULL)
+		WHERE id = :id`, task)
+	return err
+}
+
+func (db *Database) DeleteTask(id int64) error {
+	_, err := db.querier.Exec("UPDATE tasks SET deleted_at = CURRENT_TIMESTAMP() WHERE id = ?", id)
+	return err
+}
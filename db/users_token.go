@@ -1,6 +1,7 @@
 package db
 
 import (
+	"database/sql"
 	"time"
 )
 
@@ -68,3 +69,10 @@ func (db *Database) ConsumeToken(hashedToken string) error {
 	_, err := db.querier.Exec("UPDATE users_tokens SET used = 1 WHERE hashed_token = ?", hashedToken)
 	return err
 }
+
+// GetTokenCreator returns the ID of the user that created a token, it is not valid when that user has been removed
+func (db *Database) GetTokenCreator(hashedToken string) (sql.NullInt64, error) {
+	var createdBy sql.NullInt64
+	err := db.querier.Get(&createdBy, "SELECT created_by FROM users_tokens WHERE hashed_token = ?", hashedToken)
+	return createdBy, err
+}
@@ -0,0 +1,331 @@
+package db
+
+import (
+	"database/sql"
+	"errors"
+	"net/url"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// Statuses of webhook deliveries, pending deliveries are (re)tried at their next attempt
+const (
+	DELIVERY_PENDING   = "pending"
+	DELIVERY_SUCCEEDED = "succeeded"
+	DELIVERY_FAILED    = "failed"
+)
+
+var (
+	ErrInvalidWebhookUrl   = errors.New("invalid URL, use an http or https URL")
+	ErrInvalidWebhookEvent = errors.New("invalid event, use platform, contact, article or project followed by .created, .updated or .deleted")
+	ErrWebhookEvents       = errors.New("a webhook needs at least one event")
+)
+
+// WebhookEvents are the events webhooks can subscribe to, named <entity type>.<action> (e.g. platform.created)
+var WebhookEvents = webhookEvents()
+
+func webhookEvents() []string {
+	events := []string{}
+	for _, entityType := range []string{PLATFORM_ENTITY, CONTACT_ENTITY, ARTICLE_ENTITY, PROJECT_ENTITY} {
+		for _, action := range []string{CHANGE_CREATED, CHANGE_UPDATED, CHANGE_DELETED} {
+			events = append(events, WebhookEvent(entityType, action))
+		}
+	}
+	return events
+}
+
+// WebhookEvent returns the name of the event of an action on a record of the entity type
+func WebhookEvent(entityType, action string) string {
+	return entityType + "." + action
+}
+
+// A URL that is called with the events it is subscribed to, the payloads are signed with its secret
+type Webhook struct {
+	Model
+	Url       string        `json:"url" db:"url"`
+	Secret    string        `json:"-" db:"secret"`
+	Active    bool          `json:"active" db:"active"`
+	CreatedBy sql.NullInt64 `json:"createdBy" db:"created_by"`
+	Events    []string      `json:"events"`
+}
+
+// A call of a webhook with an event. Redeliveries are new deliveries of the payload of an earlier one.
+type WebhookDelivery struct {
+	Model
+	WebhookId      int64         `json:"webhookId" db:"webhook_id"`
+	Event          string        `json:"event" db:"event"`
+	Payload        string        `json:"payload" db:"payload"`
+	Status         string        `json:"status" db:"status"`
+	Attempts       int           `json:"attempts" db:"attempts"`
+	NextAttemptAt  sql.NullTime  `json:"nextAttemptAt" db:"next_attempt_at"`
+	LastAttemptAt  sql.NullTime  `json:"lastAttemptAt" db:"last_attempt_at"`
+	ResponseStatus sql.NullInt64 `json:"responseStatus" db:"response_status"`
+	LastError      string        `json:"lastError" db:"last_error"`
+	RedeliveryOf   sql.NullInt64 `json:"redeliveryOf" db:"redelivery_of"`
+}
+
+// A delivery that is due, with the URL and secret of its webhook
+type DueWebhookDelivery struct {
+	WebhookDelivery
+	Url    string `db:"url"`
+	Secret string `db:"secret"`
+}
+
+// Validate checks the URL and the events of the webhook
+func (w *Webhook) Validate() error {
+	u, err := url.Parse(w.Url)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return ErrInvalidWebhookUrl
+	}
+
+	if len(w.Events) == 0 {
+		return ErrWebhookEvents
+	}
+
+	for _, event := range w.Events {
+		valid := false
+		for _, e := range WebhookEvents {
+			valid = valid || event == e
+		}
+		if !valid {
+			return ErrInvalidWebhookEvent
+		}
+	}
+
+	return nil
+}
+
+func (db *Database) GetWebhook(id int64) (Webhook, error) {
+	webhook := Webhook{}
+
+	err := db.querier.Get(&webhook, "SELECT * FROM webhooks WHERE id = ? AND deleted_at IS NULL", id)
+	if err != nil {
+		return webhook, err
+	}
+
+	webhooks := []Webhook{webhook}
+	err = populateWebhookEvents(db.querier, webhooks)
+	return webhooks[0], err
+}
+
+func (db *Database) GetWebhooks() ([]Webhook, error) {
+	webhooks := []Webhook{}
+
+	err := db.querier.Select(&webhooks, "SELECT * FROM webhooks WHERE deleted_at IS NULL ORDER BY id")
+	if err != nil {
+		return nil, err
+	}
+
+	err = populateWebhookEvents(db.querier, webhooks)
+	return webhooks, err
+}
+
+// populateWebhookEvents loads the events of all webhooks with one query
+func populateWebhookEvents(q sqlx.Queryer, webhooks []Webhook) error {
+	if len(webhooks) == 0 {
+		return nil
+	}
+
+	ids := make([]int64, len(webhooks))
+	index := map[int64]int{}
+	for i := range webhooks {
+		ids[i] = webhooks[i].ID
+		index[webhooks[i].ID] = i
+		webhooks[i].Events = []string{}
+	}
+
+	events := []struct {
+		WebhookId int64  `db:"webhook_id"`
+		Event     string `db:"event"`
+	}{}
+
+	query, args, err := sqlx.In("SELECT webhook_id, event FROM webhooks_events WHERE webhook_id IN (?) ORDER BY webhook_id, event", ids)
+	if err != nil {
+		return err
+	}
+
+	err = sqlx.Select(q, &events, query, args...)
+	if err != nil {
+		return err
+	}
+
+	for _, event := range events {
+		i := index[event.WebhookId]
+		webhooks[i].Events = append(webhooks[i].Events, event.Event)
+	}
+
+	return nil
+}
+
+// InsertWebhook stores the webhook with its events in a single transaction
+func (db *Database) InsertWebhook(webhook Webhook) (int64, error) {
+	tx, err := db.querier.Beginx()
+	if err != nil {
+		return 0, err
+	}
+
+	result, err := tx.NamedExec(`
+		INSERT INTO webhooks (url, secret, active, created_by)
+		VALUES (:url, :secret, :active, :created_by)`, webhook)
+	if err != nil {
+		tx.Rollback()
+		return 0, err
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		tx.Rollback()
+		return 0, err
+	}
+
+	err = insertWebhookEvents(tx, id, webhook.Events)
+	if err != nil {
+		tx.Rollback()
+		return 0, err
+	}
+
+	return id, tx.Commit()
+}
+
+// UpdateWebhook stores the URL and whether the webhook is active, and replaces its events in a single transaction.
+// The secret is kept.
+func (db *Database) UpdateWebhook(webhook Webhook) error {
+	tx, err := db.querier.Beginx()
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.NamedExec("UPDATE webhooks SET url = :url, active = :active WHERE id = :id", webhook)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	_, err = tx.Exec("DELETE FROM webhooks_events WHERE webhook_id = ?", webhook.ID)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	err = insertWebhookEvents(tx, webhook.ID, webhook.Events)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func insertWebhookEvents(tx *sqlx.Tx, webhookId int64, events []string) error {
+	for _, event := range events {
+		_, err := tx.Exec("INSERT IGNORE INTO webhooks_events (webhook_id, event) VALUES (?, ?)", webhookId, event)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// DeleteWebhook soft deletes the webhook, its pending deliveries are no longer sent
+func (db *Database) DeleteWebhook(id int64) error {
+	_, err := db.querier.Exec("UPDATE webhooks SET deleted_at = CURRENT_TIMESTAMP() WHERE id = ?", id)
+	return err
+}
+
+// QueueWebhookDeliveries queues a delivery of the payload for every active webhook subscribed to the event, due at
+// the given time. It returns the number of deliveries that were queued.
+func (db *Database) QueueWebhookDeliveries(event, payload string, dueAt time.Time) (int64, error) {
+	result, err := db.querier.Exec(`
+	INSERT INTO webhook_deliveries (webhook_id, event, payload, next_attempt_at)
+	SELECT w.id, ?, ?, ?
+	FROM webhooks w
+	JOIN webhooks_events we ON we.webhook_id = w.id
+	WHERE we.event = ? AND w.active AND w.deleted_at IS NULL`, event, payload, dueAt, event)
+	if err != nil {
+		return 0, err
+	}
+
+	return result.RowsAffected()
+}
+
+func (db *Database) GetWebhookDelivery(id int64) (WebhookDelivery, error) {
+	delivery := WebhookDelivery{}
+
+	err := db.querier.Get(&delivery, "SELECT * FROM webhook_deliveries WHERE id = ? AND deleted_at IS NULL", id)
+	return delivery, err
+}
+
+// GetWebhookDeliveries lists the deliveries of a webhook, the latest first
+func (db *Database) GetWebhookDeliveries(webhookId int64, page, pageSize int) ([]WebhookDelivery, error) {
+	deliveries := []WebhookDelivery{}
+
+	err := db.querier.Select(&deliveries, `
+	SELECT * FROM webhook_deliveries
+	WHERE webhook_id = ? AND deleted_at IS NULL
+	ORDER BY id DESC
+	LIMIT ? OFFSET ?`, webhookId, pageSize, page*pageSize)
+	return deliveries, err
+}
+
+func (db *Database) CountWebhookDeliveries(webhookId int64) (int, error) {
+	var count int
+
+	err := db.querier.Get(&count, "SELECT COUNT(*) FROM webhook_deliveries WHERE webhook_id = ? AND deleted_at IS NULL", webhookId)
+	return count, err
+}
+
+// GetDueWebhookDeliveries lists the pending deliveries that are due at the given time, the longest due first.
+// Deliveries of webhooks that are deleted or inactive wait until the webhook is active again.
+func (db *Database) GetDueWebhookDeliveries(now time.Time, limit int) ([]DueWebhookDelivery, error) {
+	deliveries := []DueWebhookDelivery{}
+
+	err := db.querier.Select(&deliveries, `
+	SELECT d.*, w.url, w.secret
+	FROM webhook_deliveries d
+	JOIN webhooks w ON w.id = d.webhook_id
+	WHERE d.status = ? AND d.next_attempt_at <= ? AND d.deleted_at IS NULL AND w.active AND w.deleted_at IS NULL
+	ORDER BY d.next_attempt_at, d.id
+	LIMIT ?`, DELIVERY_PENDING, now, limit)
+	return deliveries, err
+}
+
+// ClaimWebhookDelivery records an attempt of the delivery and moves its next attempt to the given time, so the
+// delivery is tried again if this attempt never finishes. It returns false when the attempt was already claimed
+// (e.g. by another server).
+func (db *Database) ClaimWebhookDelivery(delivery WebhookDelivery, now, retryAt time.Time) (bool, error) {
+	result, err := db.querier.Exec(`
+	UPDATE webhook_deliveries
+	SET attempts = attempts + 1, last_attempt_at = ?, next_attempt_at = ?
+	WHERE id = ? AND status = ? AND attempts = ?`, now, retryAt, delivery.ID, DELIVERY_PENDING, delivery.Attempts)
+	if err != nil {
+		return false, err
+	}
+
+	claimed, err := result.RowsAffected()
+	return claimed > 0, err
+}
+
+// SaveWebhookAttempt records the outcome of the last attempt of a delivery, with the next attempt if it is retried
+func (db *Database) SaveWebhookAttempt(id int64, status string, responseStatus sql.NullInt64, lastError string, nextAttemptAt sql.NullTime) error {
+	_, err := db.querier.Exec(`
+	UPDATE webhook_deliveries
+	SET status = ?, response_status = ?, last_error = ?, next_attempt_at = ?
+	WHERE id = ?`, status, responseStatus, lastError, nextAttemptAt, id)
+	return err
+}
+
+// RedeliverWebhookDelivery queues a new delivery of the payload of a delivery, due at the given time
+func (db *Database) RedeliverWebhookDelivery(id int64, dueAt time.Time) (int64, error) {
+	result, err := db.querier.Exec(`
+	INSERT INTO webhook_deliveries (webhook_id, event, payload, next_attempt_at, redelivery_of)
+	SELECT webhook_id, event, payload, ?, id
+	FROM webhook_deliveries
+	WHERE id = ?`, dueAt, id)
+	if err != nil {
+		return 0, err
+	}
+
+	return result.LastInsertId()
+}
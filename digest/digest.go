@@ -0,0 +1,85 @@
+// Package digest sends users a daily overview of their tasks
+package digest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/webstradev/rsdb-backend/db"
+	"github.com/webstradev/rsdb-backend/notify"
+	"github.com/webstradev/rsdb-backend/scheduler"
+)
+
+// Name of the job, used to make sure the digest is sent once a day
+const TASK_DIGEST_JOB = "task_digest"
+
+// TaskDigest sends every user with open tasks that are due today or overdue a single message listing them
+func TaskDigest(database *db.Database, notifier notify.Notifier) scheduler.Job {
+	return func(ctx context.Context, now time.Time) error {
+		claimed, err := database.ClaimJobRun(TASK_DIGEST_JOB, now)
+		if err != nil {
+			return err
+		}
+
+		// Another server already sent today's digest
+		if !claimed {
+			return nil
+		}
+
+		tasks, err := database.GetTasksDueBy(now)
+		if err != nil {
+			return err
+		}
+
+		// Tasks are ordered by assignee, a failed message does not stop the others from being sent
+		errs := []error{}
+		for start := 0; start < len(tasks); {
+			end := start
+			for end < len(tasks) && tasks[end].AssigneeId == tasks[start].AssigneeId {
+				end++
+			}
+
+			err = notifier.Notify(ctx, Message(tasks[start:end], now))
+			if err != nil {
+				errs = append(errs, fmt.Errorf("digest for user %d: %w", tasks[start].AssigneeId, err))
+			}
+
+			start = end
+		}
+
+		return errors.Join(errs...)
+	}
+}
+
+// Message lists the tasks of a single assignee, overdue tasks first
+func Message(tasks []db.Task, now time.Time) notify.Message {
+	today := now.Format(time.DateOnly)
+
+	overdue := []string{}
+	due := []string{}
+	for _, task := range tasks {
+		date := task.DueDate.Format(time.DateOnly)
+		if date < today {
+			overdue = append(overdue, fmt.Sprintf("- %s (due %s)", task.Title, date))
+		} else {
+			due = append(due, "- "+task.Title)
+		}
+	}
+
+	sections := []string{}
+	if len(overdue) > 0 {
+		sections = append(sections, "Overdue:\n"+strings.Join(overdue, "\n"))
+	}
+	if len(due) > 0 {
+		sections = append(sections, "Due today:\n"+strings.Join(due, "\n"))
+	}
+
+	return notify.Message{
+		To:      tasks[0].AssigneeEmail,
+		Subject: fmt.Sprintf("Your tasks for %s", today),
+		Body:    strings.Join(sections, "\n\n") + "\n",
+	}
+}
@@ -0,0 +1,113 @@
+package digest
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/require"
+	"github.com/webstradev/rsdb-backend/db"
+	"github.com/webstradev/rsdb-backend/notify"
+)
+
+type recordingNotifier struct {
+	messages []notify.Message
+	fail     string
+}
+
+func (n *recordingNotifier) Notify(ctx context.Context, message notify.Message) error {
+	if message.To == n.fail {
+		return errors.New("test")
+	}
+
+	n.messages = append(n.messages, message)
+	return nil
+}
+
+func TestTaskDigest(t *testing.T) {
+	now := time.Date(2024, 5, 1, 8, 0, 0, 0, time.UTC)
+	taskColumns := []string{"id", "title", "due_date", "assignee_id", "assignee_email", "status"}
+
+	tests := []struct {
+		Name       string
+		MockDbCall func(sqlmock.Sqlmock)
+		Fail       string
+		Error      string
+		Messages   []notify.Message
+	}{
+		{
+			"TaskDigest - sql error on ClaimJobRun",
+			func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec("INSERT IGNORE INTO job_runs").WithArgs(TASK_DIGEST_JOB, "2024-05-01").WillReturnError(errors.New("test"))
+			},
+			"",
+			"test",
+			nil,
+		},
+		{
+			"TaskDigest - already sent by another server",
+			func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec("INSERT IGNORE INTO job_runs").WithArgs(TASK_DIGEST_JOB, "2024-05-01").WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+			"",
+			"",
+			nil,
+		},
+		{
+			"TaskDigest - one message per assignee",
+			func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec("INSERT IGNORE INTO job_runs").WithArgs(TASK_DIGEST_JOB, "2024-05-01").WillReturnResult(sqlmock.NewResult(1, 1))
+				rows := sqlmock.NewRows(taskColumns).
+					AddRow(1, "Call Jane", time.Date(2024, 4, 29, 0, 0, 0, 0, time.UTC), 1, "one@example.com", "open").
+					AddRow(2, "Send press kit", now, 1, "one@example.com", "in_progress").
+					AddRow(3, "Book venue", now, 2, "two@example.com", "open")
+				mock.ExpectQuery("SELECT t.(.+) FROM tasks t").WithArgs("2024-05-01").WillReturnRows(rows)
+			},
+			"",
+			"",
+			[]notify.Message{
+				{To: "one@example.com", Subject: "Your tasks for 2024-05-01", Body: "Overdue:\n- Call Jane (due 2024-04-29)\n\nDue today:\n- Send press kit\n"},
+				{To: "two@example.com", Subject: "Your tasks for 2024-05-01", Body: "Due today:\n- Book venue\n"},
+			},
+		},
+		{
+			"TaskDigest - failed message does not stop the others",
+			func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec("INSERT IGNORE INTO job_runs").WithArgs(TASK_DIGEST_JOB, "2024-05-01").WillReturnResult(sqlmock.NewResult(1, 1))
+				rows := sqlmock.NewRows(taskColumns).
+					AddRow(1, "Call Jane", now, 1, "one@example.com", "open").
+					AddRow(3, "Book venue", now, 2, "two@example.com", "open")
+				mock.ExpectQuery("SELECT t.(.+) FROM tasks t").WithArgs("2024-05-01").WillReturnRows(rows)
+			},
+			"one@example.com",
+			"digest for user 1: test",
+			[]notify.Message{
+				{To: "two@example.com", Subject: "Your tasks for 2024-05-01", Body: "Due today:\n- Book venue\n"},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			mockDb, mockSql, err := sqlmock.New()
+			require.NoError(t, err)
+			defer mockDb.Close()
+
+			test.MockDbCall(mockSql)
+
+			notifier := &recordingNotifier{fail: test.Fail}
+			err = TaskDigest(db.SetupMockDB(sqlx.NewDb(mockDb, "sqlmock")), notifier)(context.Background(), now)
+			if test.Error != "" {
+				require.EqualError(t, err, test.Error)
+			} else {
+				require.NoError(t, err)
+			}
+
+			require.Equal(t, test.Messages, notifier.messages)
+			require.NoError(t, mockSql.ExpectationsWereMet())
+		})
+	}
+}
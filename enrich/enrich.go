@@ -0,0 +1,145 @@
+// Package enrich proposes the title, description, publish date and image of an article from the page it links to,
+// so researchers do not have to copy them by hand
+package enrich
+
+import (
+	"context"
+	"database/sql"
+	"log"
+	"net/url"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/webstradev/rsdb-backend/db"
+	"github.com/webstradev/rsdb-backend/publicnet"
+)
+
+// Time a background enrichment may take, including fetching the page
+const ENRICH_TIMEOUT = 30 * time.Second
+
+var ErrInvalidLink = publicnet.ErrInvalidLink
+
+// A fetcher returns the HTML of the page at the link
+type Fetcher interface {
+	Fetch(ctx context.Context, link string) ([]byte, error)
+}
+
+// Metadata proposed for an article, empty fields were not found on the page
+type Metadata struct {
+	Title       string       `json:"title"`
+	Description string       `json:"description"`
+	Date        sql.NullTime `json:"date"`
+	Image       string       `json:"image"`
+}
+
+type Service struct {
+	fetcher Fetcher
+	db      *db.Database
+	wg      sync.WaitGroup
+}
+
+func NewService(fetcher Fetcher, database *db.Database) *Service {
+	return &Service{fetcher: fetcher, db: database}
+}
+
+// Preview fetches the page at the link and extracts its metadata, a relative image is resolved against the link
+func (s *Service) Preview(ctx context.Context, link string) (Metadata, error) {
+	base, err := url.Parse(link)
+	if err != nil || (base.Scheme != "http" && base.Scheme != "https") || base.Host == "" {
+		return Metadata{}, ErrInvalidLink
+	}
+
+	page, err := s.fetcher.Fetch(ctx, base.String())
+	if err != nil {
+		return Metadata{}, err
+	}
+
+	meta := Extract(page)
+	if meta.Image != "" {
+		image, err := base.Parse(meta.Image)
+		if err != nil {
+			meta.Image = ""
+		} else {
+			meta.Image = image.String()
+		}
+	}
+
+	return meta, nil
+}
+
+// NeedsEnrichment returns whether the article has a link and is missing any of the fields the metadata can fill
+func NeedsEnrichment(article db.Article) bool {
+	return article.Link != "" && (article.Title == "" || article.Description == "" || !article.Date.Valid || article.Image == "")
+}
+
+// Fill copies the metadata into the empty fields of the article, it returns whether any field was filled
+func Fill(article *db.Article, meta Metadata) bool {
+	filled := false
+
+	if article.Title == "" && meta.Title != "" {
+		article.Title = truncate(meta.Title, 255)
+		filled = true
+	}
+
+	if article.Description == "" && meta.Description != "" {
+		article.Description = meta.Description
+		filled = true
+	}
+
+	if !article.Date.Valid && meta.Date.Valid {
+		article.Date = meta.Date
+		filled = true
+	}
+
+	if article.Image == "" && meta.Image != "" {
+		article.Image = meta.Image
+		filled = true
+	}
+
+	return filled
+}
+
+// EnrichArticle fetches the page the article links to and stores the metadata in the fields that are still empty
+func (s *Service) EnrichArticle(ctx context.Context, article db.Article) error {
+	meta, err := s.Preview(ctx, article.Link)
+	if err != nil {
+		return err
+	}
+
+	if !Fill(&article, meta) {
+		return nil
+	}
+
+	return s.db.FillArticleMetadata(article)
+}
+
+// Enqueue enriches the article in the background, failures are logged
+func (s *Service) Enqueue(article db.Article) {
+	s.wg.Add(1)
+
+	go func() {
+		defer s.wg.Done()
+
+		ctx, cancel := context.WithTimeout(context.Background(), ENRICH_TIMEOUT)
+		defer cancel()
+
+		err := s.EnrichArticle(ctx, article)
+		if err != nil {
+			log.Printf("Enriching article %d failed: %v", article.ID, err)
+		}
+	}()
+}
+
+// Wait blocks until all enqueued enrichments are done
+func (s *Service) Wait() {
+	s.wg.Wait()
+}
+
+func truncate(value string, length int) string {
+	if utf8.RuneCountInString(value) <= length {
+		return value
+	}
+
+	return string([]rune(value)[:length])
+}
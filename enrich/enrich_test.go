@@ -0,0 +1,186 @@
+package enrich
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/require"
+	"github.com/webstradev/rsdb-backend/db"
+)
+
+const openGraphPage = `<!DOCTYPE html>
+<html>
+<head>
+	<title>Ignored | Example News</title>
+	<meta property="og:title" content="The Movie wins  big &amp; more">
+	<meta property="og:description" content="A review of
+		The Movie">
+	<meta property="og:image" content="/images/the-movie.jpg">
+	<meta property="article:published_time" content="2023-03-05T23:30:00-05:00">
+	<meta name="description" content="Ignored">
+</head>
+<body><h1>The Movie</h1></body>
+</html>`
+
+const linkedDataPage = `<html>
+<head>
+	<title>Fallback title</title>
+	<script type="application/ld+json">
+	{"@context":"https://schema.org","@graph":[
+		{"@type":"WebSite","url":"https://example.com"},
+		{"@type":"NewsArticle","headline":"Interview with the director","description":"About The Movie",
+		 "datePublished":"2023-04-01","image":[{"@type":"ImageObject","url":"https://cdn.example.com/director.jpg"}]}
+	]}
+	</script>
+</head>
+</html>`
+
+const plainPage = `<html><head><title> Just a title </title><meta name="description" content="Plain description"></head></html>`
+
+func TestExtract(t *testing.T) {
+	date := func(year int, month time.Month, day int) sql.NullTime {
+		return sql.NullTime{Time: time.Date(year, month, day, 0, 0, 0, 0, time.UTC), Valid: true}
+	}
+
+	tests := []struct {
+		Name     string
+		Page     string
+		Expected Metadata
+	}{
+		{
+			"Extract - OpenGraph",
+			openGraphPage,
+			Metadata{Title: "The Movie wins big & more", Description: "A review of The Movie", Date: date(2023, 3, 5), Image: "/images/the-movie.jpg"},
+		},
+		{
+			"Extract - JSON-LD",
+			linkedDataPage,
+			Metadata{Title: "Interview with the director", Description: "About The Movie", Date: date(2023, 4, 1), Image: "https://cdn.example.com/director.jpg"},
+		},
+		{
+			"Extract - title and description",
+			plainPage,
+			Metadata{Title: "Just a title", Description: "Plain description"},
+		},
+		{
+			"Extract - not html",
+			"%PDF-1.4",
+			Metadata{},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			require.Equal(t, test.Expected, Extract([]byte(test.Page)))
+		})
+	}
+}
+
+func TestHTTPFetcher(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/article":
+			w.Header().Set("Content-Type", "text/html; charset=iso-8859-1")
+			w.Write([]byte("<title>Caf\xe9</title>"))
+		case "/metadata":
+			http.Redirect(w, r, "http://169.254.169.254/latest/meta-data", http.StatusFound)
+		case "/file.pdf":
+			w.Header().Set("Content-Type", "application/pdf")
+			w.Write([]byte("%PDF-1.4"))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	// Local servers are refused
+	_, err := NewHTTPFetcher(time.Second).Fetch(context.Background(), server.URL+"/article")
+	require.ErrorIs(t, err, ErrForbiddenAddress)
+
+	fetcher := NewHTTPFetcher(time.Second)
+	fetcher.Allowed = func(ip netip.Addr) bool { return ip.IsLoopback() }
+
+	page, err := fetcher.Fetch(context.Background(), server.URL+"/article")
+	require.NoError(t, err)
+	require.Equal(t, "<title>Café</title>", string(page))
+
+	_, err = fetcher.Fetch(context.Background(), server.URL+"/file.pdf")
+	require.ErrorIs(t, err, ErrNotHTML)
+
+	// Redirects to forbidden addresses are not followed
+	_, err = fetcher.Fetch(context.Background(), server.URL+"/metadata")
+	require.ErrorIs(t, err, ErrForbiddenAddress)
+
+	_, err = fetcher.Fetch(context.Background(), server.URL+"/missing")
+	require.EqualError(t, err, "fetching "+server.URL+"/missing: 404 Not Found")
+}
+
+type stubFetcher struct {
+	page string
+	err  error
+}
+
+func (f stubFetcher) Fetch(ctx context.Context, link string) ([]byte, error) {
+	return []byte(f.page), f.err
+}
+
+func TestPreview(t *testing.T) {
+	service := NewService(stubFetcher{page: openGraphPage}, nil)
+
+	for _, link := range []string{"", "example.com/article", "ftp://example.com/article", "https://"} {
+		_, err := service.Preview(context.Background(), link)
+		require.ErrorIs(t, err, ErrInvalidLink, link)
+	}
+
+	meta, err := service.Preview(context.Background(), "https://example.com/news/the-movie")
+	require.NoError(t, err)
+	require.Equal(t, "https://example.com/images/the-movie.jpg", meta.Image)
+
+	_, err = NewService(stubFetcher{err: errors.New("test")}, nil).Preview(context.Background(), "https://example.com")
+	require.EqualError(t, err, "test")
+}
+
+func TestFill(t *testing.T) {
+	meta := Metadata{
+		Title:       "Proposed title",
+		Description: "Proposed description",
+		Date:        sql.NullTime{Time: time.Date(2023, 3, 5, 0, 0, 0, 0, time.UTC), Valid: true},
+		Image:       "https://example.com/image.jpg",
+	}
+
+	article := db.Article{Title: "Own title", Link: "https://example.com"}
+	require.True(t, NeedsEnrichment(article))
+	require.True(t, Fill(&article, meta))
+	require.Equal(t, "Own title", article.Title)
+	require.Equal(t, "Proposed description", article.Description)
+	require.Equal(t, meta.Date, article.Date)
+	require.Equal(t, meta.Image, article.Image)
+
+	require.False(t, NeedsEnrichment(article))
+	require.False(t, Fill(&article, meta))
+	require.False(t, NeedsEnrichment(db.Article{}))
+}
+
+func TestEnqueue(t *testing.T) {
+	mockDb, mockSql, err := sqlmock.New()
+	require.NoError(t, err)
+	defer mockDb.Close()
+
+	mockSql.ExpectExec("UPDATE articles SET").
+		WithArgs("The Movie wins big & more", "A review of The Movie", sqlmock.AnyArg(), "https://example.com/images/the-movie.jpg", 1).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	service := NewService(stubFetcher{page: openGraphPage}, db.SetupMockDB(sqlx.NewDb(mockDb, "sqlmock")))
+	service.Enqueue(db.Article{Model: db.Model{ID: 1}, Link: "https://example.com/news/the-movie"})
+	service.Wait()
+
+	require.NoError(t, mockSql.ExpectationsWereMet())
+}
@@ -0,0 +1,63 @@
+package enrich
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"time"
+
+	"github.com/webstradev/rsdb-backend/publicnet"
+	"golang.org/x/net/html/charset"
+)
+
+// Pages are cut off after this many bytes, the metadata is in the head of the page
+const MAX_PAGE_SIZE = 2 << 20
+
+var (
+	ErrNotHTML          = errors.New("the link does not point to an html page")
+	ErrForbiddenAddress = publicnet.ErrForbiddenAddress
+)
+
+// HTTPFetcher fetches pages over HTTP and decodes them to UTF-8. Only public addresses are fetched, also after
+// redirects, so links can not reach the server's own network.
+type HTTPFetcher struct {
+	*publicnet.Client
+}
+
+func NewHTTPFetcher(timeout time.Duration) *HTTPFetcher {
+	return &HTTPFetcher{Client: publicnet.NewClient(timeout)}
+}
+
+func (f *HTTPFetcher) Fetch(ctx context.Context, link string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, link, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", "rsdb-backend")
+	req.Header.Set("Accept", "text/html,application/xhtml+xml")
+
+	resp, err := f.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("fetching %s: %s", link, resp.Status)
+	}
+
+	contentType := resp.Header.Get("Content-Type")
+	if mediaType, _, err := mime.ParseMediaType(contentType); err == nil && mediaType != "text/html" && mediaType != "application/xhtml+xml" {
+		return nil, ErrNotHTML
+	}
+
+	body, err := charset.NewReader(io.LimitReader(resp.Body, MAX_PAGE_SIZE), contentType)
+	if err != nil {
+		return nil, err
+	}
+
+	return io.ReadAll(body)
+}
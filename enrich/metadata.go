@@ -0,0 +1,181 @@
+package enrich
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"golang.org/x/net/html"
+)
+
+// Layouts publish dates are written in, the date in the time zone of the publisher is used
+var dateLayouts = []string{
+	time.RFC3339,
+	"2006-01-02T15:04:05Z0700",
+	"2006-01-02T15:04:05",
+	"2006-01-02T15:04",
+	time.DateOnly,
+	time.RFC1123Z,
+	time.RFC1123,
+}
+
+// Extract reads the metadata from the OpenGraph and JSON-LD of the page, falling back to the
+// Twitter card, the description meta tag and the title element
+func Extract(page []byte) Metadata {
+	meta := map[string]string{}
+	title := ""
+	linkedData := []string{}
+
+	tokenizer := html.NewTokenizer(bytes.NewReader(page))
+	inTitle, inLinkedData := false, false
+
+	for {
+		tokenType := tokenizer.Next()
+		if tokenType == html.ErrorToken {
+			break
+		}
+
+		token := tokenizer.Token()
+		switch tokenType {
+		case html.StartTagToken, html.SelfClosingTagToken:
+			switch token.Data {
+			case "title":
+				inTitle = title == ""
+			case "meta":
+				key, content := "", ""
+				for _, attr := range token.Attr {
+					switch attr.Key {
+					case "property", "name", "itemprop":
+						key = strings.ToLower(attr.Val)
+					case "content":
+						content = clean(attr.Val)
+					}
+				}
+				if _, seen := meta[key]; key != "" && content != "" && !seen {
+					meta[key] = content
+				}
+			case "script":
+				for _, attr := range token.Attr {
+					inLinkedData = inLinkedData || (attr.Key == "type" && strings.EqualFold(attr.Val, "application/ld+json"))
+				}
+			}
+		case html.TextToken:
+			if inTitle {
+				title = clean(token.Data)
+			}
+			if inLinkedData {
+				linkedData = append(linkedData, token.Data)
+			}
+		case html.EndTagToken:
+			inTitle, inLinkedData = false, false
+		}
+	}
+
+	article := Metadata{}
+	for _, data := range linkedData {
+		var value any
+		if json.Unmarshal([]byte(data), &value) == nil {
+			article = fromLinkedData(value)
+			if article != (Metadata{}) {
+				break
+			}
+		}
+	}
+
+	result := Metadata{
+		Title:       first(meta["og:title"], article.Title, meta["twitter:title"], title),
+		Description: first(meta["og:description"], article.Description, meta["twitter:description"], meta["description"]),
+		Image:       first(meta["og:image"], meta["og:image:url"], article.Image, meta["twitter:image"]),
+	}
+
+	result.Date = parseDate(meta["article:published_time"])
+	if !result.Date.Valid {
+		result.Date = article.Date
+	}
+	for _, key := range []string{"datepublished", "date", "pubdate"} {
+		if !result.Date.Valid {
+			result.Date = parseDate(meta[key])
+		}
+	}
+
+	return result
+}
+
+// fromLinkedData returns the metadata of the first node in the JSON-LD that has a headline, name or publish date
+func fromLinkedData(value any) Metadata {
+	switch node := value.(type) {
+	case []any:
+		for _, item := range node {
+			if meta := fromLinkedData(item); meta != (Metadata{}) {
+				return meta
+			}
+		}
+	case map[string]any:
+		if graph, ok := node["@graph"]; ok {
+			return fromLinkedData(graph)
+		}
+
+		meta := Metadata{
+			Title:       first(text(node["headline"]), text(node["name"])),
+			Description: text(node["description"]),
+			Image:       imageURL(node["image"]),
+			Date:        parseDate(first(text(node["datePublished"]), text(node["dateCreated"]))),
+		}
+		if meta.Title != "" || meta.Date.Valid {
+			return meta
+		}
+	}
+
+	return Metadata{}
+}
+
+// imageURL returns the url of a JSON-LD image, which is a url, an ImageObject or a list of either
+func imageURL(value any) string {
+	switch image := value.(type) {
+	case string:
+		return clean(image)
+	case []any:
+		for _, item := range image {
+			if url := imageURL(item); url != "" {
+				return url
+			}
+		}
+	case map[string]any:
+		return first(text(image["url"]), text(image["contentUrl"]))
+	}
+
+	return ""
+}
+
+func text(value any) string {
+	s, _ := value.(string)
+	return clean(s)
+}
+
+func parseDate(value string) sql.NullTime {
+	for _, layout := range dateLayouts {
+		date, err := time.Parse(layout, value)
+		if err == nil {
+			return sql.NullTime{Time: time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC), Valid: true}
+		}
+	}
+
+	return sql.NullTime{}
+}
+
+func first(values ...string) string {
+	for _, value := range values {
+		if value != "" {
+			return value
+		}
+	}
+
+	return ""
+}
+
+// clean collapses the whitespace of a value
+func clean(value string) string {
+	return strings.Join(strings.Fields(value), " ")
+}
@@ -0,0 +1,136 @@
+// Package inbox keeps the in-app notifications of users. Notifications are stored in the database, subscribers such as
+// live notification streams are woken up when a notification for their user arrives.
+package inbox
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/webstradev/rsdb-backend/broadcast"
+	"github.com/webstradev/rsdb-backend/db"
+)
+
+// Servicer is the part of the inbox the handlers use, it is implemented by *Inbox
+type Servicer interface {
+	Subscribe(userId int64) (<-chan struct{}, func())
+	RecordEdited(entityType string, entityId, editedBy int64) error
+	Mentioned(comment db.Comment, userIds []int64) error
+	TaskAssigned(task db.Task, assignedBy int64) error
+	InviteAccepted(invitedBy int64, user db.User) error
+}
+
+type Inbox struct {
+	db *db.Database
+
+	subscribers broadcast.Group[int64]
+}
+
+func New(database *db.Database) *Inbox {
+	return &Inbox{db: database}
+}
+
+// Send stores the notifications and wakes up the subscribers of their users. Users are not notified of their own
+// actions. Every notification is attempted, the errors of those that failed are joined.
+func (i *Inbox) Send(notifications ...db.Notification) error {
+	errs := []error{}
+
+	for _, notification := range notifications {
+		if notification.ActorId.Valid && notification.ActorId.Int64 == notification.UserId {
+			continue
+		}
+
+		_, err := i.db.InsertNotification(notification)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("notification for user %d: %w", notification.UserId, err))
+			continue
+		}
+
+		i.subscribers.Wake(notification.UserId)
+	}
+
+	return errors.Join(errs...)
+}
+
+// Subscribe returns a channel that receives a value when notifications for the user arrive, see
+// broadcast.Group.Subscribe
+func (i *Inbox) Subscribe(userId int64) (<-chan struct{}, func()) {
+	return i.subscribers.Subscribe(userId)
+}
+
+// RecordEdited notifies the owners of a record that it was edited by another user
+func (i *Inbox) RecordEdited(entityType string, entityId, editedBy int64) error {
+	owners, err := i.db.GetRecordOwners(entityType, entityId)
+	if err != nil {
+		return err
+	}
+
+	notifications := []db.Notification{}
+	for _, owner := range owners {
+		notifications = append(notifications, db.Notification{
+			UserId:     owner,
+			Kind:       db.NOTIFICATION_RECORD_EDITED,
+			EntityType: sql.NullString{String: entityType, Valid: true},
+			EntityId:   sql.NullInt64{Int64: entityId, Valid: true},
+			ActorId:    sql.NullInt64{Int64: editedBy, Valid: true},
+			Message:    fmt.Sprintf("Your %s %d was edited", entityType, entityId),
+		})
+	}
+
+	return i.Send(notifications...)
+}
+
+// Mentioned notifies the users mentioned in a comment, the notification refers to the record the comment is on
+func (i *Inbox) Mentioned(comment db.Comment, userIds []int64) error {
+	notifications := []db.Notification{}
+	for _, userId := range userIds {
+		notifications = append(notifications, db.Notification{
+			UserId:     userId,
+			Kind:       db.NOTIFICATION_MENTION,
+			EntityType: sql.NullString{String: comment.EntityType, Valid: true},
+			EntityId:   sql.NullInt64{Int64: comment.EntityId, Valid: true},
+			ActorId:    comment.AuthorId,
+			Message:    fmt.Sprintf("You were mentioned in a comment on %s %d", comment.EntityType, comment.EntityId),
+		})
+	}
+
+	return i.Send(notifications...)
+}
+
+// TaskAssigned notifies the assignee of a task that it was assigned to them
+func (i *Inbox) TaskAssigned(task db.Task, assignedBy int64) error {
+	return i.Send(db.Notification{
+		UserId:     task.AssigneeId,
+		Kind:       db.NOTIFICATION_TASK_ASSIGNED,
+		EntityType: sql.NullString{String: db.TASK_ENTITY, Valid: true},
+		EntityId:   sql.NullInt64{Int64: task.ID, Valid: true},
+		ActorId:    sql.NullInt64{Int64: assignedBy, Valid: true},
+		Message:    fmt.Sprintf("You were assigned the task %q", task.Title),
+	})
+}
+
+// InviteAccepted notifies the user that created a registration token that it was used to register
+func (i *Inbox) InviteAccepted(invitedBy int64, user db.User) error {
+	return i.Send(db.Notification{
+		UserId:  invitedBy,
+		Kind:    db.NOTIFICATION_INVITE_ACCEPTED,
+		ActorId: sql.NullInt64{Int64: user.ID, Valid: true},
+		Message: fmt.Sprintf("%s accepted your invite", user.Email),
+	})
+}
+
+// SearchMatches notifies a subscriber of a saved search of the number of records that newly match it
+func (i *Inbox) SearchMatches(search db.SavedSearch, userId int64, count int) error {
+	matches := "matches"
+	if count == 1 {
+		matches = "match"
+	}
+
+	return i.Send(db.Notification{
+		UserId:     userId,
+		Kind:       db.NOTIFICATION_SEARCH_MATCHES,
+		EntityType: sql.NullString{String: db.SEARCH_ENTITY, Valid: true},
+		EntityId:   sql.NullInt64{Int64: search.ID, Valid: true},
+		Message:    fmt.Sprintf("%d new %s for the saved search %q", count, matches, search.Name),
+	})
+}
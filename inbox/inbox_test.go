@@ -0,0 +1,142 @@
+package inbox
+
+import (
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/require"
+	"github.com/webstradev/rsdb-backend/db"
+)
+
+func setupInbox(t *testing.T, mockDbCall func(sqlmock.Sqlmock)) (*Inbox, sqlmock.Sqlmock) {
+	mockDb, mockSql, err := sqlmock.New()
+	require.NoError(t, err)
+	t.Cleanup(func() { mockDb.Close() })
+
+	mockDbCall(mockSql)
+
+	return New(db.SetupMockDB(sqlx.NewDb(mockDb, "sqlmock"))), mockSql
+}
+
+// requireWoken checks whether the subscription received a wake-up
+func requireWoken(t *testing.T, ch <-chan struct{}, expected bool) {
+	select {
+	case <-ch:
+		require.True(t, expected, "unexpected wake-up")
+	case <-time.After(10 * time.Millisecond):
+		require.False(t, expected, "no wake-up")
+	}
+}
+
+func TestSend(t *testing.T) {
+	inbox, mockSql := setupInbox(t, func(mock sqlmock.Sqlmock) {
+		mock.ExpectExec("INSERT INTO notifications").WithArgs(2, db.NOTIFICATION_INVITE_ACCEPTED, nil, nil, 5, "test").WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectExec("INSERT INTO notifications").WithArgs(3, db.NOTIFICATION_INVITE_ACCEPTED, nil, nil, 5, "test").WillReturnError(errors.New("test"))
+	})
+
+	woken, unsubscribe := inbox.Subscribe(2)
+	defer unsubscribe()
+	other, unsubscribeOther := inbox.Subscribe(3)
+	defer unsubscribeOther()
+
+	err := inbox.Send(
+		db.Notification{UserId: 2, Kind: db.NOTIFICATION_INVITE_ACCEPTED, ActorId: sql.NullInt64{Int64: 5, Valid: true}, Message: "test"},
+		// Users are not notified of their own actions
+		db.Notification{UserId: 5, Kind: db.NOTIFICATION_INVITE_ACCEPTED, ActorId: sql.NullInt64{Int64: 5, Valid: true}, Message: "test"},
+		db.Notification{UserId: 3, Kind: db.NOTIFICATION_INVITE_ACCEPTED, ActorId: sql.NullInt64{Int64: 5, Valid: true}, Message: "test"},
+	)
+	require.EqualError(t, err, "notification for user 3: test")
+
+	requireWoken(t, woken, true)
+	requireWoken(t, other, false)
+
+	require.NoError(t, mockSql.ExpectationsWereMet())
+}
+
+func TestRecordEdited(t *testing.T) {
+	tests := []struct {
+		Name       string
+		EntityType string
+		MockDbCall func(sqlmock.Sqlmock)
+		Error      string
+	}{
+		{
+			"RecordEdited - invalid entity type",
+			"person",
+			func(mock sqlmock.Sqlmock) {},
+			db.ErrInvalidEntityType.Error(),
+		},
+		{
+			"RecordEdited - sql error on GetRecordOwners",
+			db.ARTICLE_ENTITY,
+			func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT created_by FROM articles").WithArgs(4).WillReturnError(errors.New("test"))
+			},
+			"test",
+		},
+		{
+			"RecordEdited - edited by its owner",
+			db.ARTICLE_ENTITY,
+			func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT created_by FROM articles").WithArgs(4).WillReturnRows(sqlmock.NewRows([]string{"created_by"}).AddRow(1))
+			},
+			"",
+		},
+		{
+			"RecordEdited - project with creator and assignee",
+			db.PROJECT_ENTITY,
+			func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT created_by FROM projects (.+) UNION SELECT assignee_id FROM projects").WithArgs(4, 4).
+					WillReturnRows(sqlmock.NewRows([]string{"created_by"}).AddRow(2).AddRow(3))
+				mock.ExpectExec("INSERT INTO notifications").WithArgs(2, db.NOTIFICATION_RECORD_EDITED, db.PROJECT_ENTITY, 4, 1, "Your project 4 was edited").WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec("INSERT INTO notifications").WithArgs(3, db.NOTIFICATION_RECORD_EDITED, db.PROJECT_ENTITY, 4, 1, "Your project 4 was edited").WillReturnResult(sqlmock.NewResult(2, 1))
+			},
+			"",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			inbox, mockSql := setupInbox(t, test.MockDbCall)
+
+			err := inbox.RecordEdited(test.EntityType, 4, 1)
+			if test.Error != "" {
+				require.EqualError(t, err, test.Error)
+			} else {
+				require.NoError(t, err)
+			}
+
+			require.NoError(t, mockSql.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestEvents(t *testing.T) {
+	inbox, mockSql := setupInbox(t, func(mock sqlmock.Sqlmock) {
+		mock.ExpectExec("INSERT INTO notifications").WithArgs(5, db.NOTIFICATION_MENTION, db.CONTACT_ENTITY, 8, 2, "You were mentioned in a comment on contact 8").WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectExec("INSERT INTO notifications").WithArgs(6, db.NOTIFICATION_MENTION, db.CONTACT_ENTITY, 8, 2, "You were mentioned in a comment on contact 8").WillReturnResult(sqlmock.NewResult(2, 1))
+		mock.ExpectExec("INSERT INTO notifications").WithArgs(5, db.NOTIFICATION_TASK_ASSIGNED, db.TASK_ENTITY, 3, 2, `You were assigned the task "Call back"`).WillReturnResult(sqlmock.NewResult(3, 1))
+		mock.ExpectExec("INSERT INTO notifications").WithArgs(2, db.NOTIFICATION_INVITE_ACCEPTED, nil, nil, 7, "jane@example.com accepted your invite").WillReturnResult(sqlmock.NewResult(4, 1))
+		mock.ExpectExec("INSERT INTO notifications").WithArgs(5, db.NOTIFICATION_SEARCH_MATCHES, db.SEARCH_ENTITY, 9, nil, `1 new match for the saved search "Editors"`).WillReturnResult(sqlmock.NewResult(5, 1))
+		mock.ExpectExec("INSERT INTO notifications").WithArgs(6, db.NOTIFICATION_SEARCH_MATCHES, db.SEARCH_ENTITY, 9, nil, `3 new matches for the saved search "Editors"`).WillReturnResult(sqlmock.NewResult(6, 1))
+	})
+
+	comment := db.Comment{EntityType: db.CONTACT_ENTITY, EntityId: 8, AuthorId: sql.NullInt64{Int64: 2, Valid: true}}
+	require.NoError(t, inbox.Mentioned(comment, []int64{5, 6}))
+
+	task := db.Task{Model: db.Model{ID: 3}, Title: "Call back", AssigneeId: 5}
+	require.NoError(t, inbox.TaskAssigned(task, 2))
+
+	user := db.User{Model: db.Model{ID: 7}, Email: "jane@example.com"}
+	require.NoError(t, inbox.InviteAccepted(2, user))
+
+	search := db.SavedSearch{Model: db.Model{ID: 9}, Name: "Editors"}
+	require.NoError(t, inbox.SearchMatches(search, 5, 1))
+	require.NoError(t, inbox.SearchMatches(search, 6, 3))
+
+	require.NoError(t, mockSql.ExpectationsWereMet())
+}
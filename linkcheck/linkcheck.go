@@ -0,0 +1,194 @@
+// Package linkcheck periodically checks the links of articles, projects and platforms so rotten links can be fixed
+package linkcheck
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/webstradev/rsdb-backend/db"
+	"github.com/webstradev/rsdb-backend/publicnet"
+	"github.com/webstradev/rsdb-backend/scheduler"
+)
+
+// Name of the job, used to make sure the links are checked once a day
+const LINK_CHECK_JOB = "link_check"
+
+// Checker fetches links with a limited number of hosts at a time. Links to the same host are checked one after
+// the other with a delay in between, so no site gets more than one request at a time from us. Only public addresses
+// are requested, also after redirects, so links can not be used to probe the server's own network.
+type Checker struct {
+	client      *publicnet.Client
+	concurrency int
+	delay       time.Duration
+}
+
+func NewChecker(timeout time.Duration, concurrency int, delay time.Duration) *Checker {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	return &Checker{client: publicnet.NewClient(timeout), concurrency: concurrency, delay: delay}
+}
+
+// CheckAll checks the links and returns the results in the order of the links. Links that were not checked
+// before the context was cancelled are left out.
+func (c *Checker) CheckAll(ctx context.Context, links []db.Link, now time.Time) []db.LinkCheck {
+	// Group the links by host, keeping the order of the links within each host
+	hosts := [][]int{}
+	index := map[string]int{}
+	for i, link := range links {
+		host := host(link.URL)
+		if _, ok := index[host]; !ok {
+			index[host] = len(hosts)
+			hosts = append(hosts, []int{})
+		}
+		hosts[index[host]] = append(hosts[index[host]], i)
+	}
+
+	results := make([]*db.LinkCheck, len(links))
+	queue := make(chan []int)
+	wg := sync.WaitGroup{}
+
+	for range min(c.concurrency, len(hosts)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for indexes := range queue {
+				for n, i := range indexes {
+					if n > 0 && !sleep(ctx, c.delay) {
+						break
+					}
+					check := c.Check(ctx, links[i])
+					if ctx.Err() != nil {
+						break
+					}
+					check.CheckedAt = now
+					results[i] = &check
+				}
+			}
+		}()
+	}
+
+	for _, indexes := range hosts {
+		queue <- indexes
+	}
+	close(queue)
+	wg.Wait()
+
+	checks := []db.LinkCheck{}
+	for _, check := range results {
+		if check != nil {
+			checks = append(checks, *check)
+		}
+	}
+
+	return checks
+}
+
+// Check requests the link with HEAD, servers that do not handle HEAD get a GET. Redirects are followed and the
+// final location is stored as the redirect target.
+func (c *Checker) Check(ctx context.Context, link db.Link) db.LinkCheck {
+	check := db.LinkCheck{Link: link}
+
+	target := absolute(link.URL)
+	resp, err := c.request(ctx, http.MethodHead, target)
+	if err != nil || resp.StatusCode >= 400 {
+		resp, err = c.request(ctx, http.MethodGet, target)
+	}
+	if err != nil {
+		// The error of a refused address would include how it resolved, only the reason is stored
+		check.Error = err.Error()
+		if errors.Is(err, publicnet.ErrForbiddenAddress) {
+			check.Error = publicnet.ErrForbiddenAddress.Error()
+		}
+		check.Broken = true
+		return check
+	}
+
+	check.StatusCode.Int64, check.StatusCode.Valid = int64(resp.StatusCode), true
+	check.Broken = resp.StatusCode >= 400
+	if final := resp.Request.URL.String(); final != target {
+		check.RedirectURL = final
+	}
+
+	return check
+}
+
+func (c *Checker) request(ctx context.Context, method, target string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, target, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", "rsdb-backend")
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	// Only the status matters, drain a little of the body so the connection can be reused
+	io.CopyN(io.Discard, resp.Body, 4096)
+	resp.Body.Close()
+
+	return resp, nil
+}
+
+// Job checks the links of all articles, projects and platforms and stores the results
+func Job(database *db.Database, checker *Checker) scheduler.Job {
+	return func(ctx context.Context, now time.Time) error {
+		claimed, err := database.ClaimJobRun(LINK_CHECK_JOB, now)
+		if err != nil {
+			return err
+		}
+
+		// Another server already checked the links today
+		if !claimed {
+			return nil
+		}
+
+		links, err := database.GetLinks()
+		if err != nil {
+			return err
+		}
+
+		return database.SaveLinkChecks(checker.CheckAll(ctx, links, now))
+	}
+}
+
+// absolute adds a scheme to links that were stored without one (e.g. example.com)
+func absolute(link string) string {
+	link = strings.TrimSpace(link)
+	if strings.Contains(link, "://") {
+		return link
+	}
+
+	return "http://" + link
+}
+
+func host(link string) string {
+	parsed, err := url.Parse(absolute(link))
+	if err != nil {
+		return ""
+	}
+
+	return strings.ToLower(parsed.Hostname())
+}
+
+// sleep waits for the duration, it returns false when the context was cancelled first
+func sleep(ctx context.Context, d time.Duration) bool {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
@@ -0,0 +1,206 @@
+package linkcheck
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/require"
+	"github.com/webstradev/rsdb-backend/db"
+	"github.com/webstradev/rsdb-backend/publicnet"
+)
+
+func newSite() *httptest.Server {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/ok", func(w http.ResponseWriter, r *http.Request) {})
+	mux.HandleFunc("/moved", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/ok", http.StatusMovedPermanently)
+	})
+	mux.HandleFunc("/no-head", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodHead {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		}
+	})
+	mux.HandleFunc("/gone", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusGone)
+	})
+
+	return httptest.NewServer(mux)
+}
+
+// newLocalChecker returns a checker that can reach the local test servers
+func newLocalChecker(concurrency int, delay time.Duration) *Checker {
+	checker := NewChecker(time.Second, concurrency, delay)
+	checker.client.Allowed = func(ip netip.Addr) bool { return ip.IsLoopback() }
+	return checker
+}
+
+func TestCheck(t *testing.T) {
+	site := newSite()
+	defer site.Close()
+
+	checker := newLocalChecker(2, 0)
+
+	tests := []struct {
+		Name     string
+		URL      string
+		Status   sql.NullInt64
+		Redirect string
+		Broken   bool
+	}{
+		{"Check - ok", site.URL + "/ok", sql.NullInt64{Int64: 200, Valid: true}, "", false},
+		{"Check - redirect", site.URL + "/moved", sql.NullInt64{Int64: 200, Valid: true}, site.URL + "/ok", false},
+		{"Check - HEAD not allowed", site.URL + "/no-head", sql.NullInt64{Int64: 200, Valid: true}, "", false},
+		{"Check - gone", site.URL + "/gone", sql.NullInt64{Int64: 410, Valid: true}, "", true},
+		{"Check - without scheme", strings.TrimPrefix(site.URL, "http://") + "/ok", sql.NullInt64{Int64: 200, Valid: true}, "", false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			check := checker.Check(context.Background(), db.Link{EntityType: db.ARTICLE_ENTITY, EntityId: 1, URL: test.URL})
+			require.Equal(t, test.Status, check.StatusCode)
+			require.Equal(t, test.Redirect, check.RedirectURL)
+			require.Equal(t, test.Broken, check.Broken)
+			require.Empty(t, check.Error)
+		})
+	}
+
+	// Nothing listens on a closed server
+	closed := httptest.NewServer(http.NotFoundHandler())
+	closed.Close()
+
+	check := checker.Check(context.Background(), db.Link{URL: closed.URL})
+	require.True(t, check.Broken)
+	require.False(t, check.StatusCode.Valid)
+	require.NotEmpty(t, check.Error)
+
+	// Local addresses are not requested
+	check = NewChecker(time.Second, 2, 0).Check(context.Background(), db.Link{URL: site.URL + "/ok"})
+	require.True(t, check.Broken)
+	require.False(t, check.StatusCode.Valid)
+	require.Equal(t, publicnet.ErrForbiddenAddress.Error(), check.Error)
+}
+
+func TestCheckAll(t *testing.T) {
+	// Record how many requests are handled at the same time
+	mu := sync.Mutex{}
+	active, maxActive := 0, 0
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		active++
+		maxActive = max(maxActive, active)
+		mu.Unlock()
+
+		time.Sleep(5 * time.Millisecond)
+
+		mu.Lock()
+		active--
+		mu.Unlock()
+	})
+
+	one := httptest.NewServer(handler)
+	defer one.Close()
+	two := httptest.NewServer(handler)
+	defer two.Close()
+
+	links := []db.Link{
+		{EntityType: db.ARTICLE_ENTITY, EntityId: 1, URL: one.URL + "/a"},
+		{EntityType: db.PROJECT_ENTITY, EntityId: 1, URL: two.URL + "/a"},
+		{EntityType: db.ARTICLE_ENTITY, EntityId: 2, URL: one.URL + "/b"},
+		{EntityType: db.PLATFORM_ENTITY, EntityId: 1, URL: one.URL + "/c"},
+	}
+
+	now := time.Date(2024, 5, 1, 3, 0, 0, 0, time.UTC)
+	checks := newLocalChecker(4, time.Millisecond).CheckAll(context.Background(), links, now)
+
+	require.Len(t, checks, len(links))
+	for i, check := range checks {
+		require.Equal(t, links[i], check.Link)
+		require.Equal(t, now, check.CheckedAt)
+		require.False(t, check.Broken)
+	}
+
+	// Both servers run on 127.0.0.1, so they count as the same host and are never requested at the same time
+	require.Equal(t, 1, maxActive)
+
+	// Cancelled checks are left out
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	require.Empty(t, newLocalChecker(4, 0).CheckAll(ctx, links, now))
+}
+
+func TestJob(t *testing.T) {
+	site := newSite()
+	defer site.Close()
+
+	now := time.Date(2024, 5, 1, 3, 0, 0, 0, time.UTC)
+	linkColumns := []string{"entity_type", "entity_id", "name", "url"}
+
+	tests := []struct {
+		Name       string
+		MockDbCall func(sqlmock.Sqlmock)
+		Error      string
+	}{
+		{
+			"Job - already ran on another server",
+			func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec("INSERT IGNORE INTO job_runs").WithArgs(LINK_CHECK_JOB, "2024-05-01").WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+			"",
+		},
+		{
+			"Job - sql error on GetLinks",
+			func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec("INSERT IGNORE INTO job_runs").WithArgs(LINK_CHECK_JOB, "2024-05-01").WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectQuery("SELECT (.+) FROM articles").WithArgs(db.ARTICLE_ENTITY, db.PROJECT_ENTITY, db.PLATFORM_ENTITY).WillReturnError(errors.New("test"))
+			},
+			"test",
+		},
+		{
+			"Job - stores the results",
+			func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec("INSERT IGNORE INTO job_runs").WithArgs(LINK_CHECK_JOB, "2024-05-01").WillReturnResult(sqlmock.NewResult(1, 1))
+				rows := sqlmock.NewRows(linkColumns).
+					AddRow(db.ARTICLE_ENTITY, 1, "Review", site.URL+"/moved").
+					AddRow(db.PLATFORM_ENTITY, 2, "Example", site.URL+"/gone")
+				mock.ExpectQuery("SELECT (.+) FROM articles").WithArgs(db.ARTICLE_ENTITY, db.PROJECT_ENTITY, db.PLATFORM_ENTITY).WillReturnRows(rows)
+				mock.ExpectExec("INSERT INTO link_checks").
+					WithArgs(db.ARTICLE_ENTITY, 1, site.URL+"/moved", sql.NullInt64{Int64: 200, Valid: true}, site.URL+"/ok", "", false, now).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec("INSERT INTO link_checks").
+					WithArgs(db.PLATFORM_ENTITY, 2, site.URL+"/gone", sql.NullInt64{Int64: 410, Valid: true}, "", "", true, now).
+					WillReturnResult(sqlmock.NewResult(2, 1))
+			},
+			"",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			mockDb, mockSql, err := sqlmock.New()
+			require.NoError(t, err)
+			defer mockDb.Close()
+
+			test.MockDbCall(mockSql)
+
+			err = Job(db.SetupMockDB(sqlx.NewDb(mockDb, "sqlmock")), newLocalChecker(2, 0))(context.Background(), now)
+			if test.Error != "" {
+				require.EqualError(t, err, test.Error)
+			} else {
+				require.NoError(t, err)
+			}
+
+			require.NoError(t, mockSql.ExpectationsWereMet())
+		})
+	}
+}
@@ -7,14 +7,26 @@ import (
 	"net/http"
 	"os"
 	"os/signal"
+	"strings"
 	"syscall"
 	"time"
 
 	"github.com/joho/godotenv"
 	"github.com/webstradev/rsdb-backend/auth"
+	"github.com/webstradev/rsdb-backend/changes"
 	"github.com/webstradev/rsdb-backend/db"
+	"github.com/webstradev/rsdb-backend/digest"
+	"github.com/webstradev/rsdb-backend/enrich"
+	"github.com/webstradev/rsdb-backend/inbox"
+	"github.com/webstradev/rsdb-backend/linkcheck"
 	"github.com/webstradev/rsdb-backend/migrations"
+	"github.com/webstradev/rsdb-backend/notify"
+	"github.com/webstradev/rsdb-backend/scheduler"
+	"github.com/webstradev/rsdb-backend/searchalerts"
+	"github.com/webstradev/rsdb-backend/storage"
 	"github.com/webstradev/rsdb-backend/utils"
+	"github.com/webstradev/rsdb-backend/webhooks"
+	"github.com/webstradev/rsdb-backend/workflow"
 )
 
 func main() {
@@ -41,14 +53,99 @@ func main() {
 		log.Fatal(err)
 	}
 
+	// Statuses projects go through
+	projectWorkflow, err := workflow.FromEnv()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// Projects in a status the workflow does not have can not be moved, they are only reported here so that a
+	// misconfigured workflow does not touch them. They can be moved to the first status with cmd/resetstatuses.
+	unknown, err := db.GetUnknownProjectStatuses(projectWorkflow.Statuses)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if len(unknown) > 0 {
+		log.Printf("Projects have statuses that are not in the workflow: %s, run cmd/resetstatuses to move them", strings.Join(unknown, ", "))
+	}
+
+	// Uploaded files are kept in the storage configured in the environment
+	fileStorage, err := storage.FromEnv()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// Notifications are sent to users through the notifier configured in the environment
+	notifier, err := notify.FromEnv()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// Changes to records are streamed to open frontends and passed on to the subscribed webhooks
+	dispatcher := webhooks.New(db, &http.Client{Timeout: 10 * time.Second})
+
+	// In-app notifications are sent by the handlers and the search alerts
+	notifications := inbox.New(db)
+
 	// Initialize Environment (for dependency injection)
 	env := &utils.Environment{
 		DB:          db,
 		JWT:         jwtService,
 		UUID:        auth.NewUUIDService(),
 		AuthService: auth.NewAuthService(),
+		Workflow:    projectWorkflow,
+		Enricher:    enrich.NewService(enrich.NewHTTPFetcher(10*time.Second), db),
+		Storage:     fileStorage,
+		Notifier:    notifier,
+		Inbox:       notifications,
+		Webhooks:    dispatcher,
+		Changes:     changes.New(db, dispatcher),
+	}
+
+	// Time of day the task digest is sent
+	digestTime := os.Getenv("DIGEST_TIME")
+	if digestTime == "" {
+		digestTime = "08:00"
 	}
 
+	digestAt, err := scheduler.ParseClock(digestTime)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// Time of day the links of articles, projects and platforms are checked
+	linkCheckTime := os.Getenv("LINK_CHECK_TIME")
+	if linkCheckTime == "" {
+		linkCheckTime = "03:00"
+	}
+
+	linkCheckAt, err := scheduler.ParseClock(linkCheckTime)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// Time of day subscribers of saved searches are notified of new matches, each subscriber once a week
+	searchAlertsTime := os.Getenv("SEARCH_ALERTS_TIME")
+	if searchAlertsTime == "" {
+		searchAlertsTime = "07:00"
+	}
+
+	searchAlertsAt, err := scheduler.ParseClock(searchAlertsTime)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// Scheduled jobs run until the server shuts down
+	jobs, stopJobs := context.WithCancel(context.Background())
+	defer stopJobs()
+
+	go scheduler.Daily(jobs, digest.TASK_DIGEST_JOB, digestAt, digest.TaskDigest(db, notifier))
+	go scheduler.Daily(jobs, linkcheck.LINK_CHECK_JOB, linkCheckAt, linkcheck.Job(db, linkcheck.NewChecker(15*time.Second, 8, 2*time.Second)))
+	go scheduler.Daily(jobs, searchalerts.SEARCH_ALERTS_JOB, searchAlertsAt, searchalerts.Job(db, notifications))
+
+	// Webhooks are delivered in the background until the server shuts down
+	go env.Webhooks.Run(jobs)
+
 	// Server object
 	s := &http.Server{
 		Addr:         ":8080",
@@ -71,6 +168,9 @@ func main() {
 	<-quit
 	log.Println("Shutting down server...")
 
+	// Stop scheduling jobs
+	stopJobs()
+
 	// The context is used to inform the server it has 5 seconds to finish
 	// the request it is currently handling
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
@@ -80,6 +180,9 @@ func main() {
 		log.Fatalln("Server forced to shutdown")
 	}
 
+	// Let articles that are being enriched finish
+	env.Enricher.Wait()
+
 	log.Println("Server exiting.")
 }
 
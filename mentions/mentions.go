@@ -0,0 +1,70 @@
+// Package mentions finds the users mentioned in comments. Users are known by their email address, so a mention is an
+// @ followed by the address of a user (@jane@example.com) or by the part of the address before the @ (@jane) when no
+// other user shares it.
+package mentions
+
+import (
+	"regexp"
+	"strings"
+)
+
+// An @ at the start of the text or after a character that can't be part of an email address, so addresses in the
+// text itself are not read as mentions
+var mentionPattern = regexp.MustCompile(`(?:^|[^\w.+@-])@([\w.+-]+(?:@[\w-]+(?:\.[\w-]+)+)?)`)
+
+// Parse returns the handles mentioned in a text, lowercased and without duplicates, in the order they appear
+func Parse(text string) []string {
+	handles := []string{}
+	seen := map[string]bool{}
+
+	for _, match := range mentionPattern.FindAllStringSubmatch(text, -1) {
+		// Punctuation after a mention ("thanks @jane.") is not part of it
+		handle := strings.ToLower(strings.TrimRight(match[1], ".-"))
+		if handle == "" || seen[handle] {
+			continue
+		}
+
+		seen[handle] = true
+		handles = append(handles, handle)
+	}
+
+	return handles
+}
+
+// LocalPart returns the part of an email address before the @
+func LocalPart(email string) string {
+	local, _, _ := strings.Cut(email, "@")
+	return local
+}
+
+// Match returns the email addresses mentioned by the handles. A handle without domain only matches when exactly one
+// of the addresses has it as local part.
+func Match(handles []string, emails []string) []string {
+	byAddress := map[string]string{}
+	byLocalPart := map[string][]string{}
+
+	for _, email := range emails {
+		lower := strings.ToLower(email)
+		byAddress[lower] = email
+		byLocalPart[LocalPart(lower)] = append(byLocalPart[LocalPart(lower)], email)
+	}
+
+	matched := []string{}
+	seen := map[string]bool{}
+
+	for _, handle := range handles {
+		email, ok := byAddress[handle]
+		if !ok {
+			if !strings.Contains(handle, "@") && len(byLocalPart[handle]) == 1 {
+				email, ok = byLocalPart[handle][0], true
+			}
+		}
+
+		if ok && !seen[email] {
+			seen[email] = true
+			matched = append(matched, email)
+		}
+	}
+
+	return matched
+}
@@ -0,0 +1,52 @@
+package mentions
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		Name     string
+		Text     string
+		Expected []string
+	}{
+		{"No mentions", "Nothing to see here", []string{}},
+		{"Local part", "@jane can you call them?", []string{"jane"}},
+		{"Email address", "Thanks (@Jane.Doe@Example.com)", []string{"jane.doe@example.com"}},
+		{"Trailing punctuation", "Ask @jane. Or @john-", []string{"jane", "john"}},
+		{"Duplicates", "@jane and @JANE", []string{"jane"}},
+		{"Email address in text", "Mail info@example.com", []string{}},
+		{"Several", "@jane, @john@example.com: see above", []string{"jane", "john@example.com"}},
+		{"Lone @", "meet @ 5", []string{}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			require.Equal(t, test.Expected, Parse(test.Text))
+		})
+	}
+}
+
+func TestMatch(t *testing.T) {
+	emails := []string{"jane@example.com", "john@example.com", "john@example.org"}
+
+	tests := []struct {
+		Name     string
+		Handles  []string
+		Expected []string
+	}{
+		{"Unique local part", []string{"jane"}, []string{"jane@example.com"}},
+		{"Ambiguous local part", []string{"john"}, []string{}},
+		{"Email address", []string{"john@example.org"}, []string{"john@example.org"}},
+		{"Unknown", []string{"bob", "bob@example.com"}, []string{}},
+		{"Same user twice", []string{"jane", "jane@example.com"}, []string{"jane@example.com"}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			require.Equal(t, test.Expected, Match(test.Handles, emails))
+		})
+	}
+}
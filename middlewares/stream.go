@@ -0,0 +1,23 @@
+package middlewares
+
+import (
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// StreamMiddleware lifts the write timeout of the server for routes that keep the connection open, such as streams of
+// Server-Sent Events. Other routes keep the timeout.
+func StreamMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		err := http.NewResponseController(c.Writer).SetWriteDeadline(time.Time{})
+		if err != nil {
+			log.Println(err)
+		}
+
+		// Continue to next middleware
+		c.Next()
+	}
+}
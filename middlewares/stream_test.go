@@ -0,0 +1,73 @@
+package middlewares
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStreamMiddleware(t *testing.T) {
+	tests := []struct {
+		name   string
+		stream bool
+		body   string
+	}{
+		{
+			"Write timeout - response cut off",
+			false,
+			"",
+		},
+		{
+			"Stream - write timeout lifted",
+			true,
+			"late",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gin.SetMode(gin.TestMode)
+			r := gin.New()
+
+			handlers := []gin.HandlerFunc{}
+			if tt.stream {
+				handlers = append(handlers, StreamMiddleware())
+			}
+
+			// Write after the write timeout of the server has passed
+			handlers = append(handlers, func(c *gin.Context) {
+				time.Sleep(200 * time.Millisecond)
+				c.String(http.StatusOK, "late")
+			})
+			r.GET("/stream", handlers...)
+
+			server := httptest.NewUnstartedServer(r)
+			server.Config.WriteTimeout = 50 * time.Millisecond
+			server.Start()
+			defer server.Close()
+
+			res, err := http.Get(server.URL + "/stream")
+			if !tt.stream {
+				// The connection is closed before the response is received
+				if err == nil {
+					defer res.Body.Close()
+					body, _ := io.ReadAll(res.Body)
+					require.Equal(t, tt.body, string(body))
+				}
+				return
+			}
+
+			require.NoError(t, err)
+			defer res.Body.Close()
+
+			body, err := io.ReadAll(res.Body)
+			require.NoError(t, err)
+			require.Equal(t, http.StatusOK, res.StatusCode)
+			require.Equal(t, tt.body, string(body))
+		})
+	}
+}
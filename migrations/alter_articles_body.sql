@@ -0,0 +1,4 @@
+ALTER TABLE `articles`
+	ADD COLUMN `body_format` VARCHAR(10) NOT NULL DEFAULT 'html' AFTER `body`,
+	ADD COLUMN `body_html` LONGTEXT NOT NULL AFTER `body_format`,
+	ADD COLUMN `body_text` LONGTEXT NOT NULL AFTER `body_html`;
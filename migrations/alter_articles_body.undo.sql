@@ -0,0 +1,4 @@
+ALTER TABLE `articles`
+	DROP COLUMN `body_format`,
+	DROP COLUMN `body_html`,
+	DROP COLUMN `body_text`;
@@ -0,0 +1,4 @@
+ALTER TABLE `articles`
+	ADD COLUMN `created_by` INT(11) NULL DEFAULT NULL,
+	ADD INDEX `articles_created_by_fk` (`created_by`) USING BTREE,
+	ADD CONSTRAINT `articles_created_by_fk` FOREIGN KEY (`created_by`) REFERENCES `users` (`id`) ON UPDATE CASCADE ON DELETE SET NULL;
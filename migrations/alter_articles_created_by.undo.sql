@@ -0,0 +1,4 @@
+ALTER TABLE `articles`
+	DROP FOREIGN KEY `articles_created_by_fk`,
+	DROP INDEX `articles_created_by_fk`,
+	DROP COLUMN `created_by`;
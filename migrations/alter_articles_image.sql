@@ -0,0 +1,2 @@
+ALTER TABLE `articles`
+	ADD COLUMN `image` TEXT NOT NULL AFTER `date`;
@@ -0,0 +1,4 @@
+ALTER TABLE `contacts`
+	ADD COLUMN `created_by` INT(11) NULL DEFAULT NULL,
+	ADD INDEX `contacts_created_by_fk` (`created_by`) USING BTREE,
+	ADD CONSTRAINT `contacts_created_by_fk` FOREIGN KEY (`created_by`) REFERENCES `users` (`id`) ON UPDATE CASCADE ON DELETE SET NULL;
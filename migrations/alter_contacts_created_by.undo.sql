@@ -0,0 +1,4 @@
+ALTER TABLE `contacts`
+	DROP FOREIGN KEY `contacts_created_by_fk`,
+	DROP INDEX `contacts_created_by_fk`,
+	DROP COLUMN `created_by`;
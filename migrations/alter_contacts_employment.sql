@@ -0,0 +1,7 @@
+ALTER TABLE `contacts`
+	ADD COLUMN `person_id` INT(11) NULL DEFAULT NULL AFTER `platform_id`,
+	ADD COLUMN `start_date` DATE NULL DEFAULT NULL AFTER `title`,
+	ADD COLUMN `end_date` DATE NULL DEFAULT NULL AFTER `start_date`,
+	ADD COLUMN `current` TINYINT(1) NOT NULL DEFAULT '1' AFTER `end_date`,
+	ADD INDEX `contacts_people_fk` (`person_id`) USING BTREE,
+	ADD CONSTRAINT `contacts_people_fk` FOREIGN KEY (`person_id`) REFERENCES `people` (`id`) ON UPDATE CASCADE ON DELETE RESTRICT;
@@ -0,0 +1,7 @@
+ALTER TABLE `contacts`
+	DROP FOREIGN KEY `contacts_people_fk`,
+	DROP INDEX `contacts_people_fk`,
+	DROP COLUMN `person_id`,
+	DROP COLUMN `start_date`,
+	DROP COLUMN `end_date`,
+	DROP COLUMN `current`;
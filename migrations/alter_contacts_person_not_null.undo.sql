@@ -0,0 +1,2 @@
+ALTER TABLE `contacts`
+	CHANGE COLUMN `person_id` `person_id` INT(11) NULL DEFAULT NULL AFTER `platform_id`;
@@ -0,0 +1,4 @@
+ALTER TABLE `platforms`
+	DROP FOREIGN KEY `platforms_created_by_fk`,
+	DROP INDEX `platforms_created_by_fk`,
+	DROP COLUMN `created_by`;
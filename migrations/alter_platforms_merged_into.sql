@@ -0,0 +1,4 @@
+ALTER TABLE `platforms`
+	ADD COLUMN `merged_into` INT(11) NULL DEFAULT NULL AFTER `comment`,
+	ADD INDEX `platforms_merged_into_fk` (`merged_into`) USING BTREE,
+	ADD CONSTRAINT `platforms_merged_into_fk` FOREIGN KEY (`merged_into`) REFERENCES `platforms` (`id`) ON UPDATE CASCADE ON DELETE SET NULL;
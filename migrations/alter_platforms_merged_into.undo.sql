@@ -0,0 +1,4 @@
+ALTER TABLE `platforms`
+	DROP FOREIGN KEY `platforms_merged_into_fk`,
+	DROP INDEX `platforms_merged_into_fk`,
+	DROP COLUMN `merged_into`;
@@ -0,0 +1,4 @@
+ALTER TABLE `projects`
+	DROP COLUMN `body_format`,
+	DROP COLUMN `body_html`,
+	DROP COLUMN `body_text`;
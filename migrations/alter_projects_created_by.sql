@@ -0,0 +1,4 @@
+ALTER TABLE `projects`
+	ADD COLUMN `created_by` INT(11) NULL DEFAULT NULL,
+	ADD INDEX `projects_created_by_fk` (`created_by`) USING BTREE,
+	ADD CONSTRAINT `projects_created_by_fk` FOREIGN KEY (`created_by`) REFERENCES `users` (`id`) ON UPDATE CASCADE ON DELETE SET NULL;
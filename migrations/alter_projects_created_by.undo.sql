@@ -0,0 +1,4 @@
+ALTER TABLE `projects`
+	DROP FOREIGN KEY `projects_created_by_fk`,
+	DROP INDEX `projects_created_by_fk`,
+	DROP COLUMN `created_by`;
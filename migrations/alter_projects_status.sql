@@ -0,0 +1,7 @@
+ALTER TABLE `projects`
+	ADD COLUMN `status` VARCHAR(30) NOT NULL DEFAULT 'development' AFTER `body`,
+	ADD COLUMN `status_changed_at` DATETIME NULL DEFAULT NULL AFTER `status`,
+	ADD COLUMN `assignee_id` INT(11) NULL DEFAULT NULL AFTER `status_changed_at`,
+	ADD INDEX `projects_status` (`status`, `status_changed_at`) USING BTREE,
+	ADD INDEX `projects_assignee_fk` (`assignee_id`) USING BTREE,
+	ADD CONSTRAINT `projects_assignee_fk` FOREIGN KEY (`assignee_id`) REFERENCES `users` (`id`) ON UPDATE CASCADE ON DELETE SET NULL;
@@ -0,0 +1,7 @@
+ALTER TABLE `projects`
+	DROP FOREIGN KEY `projects_assignee_fk`,
+	DROP INDEX `projects_assignee_fk`,
+	DROP INDEX `projects_status`,
+	DROP COLUMN `assignee_id`,
+	DROP COLUMN `status_changed_at`,
+	DROP COLUMN `status`;
\ No newline at end of file
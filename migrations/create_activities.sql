@@ -0,0 +1,23 @@
+CREATE TABLE `activities` (
+	`id` INT(11) NOT NULL AUTO_INCREMENT,
+	`created_at` DATETIME NOT NULL DEFAULT current_timestamp(),
+	`modified_at` DATETIME NOT NULL DEFAULT current_timestamp() ON UPDATE current_timestamp(),
+	`deleted_at` DATETIME NULL DEFAULT NULL,
+	`type` VARCHAR(20) NOT NULL,
+	`date` DATETIME NOT NULL,
+	`summary` TEXT NOT NULL,
+	`platform_id` INT(11) NULL DEFAULT NULL,
+	`project_id` INT(11) NULL DEFAULT NULL,
+	`created_by` INT(11) NULL DEFAULT NULL,
+	PRIMARY KEY (`id`) USING BTREE,
+	INDEX `activities_date` (`date`) USING BTREE,
+	INDEX `activities_platform_fk` (`platform_id`) USING BTREE,
+	INDEX `activities_project_fk` (`project_id`) USING BTREE,
+	INDEX `activities_users_fk` (`created_by`) USING BTREE,
+	CONSTRAINT `activities_platform_fk` FOREIGN KEY (`platform_id`) REFERENCES `platforms` (`id`) ON UPDATE CASCADE ON DELETE RESTRICT,
+	CONSTRAINT `activities_project_fk` FOREIGN KEY (`project_id`) REFERENCES `projects` (`id`) ON UPDATE CASCADE ON DELETE SET NULL,
+	CONSTRAINT `activities_users_fk` FOREIGN KEY (`created_by`) REFERENCES `users` (`id`) ON UPDATE CASCADE ON DELETE SET NULL
+)
+COLLATE='utf8mb4_general_ci'
+ENGINE=InnoDB
+;
@@ -0,0 +1 @@
+DROP TABLE `activities`;
\ No newline at end of file
@@ -0,0 +1,11 @@
+CREATE TABLE `activities_contacts` (
+	`activity_id` INT(11) NOT NULL,
+	`contact_id` INT(11) NOT NULL,
+	PRIMARY KEY (`activity_id`, `contact_id`) USING BTREE,
+	INDEX `activities_contacts_contact_fk` (`contact_id`) USING BTREE,
+	CONSTRAINT `activities_contacts_activity_fk` FOREIGN KEY (`activity_id`) REFERENCES `activities` (`id`) ON UPDATE CASCADE ON DELETE CASCADE,
+	CONSTRAINT `activities_contacts_contact_fk` FOREIGN KEY (`contact_id`) REFERENCES `contacts` (`id`) ON UPDATE CASCADE ON DELETE RESTRICT
+)
+COLLATE='utf8mb4_general_ci'
+ENGINE=InnoDB
+;
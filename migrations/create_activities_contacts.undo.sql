@@ -0,0 +1 @@
+DROP TABLE `activities_contacts`;
\ No newline at end of file
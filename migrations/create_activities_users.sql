@@ -0,0 +1,11 @@
+CREATE TABLE `activities_users` (
+	`activity_id` INT(11) NOT NULL,
+	`user_id` INT(11) NOT NULL,
+	PRIMARY KEY (`activity_id`, `user_id`) USING BTREE,
+	INDEX `activities_users_user_fk` (`user_id`) USING BTREE,
+	CONSTRAINT `activities_users_activity_fk` FOREIGN KEY (`activity_id`) REFERENCES `activities` (`id`) ON UPDATE CASCADE ON DELETE CASCADE,
+	CONSTRAINT `activities_users_user_fk` FOREIGN KEY (`user_id`) REFERENCES `users` (`id`) ON UPDATE CASCADE ON DELETE CASCADE
+)
+COLLATE='utf8mb4_general_ci'
+ENGINE=InnoDB
+;
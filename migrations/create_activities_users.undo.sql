@@ -0,0 +1 @@
+DROP TABLE `activities_users`;
\ No newline at end of file
@@ -0,0 +1,11 @@
+CREATE TABLE `articles_projects` (
+	`article_id` INT(11) NOT NULL,
+	`project_id` INT(11) NOT NULL,
+	PRIMARY KEY (`article_id`, `project_id`) USING BTREE,
+	INDEX `articles_projects_project_fk` (`project_id`) USING BTREE,
+	CONSTRAINT `articles_projects_article_fk` FOREIGN KEY (`article_id`) REFERENCES `articles` (`id`) ON UPDATE CASCADE ON DELETE RESTRICT,
+	CONSTRAINT `articles_projects_project_fk` FOREIGN KEY (`project_id`) REFERENCES `projects` (`id`) ON UPDATE CASCADE ON DELETE RESTRICT
+)
+COLLATE='utf8mb4_general_ci'
+ENGINE=InnoDB
+;
@@ -0,0 +1 @@
+DROP TABLE `articles_projects`;
\ No newline at end of file
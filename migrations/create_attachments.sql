@@ -0,0 +1,22 @@
+CREATE TABLE `attachments` (
+	`id` INT(11) NOT NULL AUTO_INCREMENT,
+	`created_at` DATETIME NOT NULL DEFAULT current_timestamp(),
+	`modified_at` DATETIME NOT NULL DEFAULT current_timestamp() ON UPDATE current_timestamp(),
+	`deleted_at` DATETIME NULL DEFAULT NULL,
+	`entity_type` VARCHAR(30) NOT NULL,
+	`entity_id` INT(11) NOT NULL,
+	`filename` VARCHAR(255) NOT NULL,
+	`content_type` VARCHAR(255) NOT NULL,
+	`size` BIGINT NOT NULL,
+	`checksum` CHAR(64) NOT NULL,
+	`storage_key` VARCHAR(255) NOT NULL,
+	`created_by` INT(11) NULL DEFAULT NULL,
+	PRIMARY KEY (`id`) USING BTREE,
+	UNIQUE INDEX `attachments_storage_key` (`storage_key`) USING BTREE,
+	INDEX `attachments_entity` (`entity_type`, `entity_id`) USING BTREE,
+	INDEX `attachments_created_by_fk` (`created_by`) USING BTREE,
+	CONSTRAINT `attachments_created_by_fk` FOREIGN KEY (`created_by`) REFERENCES `users` (`id`) ON UPDATE CASCADE ON DELETE SET NULL
+)
+COLLATE='utf8mb4_general_ci'
+ENGINE=InnoDB
+;
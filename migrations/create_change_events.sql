@@ -0,0 +1,16 @@
+CREATE TABLE `change_events` (
+	`id` INT(11) NOT NULL AUTO_INCREMENT,
+	`created_at` DATETIME NOT NULL DEFAULT current_timestamp(),
+	`entity_type` VARCHAR(30) NOT NULL,
+	`entity_id` INT(11) NOT NULL,
+	`action` VARCHAR(20) NOT NULL,
+	`actor_id` INT(11) NULL DEFAULT NULL,
+	`private` TINYINT(1) NOT NULL DEFAULT 0,
+	PRIMARY KEY (`id`) USING BTREE,
+	INDEX `change_events_private` (`private`, `id`) USING BTREE,
+	INDEX `change_events_actor_fk` (`actor_id`) USING BTREE,
+	CONSTRAINT `change_events_actor_fk` FOREIGN KEY (`actor_id`) REFERENCES `users` (`id`) ON UPDATE CASCADE ON DELETE SET NULL
+)
+COLLATE='utf8mb4_general_ci'
+ENGINE=InnoDB
+;
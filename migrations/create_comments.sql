@@ -0,0 +1,21 @@
+CREATE TABLE `comments` (
+	`id` INT(11) NOT NULL AUTO_INCREMENT,
+	`created_at` DATETIME NOT NULL DEFAULT current_timestamp(),
+	`modified_at` DATETIME NOT NULL DEFAULT current_timestamp() ON UPDATE current_timestamp(),
+	`deleted_at` DATETIME NULL DEFAULT NULL,
+	`entity_type` VARCHAR(30) NOT NULL,
+	`entity_id` INT(11) NOT NULL,
+	`parent_id` INT(11) NULL DEFAULT NULL,
+	`author_id` INT(11) NULL DEFAULT NULL,
+	`body` TEXT NOT NULL,
+	`edited_at` DATETIME NULL DEFAULT NULL,
+	PRIMARY KEY (`id`) USING BTREE,
+	INDEX `comments_entity` (`entity_type`, `entity_id`) USING BTREE,
+	INDEX `comments_parent_fk` (`parent_id`) USING BTREE,
+	INDEX `comments_author_fk` (`author_id`) USING BTREE,
+	CONSTRAINT `comments_parent_fk` FOREIGN KEY (`parent_id`) REFERENCES `comments` (`id`) ON UPDATE CASCADE ON DELETE RESTRICT,
+	CONSTRAINT `comments_author_fk` FOREIGN KEY (`author_id`) REFERENCES `users` (`id`) ON UPDATE CASCADE ON DELETE SET NULL
+)
+COLLATE='utf8mb4_general_ci'
+ENGINE=InnoDB
+;
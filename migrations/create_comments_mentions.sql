@@ -0,0 +1,11 @@
+CREATE TABLE `comments_mentions` (
+	`comment_id` INT(11) NOT NULL,
+	`user_id` INT(11) NOT NULL,
+	PRIMARY KEY (`comment_id`, `user_id`) USING BTREE,
+	INDEX `comments_mentions_user_fk` (`user_id`) USING BTREE,
+	CONSTRAINT `comments_mentions_comment_fk` FOREIGN KEY (`comment_id`) REFERENCES `comments` (`id`) ON UPDATE CASCADE ON DELETE CASCADE,
+	CONSTRAINT `comments_mentions_user_fk` FOREIGN KEY (`user_id`) REFERENCES `users` (`id`) ON UPDATE CASCADE ON DELETE CASCADE
+)
+COLLATE='utf8mb4_general_ci'
+ENGINE=InnoDB
+;
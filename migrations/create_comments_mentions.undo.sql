@@ -0,0 +1 @@
+DROP TABLE `comments_mentions`;
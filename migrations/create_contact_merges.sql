@@ -0,0 +1,21 @@
+CREATE TABLE `contact_merges` (
+	`id` INT(11) NOT NULL AUTO_INCREMENT,
+	`created_at` DATETIME NOT NULL DEFAULT current_timestamp(),
+	`modified_at` DATETIME NOT NULL DEFAULT current_timestamp() ON UPDATE current_timestamp(),
+	`deleted_at` DATETIME NULL DEFAULT NULL,
+	`contact_id` INT(11) NOT NULL,
+	`merged_contact_id` INT(11) NOT NULL,
+	`merged_by` INT(11) NULL DEFAULT NULL,
+	`fields` TEXT NOT NULL,
+	`merged_contact` TEXT NOT NULL,
+	PRIMARY KEY (`id`) USING BTREE,
+	INDEX `contact_merges_contact_fk` (`contact_id`) USING BTREE,
+	INDEX `contact_merges_merged_contact_fk` (`merged_contact_id`) USING BTREE,
+	INDEX `contact_merges_users_fk` (`merged_by`) USING BTREE,
+	CONSTRAINT `contact_merges_contact_fk` FOREIGN KEY (`contact_id`) REFERENCES `contacts` (`id`) ON UPDATE CASCADE ON DELETE RESTRICT,
+	CONSTRAINT `contact_merges_merged_contact_fk` FOREIGN KEY (`merged_contact_id`) REFERENCES `contacts` (`id`) ON UPDATE CASCADE ON DELETE RESTRICT,
+	CONSTRAINT `contact_merges_users_fk` FOREIGN KEY (`merged_by`) REFERENCES `users` (`id`) ON UPDATE CASCADE ON DELETE SET NULL
+)
+COLLATE='utf8mb4_general_ci'
+ENGINE=InnoDB
+;
@@ -0,0 +1 @@
+DROP TABLE `contact_merges`;
\ No newline at end of file
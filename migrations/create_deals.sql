@@ -0,0 +1,25 @@
+CREATE TABLE `deals` (
+	`id` INT(11) NOT NULL AUTO_INCREMENT,
+	`created_at` DATETIME NOT NULL DEFAULT current_timestamp(),
+	`modified_at` DATETIME NOT NULL DEFAULT current_timestamp() ON UPDATE current_timestamp(),
+	`deleted_at` DATETIME NULL DEFAULT NULL,
+	`project_id` INT(11) NOT NULL,
+	`platform_id` INT(11) NOT NULL,
+	`exclusive` TINYINT(1) NOT NULL DEFAULT 0,
+	`window_start` DATE NOT NULL,
+	`window_end` DATE NOT NULL,
+	`fee` BIGINT(20) NULL DEFAULT NULL,
+	`currency` CHAR(3) NOT NULL DEFAULT '',
+	`status` VARCHAR(20) NOT NULL DEFAULT 'draft',
+	`created_by` INT(11) NULL DEFAULT NULL,
+	PRIMARY KEY (`id`) USING BTREE,
+	INDEX `deals_project_window` (`project_id`, `window_start`, `window_end`) USING BTREE,
+	INDEX `deals_platform_fk` (`platform_id`) USING BTREE,
+	INDEX `deals_created_by_fk` (`created_by`) USING BTREE,
+	CONSTRAINT `deals_project_fk` FOREIGN KEY (`project_id`) REFERENCES `projects` (`id`) ON UPDATE CASCADE ON DELETE RESTRICT,
+	CONSTRAINT `deals_platform_fk` FOREIGN KEY (`platform_id`) REFERENCES `platforms` (`id`) ON UPDATE CASCADE ON DELETE RESTRICT,
+	CONSTRAINT `deals_created_by_fk` FOREIGN KEY (`created_by`) REFERENCES `users` (`id`) ON UPDATE CASCADE ON DELETE SET NULL
+)
+COLLATE='utf8mb4_general_ci'
+ENGINE=InnoDB
+;
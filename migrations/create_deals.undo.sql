@@ -0,0 +1 @@
+DROP TABLE `deals`;
\ No newline at end of file
@@ -0,0 +1,10 @@
+CREATE TABLE `deals_media` (
+	`deal_id` INT(11) NOT NULL,
+	`media` VARCHAR(20) NOT NULL,
+	PRIMARY KEY (`deal_id`, `media`) USING BTREE,
+	INDEX `deals_media_media` (`media`) USING BTREE,
+	CONSTRAINT `deals_media_deal_fk` FOREIGN KEY (`deal_id`) REFERENCES `deals` (`id`) ON UPDATE CASCADE ON DELETE CASCADE
+)
+COLLATE='utf8mb4_general_ci'
+ENGINE=InnoDB
+;
@@ -0,0 +1 @@
+DROP TABLE `deals_media`;
\ No newline at end of file
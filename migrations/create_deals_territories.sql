@@ -0,0 +1,10 @@
+CREATE TABLE `deals_territories` (
+	`deal_id` INT(11) NOT NULL,
+	`territory` CHAR(2) NOT NULL,
+	PRIMARY KEY (`deal_id`, `territory`) USING BTREE,
+	INDEX `deals_territories_territory` (`territory`) USING BTREE,
+	CONSTRAINT `deals_territories_deal_fk` FOREIGN KEY (`deal_id`) REFERENCES `deals` (`id`) ON UPDATE CASCADE ON DELETE CASCADE
+)
+COLLATE='utf8mb4_general_ci'
+ENGINE=InnoDB
+;
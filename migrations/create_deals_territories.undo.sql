@@ -0,0 +1 @@
+DROP TABLE `deals_territories`;
\ No newline at end of file
@@ -0,0 +1,12 @@
+CREATE TABLE `favorites` (
+	`user_id` INT(11) NOT NULL,
+	`entity_type` VARCHAR(30) NOT NULL,
+	`entity_id` INT(11) NOT NULL,
+	`created_at` DATETIME NOT NULL DEFAULT current_timestamp(),
+	PRIMARY KEY (`user_id`, `entity_type`, `entity_id`) USING BTREE,
+	INDEX `favorites_entity` (`entity_type`, `entity_id`) USING BTREE,
+	CONSTRAINT `favorites_user_fk` FOREIGN KEY (`user_id`) REFERENCES `users` (`id`) ON UPDATE CASCADE ON DELETE CASCADE
+)
+COLLATE='utf8mb4_general_ci'
+ENGINE=InnoDB
+;
@@ -0,0 +1,17 @@
+CREATE TABLE `imports` (
+	`id` INT(11) NOT NULL AUTO_INCREMENT,
+	`created_at` DATETIME NOT NULL DEFAULT current_timestamp(),
+	`modified_at` DATETIME NOT NULL DEFAULT current_timestamp() ON UPDATE current_timestamp(),
+	`deleted_at` DATETIME NULL DEFAULT NULL,
+	`created_by` INT(11) NULL DEFAULT NULL,
+	`filename` VARCHAR(255) NOT NULL,
+	`platforms_created` INT(11) NOT NULL DEFAULT 0,
+	`contacts_created` INT(11) NOT NULL DEFAULT 0,
+	`report` LONGTEXT NOT NULL,
+	PRIMARY KEY (`id`) USING BTREE,
+	INDEX `imports_users_fk` (`created_by`) USING BTREE,
+	CONSTRAINT `imports_users_fk` FOREIGN KEY (`created_by`) REFERENCES `users` (`id`) ON UPDATE CASCADE ON DELETE SET NULL
+)
+COLLATE='utf8mb4_general_ci'
+ENGINE=InnoDB
+;
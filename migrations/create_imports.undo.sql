@@ -0,0 +1 @@
+DROP TABLE `imports`;
\ No newline at end of file
@@ -0,0 +1,11 @@
+CREATE TABLE `job_runs` (
+	`id` INT(11) NOT NULL AUTO_INCREMENT,
+	`created_at` DATETIME NOT NULL DEFAULT current_timestamp(),
+	`name` VARCHAR(64) NOT NULL,
+	`run_date` DATE NOT NULL,
+	PRIMARY KEY (`id`) USING BTREE,
+	UNIQUE INDEX `job_runs_name_date` (`name`, `run_date`) USING BTREE
+)
+COLLATE='utf8mb4_general_ci'
+ENGINE=InnoDB
+;
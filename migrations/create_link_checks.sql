@@ -0,0 +1,19 @@
+CREATE TABLE `link_checks` (
+	`id` INT(11) NOT NULL AUTO_INCREMENT,
+	`created_at` DATETIME NOT NULL DEFAULT current_timestamp(),
+	`modified_at` DATETIME NOT NULL DEFAULT current_timestamp() ON UPDATE current_timestamp(),
+	`entity_type` VARCHAR(30) NOT NULL,
+	`entity_id` INT(11) NOT NULL,
+	`url` TEXT NOT NULL,
+	`status_code` INT(11) NULL DEFAULT NULL,
+	`redirect_url` TEXT NOT NULL,
+	`error` TEXT NOT NULL,
+	`broken` TINYINT(1) NOT NULL DEFAULT 0,
+	`checked_at` DATETIME NOT NULL,
+	PRIMARY KEY (`id`) USING BTREE,
+	UNIQUE INDEX `link_checks_entity` (`entity_type`, `entity_id`) USING BTREE,
+	INDEX `link_checks_broken` (`broken`) USING BTREE
+)
+COLLATE='utf8mb4_general_ci'
+ENGINE=InnoDB
+;
@@ -0,0 +1,21 @@
+CREATE TABLE `notifications` (
+	`id` INT(11) NOT NULL AUTO_INCREMENT,
+	`created_at` DATETIME NOT NULL DEFAULT current_timestamp(),
+	`modified_at` DATETIME NOT NULL DEFAULT current_timestamp() ON UPDATE current_timestamp(),
+	`deleted_at` DATETIME NULL DEFAULT NULL,
+	`user_id` INT(11) NOT NULL,
+	`kind` VARCHAR(30) NOT NULL,
+	`entity_type` VARCHAR(30) NULL DEFAULT NULL,
+	`entity_id` INT(11) NULL DEFAULT NULL,
+	`actor_id` INT(11) NULL DEFAULT NULL,
+	`message` TEXT NOT NULL,
+	`read_at` DATETIME NULL DEFAULT NULL,
+	PRIMARY KEY (`id`) USING BTREE,
+	INDEX `notifications_user_fk` (`user_id`, `read_at`) USING BTREE,
+	INDEX `notifications_actor_fk` (`actor_id`) USING BTREE,
+	CONSTRAINT `notifications_user_fk` FOREIGN KEY (`user_id`) REFERENCES `users` (`id`) ON UPDATE CASCADE ON DELETE CASCADE,
+	CONSTRAINT `notifications_actor_fk` FOREIGN KEY (`actor_id`) REFERENCES `users` (`id`) ON UPDATE CASCADE ON DELETE SET NULL
+)
+COLLATE='utf8mb4_general_ci'
+ENGINE=InnoDB
+;
@@ -0,0 +1 @@
+DROP TABLE `people`;
\ No newline at end of file
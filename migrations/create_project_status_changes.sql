@@ -0,0 +1,19 @@
+CREATE TABLE `project_status_changes` (
+	`id` INT(11) NOT NULL AUTO_INCREMENT,
+	`created_at` DATETIME NOT NULL DEFAULT current_timestamp(),
+	`project_id` INT(11) NOT NULL,
+	`from_status` VARCHAR(30) NULL DEFAULT NULL,
+	`to_status` VARCHAR(30) NOT NULL,
+	`assignee_id` INT(11) NULL DEFAULT NULL,
+	`changed_by` INT(11) NULL DEFAULT NULL,
+	PRIMARY KEY (`id`) USING BTREE,
+	INDEX `project_status_changes_project` (`project_id`, `created_at`) USING BTREE,
+	INDEX `project_status_changes_assignee_fk` (`assignee_id`) USING BTREE,
+	INDEX `project_status_changes_changed_by_fk` (`changed_by`) USING BTREE,
+	CONSTRAINT `project_status_changes_project_fk` FOREIGN KEY (`project_id`) REFERENCES `projects` (`id`) ON UPDATE CASCADE ON DELETE CASCADE,
+	CONSTRAINT `project_status_changes_assignee_fk` FOREIGN KEY (`assignee_id`) REFERENCES `users` (`id`) ON UPDATE CASCADE ON DELETE SET NULL,
+	CONSTRAINT `project_status_changes_changed_by_fk` FOREIGN KEY (`changed_by`) REFERENCES `users` (`id`) ON UPDATE CASCADE ON DELETE SET NULL
+)
+COLLATE='utf8mb4_general_ci'
+ENGINE=InnoDB
+;
@@ -0,0 +1 @@
+DROP TABLE `project_status_changes`;
\ No newline at end of file
@@ -0,0 +1,18 @@
+CREATE TABLE `revisions` (
+	`id` INT(11) NOT NULL AUTO_INCREMENT,
+	`created_at` DATETIME NOT NULL DEFAULT current_timestamp(),
+	`modified_at` DATETIME NOT NULL DEFAULT current_timestamp() ON UPDATE current_timestamp(),
+	`deleted_at` DATETIME NULL DEFAULT NULL,
+	`entity_type` VARCHAR(50) NOT NULL,
+	`entity_id` INT(11) NOT NULL,
+	`revision` INT(11) NOT NULL,
+	`created_by` INT(11) NULL DEFAULT NULL,
+	`data` LONGTEXT NOT NULL,
+	PRIMARY KEY (`id`) USING BTREE,
+	UNIQUE INDEX `entity_revision` (`entity_type`, `entity_id`, `revision`) USING BTREE,
+	INDEX `revisions_users_fk` (`created_by`) USING BTREE,
+	CONSTRAINT `revisions_users_fk` FOREIGN KEY (`created_by`) REFERENCES `users` (`id`) ON UPDATE CASCADE ON DELETE SET NULL
+)
+COLLATE='utf8mb4_general_ci'
+ENGINE=InnoDB
+;
@@ -0,0 +1 @@
+DROP TABLE `revisions`;
\ No newline at end of file
@@ -0,0 +1,17 @@
+CREATE TABLE `saved_searches` (
+	`id` INT(11) NOT NULL AUTO_INCREMENT,
+	`created_at` DATETIME NOT NULL DEFAULT current_timestamp(),
+	`modified_at` DATETIME NOT NULL DEFAULT current_timestamp() ON UPDATE current_timestamp(),
+	`deleted_at` DATETIME NULL DEFAULT NULL,
+	`owner_id` INT(11) NOT NULL,
+	`name` VARCHAR(128) NOT NULL,
+	`target` VARCHAR(30) NOT NULL,
+	`query` TEXT NOT NULL,
+	`shared` TINYINT(1) NOT NULL DEFAULT 0,
+	PRIMARY KEY (`id`) USING BTREE,
+	INDEX `saved_searches_owner_fk` (`owner_id`) USING BTREE,
+	CONSTRAINT `saved_searches_owner_fk` FOREIGN KEY (`owner_id`) REFERENCES `users` (`id`) ON UPDATE CASCADE ON DELETE CASCADE
+)
+COLLATE='utf8mb4_general_ci'
+ENGINE=InnoDB
+;
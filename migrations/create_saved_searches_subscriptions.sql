@@ -0,0 +1,14 @@
+CREATE TABLE `saved_searches_subscriptions` (
+	`saved_search_id` INT(11) NOT NULL,
+	`user_id` INT(11) NOT NULL,
+	`created_at` DATETIME NOT NULL DEFAULT current_timestamp(),
+	`notified_at` DATETIME NOT NULL,
+	PRIMARY KEY (`saved_search_id`, `user_id`) USING BTREE,
+	INDEX `saved_searches_subscriptions_user_fk` (`user_id`) USING BTREE,
+	INDEX `saved_searches_subscriptions_notified` (`notified_at`) USING BTREE,
+	CONSTRAINT `saved_searches_subscriptions_search_fk` FOREIGN KEY (`saved_search_id`) REFERENCES `saved_searches` (`id`) ON UPDATE CASCADE ON DELETE CASCADE,
+	CONSTRAINT `saved_searches_subscriptions_user_fk` FOREIGN KEY (`user_id`) REFERENCES `users` (`id`) ON UPDATE CASCADE ON DELETE CASCADE
+)
+COLLATE='utf8mb4_general_ci'
+ENGINE=InnoDB
+;
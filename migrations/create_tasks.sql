@@ -0,0 +1,25 @@
+CREATE TABLE `tasks` (
+	`id` INT(11) NOT NULL AUTO_INCREMENT,
+	`created_at` DATETIME NOT NULL DEFAULT current_timestamp(),
+	`modified_at` DATETIME NOT NULL DEFAULT current_timestamp() ON UPDATE current_timestamp(),
+	`deleted_at` DATETIME NULL DEFAULT NULL,
+	`title` VARCHAR(255) NOT NULL,
+	`notes` TEXT NOT NULL,
+	`due_date` DATE NOT NULL,
+	`assignee_id` INT(11) NOT NULL,
+	`entity_type` VARCHAR(20) NULL DEFAULT NULL,
+	`entity_id` INT(11) NULL DEFAULT NULL,
+	`status` VARCHAR(20) NOT NULL DEFAULT 'open',
+	`completed_at` DATETIME NULL DEFAULT NULL,
+	`created_by` INT(11) NULL DEFAULT NULL,
+	PRIMARY KEY (`id`) USING BTREE,
+	INDEX `tasks_assignee_status` (`assignee_id`, `status`, `due_date`) USING BTREE,
+	INDEX `tasks_status_due_date` (`status`, `due_date`) USING BTREE,
+	INDEX `tasks_entity` (`entity_type`, `entity_id`) USING BTREE,
+	INDEX `tasks_created_by_fk` (`created_by`) USING BTREE,
+	CONSTRAINT `tasks_assignee_fk` FOREIGN KEY (`assignee_id`) REFERENCES `users` (`id`) ON UPDATE CASCADE ON DELETE RESTRICT,
+	CONSTRAINT `tasks_created_by_fk` FOREIGN KEY (`created_by`) REFERENCES `users` (`id`) ON UPDATE CASCADE ON DELETE SET NULL
+)
+COLLATE='utf8mb4_general_ci'
+ENGINE=InnoDB
+;
@@ -0,0 +1,9 @@
+CREATE TABLE `watchlist_visits` (
+	`user_id` INT(11) NOT NULL,
+	`visited_at` DATETIME NOT NULL,
+	PRIMARY KEY (`user_id`) USING BTREE,
+	CONSTRAINT `watchlist_visits_user_fk` FOREIGN KEY (`user_id`) REFERENCES `users` (`id`) ON UPDATE CASCADE ON DELETE CASCADE
+)
+COLLATE='utf8mb4_general_ci'
+ENGINE=InnoDB
+;
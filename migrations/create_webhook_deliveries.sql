@@ -0,0 +1,25 @@
+CREATE TABLE `webhook_deliveries` (
+	`id` INT(11) NOT NULL AUTO_INCREMENT,
+	`created_at` DATETIME NOT NULL DEFAULT current_timestamp(),
+	`modified_at` DATETIME NOT NULL DEFAULT current_timestamp() ON UPDATE current_timestamp(),
+	`deleted_at` DATETIME NULL DEFAULT NULL,
+	`webhook_id` INT(11) NOT NULL,
+	`event` VARCHAR(50) NOT NULL,
+	`payload` TEXT NOT NULL,
+	`status` VARCHAR(20) NOT NULL DEFAULT 'pending',
+	`attempts` INT(11) NOT NULL DEFAULT 0,
+	`next_attempt_at` DATETIME NULL DEFAULT NULL,
+	`last_attempt_at` DATETIME NULL DEFAULT NULL,
+	`response_status` INT(11) NULL DEFAULT NULL,
+	`last_error` VARCHAR(1000) NOT NULL DEFAULT '',
+	`redelivery_of` INT(11) NULL DEFAULT NULL,
+	PRIMARY KEY (`id`) USING BTREE,
+	INDEX `webhook_deliveries_webhook_fk` (`webhook_id`, `id`) USING BTREE,
+	INDEX `webhook_deliveries_due` (`status`, `next_attempt_at`) USING BTREE,
+	INDEX `webhook_deliveries_redelivery_of_fk` (`redelivery_of`) USING BTREE,
+	CONSTRAINT `webhook_deliveries_webhook_fk` FOREIGN KEY (`webhook_id`) REFERENCES `webhooks` (`id`) ON UPDATE CASCADE ON DELETE CASCADE,
+	CONSTRAINT `webhook_deliveries_redelivery_of_fk` FOREIGN KEY (`redelivery_of`) REFERENCES `webhook_deliveries` (`id`) ON UPDATE CASCADE ON DELETE SET NULL
+)
+COLLATE='utf8mb4_general_ci'
+ENGINE=InnoDB
+;
@@ -0,0 +1,16 @@
+CREATE TABLE `webhooks` (
+	`id` INT(11) NOT NULL AUTO_INCREMENT,
+	`created_at` DATETIME NOT NULL DEFAULT current_timestamp(),
+	`modified_at` DATETIME NOT NULL DEFAULT current_timestamp() ON UPDATE current_timestamp(),
+	`deleted_at` DATETIME NULL DEFAULT NULL,
+	`url` VARCHAR(2048) NOT NULL,
+	`secret` VARCHAR(128) NOT NULL,
+	`active` TINYINT(1) NOT NULL DEFAULT 1,
+	`created_by` INT(11) NULL DEFAULT NULL,
+	PRIMARY KEY (`id`) USING BTREE,
+	INDEX `webhooks_created_by_fk` (`created_by`) USING BTREE,
+	CONSTRAINT `webhooks_created_by_fk` FOREIGN KEY (`created_by`) REFERENCES `users` (`id`) ON UPDATE CASCADE ON DELETE SET NULL
+)
+COLLATE='utf8mb4_general_ci'
+ENGINE=InnoDB
+;
@@ -0,0 +1,10 @@
+CREATE TABLE `webhooks_events` (
+	`webhook_id` INT(11) NOT NULL,
+	`event` VARCHAR(50) NOT NULL,
+	PRIMARY KEY (`webhook_id`, `event`) USING BTREE,
+	INDEX `webhooks_events_event` (`event`) USING BTREE,
+	CONSTRAINT `webhooks_events_webhook_fk` FOREIGN KEY (`webhook_id`) REFERENCES `webhooks` (`id`) ON UPDATE CASCADE ON DELETE CASCADE
+)
+COLLATE='utf8mb4_general_ci'
+ENGINE=InnoDB
+;
@@ -0,0 +1,6 @@
+INSERT
+	INTO `people` (`id`, `created_at`, `modified_at`, `deleted_at`, `name`, `notes`)
+SELECT
+	`id`, `created_at`, `modified_at`, `deleted_at`, `name`, ''
+FROM
+	`contacts`
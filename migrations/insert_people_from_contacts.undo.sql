@@ -0,0 +1 @@
+DELETE FROM `people` WHERE `id` IN (SELECT `person_id` FROM `contacts`)
\ No newline at end of file
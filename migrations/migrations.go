@@ -27,6 +27,90 @@ func LoadMigrations() *Sqlx {
 			SqlxFileMigration("alter_articles_table", "migrations/alter_articles_table.sql", "migrations/alter_articles_table.undo.sql"),
 			// Set 0000-00-00 to null
 			SqlxFileMigration("set_articles_date_null", "migrations/set_articles_date_null.sql", "migrations/set_articles_date_null.undo.sql"),
+
+			// Revision history for articles, projects and platforms
+			SqlxFileMigration("create_revisions", "migrations/create_revisions.sql", "migrations/create_revisions.undo.sql"),
+
+			// Keep track of which platform a merged duplicate ended up in
+			SqlxFileMigration("alter_platforms_merged_into", "migrations/alter_platforms_merged_into.sql", "migrations/alter_platforms_merged_into.undo.sql"),
+
+			// Audit of merged duplicate contacts
+			SqlxFileMigration("create_contact_merges", "migrations/create_contact_merges.sql", "migrations/create_contact_merges.undo.sql"),
+
+			// People, a contact is a person's employment at a platform
+			SqlxFileMigration("create_people", "migrations/create_people.sql", "migrations/create_people.undo.sql"),
+			SqlxFileMigration("alter_contacts_employment", "migrations/alter_contacts_employment.sql", "migrations/alter_contacts_employment.undo.sql"),
+			// Every existing contact becomes a person (with the same id)
+			SqlxFileMigration("insert_people_from_contacts", "migrations/insert_people_from_contacts.sql", "migrations/insert_people_from_contacts.undo.sql"),
+			SqlxFileMigration("set_contacts_person_id", "migrations/set_contacts_person_id.sql", "migrations/set_contacts_person_id.undo.sql"),
+			SqlxFileMigration("alter_contacts_person_not_null", "migrations/alter_contacts_person_not_null.sql", "migrations/alter_contacts_person_not_null.undo.sql"),
+
+			// Reports of spreadsheet imports
+			SqlxFileMigration("create_imports", "migrations/create_imports.sql", "migrations/create_imports.undo.sql"),
+
+			// Interaction log of calls, meetings and emails with contacts
+			SqlxFileMigration("create_activities", "migrations/create_activities.sql", "migrations/create_activities.undo.sql"),
+			SqlxFileMigration("create_activities_contacts", "migrations/create_activities_contacts.sql", "migrations/create_activities_contacts.undo.sql"),
+			SqlxFileMigration("create_activities_users", "migrations/create_activities_users.sql", "migrations/create_activities_users.undo.sql"),
+
+			// Follow-up tasks assigned to users
+			SqlxFileMigration("create_tasks", "migrations/create_tasks.sql", "migrations/create_tasks.undo.sql"),
+			// Scheduled jobs that already ran, so they run once a day when several servers are running
+			SqlxFileMigration("create_job_runs", "migrations/create_job_runs.sql", "migrations/create_job_runs.undo.sql"),
+
+			// Rights deals licensing a project to a platform for territories, media and a window
+			SqlxFileMigration("create_deals", "migrations/create_deals.sql", "migrations/create_deals.undo.sql"),
+			SqlxFileMigration("create_deals_territories", "migrations/create_deals_territories.sql", "migrations/create_deals_territories.undo.sql"),
+			SqlxFileMigration("create_deals_media", "migrations/create_deals_media.sql", "migrations/create_deals_media.undo.sql"),
+
+			// Lifecycle status and assignee of projects, with the history of status changes
+			SqlxFileMigration("alter_projects_status", "migrations/alter_projects_status.sql", "migrations/alter_projects_status.undo.sql"),
+			SqlxFileMigration("create_project_status_changes", "migrations/create_project_status_changes.sql", "migrations/create_project_status_changes.undo.sql"),
+
+			// Press coverage of projects
+			SqlxFileMigration("create_articles_projects", "migrations/create_articles_projects.sql", "migrations/create_articles_projects.undo.sql"),
+
+			// Preview image of articles, filled from the metadata of the linked page
+			SqlxFileMigration("alter_articles_image", "migrations/alter_articles_image.sql", "migrations/alter_articles_image.undo.sql"),
+
+			// Last result of checking the links of articles, projects and platforms
+			SqlxFileMigration("create_link_checks", "migrations/create_link_checks.sql", "migrations/create_link_checks.undo.sql"),
+
+			// Files attached to platforms, contacts, articles and projects
+			SqlxFileMigration("create_attachments", "migrations/create_attachments.sql", "migrations/create_attachments.undo.sql"),
+
+			// Format of the bodies of articles and projects, with their rendered HTML and plain text
+			SqlxFileMigration("alter_articles_body", "migrations/alter_articles_body.sql", "migrations/alter_articles_body.undo.sql"),
+			SqlxFileMigration("alter_projects_body", "migrations/alter_projects_body.sql", "migrations/alter_projects_body.undo.sql"),
+
+			// Threaded comments on platforms, contacts, articles and projects, with the users mentioned in them
+			SqlxFileMigration("create_comments", "migrations/create_comments.sql", "migrations/create_comments.undo.sql"),
+			SqlxFileMigration("create_comments_mentions", "migrations/create_comments_mentions.sql", "migrations/create_comments_mentions.undo.sql"),
+
+			// Users that created platforms, contacts, articles and projects, they are notified when their records are edited
+			SqlxFileMigration("alter_platforms_created_by", "migrations/alter_platforms_created_by.sql", "migrations/alter_platforms_created_by.undo.sql"),
+			SqlxFileMigration("alter_contacts_created_by", "migrations/alter_contacts_created_by.sql", "migrations/alter_contacts_created_by.undo.sql"),
+			SqlxFileMigration("alter_articles_created_by", "migrations/alter_articles_created_by.sql", "migrations/alter_articles_created_by.undo.sql"),
+			SqlxFileMigration("alter_projects_created_by", "migrations/alter_projects_created_by.sql", "migrations/alter_projects_created_by.undo.sql"),
+
+			// In-app notifications of users
+			SqlxFileMigration("create_notifications", "migrations/create_notifications.sql", "migrations/create_notifications.undo.sql"),
+
+			// Watchlists of platforms, contacts and projects, with the last time each user looked at the changes
+			SqlxFileMigration("create_favorites", "migrations/create_favorites.sql", "migrations/create_favorites.undo.sql"),
+			SqlxFileMigration("create_watchlist_visits", "migrations/create_watchlist_visits.sql", "migrations/create_watchlist_visits.undo.sql"),
+
+			// Saved searches of the contacts directory, with the users subscribed to their new matches
+			SqlxFileMigration("create_saved_searches", "migrations/create_saved_searches.sql", "migrations/create_saved_searches.undo.sql"),
+			SqlxFileMigration("create_saved_searches_subscriptions", "migrations/create_saved_searches_subscriptions.sql", "migrations/create_saved_searches_subscriptions.undo.sql"),
+
+			// Webhooks called on changes to records, with the queue and log of their deliveries
+			SqlxFileMigration("create_webhooks", "migrations/create_webhooks.sql", "migrations/create_webhooks.undo.sql"),
+			SqlxFileMigration("create_webhooks_events", "migrations/create_webhooks_events.sql", "migrations/create_webhooks_events.undo.sql"),
+			SqlxFileMigration("create_webhook_deliveries", "migrations/create_webhook_deliveries.sql", "migrations/create_webhook_deliveries.undo.sql"),
+
+			// Log of changes to records, streamed to open frontends
+			SqlxFileMigration("create_change_events", "migrations/create_change_events.sql", "migrations/create_change_events.undo.sql"),
 		},
 	}
 }
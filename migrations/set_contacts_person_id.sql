@@ -0,0 +1 @@
+UPDATE `contacts` SET `person_id` = `id` WHERE `person_id` IS NULL
\ No newline at end of file
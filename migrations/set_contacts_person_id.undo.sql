@@ -0,0 +1 @@
+UPDATE `contacts` SET `person_id` = NULL WHERE `person_id` = `id`
\ No newline at end of file
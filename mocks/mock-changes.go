@@ -0,0 +1,16 @@
+package mocks
+
+// MockChangeFeed drops all changes, subscribers are never woken up
+type MockChangeFeed struct{}
+
+func NewMockChangeFeed() *MockChangeFeed {
+	return &MockChangeFeed{}
+}
+
+func (f *MockChangeFeed) Publish(entityType, action string, entityId, actorId int64) error {
+	return nil
+}
+
+func (f *MockChangeFeed) Subscribe() (<-chan struct{}, func()) {
+	return make(chan struct{}), func() {}
+}
@@ -0,0 +1,30 @@
+package mocks
+
+import "github.com/webstradev/rsdb-backend/db"
+
+// MockInbox drops all notifications, subscribers are never woken up
+type MockInbox struct{}
+
+func NewMockInbox() *MockInbox {
+	return &MockInbox{}
+}
+
+func (i *MockInbox) Subscribe(userId int64) (<-chan struct{}, func()) {
+	return make(chan struct{}), func() {}
+}
+
+func (i *MockInbox) RecordEdited(entityType string, entityId, editedBy int64) error {
+	return nil
+}
+
+func (i *MockInbox) Mentioned(comment db.Comment, userIds []int64) error {
+	return nil
+}
+
+func (i *MockInbox) TaskAssigned(task db.Task, assignedBy int64) error {
+	return nil
+}
+
+func (i *MockInbox) InviteAccepted(invitedBy int64, user db.User) error {
+	return nil
+}
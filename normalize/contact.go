@@ -0,0 +1,94 @@
+package normalize
+
+import (
+	"errors"
+	"net/mail"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/nyaruka/phonenumbers"
+)
+
+var (
+	ErrInvalidEmail = errors.New("invalid email address")
+	ErrInvalidPhone = errors.New("invalid phone number, use international format (+31 20 123 4567) if the number is not from the platform's country")
+)
+
+// Number of trailing digits used to compare phone numbers, this ignores differences in
+// country code notation such as "+31 6..." and "06..."
+const phoneKeyDigits = 9
+
+// Email lowercases and trims an email address
+func Email(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
+// ValidEmail lowercases and trims an email address and checks it is a single address without display name.
+// Empty values are left empty.
+func ValidEmail(email string) (string, error) {
+	email = Email(email)
+	if email == "" {
+		return "", nil
+	}
+
+	address, err := mail.ParseAddress(email)
+	if err != nil || address.Address != email || !strings.Contains(email[strings.LastIndex(email, "@"):], ".") {
+		return "", ErrInvalidEmail
+	}
+
+	return email, nil
+}
+
+// E164 formats a valid phone number as E.164 (+31201234567). Numbers without country code are read as
+// numbers of the given ISO 3166-1 country, an extension is kept behind the number (+31201234567 ext. 12).
+// Empty values are left empty.
+func E164(phone, country string) (string, error) {
+	if strings.TrimSpace(phone) == "" {
+		return "", nil
+	}
+
+	number, err := phonenumbers.Parse(phone, strings.ToUpper(country))
+	if err != nil || !phonenumbers.IsValidNumber(number) {
+		return "", ErrInvalidPhone
+	}
+
+	formatted := phonenumbers.Format(number, phonenumbers.E164)
+	if extension := number.GetExtension(); extension != "" {
+		formatted += " ext. " + extension
+	}
+
+	return formatted, nil
+}
+
+// PhoneKey reduces a phone number to its last digits so differently formatted numbers can be compared.
+// Numbers that are too short to compare reliably return an empty string.
+func PhoneKey(phone string) string {
+	digits := strings.Map(func(r rune) rune {
+		if unicode.IsDigit(r) {
+			return r
+		}
+		return -1
+	}, phone)
+
+	if len(digits) < 7 {
+		return ""
+	}
+
+	if len(digits) > phoneKeyDigits {
+		digits = digits[len(digits)-phoneKeyDigits:]
+	}
+
+	return digits
+}
+
+// PersonName lowercases a name, strips punctuation and sorts the parts so "Westra, Erik" matches "Erik Westra"
+func PersonName(name string) string {
+	parts := strings.FieldsFunc(strings.ToLower(name), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	sort.Strings(parts)
+
+	return strings.Join(parts, " ")
+}
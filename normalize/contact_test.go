@@ -0,0 +1,61 @@
+package normalize
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEmail(t *testing.T) {
+	require.Equal(t, "erik@example.com", Email("  Erik@Example.COM "))
+}
+
+func TestValidEmail(t *testing.T) {
+	email, err := ValidEmail("  Erik@Example.COM ")
+	require.NoError(t, err)
+	require.Equal(t, "erik@example.com", email)
+
+	email, err = ValidEmail("")
+	require.NoError(t, err)
+	require.Equal(t, "", email)
+
+	for _, invalid := range []string{"erik", "erik@example", "Erik <erik@example.com>", "erik@example.com, jan@example.com"} {
+		_, err = ValidEmail(invalid)
+		require.ErrorIs(t, err, ErrInvalidEmail, invalid)
+	}
+}
+
+func TestE164(t *testing.T) {
+	phone, err := E164("020-123 4567", "NL")
+	require.NoError(t, err)
+	require.Equal(t, "+31201234567", phone)
+
+	phone, err = E164("+44 20 7946 0018", "NL")
+	require.NoError(t, err)
+	require.Equal(t, "+442079460018", phone)
+
+	phone, err = E164("(212) 555-0123 ext. 12", "us")
+	require.NoError(t, err)
+	require.Equal(t, "+12125550123 ext. 12", phone)
+
+	phone, err = E164(" ", "NL")
+	require.NoError(t, err)
+	require.Equal(t, "", phone)
+
+	_, err = E164("020-123 4567", "")
+	require.ErrorIs(t, err, ErrInvalidPhone)
+
+	_, err = E164("12", "NL")
+	require.ErrorIs(t, err, ErrInvalidPhone)
+}
+
+func TestPhoneKey(t *testing.T) {
+	require.Equal(t, "612345678", PhoneKey("+31 6 1234 5678"))
+	require.Equal(t, "612345678", PhoneKey("06-12345678"))
+	require.Equal(t, "", PhoneKey("12 34"))
+}
+
+func TestPersonName(t *testing.T) {
+	require.Equal(t, "erik westra", PersonName("Westra, Erik"))
+	require.Equal(t, "erik westra", PersonName("Erik  WESTRA"))
+}
@@ -0,0 +1,478 @@
+package normalize
+
+// ISO 3166-1 countries, the display name is the common English name
+var countries = []ISOCountry{
+	{"AD", "AND", "Andorra"},
+	{"AE", "ARE", "United Arab Emirates"},
+	{"AF", "AFG", "Afghanistan"},
+	{"AG", "ATG", "Antigua and Barbuda"},
+	{"AI", "AIA", "Anguilla"},
+	{"AL", "ALB", "Albania"},
+	{"AM", "ARM", "Armenia"},
+	{"AO", "AGO", "Angola"},
+	{"AQ", "ATA", "Antarctica"},
+	{"AR", "ARG", "Argentina"},
+	{"AS", "ASM", "American Samoa"},
+	{"AT", "AUT", "Austria"},
+	{"AU", "AUS", "Australia"},
+	{"AW", "ABW", "Aruba"},
+	{"AX", "ALA", "Åland Islands"},
+	{"AZ", "AZE", "Azerbaijan"},
+	{"BA", "BIH", "Bosnia and Herzegovina"},
+	{"BB", "BRB", "Barbados"},
+	{"BD", "BGD", "Bangladesh"},
+	{"BE", "BEL", "Belgium"},
+	{"BF", "BFA", "Burkina Faso"},
+	{"BG", "BGR", "Bulgaria"},
+	{"BH", "BHR", "Bahrain"},
+	{"BI", "BDI", "Burundi"},
+	{"BJ", "BEN", "Benin"},
+	{"BL", "BLM", "Saint Barthélemy"},
+	{"BM", "BMU", "Bermuda"},
+	{"BN", "BRN", "Brunei Darussalam"},
+	{"BO", "BOL", "Bolivia"},
+	{"BQ", "BES", "Bonaire, Sint Eustatius and Saba"},
+	{"BR", "BRA", "Brazil"},
+	{"BS", "BHS", "Bahamas"},
+	{"BT", "BTN", "Bhutan"},
+	{"BV", "BVT", "Bouvet Island"},
+	{"BW", "BWA", "Botswana"},
+	{"BY", "BLR", "Belarus"},
+	{"BZ", "BLZ", "Belize"},
+	{"CA", "CAN", "Canada"},
+	{"CC", "CCK", "Cocos (Keeling) Islands"},
+	{"CD", "COD", "Congo, The Democratic Republic of the"},
+	{"CF", "CAF", "Central African Republic"},
+	{"CG", "COG", "Congo"},
+	{"CH", "CHE", "Switzerland"},
+	{"CI", "CIV", "Côte d'Ivoire"},
+	{"CK", "COK", "Cook Islands"},
+	{"CL", "CHL", "Chile"},
+	{"CM", "CMR", "Cameroon"},
+	{"CN", "CHN", "China"},
+	{"CO", "COL", "Colombia"},
+	{"CR", "CRI", "Costa Rica"},
+	{"CU", "CUB", "Cuba"},
+	{"CV", "CPV", "Cabo Verde"},
+	{"CW", "CUW", "Curaçao"},
+	{"CX", "CXR", "Christmas Island"},
+	{"CY", "CYP", "Cyprus"},
+	{"CZ", "CZE", "Czechia"},
+	{"DE", "DEU", "Germany"},
+	{"DJ", "DJI", "Djibouti"},
+	{"DK", "DNK", "Denmark"},
+	{"DM", "DMA", "Dominica"},
+	{"DO", "DOM", "Dominican Republic"},
+	{"DZ", "DZA", "Algeria"},
+	{"EC", "ECU", "Ecuador"},
+	{"EE", "EST", "Estonia"},
+	{"EG", "EGY", "Egypt"},
+	{"EH", "ESH", "Western Sahara"},
+	{"ER", "ERI", "Eritrea"},
+	{"ES", "ESP", "Spain"},
+	{"ET", "ETH", "Ethiopia"},
+	{"FI", "FIN", "Finland"},
+	{"FJ", "FJI", "Fiji"},
+	{"FK", "FLK", "Falkland Islands (Malvinas)"},
+	{"FM", "FSM", "Micronesia, Federated States of"},
+	{"FO", "FRO", "Faroe Islands"},
+	{"FR", "FRA", "France"},
+	{"GA", "GAB", "Gabon"},
+	{"GB", "GBR", "United Kingdom"},
+	{"GD", "GRD", "Grenada"},
+	{"GE", "GEO", "Georgia"},
+	{"GF", "GUF", "French Guiana"},
+	{"GG", "GGY", "Guernsey"},
+	{"GH", "GHA", "Ghana"},
+	{"GI", "GIB", "Gibraltar"},
+	{"GL", "GRL", "Greenland"},
+	{"GM", "GMB", "Gambia"},
+	{"GN", "GIN", "Guinea"},
+	{"GP", "GLP", "Guadeloupe"},
+	{"GQ", "GNQ", "Equatorial Guinea"},
+	{"GR", "GRC", "Greece"},
+	{"GS", "SGS", "South Georgia and the South Sandwich Islands"},
+	{"GT", "GTM", "Guatemala"},
+	{"GU", "GUM", "Guam"},
+	{"GW", "GNB", "Guinea-Bissau"},
+	{"GY", "GUY", "Guyana"},
+	{"HK", "HKG", "Hong Kong"},
+	{"HM", "HMD", "Heard Island and McDonald Islands"},
+	{"HN", "HND", "Honduras"},
+	{"HR", "HRV", "Croatia"},
+	{"HT", "HTI", "Haiti"},
+	{"HU", "HUN", "Hungary"},
+	{"ID", "IDN", "Indonesia"},
+	{"IE", "IRL", "Ireland"},
+	{"IL", "ISR", "Israel"},
+	{"IM", "IMN", "Isle of Man"},
+	{"IN", "IND", "India"},
+	{"IO", "IOT", "British Indian Ocean Territory"},
+	{"IQ", "IRQ", "Iraq"},
+	{"IR", "IRN", "Iran"},
+	{"IS", "ISL", "Iceland"},
+	{"IT", "ITA", "Italy"},
+	{"JE", "JEY", "Jersey"},
+	{"JM", "JAM", "Jamaica"},
+	{"JO", "JOR", "Jordan"},
+	{"JP", "JPN", "Japan"},
+	{"KE", "KEN", "Kenya"},
+	{"KG", "KGZ", "Kyrgyzstan"},
+	{"KH", "KHM", "Cambodia"},
+	{"KI", "KIR", "Kiribati"},
+	{"KM", "COM", "Comoros"},
+	{"KN", "KNA", "Saint Kitts and Nevis"},
+	{"KP", "PRK", "North Korea"},
+	{"KR", "KOR", "South Korea"},
+	{"KW", "KWT", "Kuwait"},
+	{"KY", "CYM", "Cayman Islands"},
+	{"KZ", "KAZ", "Kazakhstan"},
+	{"LA", "LAO", "Laos"},
+	{"LB", "LBN", "Lebanon"},
+	{"LC", "LCA", "Saint Lucia"},
+	{"LI", "LIE", "Liechtenstein"},
+	{"LK", "LKA", "Sri Lanka"},
+	{"LR", "LBR", "Liberia"},
+	{"LS", "LSO", "Lesotho"},
+	{"LT", "LTU", "Lithuania"},
+	{"LU", "LUX", "Luxembourg"},
+	{"LV", "LVA", "Latvia"},
+	{"LY", "LBY", "Libya"},
+	{"MA", "MAR", "Morocco"},
+	{"MC", "MCO", "Monaco"},
+	{"MD", "MDA", "Moldova"},
+	{"ME", "MNE", "Montenegro"},
+	{"MF", "MAF", "Saint Martin (French part)"},
+	{"MG", "MDG", "Madagascar"},
+	{"MH", "MHL", "Marshall Islands"},
+	{"MK", "MKD", "North Macedonia"},
+	{"ML", "MLI", "Mali"},
+	{"MM", "MMR", "Myanmar"},
+	{"MN", "MNG", "Mongolia"},
+	{"MO", "MAC", "Macao"},
+	{"MP", "MNP", "Northern Mariana Islands"},
+	{"MQ", "MTQ", "Martinique"},
+	{"MR", "MRT", "Mauritania"},
+	{"MS", "MSR", "Montserrat"},
+	{"MT", "MLT", "Malta"},
+	{"MU", "MUS", "Mauritius"},
+	{"MV", "MDV", "Maldives"},
+	{"MW", "MWI", "Malawi"},
+	{"MX", "MEX", "Mexico"},
+	{"MY", "MYS", "Malaysia"},
+	{"MZ", "MOZ", "Mozambique"},
+	{"NA", "NAM", "Namibia"},
+	{"NC", "NCL", "New Caledonia"},
+	{"NE", "NER", "Niger"},
+	{"NF", "NFK", "Norfolk Island"},
+	{"NG", "NGA", "Nigeria"},
+	{"NI", "NIC", "Nicaragua"},
+	{"NL", "NLD", "Netherlands"},
+	{"NO", "NOR", "Norway"},
+	{"NP", "NPL", "Nepal"},
+	{"NR", "NRU", "Nauru"},
+	{"NU", "NIU", "Niue"},
+	{"NZ", "NZL", "New Zealand"},
+	{"OM", "OMN", "Oman"},
+	{"PA", "PAN", "Panama"},
+	{"PE", "PER", "Peru"},
+	{"PF", "PYF", "French Polynesia"},
+	{"PG", "PNG", "Papua New Guinea"},
+	{"PH", "PHL", "Philippines"},
+	{"PK", "PAK", "Pakistan"},
+	{"PL", "POL", "Poland"},
+	{"PM", "SPM", "Saint Pierre and Miquelon"},
+	{"PN", "PCN", "Pitcairn"},
+	{"PR", "PRI", "Puerto Rico"},
+	{"PS", "PSE", "Palestine, State of"},
+	{"PT", "PRT", "Portugal"},
+	{"PW", "PLW", "Palau"},
+	{"PY", "PRY", "Paraguay"},
+	{"QA", "QAT", "Qatar"},
+	{"RE", "REU", "Réunion"},
+	{"RO", "ROU", "Romania"},
+	{"RS", "SRB", "Serbia"},
+	{"RU", "RUS", "Russian Federation"},
+	{"RW", "RWA", "Rwanda"},
+	{"SA", "SAU", "Saudi Arabia"},
+	{"SB", "SLB", "Solomon Islands"},
+	{"SC", "SYC", "Seychelles"},
+	{"SD", "SDN", "Sudan"},
+	{"SE", "SWE", "Sweden"},
+	{"SG", "SGP", "Singapore"},
+	{"SH", "SHN", "Saint Helena, Ascension and Tristan da Cunha"},
+	{"SI", "SVN", "Slovenia"},
+	{"SJ", "SJM", "Svalbard and Jan Mayen"},
+	{"SK", "SVK", "Slovakia"},
+	{"SL", "SLE", "Sierra Leone"},
+	{"SM", "SMR", "San Marino"},
+	{"SN", "SEN", "Senegal"},
+	{"SO", "SOM", "Somalia"},
+	{"SR", "SUR", "Suriname"},
+	{"SS", "SSD", "South Sudan"},
+	{"ST", "STP", "Sao Tome and Principe"},
+	{"SV", "SLV", "El Salvador"},
+	{"SX", "SXM", "Sint Maarten (Dutch part)"},
+	{"SY", "SYR", "Syria"},
+	{"SZ", "SWZ", "Eswatini"},
+	{"TC", "TCA", "Turks and Caicos Islands"},
+	{"TD", "TCD", "Chad"},
+	{"TF", "ATF", "French Southern Territories"},
+	{"TG", "TGO", "Togo"},
+	{"TH", "THA", "Thailand"},
+	{"TJ", "TJK", "Tajikistan"},
+	{"TK", "TKL", "Tokelau"},
+	{"TL", "TLS", "Timor-Leste"},
+	{"TM", "TKM", "Turkmenistan"},
+	{"TN", "TUN", "Tunisia"},
+	{"TO", "TON", "Tonga"},
+	{"TR", "TUR", "Türkiye"},
+	{"TT", "TTO", "Trinidad and Tobago"},
+	{"TV", "TUV", "Tuvalu"},
+	{"TW", "TWN", "Taiwan"},
+	{"TZ", "TZA", "Tanzania"},
+	{"UA", "UKR", "Ukraine"},
+	{"UG", "UGA", "Uganda"},
+	{"UM", "UMI", "United States Minor Outlying Islands"},
+	{"US", "USA", "United States"},
+	{"UY", "URY", "Uruguay"},
+	{"UZ", "UZB", "Uzbekistan"},
+	{"VA", "VAT", "Holy See (Vatican City State)"},
+	{"VC", "VCT", "Saint Vincent and the Grenadines"},
+	{"VE", "VEN", "Venezuela"},
+	{"VG", "VGB", "Virgin Islands, British"},
+	{"VI", "VIR", "Virgin Islands, U.S."},
+	{"VN", "VNM", "Vietnam"},
+	{"VU", "VUT", "Vanuatu"},
+	{"WF", "WLF", "Wallis and Futuna"},
+	{"WS", "WSM", "Samoa"},
+	{"YE", "YEM", "Yemen"},
+	{"YT", "MYT", "Mayotte"},
+	{"ZA", "ZAF", "South Africa"},
+	{"ZM", "ZMB", "Zambia"},
+	{"ZW", "ZWE", "Zimbabwe"},
+}
+
+// Official and alternative names that are also recognized
+var countryAliases = map[string]string{
+	"Principality of Andorra":                              "AD",
+	"Islamic Republic of Afghanistan":                      "AF",
+	"Republic of Albania":                                  "AL",
+	"Republic of Armenia":                                  "AM",
+	"Republic of Angola":                                   "AO",
+	"Argentine Republic":                                   "AR",
+	"Republic of Austria":                                  "AT",
+	"Republic of Azerbaijan":                               "AZ",
+	"Republic of Bosnia and Herzegovina":                   "BA",
+	"People's Republic of Bangladesh":                      "BD",
+	"Kingdom of Belgium":                                   "BE",
+	"Republic of Bulgaria":                                 "BG",
+	"Kingdom of Bahrain":                                   "BH",
+	"Republic of Burundi":                                  "BI",
+	"Republic of Benin":                                    "BJ",
+	"Bolivia, Plurinational State of":                      "BO",
+	"Plurinational State of Bolivia":                       "BO",
+	"Federative Republic of Brazil":                        "BR",
+	"Commonwealth of the Bahamas":                          "BS",
+	"Kingdom of Bhutan":                                    "BT",
+	"Republic of Botswana":                                 "BW",
+	"Republic of Belarus":                                  "BY",
+	"Republic of the Congo":                                "CG",
+	"Swiss Confederation":                                  "CH",
+	"Republic of Côte d'Ivoire":                            "CI",
+	"Republic of Chile":                                    "CL",
+	"Republic of Cameroon":                                 "CM",
+	"People's Republic of China":                           "CN",
+	"Republic of Colombia":                                 "CO",
+	"Republic of Costa Rica":                               "CR",
+	"Republic of Cuba":                                     "CU",
+	"Republic of Cabo Verde":                               "CV",
+	"Republic of Cyprus":                                   "CY",
+	"Czech Republic":                                       "CZ",
+	"Federal Republic of Germany":                          "DE",
+	"Republic of Djibouti":                                 "DJ",
+	"Kingdom of Denmark":                                   "DK",
+	"Commonwealth of Dominica":                             "DM",
+	"People's Democratic Republic of Algeria":              "DZ",
+	"Republic of Ecuador":                                  "EC",
+	"Republic of Estonia":                                  "EE",
+	"Arab Republic of Egypt":                               "EG",
+	"the State of Eritrea":                                 "ER",
+	"Kingdom of Spain":                                     "ES",
+	"Federal Democratic Republic of Ethiopia":              "ET",
+	"Republic of Finland":                                  "FI",
+	"Republic of Fiji":                                     "FJ",
+	"Federated States of Micronesia":                       "FM",
+	"French Republic":                                      "FR",
+	"Gabonese Republic":                                    "GA",
+	"United Kingdom of Great Britain and Northern Ireland": "GB",
+	"Republic of Ghana":                                    "GH",
+	"Republic of the Gambia":                               "GM",
+	"Republic of Guinea":                                   "GN",
+	"Republic of Equatorial Guinea":                        "GQ",
+	"Hellenic Republic":                                    "GR",
+	"Republic of Guatemala":                                "GT",
+	"Republic of Guinea-Bissau":                            "GW",
+	"Republic of Guyana":                                   "GY",
+	"Hong Kong Special Administrative Region of China":     "HK",
+	"Republic of Honduras":                                 "HN",
+	"Republic of Croatia":                                  "HR",
+	"Republic of Haiti":                                    "HT",
+	"Republic of Indonesia":                                "ID",
+	"State of Israel":                                      "IL",
+	"Republic of India":                                    "IN",
+	"Republic of Iraq":                                     "IQ",
+	"Iran, Islamic Republic of":                            "IR",
+	"Islamic Republic of Iran":                             "IR",
+	"Republic of Iceland":                                  "IS",
+	"Italian Republic":                                     "IT",
+	"Hashemite Kingdom of Jordan":                          "JO",
+	"Republic of Kenya":                                    "KE",
+	"Kyrgyz Republic":                                      "KG",
+	"Kingdom of Cambodia":                                  "KH",
+	"Republic of Kiribati":                                 "KI",
+	"Union of the Comoros":                                 "KM",
+	"Korea, Democratic People's Republic of":               "KP",
+	"Democratic People's Republic of Korea":                "KP",
+	"Korea, Republic of":                                   "KR",
+	"State of Kuwait":                                      "KW",
+	"Republic of Kazakhstan":                               "KZ",
+	"Lao People's Democratic Republic":                     "LA",
+	"Lebanese Republic":                                    "LB",
+	"Principality of Liechtenstein":                        "LI",
+	"Democratic Socialist Republic of Sri Lanka":           "LK",
+	"Republic of Liberia":                                  "LR",
+	"Kingdom of Lesotho":                                   "LS",
+	"Republic of Lithuania":                                "LT",
+	"Grand Duchy of Luxembourg":                            "LU",
+	"Republic of Latvia":                                   "LV",
+	"Kingdom of Morocco":                                   "MA",
+	"Principality of Monaco":                               "MC",
+	"Moldova, Republic of":                                 "MD",
+	"Republic of Moldova":                                  "MD",
+	"Republic of Madagascar":                               "MG",
+	"Republic of the Marshall Islands":                     "MH",
+	"Republic of North Macedonia":                          "MK",
+	"Republic of Mali":                                     "ML",
+	"Republic of Myanmar":                                  "MM",
+	"Macao Special Administrative Region of China":         "MO",
+	"Commonwealth of the Northern Mariana Islands":         "MP",
+	"Islamic Republic of Mauritania":                       "MR",
+	"Republic of Malta":                                    "MT",
+	"Republic of Mauritius":                                "MU",
+	"Republic of Maldives":                                 "MV",
+	"Republic of Malawi":                                   "MW",
+	"United Mexican States":                                "MX",
+	"Republic of Mozambique":                               "MZ",
+	"Republic of Namibia":                                  "NA",
+	"Republic of the Niger":                                "NE",
+	"Federal Republic of Nigeria":                          "NG",
+	"Republic of Nicaragua":                                "NI",
+	"Kingdom of the Netherlands":                           "NL",
+	"Kingdom of Norway":                                    "NO",
+	"Federal Democratic Republic of Nepal":                 "NP",
+	"Republic of Nauru":                                    "NR",
+	"Sultanate of Oman":                                    "OM",
+	"Republic of Panama":                                   "PA",
+	"Republic of Peru":                                     "PE",
+	"Independent State of Papua New Guinea":                "PG",
+	"Republic of the Philippines":                          "PH",
+	"Islamic Republic of Pakistan":                         "PK",
+	"Republic of Poland":                                   "PL",
+	"the State of Palestine":                               "PS",
+	"Portuguese Republic":                                  "PT",
+	"Republic of Palau":                                    "PW",
+	"Republic of Paraguay":                                 "PY",
+	"State of Qatar":                                       "QA",
+	"Republic of Serbia":                                   "RS",
+	"Rwandese Republic":                                    "RW",
+	"Kingdom of Saudi Arabia":                              "SA",
+	"Republic of Seychelles":                               "SC",
+	"Republic of the Sudan":                                "SD",
+	"Kingdom of Sweden":                                    "SE",
+	"Republic of Singapore":                                "SG",
+	"Republic of Slovenia":                                 "SI",
+	"Slovak Republic":                                      "SK",
+	"Republic of Sierra Leone":                             "SL",
+	"Republic of San Marino":                               "SM",
+	"Republic of Senegal":                                  "SN",
+	"Federal Republic of Somalia":                          "SO",
+	"Republic of Suriname":                                 "SR",
+	"Republic of South Sudan":                              "SS",
+	"Democratic Republic of Sao Tome and Principe":         "ST",
+	"Republic of El Salvador":                              "SV",
+	"Syrian Arab Republic":                                 "SY",
+	"Kingdom of Eswatini":                                  "SZ",
+	"Republic of Chad":                                     "TD",
+	"Togolese Republic":                                    "TG",
+	"Kingdom of Thailand":                                  "TH",
+	"Republic of Tajikistan":                               "TJ",
+	"Democratic Republic of Timor-Leste":                   "TL",
+	"Republic of Tunisia":                                  "TN",
+	"Kingdom of Tonga":                                     "TO",
+	"Republic of Türkiye":                                  "TR",
+	"Republic of Trinidad and Tobago":                      "TT",
+	"Taiwan, Province of China":                            "TW",
+	"Tanzania, United Republic of":                         "TZ",
+	"United Republic of Tanzania":                          "TZ",
+	"Republic of Uganda":                                   "UG",
+	"United States of America":                             "US",
+	"Eastern Republic of Uruguay":                          "UY",
+	"Republic of Uzbekistan":                               "UZ",
+	"Venezuela, Bolivarian Republic of":                    "VE",
+	"Bolivarian Republic of Venezuela":                     "VE",
+	"British Virgin Islands":                               "VG",
+	"Virgin Islands of the United States":                  "VI",
+	"Viet Nam":                                             "VN",
+	"Socialist Republic of Viet Nam":                       "VN",
+	"Republic of Vanuatu":                                  "VU",
+	"Independent State of Samoa":                           "WS",
+	"Republic of Yemen":                                    "YE",
+	"Republic of South Africa":                             "ZA",
+	"Republic of Zambia":                                   "ZM",
+	"Republic of Zimbabwe":                                 "ZW",
+	"UK":                                                   "GB",
+	"Great Britain":                                        "GB",
+	"England":                                              "GB",
+	"Scotland":                                             "GB",
+	"Wales":                                                "GB",
+	"Northern Ireland":                                     "GB",
+	"Britain":                                              "GB",
+	"USA":                                                  "US",
+	"America":                                              "US",
+	"Holland":                                              "NL",
+	"The Netherlands":                                      "NL",
+	"Korea":                                                "KR",
+	"Republic of Korea":                                    "KR",
+	"North Korea":                                          "KP",
+	"Russia":                                               "RU",
+	"Ivory Coast":                                          "CI",
+	"Turkey":                                               "TR",
+	"Vietnam":                                              "VN",
+	"Macedonia":                                            "MK",
+	"Swaziland":                                            "SZ",
+	"Burma":                                                "MM",
+	"UAE":                                                  "AE",
+	"Cape Verde":                                           "CV",
+	"Vatican":                                              "VA",
+	"Palestine":                                            "PS",
+	"Congo-Kinshasa":                                       "CD",
+	"DR Congo":                                             "CD",
+	"Congo-Brazzaville":                                    "CG",
+	"East Timor":                                           "TL",
+	"Brasil":                                               "BR",
+	"Deutschland":                                          "DE",
+	"Nederland":                                            "NL",
+	"España":                                               "ES",
+	"Österreich":                                           "AT",
+	"Schweiz":                                              "CH",
+	"Suisse":                                               "CH",
+	"Belgique":                                             "BE",
+	"België":                                               "BE",
+	"Italia":                                               "IT",
+	"Polska":                                               "PL",
+	"Sverige":                                              "SE",
+	"Norge":                                                "NO",
+	"Danmark":                                              "DK",
+	"Suomi":                                                "FI",
+}
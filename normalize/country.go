@@ -0,0 +1,70 @@
+package normalize
+
+import (
+	"errors"
+	"sort"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
+)
+
+var ErrInvalidCountry = errors.New("unknown country, use an ISO 3166-1 code or English country name")
+
+type ISOCountry struct {
+	Code   string `json:"code"`
+	Alpha3 string `json:"-"`
+	Name   string `json:"name"`
+}
+
+// Countries lists all ISO 3166-1 countries with their alpha-2 code and display name, sorted by name
+func Countries() []ISOCountry {
+	sorted := make([]ISOCountry, len(countries))
+	copy(sorted, countries)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Name < sorted[j].Name })
+	return sorted
+}
+
+// Lookup of countries by alpha-2 code, alpha-3 code, name and alias (see countryKey)
+var countryIndex = map[string]string{}
+
+func init() {
+	for _, c := range countries {
+		countryIndex[countryKey(c.Code)] = c.Code
+		countryIndex[countryKey(c.Alpha3)] = c.Code
+		countryIndex[countryKey(c.Name)] = c.Code
+	}
+
+	for alias, code := range countryAliases {
+		countryIndex[countryKey(alias)] = code
+	}
+}
+
+// countryKey lowercases a country name and strips accents and punctuation, so "Côte d'Ivoire" matches "cote divoire"
+func countryKey(value string) string {
+	value, _, _ = transform.String(transform.Chain(norm.NFD, runes.Remove(runes.In(unicode.Mn)), norm.NFC), value)
+
+	return strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return unicode.ToLower(r)
+		}
+		return -1
+	}, value)
+}
+
+// Country returns the ISO 3166-1 alpha-2 code for a country code or (English) country name.
+// Empty values are left empty.
+func Country(value string) (string, error) {
+	if strings.TrimSpace(value) == "" {
+		return "", nil
+	}
+
+	code, ok := countryIndex[countryKey(value)]
+	if !ok {
+		return "", ErrInvalidCountry
+	}
+
+	return code, nil
+}
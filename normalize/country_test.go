@@ -0,0 +1,34 @@
+package normalize
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCountry(t *testing.T) {
+	for value, code := range map[string]string{
+		"NL":              "NL",
+		"nld":             "NL",
+		"The Netherlands": "NL",
+		"netherlands":     "NL",
+		"UK":              "GB",
+		"United Kingdom":  "GB",
+		"Cote d'Ivoire":   "CI",
+		"Côte d'Ivoire":   "CI",
+		"Turkey":          "TR",
+		"Deutschland":     "DE",
+		"":                "",
+	} {
+		result, err := Country(value)
+		require.NoError(t, err, value)
+		require.Equal(t, code, result, value)
+	}
+
+	_, err := Country("Atlantis")
+	require.ErrorIs(t, err, ErrInvalidCountry)
+}
+
+func TestCountries(t *testing.T) {
+	require.Len(t, Countries(), 249)
+}
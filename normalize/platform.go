@@ -0,0 +1,106 @@
+package normalize
+
+import (
+	"net/url"
+	"strings"
+	"unicode"
+)
+
+// Hosts that are shared by many unrelated organisations, a matching domain on these says nothing about duplicates
+var sharedDomains = map[string]bool{
+	"facebook.com":     true,
+	"instagram.com":    true,
+	"linkedin.com":     true,
+	"twitter.com":      true,
+	"x.com":            true,
+	"youtube.com":      true,
+	"vimeo.com":        true,
+	"google.com":       true,
+	"sites.google.com": true,
+}
+
+// Words that are left out when comparing organisation names
+var nameStopWords = map[string]bool{
+	"the": true, "ltd": true, "limited": true, "inc": true, "llc": true, "co": true,
+	"corp": true, "company": true, "gmbh": true, "bv": true, "nv": true, "sa": true,
+	"sas": true, "srl": true, "ag": true, "plc": true, "group": true,
+}
+
+// Domain reduces a website to its lowercase host without scheme, port or "www." prefix.
+// An empty string is returned when the website is empty, unparsable or on a shared domain.
+func Domain(website string) string {
+	website = strings.TrimSpace(strings.ToLower(website))
+	if website == "" {
+		return ""
+	}
+
+	if !strings.Contains(website, "://") {
+		website = "http://" + website
+	}
+
+	u, err := url.Parse(website)
+	if err != nil {
+		return ""
+	}
+
+	host := strings.TrimPrefix(u.Hostname(), "www.")
+	if !strings.Contains(host, ".") || sharedDomains[host] {
+		return ""
+	}
+
+	return host
+}
+
+// Name lowercases an organisation name, strips punctuation and drops legal suffixes such as "Ltd" or "GmbH"
+func Name(name string) string {
+	words := strings.FieldsFunc(strings.ToLower(name), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	kept := []string{}
+	for _, word := range words {
+		if !nameStopWords[word] {
+			kept = append(kept, word)
+		}
+	}
+
+	return strings.Join(kept, " ")
+}
+
+// Similarity returns a score between 0 and 1 based on the levenshtein distance between two strings
+func Similarity(a, b string) float64 {
+	ra, rb := []rune(a), []rune(b)
+
+	longest := len(ra)
+	if len(rb) > longest {
+		longest = len(rb)
+	}
+	if longest == 0 {
+		return 1
+	}
+
+	return 1 - float64(levenshtein(ra, rb))/float64(longest)
+}
+
+func levenshtein(a, b []rune) int {
+	previous := make([]int, len(b)+1)
+	current := make([]int, len(b)+1)
+
+	for j := range previous {
+		previous[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+
+	return previous[len(b)]
+}
@@ -0,0 +1,47 @@
+package normalize
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDomain(t *testing.T) {
+	tests := []struct {
+		Website string
+		Domain  string
+	}{
+		{"", ""},
+		{"https://www.Example.com/about", "example.com"},
+		{"example.com", "example.com"},
+		{"http://sub.example.com:8080", "sub.example.com"},
+		{"https://facebook.com/somepage", ""},
+		{"not a website", ""},
+	}
+
+	for _, test := range tests {
+		require.Equal(t, test.Domain, Domain(test.Website), test.Website)
+	}
+}
+
+func TestName(t *testing.T) {
+	tests := []struct {
+		Name       string
+		Normalized string
+	}{
+		{"Acme Films Ltd.", "acme films"},
+		{"The ACME-Films GmbH", "acme films"},
+		{"  ", ""},
+	}
+
+	for _, test := range tests {
+		require.Equal(t, test.Normalized, Name(test.Name), test.Name)
+	}
+}
+
+func TestSimilarity(t *testing.T) {
+	require.Equal(t, 1.0, Similarity("", ""))
+	require.Equal(t, 1.0, Similarity("acme", "acme"))
+	require.Equal(t, 0.75, Similarity("acme", "acne"))
+	require.Equal(t, 0.0, Similarity("abc", "xyz"))
+}
@@ -0,0 +1,46 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"sync"
+)
+
+// FileNotifier appends every message as a line of JSON to a file
+type FileNotifier struct {
+	path string
+	mu   sync.Mutex
+}
+
+func NewFileNotifier(path string) (*FileNotifier, error) {
+	if path == "" {
+		return nil, errors.New("the file notifier needs a file (NOTIFIER_FILE)")
+	}
+
+	return &FileNotifier{path: path}, nil
+}
+
+func (n *FileNotifier) Notify(ctx context.Context, message Message) error {
+	line, err := json.Marshal(message)
+	if err != nil {
+		return err
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	file, err := os.OpenFile(n.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+
+	_, err = file.Write(append(line, '\n'))
+	if err != nil {
+		file.Close()
+		return err
+	}
+
+	return file.Close()
+}
@@ -0,0 +1,20 @@
+package notify
+
+import (
+	"context"
+	"log"
+)
+
+// LogNotifier writes messages to the log instead of sending them
+type LogNotifier struct {
+	logger *log.Logger
+}
+
+func NewLogNotifier() *LogNotifier {
+	return &LogNotifier{logger: log.Default()}
+}
+
+func (n *LogNotifier) Notify(ctx context.Context, message Message) error {
+	n.logger.Printf("Notification to %s: %s\n%s", message.To, message.Subject, message.Body)
+	return nil
+}
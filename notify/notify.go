@@ -0,0 +1,35 @@
+// Package notify sends messages to users. Which notifier is used is configured with environment variables so that
+// development and test environments can log or write messages to a file instead of sending email.
+package notify
+
+import (
+	"context"
+	"fmt"
+	"os"
+)
+
+type Message struct {
+	To      string `json:"to"`
+	Subject string `json:"subject"`
+	Body    string `json:"body"`
+}
+
+type Notifier interface {
+	Notify(ctx context.Context, message Message) error
+}
+
+// FromEnv creates the notifier selected by NOTIFIER (log, file, smtp or webhook), messages are logged by default
+func FromEnv() (Notifier, error) {
+	switch kind := os.Getenv("NOTIFIER"); kind {
+	case "", "log":
+		return NewLogNotifier(), nil
+	case "file":
+		return NewFileNotifier(os.Getenv("NOTIFIER_FILE"))
+	case "smtp":
+		return NewSMTPNotifier(os.Getenv("SMTP_ADDR"), os.Getenv("SMTP_FROM"), os.Getenv("SMTP_USERNAME"), os.Getenv("SMTP_PASSWORD"))
+	case "webhook":
+		return NewWebhookNotifier(os.Getenv("NOTIFIER_WEBHOOK_URL"))
+	default:
+		return nil, fmt.Errorf("unknown notifier %q, use log, file, smtp or webhook", kind)
+	}
+}
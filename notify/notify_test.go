@@ -0,0 +1,123 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"net/smtp"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var testMessage = Message{To: "user@example.com", Subject: "Your tasks", Body: "- Call Jane\n- Send press kit"}
+
+func TestFromEnv(t *testing.T) {
+	t.Setenv("NOTIFIER", "")
+	notifier, err := FromEnv()
+	require.NoError(t, err)
+	require.IsType(t, &LogNotifier{}, notifier)
+
+	t.Setenv("NOTIFIER", "file")
+	t.Setenv("NOTIFIER_FILE", "")
+	_, err = FromEnv()
+	require.Error(t, err)
+
+	t.Setenv("NOTIFIER", "webhook")
+	t.Setenv("NOTIFIER_WEBHOOK_URL", "http://localhost/hook")
+	notifier, err = FromEnv()
+	require.NoError(t, err)
+	require.IsType(t, &WebhookNotifier{}, notifier)
+
+	t.Setenv("NOTIFIER", "smtp")
+	t.Setenv("SMTP_ADDR", "mail.example.com:587")
+	t.Setenv("SMTP_FROM", "rsdb@example.com")
+	notifier, err = FromEnv()
+	require.NoError(t, err)
+	require.IsType(t, &SMTPNotifier{}, notifier)
+
+	t.Setenv("NOTIFIER", "pigeon")
+	_, err = FromEnv()
+	require.EqualError(t, err, `unknown notifier "pigeon", use log, file, smtp or webhook`)
+}
+
+func TestLogNotifier(t *testing.T) {
+	output := &bytes.Buffer{}
+	notifier := &LogNotifier{logger: log.New(output, "", 0)}
+
+	require.NoError(t, notifier.Notify(context.Background(), testMessage))
+	require.Equal(t, "Notification to user@example.com: Your tasks\n- Call Jane\n- Send press kit\n", output.String())
+}
+
+func TestFileNotifier(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "notifications.ndjson")
+	notifier, err := NewFileNotifier(path)
+	require.NoError(t, err)
+
+	require.NoError(t, notifier.Notify(context.Background(), testMessage))
+	require.NoError(t, notifier.Notify(context.Background(), Message{To: "other@example.com", Subject: "Hi"}))
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	require.Len(t, lines, 2)
+	require.JSONEq(t, `{"to":"user@example.com","subject":"Your tasks","body":"- Call Jane\n- Send press kit"}`, lines[0])
+	require.JSONEq(t, `{"to":"other@example.com","subject":"Hi","body":""}`, lines[1])
+}
+
+func TestSMTPNotifier(t *testing.T) {
+	_, err := NewSMTPNotifier("", "rsdb@example.com", "", "")
+	require.Error(t, err)
+
+	notifier, err := NewSMTPNotifier("mail.example.com:587", "rsdb@example.com", "user", "secret")
+	require.NoError(t, err)
+	require.NotNil(t, notifier.auth)
+
+	var sent []byte
+	notifier.send = func(addr string, a smtp.Auth, from string, to []string, msg []byte) error {
+		require.Equal(t, "mail.example.com:587", addr)
+		require.Equal(t, "rsdb@example.com", from)
+		require.Equal(t, []string{"user@example.com"}, to)
+		sent = msg
+		return nil
+	}
+
+	require.NoError(t, notifier.Notify(context.Background(), testMessage))
+	require.Equal(t, "From: rsdb@example.com\r\nTo: user@example.com\r\nSubject: Your tasks\r\nMIME-Version: 1.0\r\nContent-Type: text/plain; charset=UTF-8\r\n\r\n- Call Jane\r\n- Send press kit\r\n", string(sent))
+
+	err = notifier.Notify(context.Background(), Message{To: "user@example.com", Subject: "Hi\r\nBcc: someone@example.com"})
+	require.EqualError(t, err, "invalid recipient or subject")
+}
+
+func TestWebhookNotifier(t *testing.T) {
+	var received Message
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodPost, r.Method)
+		require.Equal(t, "application/json", r.Header.Get("Content-Type"))
+
+		body, _ := io.ReadAll(r.Body)
+		require.NoError(t, json.Unmarshal(body, &received))
+
+		if received.To == "fail@example.com" {
+			w.WriteHeader(http.StatusBadGateway)
+		}
+	}))
+	defer server.Close()
+
+	notifier, err := NewWebhookNotifier(server.URL)
+	require.NoError(t, err)
+
+	require.NoError(t, notifier.Notify(context.Background(), testMessage))
+	require.Equal(t, testMessage, received)
+
+	err = notifier.Notify(context.Background(), Message{To: "fail@example.com"})
+	require.EqualError(t, err, "webhook responded with status 502")
+}
@@ -0,0 +1,49 @@
+package notify
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/smtp"
+	"strings"
+)
+
+// SMTPNotifier sends messages as plain text email
+type SMTPNotifier struct {
+	addr string
+	from string
+	auth smtp.Auth
+	send func(addr string, a smtp.Auth, from string, to []string, msg []byte) error
+}
+
+// NewSMTPNotifier sends email through the server at addr (host:port), a username enables PLAIN authentication
+func NewSMTPNotifier(addr, from, username, password string) (*SMTPNotifier, error) {
+	if addr == "" || from == "" {
+		return nil, errors.New("the smtp notifier needs a server and sender (SMTP_ADDR and SMTP_FROM)")
+	}
+
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return nil, err
+	}
+
+	n := &SMTPNotifier{addr: addr, from: from, send: smtp.SendMail}
+	if username != "" {
+		n.auth = smtp.PlainAuth("", username, password, host)
+	}
+
+	return n, nil
+}
+
+func (n *SMTPNotifier) Notify(ctx context.Context, message Message) error {
+	// Headers can not contain line breaks
+	if strings.ContainsAny(message.To+message.Subject, "\r\n") {
+		return errors.New("invalid recipient or subject")
+	}
+
+	body := strings.ReplaceAll(message.Body, "\n", "\r\n")
+	msg := fmt.Sprintf("From: %s\r\nTo: %s\r\nSubject: %s\r\nMIME-Version: 1.0\r\nContent-Type: text/plain; charset=UTF-8\r\n\r\n%s\r\n", n.from, message.To, message.Subject, body)
+
+	return n.send(n.addr, n.auth, n.from, []string{message.To}, []byte(msg))
+}
@@ -0,0 +1,96 @@
+// Package publicnet provides an HTTP client that only connects to public addresses, so links entered by users can not
+// make the server reach its own network.
+package publicnet
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"syscall"
+	"time"
+)
+
+// Number of redirects followed before giving up
+const MAX_REDIRECTS = 10
+
+var (
+	ErrInvalidLink      = errors.New("invalid link, use an http or https URL")
+	ErrForbiddenAddress = errors.New("the link does not point to a public address")
+)
+
+// Client is an HTTP client that only connects to allowed addresses, also after redirects
+type Client struct {
+	*http.Client
+
+	// Allowed decides which addresses may be reached, IsPublicAddress unless set otherwise (e.g. to reach local test
+	// servers)
+	Allowed func(ip netip.Addr) bool
+}
+
+func NewClient(timeout time.Duration) *Client {
+	c := &Client{Allowed: IsPublicAddress}
+
+	// The address is checked after the host name was resolved, right before connecting
+	dialer := &net.Dialer{Timeout: timeout, Control: c.control}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+
+	c.Client = &http.Client{Timeout: timeout, Transport: transport, CheckRedirect: c.checkRedirect}
+	return c
+}
+
+func (c *Client) control(network, address string, conn syscall.RawConn) error {
+	addrPort, err := netip.ParseAddrPort(address)
+	if err != nil || !c.Allowed(addrPort.Addr().Unmap()) {
+		return ErrForbiddenAddress
+	}
+
+	return nil
+}
+
+// checkRedirect only follows redirects to http(s) links, links to forbidden IP addresses are refused before they are
+// resolved
+func (c *Client) checkRedirect(req *http.Request, via []*http.Request) error {
+	if len(via) >= MAX_REDIRECTS {
+		return fmt.Errorf("stopped after %d redirects", MAX_REDIRECTS)
+	}
+
+	if req.URL.Scheme != "http" && req.URL.Scheme != "https" {
+		return ErrInvalidLink
+	}
+
+	ip, err := netip.ParseAddr(req.URL.Hostname())
+	if err == nil && !c.Allowed(ip.Unmap()) {
+		return ErrForbiddenAddress
+	}
+
+	return nil
+}
+
+// Ranges that are not reachable on the internet but not covered by the checks of netip.Addr: "this network" and the
+// shared address space of carrier-grade NAT
+var nonPublicPrefixes = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),
+	netip.MustParsePrefix("100.64.0.0/10"),
+}
+
+// IsPublicAddress reports whether the IP address is reachable on the internet, loopback, private, link-local (e.g.
+// the cloud metadata service at 169.254.169.254), multicast and unspecified addresses are not
+func IsPublicAddress(ip netip.Addr) bool {
+	ip = ip.Unmap()
+	if !ip.IsGlobalUnicast() || ip.IsPrivate() {
+		return false
+	}
+
+	for _, prefix := range nonPublicPrefixes {
+		if prefix.Contains(ip) {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,109 @@
+// Package richtext turns the bodies of articles and projects into sanitized HTML and plain text. Bodies are written
+// as Markdown or HTML, whatever is rendered is sanitized against an allowlist so it is safe to show in the browser.
+package richtext
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+
+	"github.com/microcosm-cc/bluemonday"
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/extension"
+	goldmarkhtml "github.com/yuin/goldmark/renderer/html"
+	"golang.org/x/net/html"
+)
+
+const (
+	FORMAT_MARKDOWN = "markdown"
+	FORMAT_HTML     = "html"
+)
+
+var ErrInvalidFormat = errors.New("invalid body format, use markdown or html")
+
+// Markdown with tables, strikethrough and autolinks. Raw HTML is passed through, the sanitizer removes what is not allowed.
+var markdown = goldmark.New(
+	goldmark.WithExtensions(extension.GFM),
+	goldmark.WithRendererOptions(goldmarkhtml.WithUnsafe()),
+)
+
+// The allowlist of user generated content: formatting, links, images, lists and tables, but no scripts, styles,
+// forms or event handlers. Links to other sites open in a new tab.
+var policy = bluemonday.UGCPolicy().AddTargetBlankToFullyQualifiedLinks(true)
+
+// Elements that start a new line in the plain text
+var blockElements = map[string]bool{
+	"address": true, "article": true, "aside": true, "blockquote": true, "br": true, "dd": true, "div": true,
+	"dl": true, "dt": true, "figcaption": true, "figure": true, "footer": true, "h1": true, "h2": true, "h3": true,
+	"h4": true, "h5": true, "h6": true, "header": true, "hr": true, "li": true, "ol": true, "p": true, "pre": true,
+	"section": true, "table": true, "td": true, "th": true, "tr": true, "ul": true,
+}
+
+type Body struct {
+	Format string
+	Source string // As written for Markdown, sanitized for HTML
+	HTML   string
+	Text   string
+}
+
+// Render sanitizes the body and renders it as HTML and plain text, an empty format is taken as HTML
+func Render(format, source string) (Body, error) {
+	body := Body{Format: format, Source: source}
+
+	switch format {
+	case "", FORMAT_HTML:
+		body.Format = FORMAT_HTML
+		body.Source = Sanitize(source)
+		body.HTML = body.Source
+	case FORMAT_MARKDOWN:
+		rendered := bytes.Buffer{}
+		err := markdown.Convert([]byte(source), &rendered)
+		if err != nil {
+			return Body{}, err
+		}
+		body.HTML = Sanitize(rendered.String())
+	default:
+		return Body{}, ErrInvalidFormat
+	}
+
+	body.Text = Text(body.HTML)
+	return body, nil
+}
+
+// Sanitize removes the elements and attributes that are not on the allowlist
+func Sanitize(source string) string {
+	return strings.TrimSpace(policy.Sanitize(source))
+}
+
+// Text returns the text of the HTML with a line per paragraph, list item or other block
+func Text(source string) string {
+	text := strings.Builder{}
+	tokenizer := html.NewTokenizer(strings.NewReader(source))
+
+	for {
+		tokenType := tokenizer.Next()
+		if tokenType == html.ErrorToken {
+			break
+		}
+
+		token := tokenizer.Token()
+		switch tokenType {
+		case html.TextToken:
+			text.WriteString(token.Data)
+		case html.StartTagToken, html.EndTagToken, html.SelfClosingTagToken:
+			if blockElements[token.Data] {
+				text.WriteString("\n")
+			}
+		}
+	}
+
+	// Collapse the whitespace within lines and drop empty lines
+	lines := []string{}
+	for _, line := range strings.Split(text.String(), "\n") {
+		if line = strings.Join(strings.Fields(line), " "); line != "" {
+			lines = append(lines, line)
+		}
+	}
+
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,64 @@
+package richtext
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRender(t *testing.T) {
+	tests := []struct {
+		Name     string
+		Format   string
+		Source   string
+		Expected Body
+		Error    error
+	}{
+		{
+			"Render - Markdown",
+			FORMAT_MARKDOWN,
+			"# The Movie\n\nA **bold** [review](https://example.com/review) <script>alert(1)</script>\n\n- one\n- two",
+			Body{
+				Format: FORMAT_MARKDOWN,
+				Source: "# The Movie\n\nA **bold** [review](https://example.com/review) <script>alert(1)</script>\n\n- one\n- two",
+				HTML:   "<h1>The Movie</h1>\n<p>A <strong>bold</strong> <a href=\"https://example.com/review\" rel=\"nofollow noopener\" target=\"_blank\">review</a> </p>\n<ul>\n<li>one</li>\n<li>two</li>\n</ul>",
+				Text:   "The Movie\nA bold review\none\ntwo",
+			},
+			nil,
+		},
+		{
+			"Render - HTML",
+			FORMAT_HTML,
+			`<p onclick="steal()">Hello <em>world</em></p><img src="x" onerror="steal()"><a href="javascript:steal()">link</a><style>p{}</style>`,
+			Body{
+				Format: FORMAT_HTML,
+				Source: `<p>Hello <em>world</em></p><img src="x">link`,
+				HTML:   `<p>Hello <em>world</em></p><img src="x">link`,
+				Text:   "Hello world\nlink",
+			},
+			nil,
+		},
+		{
+			"Render - no format is HTML",
+			"",
+			"Plain &amp; simple",
+			Body{Format: FORMAT_HTML, Source: "Plain &amp; simple", HTML: "Plain &amp; simple", Text: "Plain & simple"},
+			nil,
+		},
+		{
+			"Render - invalid format",
+			"rtf",
+			"{\\rtf1}",
+			Body{},
+			ErrInvalidFormat,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			body, err := Render(test.Format, test.Source)
+			require.ErrorIs(t, err, test.Error)
+			require.Equal(t, test.Expected, body)
+		})
+	}
+}
@@ -0,0 +1,107 @@
+// Package rights works out which rights of a project are still free to license
+package rights
+
+import (
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/webstradev/rsdb-backend/db"
+)
+
+const day = 24 * time.Hour
+
+// A window of a territory and media, the dates are inclusive
+type Window struct {
+	Territory string    `json:"territory"`
+	Media     string    `json:"media"`
+	From      time.Time `json:"from"`
+	To        time.Time `json:"to"`
+	DealIds   []int64   `json:"dealIds,omitempty"`
+}
+
+type Availability struct {
+	Available []Window  `json:"available"`
+	Blocked   []Window  `json:"blocked"`
+	Deals     []db.Deal `json:"deals"`
+}
+
+// Available splits the window between from and to for every territory and media into the parts that are still free
+// and the parts that are blocked by the given deals. Blocked windows list the deals blocking them.
+func Available(territories, media []string, from, to time.Time, deals []db.Deal) Availability {
+	availability := Availability{Available: []Window{}, Blocked: []Window{}, Deals: deals}
+
+	for _, territory := range territories {
+		for _, m := range media {
+			blocking := []db.Deal{}
+			for _, deal := range deals {
+				if slices.Contains(deal.Territories, territory) && slices.Contains(deal.Media, m) {
+					blocking = append(blocking, deal)
+				}
+			}
+
+			available, blocked := split(territory, m, from, to, blocking)
+			availability.Available = append(availability.Available, available...)
+			availability.Blocked = append(availability.Blocked, blocked...)
+		}
+	}
+
+	return availability
+}
+
+// split walks through the days between from and to, starting a new window whenever the set of blocking deals changes
+func split(territory, media string, from, to time.Time, deals []db.Deal) ([]Window, []Window) {
+	available, blocked := []Window{}, []Window{}
+
+	// The days on which a deal starts or the day after it ends are the only days the blocking deals change
+	boundaries := []time.Time{from}
+	for _, deal := range deals {
+		if deal.WindowStart.After(from) && !deal.WindowStart.After(to) {
+			boundaries = append(boundaries, deal.WindowStart)
+		}
+		end := deal.WindowEnd.Add(day)
+		if end.After(from) && !end.After(to) {
+			boundaries = append(boundaries, end)
+		}
+	}
+	sort.Slice(boundaries, func(i, j int) bool { return boundaries[i].Before(boundaries[j]) })
+	boundaries = slices.CompactFunc(boundaries, func(a, b time.Time) bool { return a.Equal(b) })
+
+	for i, start := range boundaries {
+		end := to
+		if i+1 < len(boundaries) {
+			end = boundaries[i+1].Add(-day)
+		}
+
+		ids := []int64{}
+		for _, deal := range deals {
+			if !deal.WindowStart.After(start) && !deal.WindowEnd.Before(start) {
+				ids = append(ids, deal.ID)
+			}
+		}
+
+		window := Window{Territory: territory, Media: media, From: start, To: end}
+		if len(ids) == 0 {
+			available = appendWindow(available, window)
+			continue
+		}
+
+		window.DealIds = ids
+		blocked = appendWindow(blocked, window)
+	}
+
+	return available, blocked
+}
+
+// appendWindow adds the window, extending the last one instead when it directly follows it with the same deals
+func appendWindow(windows []Window, window Window) []Window {
+	if len(windows) > 0 {
+		last := &windows[len(windows)-1]
+		if last.To.Add(day).Equal(window.From) && slices.Equal(last.DealIds, window.DealIds) {
+			last.To = window.To
+			return windows
+		}
+	}
+
+	return append(windows, window)
+}
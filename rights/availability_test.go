@@ -0,0 +1,98 @@
+package rights
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/webstradev/rsdb-backend/db"
+)
+
+func date(month time.Month, d int) time.Time {
+	return time.Date(2025, month, d, 0, 0, 0, 0, time.UTC)
+}
+
+func TestAvailable(t *testing.T) {
+	deals := []db.Deal{
+		{Model: db.Model{ID: 1}, Territories: []string{"NL", "BE"}, Media: []string{"svod"}, WindowStart: date(3, 1), WindowEnd: date(5, 31)},
+		{Model: db.Model{ID: 2}, Territories: []string{"NL"}, Media: []string{"svod", "tv"}, WindowStart: date(5, 1), WindowEnd: date(8, 31)},
+		{Model: db.Model{ID: 3}, Territories: []string{"BE"}, Media: []string{"svod"}, WindowStart: date(6, 1), WindowEnd: date(12, 31)},
+	}
+
+	tests := []struct {
+		Name        string
+		Territories []string
+		Media       []string
+		Deals       []db.Deal
+		Available   []Window
+		Blocked     []Window
+	}{
+		{
+			"Available - no deals",
+			[]string{"NL"},
+			[]string{"svod", "tv"},
+			[]db.Deal{},
+			[]Window{
+				{Territory: "NL", Media: "svod", From: date(1, 1), To: date(12, 31)},
+				{Territory: "NL", Media: "tv", From: date(1, 1), To: date(12, 31)},
+			},
+			[]Window{},
+		},
+		{
+			"Available - overlapping deals",
+			[]string{"NL"},
+			[]string{"svod", "tv"},
+			deals,
+			[]Window{
+				{Territory: "NL", Media: "svod", From: date(1, 1), To: date(2, 28)},
+				{Territory: "NL", Media: "svod", From: date(9, 1), To: date(12, 31)},
+				{Territory: "NL", Media: "tv", From: date(1, 1), To: date(4, 30)},
+				{Territory: "NL", Media: "tv", From: date(9, 1), To: date(12, 31)},
+			},
+			[]Window{
+				{Territory: "NL", Media: "svod", From: date(3, 1), To: date(4, 30), DealIds: []int64{1}},
+				{Territory: "NL", Media: "svod", From: date(5, 1), To: date(5, 31), DealIds: []int64{1, 2}},
+				{Territory: "NL", Media: "svod", From: date(6, 1), To: date(8, 31), DealIds: []int64{2}},
+				{Territory: "NL", Media: "tv", From: date(5, 1), To: date(8, 31), DealIds: []int64{2}},
+			},
+		},
+		{
+			"Available - deals following each other",
+			[]string{"BE"},
+			[]string{"svod"},
+			deals,
+			[]Window{
+				{Territory: "BE", Media: "svod", From: date(1, 1), To: date(2, 28)},
+			},
+			[]Window{
+				{Territory: "BE", Media: "svod", From: date(3, 1), To: date(5, 31), DealIds: []int64{1}},
+				{Territory: "BE", Media: "svod", From: date(6, 1), To: date(12, 31), DealIds: []int64{3}},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			availability := Available(test.Territories, test.Media, date(1, 1), date(12, 31), test.Deals)
+
+			require.Equal(t, test.Available, availability.Available)
+			require.Equal(t, test.Blocked, availability.Blocked)
+			require.Equal(t, test.Deals, availability.Deals)
+		})
+	}
+}
+
+func TestAvailableDealsOutsideWindow(t *testing.T) {
+	deals := []db.Deal{
+		{Model: db.Model{ID: 1}, Territories: []string{"NL"}, Media: []string{"svod"}, WindowStart: date(1, 1), WindowEnd: date(3, 31)},
+		{Model: db.Model{ID: 2}, Territories: []string{"NL"}, Media: []string{"svod"}, WindowStart: date(10, 1), WindowEnd: date(12, 31)},
+	}
+
+	availability := Available([]string{"NL"}, []string{"svod"}, date(2, 1), date(10, 31), deals)
+
+	require.Equal(t, []Window{{Territory: "NL", Media: "svod", From: date(4, 1), To: date(9, 30)}}, availability.Available)
+	require.Equal(t, []Window{
+		{Territory: "NL", Media: "svod", From: date(2, 1), To: date(3, 31), DealIds: []int64{1}},
+		{Territory: "NL", Media: "svod", From: date(10, 1), To: date(10, 31), DealIds: []int64{2}},
+	}, availability.Blocked)
+}
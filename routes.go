@@ -8,10 +8,26 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/webstradev/gin-pagination/v2/pkg/pagination"
 	"github.com/webstradev/rsdb-backend/controllers"
+	"github.com/webstradev/rsdb-backend/controllers/activities"
 	"github.com/webstradev/rsdb-backend/controllers/articles"
+	"github.com/webstradev/rsdb-backend/controllers/attachments"
+	"github.com/webstradev/rsdb-backend/controllers/comments"
+	"github.com/webstradev/rsdb-backend/controllers/contacts"
+	"github.com/webstradev/rsdb-backend/controllers/deals"
+	"github.com/webstradev/rsdb-backend/controllers/events"
+	"github.com/webstradev/rsdb-backend/controllers/imports"
+	"github.com/webstradev/rsdb-backend/controllers/links"
+	"github.com/webstradev/rsdb-backend/controllers/notifications"
+	"github.com/webstradev/rsdb-backend/controllers/people"
 	"github.com/webstradev/rsdb-backend/controllers/platforms"
 	"github.com/webstradev/rsdb-backend/controllers/projects"
+	"github.com/webstradev/rsdb-backend/controllers/revisions"
+	"github.com/webstradev/rsdb-backend/controllers/searches"
+	"github.com/webstradev/rsdb-backend/controllers/tasks"
 	"github.com/webstradev/rsdb-backend/controllers/users"
+	"github.com/webstradev/rsdb-backend/controllers/watchlist"
+	"github.com/webstradev/rsdb-backend/controllers/webhooks"
+	"github.com/webstradev/rsdb-backend/db"
 	"github.com/webstradev/rsdb-backend/middlewares"
 	"github.com/webstradev/rsdb-backend/utils"
 )
@@ -50,19 +66,62 @@ func registerRoutes(env *utils.Environment) *gin.Engine {
 
 	// General
 	api.GET("/counts", controllers.GetCounts(env))
+	api.GET("/countries", controllers.GetCountries(env))
 
 	// Platforms
 	api.GET("/platforms", pagination.New(pagination.WithSizeText("pageSize"), pagination.WithMinPageSize(1), pagination.WithMaxPageSize(100)), platforms.GetPlatforms(env))
 	api.POST("/platforms", platforms.CreatePlatform(env))
+	api.GET("/platforms/export", platforms.ExportPlatforms(env))
 	api.GET("/platforms/:platformId", platforms.GetPlatform(env))
 	api.PUT("/platforms/:platformId", platforms.EditPlatform(env))
 	api.DELETE("/platforms/:platformId", platforms.DeletePlatform(env))
+	api.GET("/platforms/:platformId/revisions", revisions.GetRevisions(env, db.PLATFORM_ENTITY, "platformId"))
+	api.GET("/platforms/:platformId/revisions/diff", revisions.DiffRevisions(env, db.PLATFORM_ENTITY, "platformId"))
+	api.GET("/platforms/:platformId/revisions/:revision", revisions.GetRevision(env, db.PLATFORM_ENTITY, "platformId"))
+	api.POST("/platforms/:platformId/revisions/:revision/restore", revisions.RestoreRevision(env, db.PLATFORM_ENTITY, "platformId"))
 
 	// Contacts
 	api.GET("/platforms/:platformId/contacts", platforms.GetContacts(env))
+	api.GET("/platforms/:platformId/contacts/vcard", contacts.ExportVCards(env, "platformId"))
+	api.POST("/platforms/:platformId/contacts/vcard", platforms.ImportVCard(env))
 	api.POST("/platforms/:platformId/contacts", platforms.CreateContact(env))
 	api.PUT("/platforms/:platformId/contacts/:id", platforms.EditContact(env))
 	api.DELETE("/platforms/:platformId/contacts/:id", platforms.DeleteContact(env))
+	api.GET("/contacts",
+		pagination.New(
+			pagination.WithSizeText("pageSize"),
+			pagination.WithMinPageSize(1),
+			pagination.WithMaxPageSize(100),
+		),
+		contacts.GetContacts(env),
+	)
+	api.GET("/contacts/export", contacts.ExportContacts(env))
+	api.GET("/contacts/vcard", contacts.ExportVCards(env, ""))
+	api.GET("/contacts/:contactId/vcard", contacts.GetContactVCard(env))
+
+	// Activities
+	api.POST("/activities", activities.CreateActivity(env))
+	api.GET("/activities/:activityId", activities.GetActivity(env))
+	api.PUT("/activities/:activityId", activities.EditActivity(env))
+	api.DELETE("/activities/:activityId", activities.DeleteActivity(env))
+	api.GET("/contacts/:contactId/activities", activities.GetContactActivities(env))
+	api.GET("/platforms/:platformId/activities", activities.GetPlatformActivities(env))
+
+	// Tasks
+	api.POST("/tasks", tasks.CreateTask(env))
+	api.GET("/tasks/mine", tasks.GetMyTasks(env))
+	api.GET("/tasks/overdue", tasks.GetOverdueTasks(env))
+	api.GET("/tasks/:taskId", tasks.GetTask(env))
+	api.PUT("/tasks/:taskId", tasks.EditTask(env))
+	api.DELETE("/tasks/:taskId", tasks.DeleteTask(env))
+
+	// People
+	api.GET("/people/:personId/career", people.GetCareer(env))
+	api.GET("/platforms/:platformId/staff", platforms.GetStaff(env))
+
+	// Imports
+	api.POST("/imports", imports.CreateImport(env))
+	api.GET("/imports/:importId", imports.GetImport(env))
 
 	// Articles
 	api.GET("/articles",
@@ -74,9 +133,15 @@ func registerRoutes(env *utils.Environment) *gin.Engine {
 		articles.GetArticles(env),
 	)
 	api.POST("/articles", articles.CreateArticle(env))
+	api.GET("/articles/export", articles.ExportArticles(env))
+	api.GET("/articles/preview", articles.PreviewArticle(env))
 	api.GET("/articles/:articleId", articles.GetArticle(env))
 	api.PUT("/articles/:articleId", articles.EditArticle(env))
 	api.DELETE("/articles/:articleId", articles.DeleteArticle(env))
+	api.GET("/articles/:articleId/revisions", revisions.GetRevisions(env, db.ARTICLE_ENTITY, "articleId"))
+	api.GET("/articles/:articleId/revisions/diff", revisions.DiffRevisions(env, db.ARTICLE_ENTITY, "articleId"))
+	api.GET("/articles/:articleId/revisions/:revision", revisions.GetRevision(env, db.ARTICLE_ENTITY, "articleId"))
+	api.POST("/articles/:articleId/revisions/:revision/restore", revisions.RestoreRevision(env, db.ARTICLE_ENTITY, "articleId"))
 
 	// Projects
 	api.GET("/projects",
@@ -88,9 +153,99 @@ func registerRoutes(env *utils.Environment) *gin.Engine {
 		projects.GetProjects(env),
 	)
 	api.POST("/projects", projects.CreateProject(env))
+	api.GET("/projects/export", projects.ExportProjects(env))
+	api.GET("/projects/pipeline", projects.GetPipeline(env))
 	api.GET("/projects/:projectId", projects.GetProject(env))
 	api.PUT("/projects/:projectId", projects.EditProject(env))
 	api.DELETE("/projects/:projectId", projects.DeleteProject(env))
+	api.GET("/projects/:projectId/press", projects.GetPress(env))
+	api.GET("/projects/:projectId/status", projects.GetStatus(env))
+	api.PUT("/projects/:projectId/status", projects.ChangeStatus(env))
+	api.GET("/projects/:projectId/revisions", revisions.GetRevisions(env, db.PROJECT_ENTITY, "projectId"))
+	api.GET("/projects/:projectId/revisions/diff", revisions.DiffRevisions(env, db.PROJECT_ENTITY, "projectId"))
+	api.GET("/projects/:projectId/revisions/:revision", revisions.GetRevision(env, db.PROJECT_ENTITY, "projectId"))
+	api.POST("/projects/:projectId/revisions/:revision/restore", revisions.RestoreRevision(env, db.PROJECT_ENTITY, "projectId"))
+
+	// Deals
+	api.POST("/deals", deals.CreateDeal(env))
+	api.GET("/deals/:dealId", deals.GetDeal(env))
+	api.PUT("/deals/:dealId", deals.EditDeal(env))
+	api.DELETE("/deals/:dealId", deals.DeleteDeal(env))
+	api.GET("/projects/:projectId/deals", deals.GetProjectDeals(env))
+	api.GET("/projects/:projectId/availability", deals.GetAvailability(env))
+	api.GET("/platforms/:platformId/deals", deals.GetPlatformDeals(env))
+
+	// Attachments
+	api.GET("/platforms/:platformId/attachments", attachments.GetAttachments(env, db.PLATFORM_ENTITY, "platformId"))
+	api.POST("/platforms/:platformId/attachments", attachments.CreateAttachment(env, db.PLATFORM_ENTITY, "platformId"))
+	api.GET("/contacts/:contactId/attachments", attachments.GetAttachments(env, db.CONTACT_ENTITY, "contactId"))
+	api.POST("/contacts/:contactId/attachments", attachments.CreateAttachment(env, db.CONTACT_ENTITY, "contactId"))
+	api.GET("/articles/:articleId/attachments", attachments.GetAttachments(env, db.ARTICLE_ENTITY, "articleId"))
+	api.POST("/articles/:articleId/attachments", attachments.CreateAttachment(env, db.ARTICLE_ENTITY, "articleId"))
+	api.GET("/projects/:projectId/attachments", attachments.GetAttachments(env, db.PROJECT_ENTITY, "projectId"))
+	api.POST("/projects/:projectId/attachments", attachments.CreateAttachment(env, db.PROJECT_ENTITY, "projectId"))
+	api.GET("/attachments/:attachmentId", attachments.DownloadAttachment(env))
+	api.DELETE("/attachments/:attachmentId", attachments.DeleteAttachment(env))
+
+	// Comments
+	api.GET("/platforms/:platformId/comments", comments.GetComments(env, db.PLATFORM_ENTITY, "platformId"))
+	api.POST("/platforms/:platformId/comments", comments.CreateComment(env, db.PLATFORM_ENTITY, "platformId"))
+	api.GET("/contacts/:contactId/comments", comments.GetComments(env, db.CONTACT_ENTITY, "contactId"))
+	api.POST("/contacts/:contactId/comments", comments.CreateComment(env, db.CONTACT_ENTITY, "contactId"))
+	api.GET("/articles/:articleId/comments", comments.GetComments(env, db.ARTICLE_ENTITY, "articleId"))
+	api.POST("/articles/:articleId/comments", comments.CreateComment(env, db.ARTICLE_ENTITY, "articleId"))
+	api.GET("/projects/:projectId/comments", comments.GetComments(env, db.PROJECT_ENTITY, "projectId"))
+	api.POST("/projects/:projectId/comments", comments.CreateComment(env, db.PROJECT_ENTITY, "projectId"))
+	api.PUT("/comments/:commentId", comments.EditComment(env))
+	api.DELETE("/comments/:commentId", comments.DeleteComment(env))
+
+	// Notifications
+	api.GET("/notifications",
+		pagination.New(
+			pagination.WithSizeText("pageSize"),
+			pagination.WithMinPageSize(1),
+			pagination.WithMaxPageSize(100),
+		),
+		notifications.GetNotifications(env),
+	)
+	api.GET("/notifications/unread", notifications.GetUnreadCount(env))
+	api.GET("/notifications/stream", middlewares.StreamMiddleware(), notifications.StreamNotifications(env))
+	api.PUT("/notifications/read", notifications.MarkAllNotificationsRead(env))
+	api.PUT("/notifications/:notificationId/read", notifications.MarkNotificationRead(env))
+
+	// Live changes to records
+	api.GET("/events", middlewares.StreamMiddleware(), events.StreamEvents(env))
+
+	// Watchlist
+	api.GET("/watchlist", watchlist.GetWatchlist(env))
+	api.GET("/watchlist/changes", watchlist.GetWatchlistChanges(env))
+	api.PUT("/platforms/:platformId/favorite", watchlist.AddFavorite(env, db.PLATFORM_ENTITY, "platformId"))
+	api.DELETE("/platforms/:platformId/favorite", watchlist.RemoveFavorite(env, db.PLATFORM_ENTITY, "platformId"))
+	api.PUT("/contacts/:contactId/favorite", watchlist.AddFavorite(env, db.CONTACT_ENTITY, "contactId"))
+	api.DELETE("/contacts/:contactId/favorite", watchlist.RemoveFavorite(env, db.CONTACT_ENTITY, "contactId"))
+	api.PUT("/projects/:projectId/favorite", watchlist.AddFavorite(env, db.PROJECT_ENTITY, "projectId"))
+	api.DELETE("/projects/:projectId/favorite", watchlist.RemoveFavorite(env, db.PROJECT_ENTITY, "projectId"))
+
+	// Saved searches
+	api.GET("/searches", searches.GetSearches(env))
+	api.POST("/searches", searches.CreateSearch(env))
+	api.GET("/searches/:searchId", searches.GetSearch(env))
+	api.PUT("/searches/:searchId", searches.EditSearch(env))
+	api.DELETE("/searches/:searchId", searches.DeleteSearch(env))
+	api.GET("/searches/:searchId/results",
+		pagination.New(
+			pagination.WithSizeText("pageSize"),
+			pagination.WithMinPageSize(1),
+			pagination.WithMaxPageSize(100),
+		),
+		searches.RunSearch(env),
+	)
+	api.GET("/searches/:searchId/export", searches.ExportSearch(env))
+	api.PUT("/searches/:searchId/subscription", searches.SubscribeToSearch(env))
+	api.DELETE("/searches/:searchId/subscription", searches.UnsubscribeFromSearch(env))
+
+	// Links
+	api.GET("/links/broken", links.GetBrokenLinks(env))
 
 	// Users (authenticated)
 	api.PUT("/users/password", users.EditPassword(env))
@@ -103,5 +258,32 @@ func registerRoutes(env *utils.Environment) *gin.Engine {
 	admin.GET("/users/token", users.GetRegistrationToken(env))
 	admin.GET("/users/:userId/resettoken", users.GetPasswordResetToken(env))
 
+	// Platforms (admin)
+	admin.GET("/platforms/duplicates", platforms.GetDuplicatePlatforms(env))
+	admin.POST("/platforms/:platformId/merge", platforms.MergePlatforms(env))
+
+	// Contacts (admin)
+	admin.GET("/contacts/duplicates", contacts.GetDuplicateContacts(env))
+	admin.POST("/contacts/:contactId/merge", contacts.MergeContacts(env))
+	admin.GET("/contacts/:contactId/merges", contacts.GetContactMerges(env))
+
+	// Webhooks (admin)
+	admin.GET("/webhooks", webhooks.GetWebhooks(env))
+	admin.GET("/webhooks/events", webhooks.GetWebhookEvents(env))
+	admin.POST("/webhooks", webhooks.CreateWebhook(env))
+	admin.GET("/webhooks/:webhookId", webhooks.GetWebhook(env))
+	admin.PUT("/webhooks/:webhookId", webhooks.EditWebhook(env))
+	admin.DELETE("/webhooks/:webhookId", webhooks.DeleteWebhook(env))
+	admin.GET("/webhooks/:webhookId/deliveries",
+		pagination.New(
+			pagination.WithSizeText("pageSize"),
+			pagination.WithMinPageSize(1),
+			pagination.WithMaxPageSize(100),
+		),
+		webhooks.GetWebhookDeliveries(env),
+	)
+	admin.GET("/webhooks/:webhookId/deliveries/:deliveryId", webhooks.GetWebhookDelivery(env))
+	admin.POST("/webhooks/:webhookId/deliveries/:deliveryId/redeliver", webhooks.RedeliverWebhookDelivery(env))
+
 	return router
 }
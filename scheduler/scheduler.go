@@ -0,0 +1,57 @@
+// Package scheduler runs background jobs inside the server process
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+)
+
+// A job gets the time it was scheduled for
+type Job func(ctx context.Context, now time.Time) error
+
+// ParseClock parses a time of day as HH:MM into the duration since midnight
+func ParseClock(value string) (time.Duration, error) {
+	clock, err := time.Parse("15:04", value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid time of day %q, use HH:MM", value)
+	}
+
+	return time.Duration(clock.Hour())*time.Hour + time.Duration(clock.Minute())*time.Minute, nil
+}
+
+// NextRun returns the first time after now at the given time of day, in the location of now. The time of day is
+// the wall clock time, also on days that daylight saving time starts or ends.
+func NextRun(now time.Time, at time.Duration) time.Time {
+	hour, minute := int(at/time.Hour), int(at%time.Hour/time.Minute)
+
+	next := time.Date(now.Year(), now.Month(), now.Day(), hour, minute, 0, 0, now.Location())
+	if !next.After(now) {
+		next = time.Date(now.Year(), now.Month(), now.Day()+1, hour, minute, 0, 0, now.Location())
+	}
+
+	return next
+}
+
+// Daily runs the job every day at the given time of day until the context is cancelled. Errors are logged and the
+// job is tried again the next day.
+func Daily(ctx context.Context, name string, at time.Duration, job Job) {
+	for {
+		next := NextRun(time.Now(), at)
+		timer := time.NewTimer(time.Until(next))
+
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+
+		log.Printf("Running scheduled job %s", name)
+		err := job(ctx, next)
+		if err != nil {
+			log.Printf("Scheduled job %s failed: %v", name, err)
+		}
+	}
+}
@@ -0,0 +1,74 @@
+package scheduler
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseClock(t *testing.T) {
+	at, err := ParseClock("08:30")
+	require.NoError(t, err)
+	require.Equal(t, 8*time.Hour+30*time.Minute, at)
+
+	_, err = ParseClock("25:00")
+	require.EqualError(t, err, `invalid time of day "25:00", use HH:MM`)
+}
+
+func TestNextRun(t *testing.T) {
+	amsterdam, err := time.LoadLocation("Europe/Amsterdam")
+	require.NoError(t, err)
+
+	tests := []struct {
+		Name string
+		Now  time.Time
+		Next time.Time
+	}{
+		{
+			"Later today",
+			time.Date(2024, 5, 1, 7, 0, 0, 0, time.UTC),
+			time.Date(2024, 5, 1, 8, 0, 0, 0, time.UTC),
+		},
+		{
+			"Exactly at the time of day",
+			time.Date(2024, 5, 1, 8, 0, 0, 0, time.UTC),
+			time.Date(2024, 5, 2, 8, 0, 0, 0, time.UTC),
+		},
+		{
+			"Tomorrow, across a month",
+			time.Date(2024, 4, 30, 9, 0, 0, 0, time.UTC),
+			time.Date(2024, 5, 1, 8, 0, 0, 0, time.UTC),
+		},
+		{
+			"Across a daylight saving change",
+			time.Date(2024, 3, 30, 9, 0, 0, 0, amsterdam),
+			time.Date(2024, 3, 31, 8, 0, 0, 0, amsterdam),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			require.True(t, test.Next.Equal(NextRun(test.Now, 8*time.Hour)), NextRun(test.Now, 8*time.Hour))
+		})
+	}
+}
+
+func TestDailyStopsWhenCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+
+	go func() {
+		Daily(ctx, "test", 0, func(ctx context.Context, now time.Time) error { return nil })
+		close(done)
+	}()
+
+	cancel()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Daily did not stop after the context was cancelled")
+	}
+}
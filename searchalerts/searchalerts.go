@@ -0,0 +1,75 @@
+// Package searchalerts notifies users of new matches of the saved searches they are subscribed to, once a week
+package searchalerts
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/webstradev/rsdb-backend/auth"
+	"github.com/webstradev/rsdb-backend/db"
+	"github.com/webstradev/rsdb-backend/inbox"
+	"github.com/webstradev/rsdb-backend/scheduler"
+)
+
+// Name of the job, used to make sure the alerts are sent once a day
+const SEARCH_ALERTS_JOB = "search_alerts"
+
+// How often subscribers are notified of new matches
+const SEARCH_ALERTS_INTERVAL = 7 * 24 * time.Hour
+
+// Job runs daily and notifies every subscriber that was last notified a week or longer ago of the records that were
+// created since and match the search. Subscribers without new matches are not notified, their week starts over.
+func Job(database *db.Database, notifications *inbox.Inbox) scheduler.Job {
+	return func(ctx context.Context, now time.Time) error {
+		claimed, err := database.ClaimJobRun(SEARCH_ALERTS_JOB, now)
+		if err != nil {
+			return err
+		}
+
+		// Another server already sent today's alerts
+		if !claimed {
+			return nil
+		}
+
+		subscriptions, err := database.GetDueSearchSubscriptions(now.Add(-SEARCH_ALERTS_INTERVAL))
+		if err != nil {
+			return err
+		}
+
+		// A failed alert does not stop the others from being sent, it is tried again the next day
+		errs := []error{}
+		for _, subscription := range subscriptions {
+			err = alert(database, notifications, subscription, now)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("search %d for user %d: %w", subscription.ID, subscription.UserId, err))
+			}
+		}
+
+		return errors.Join(errs...)
+	}
+}
+
+func alert(database *db.Database, notifications *inbox.Inbox, subscription db.SearchSubscription, now time.Time) error {
+	filter, err := subscription.ContactFilter()
+	if err != nil {
+		return err
+	}
+	filter.CreatedAfter = subscription.NotifiedAt
+
+	// Private records only count for admins, like in the list the search is for
+	count, err := database.CountContactsDirectory(filter, subscription.UserRole == auth.AdminRole)
+	if err != nil {
+		return err
+	}
+
+	if count > 0 {
+		err = notifications.SearchMatches(subscription.SavedSearch, subscription.UserId, count)
+		if err != nil {
+			return err
+		}
+	}
+
+	return database.SaveSearchNotified(subscription.ID, subscription.UserId, now)
+}
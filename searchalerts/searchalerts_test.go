@@ -0,0 +1,105 @@
+package searchalerts
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/require"
+	"github.com/webstradev/rsdb-backend/auth"
+	"github.com/webstradev/rsdb-backend/db"
+	"github.com/webstradev/rsdb-backend/inbox"
+)
+
+func TestJob(t *testing.T) {
+	now := time.Date(2024, 5, 8, 7, 0, 0, 0, time.UTC)
+	notified := time.Date(2024, 5, 1, 7, 0, 0, 0, time.UTC)
+	subscriptionColumns := []string{"id", "owner_id", "name", "target", "query", "shared", "user_id", "user_role", "notified_at"}
+
+	tests := []struct {
+		Name       string
+		MockDbCall func(sqlmock.Sqlmock)
+		Error      string
+	}{
+		{
+			"Job - sql error on ClaimJobRun",
+			func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec("INSERT IGNORE INTO job_runs").WithArgs(SEARCH_ALERTS_JOB, "2024-05-08").WillReturnError(errors.New("test"))
+			},
+			"test",
+		},
+		{
+			"Job - already sent by another server",
+			func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec("INSERT IGNORE INTO job_runs").WithArgs(SEARCH_ALERTS_JOB, "2024-05-08").WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+			"",
+		},
+		{
+			"Job - sql error on GetDueSearchSubscriptions",
+			func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec("INSERT IGNORE INTO job_runs").WithArgs(SEARCH_ALERTS_JOB, "2024-05-08").WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectQuery("SELECT s.(.+) FROM saved_searches_subscriptions ss").WithArgs(notified).WillReturnError(errors.New("test"))
+			},
+			"test",
+		},
+		{
+			"Job - subscribers with and without new matches",
+			func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec("INSERT IGNORE INTO job_runs").WithArgs(SEARCH_ALERTS_JOB, "2024-05-08").WillReturnResult(sqlmock.NewResult(1, 1))
+				rows := sqlmock.NewRows(subscriptionColumns).
+					AddRow(1, 1, "Dutch editors", "contacts", "country=NL&title=editor", true, 2, auth.UserRole, notified).
+					AddRow(2, 3, "Newest", "contacts", "sort=-created", false, 3, auth.AdminRole, notified)
+				mock.ExpectQuery("SELECT s.(.+) FROM saved_searches_subscriptions ss").WithArgs(notified).WillReturnRows(rows)
+
+				mock.ExpectQuery("SELECT COUNT(.+) AS count FROM contacts c (.+) c.created_at > ?").WithArgs("NL", "%editor%", notified, "private", "private").WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(4))
+				mock.ExpectExec("INSERT INTO notifications").WithArgs(2, "search_matches", "search", 1, nil, `4 new matches for the saved search "Dutch editors"`).WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec("UPDATE saved_searches_subscriptions").WithArgs(now, 1, 2).WillReturnResult(sqlmock.NewResult(0, 1))
+
+				mock.ExpectQuery("SELECT COUNT(.+) AS count FROM contacts c (.+) c.created_at > ?").WithArgs(notified).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+				mock.ExpectExec("UPDATE saved_searches_subscriptions").WithArgs(now, 2, 3).WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			"",
+		},
+		{
+			"Job - failed alert does not stop the others",
+			func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec("INSERT IGNORE INTO job_runs").WithArgs(SEARCH_ALERTS_JOB, "2024-05-08").WillReturnResult(sqlmock.NewResult(1, 1))
+				rows := sqlmock.NewRows(subscriptionColumns).
+					AddRow(1, 1, "Dutch editors", "contacts", "country=NL&title=editor", true, 2, auth.UserRole, notified).
+					AddRow(2, 3, "Newest", "contacts", "sort=-created", false, 3, auth.AdminRole, notified)
+				mock.ExpectQuery("SELECT s.(.+) FROM saved_searches_subscriptions ss").WithArgs(notified).WillReturnRows(rows)
+
+				mock.ExpectQuery("SELECT COUNT(.+) AS count FROM contacts c").WithArgs("NL", "%editor%", notified, "private", "private").WillReturnError(errors.New("test"))
+
+				mock.ExpectQuery("SELECT COUNT(.+) AS count FROM contacts c").WithArgs(notified).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+				mock.ExpectExec("INSERT INTO notifications").WithArgs(3, "search_matches", "search", 2, nil, `1 new match for the saved search "Newest"`).WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec("UPDATE saved_searches_subscriptions").WithArgs(now, 2, 3).WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			"search 1 for user 2: test",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			mockDb, mockSql, err := sqlmock.New()
+			require.NoError(t, err)
+			defer mockDb.Close()
+
+			test.MockDbCall(mockSql)
+
+			database := db.SetupMockDB(sqlx.NewDb(mockDb, "sqlmock"))
+			err = Job(database, inbox.New(database))(context.Background(), now)
+			if test.Error != "" {
+				require.EqualError(t, err, test.Error)
+			} else {
+				require.NoError(t, err)
+			}
+
+			require.NoError(t, mockSql.ExpectationsWereMet())
+		})
+	}
+}
@@ -0,0 +1,68 @@
+package spreadsheet
+
+import (
+	"encoding/csv"
+	"errors"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"github.com/xuri/excelize/v2"
+)
+
+const (
+	CSV  = "csv"
+	XLSX = "xlsx"
+)
+
+var ErrUnknownFormat = errors.New("unknown file format, use csv or xlsx")
+
+// FormatFromFilename derives the format from the extension of a filename
+func FormatFromFilename(filename string) string {
+	return strings.ToLower(strings.TrimPrefix(filepath.Ext(filename), "."))
+}
+
+// Read returns all rows of a CSV file or of the first sheet of an XLSX file
+func Read(r io.Reader, format string) ([][]string, error) {
+	switch format {
+	case CSV:
+		return readCSV(r)
+	case XLSX:
+		return readXLSX(r)
+	}
+
+	return nil, ErrUnknownFormat
+}
+
+func readCSV(r io.Reader) ([][]string, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	rows, err := reader.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+
+	// Excel prefixes CSV files saved as UTF-8 with a byte order mark
+	if len(rows) > 0 && len(rows[0]) > 0 {
+		rows[0][0] = strings.TrimPrefix(rows[0][0], "\ufeff")
+	}
+
+	return rows, nil
+}
+
+func readXLSX(r io.Reader) ([][]string, error) {
+	file, err := excelize.OpenReader(r)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	sheets := file.GetSheetList()
+	if len(sheets) == 0 {
+		return [][]string{}, nil
+	}
+
+	return file.GetRows(sheets[0])
+}
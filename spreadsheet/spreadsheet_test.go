@@ -0,0 +1,83 @@
+package spreadsheet
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/xuri/excelize/v2"
+)
+
+func TestFormatFromFilename(t *testing.T) {
+	require.Equal(t, CSV, FormatFromFilename("platforms.csv"))
+	require.Equal(t, XLSX, FormatFromFilename("Platforms.XLSX"))
+	require.Equal(t, "", FormatFromFilename("platforms"))
+}
+
+func TestReadCSV(t *testing.T) {
+	rows, err := Read(strings.NewReader("\ufeffname,country\nTest,NL\nOther\n"), CSV)
+	require.NoError(t, err)
+	require.Equal(t, [][]string{{"name", "country"}, {"Test", "NL"}, {"Other"}}, rows)
+}
+
+func TestReadXLSX(t *testing.T) {
+	file := excelize.NewFile()
+	file.SetSheetRow("Sheet1", "A1", &[]any{"name", "country"})
+	file.SetSheetRow("Sheet1", "A2", &[]any{"Test", "NL"})
+
+	buffer := bytes.Buffer{}
+	_, err := file.WriteTo(&buffer)
+	require.NoError(t, err)
+
+	rows, err := Read(&buffer, XLSX)
+	require.NoError(t, err)
+	require.Equal(t, [][]string{{"name", "country"}, {"Test", "NL"}}, rows)
+}
+
+func TestReadUnknownFormat(t *testing.T) {
+	_, err := Read(strings.NewReader(""), "pdf")
+	require.ErrorIs(t, err, ErrUnknownFormat)
+}
+
+func TestWriteCSV(t *testing.T) {
+	buffer := bytes.Buffer{}
+
+	writer, err := NewWriter(&buffer, CSV, []string{"id", "name"})
+	require.NoError(t, err)
+	require.NoError(t, writer.Write([]string{"1", "Test, Ltd"}))
+	require.NoError(t, writer.Close())
+
+	require.Equal(t, "id,name\n1,\"Test, Ltd\"\n", buffer.String())
+}
+
+func TestWriteNDJSON(t *testing.T) {
+	buffer := bytes.Buffer{}
+
+	writer, err := NewWriter(&buffer, NDJSON, []string{"name", "id"})
+	require.NoError(t, err)
+	require.NoError(t, writer.Write([]string{"Test \"TV\"", "1"}))
+	require.NoError(t, writer.Write([]string{"name"}))
+	require.NoError(t, writer.Close())
+
+	require.Equal(t, "{\"name\":\"Test \\\"TV\\\"\",\"id\":\"1\"}\n{\"name\":\"name\",\"id\":\"\"}\n", buffer.String())
+}
+
+func TestWriteXLSX(t *testing.T) {
+	buffer := bytes.Buffer{}
+
+	writer, err := NewWriter(&buffer, XLSX, []string{"id", "name"})
+	require.NoError(t, err)
+	require.NoError(t, writer.Write([]string{"1", "Test"}))
+	require.NoError(t, writer.Close())
+
+	rows, err := Read(&buffer, XLSX)
+	require.NoError(t, err)
+	require.Equal(t, [][]string{{"id", "name"}, {"1", "Test"}}, rows)
+}
+
+func TestWriteUnknownFormat(t *testing.T) {
+	_, err := NewWriter(&bytes.Buffer{}, "pdf", []string{"id"})
+	require.ErrorIs(t, err, ErrUnknownFormat)
+	require.False(t, Writable("pdf"))
+}
@@ -0,0 +1,156 @@
+package spreadsheet
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"io"
+
+	"github.com/xuri/excelize/v2"
+)
+
+const NDJSON = "ndjson"
+
+// Writer writes records one by one, Close has to be called to write any buffered data
+type Writer interface {
+	Write(record []string) error
+	Close() error
+}
+
+// Writable reports whether records can be written in the format
+func Writable(format string) bool {
+	return format == CSV || format == XLSX || format == NDJSON
+}
+
+func ContentType(format string) string {
+	switch format {
+	case CSV:
+		return "text/csv; charset=utf-8"
+	case XLSX:
+		return "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	case NDJSON:
+		return "application/x-ndjson"
+	}
+
+	return "application/octet-stream"
+}
+
+// NewWriter creates a writer for the format, the header is used as the first row or, for NDJSON, as the keys of every object
+func NewWriter(w io.Writer, format string, header []string) (Writer, error) {
+	switch format {
+	case CSV:
+		writer := csv.NewWriter(w)
+		return &csvWriter{writer: writer}, writer.Write(header)
+	case XLSX:
+		return newXLSXWriter(w, header)
+	case NDJSON:
+		return &ndjsonWriter{writer: bufio.NewWriter(w), header: header}, nil
+	}
+
+	return nil, ErrUnknownFormat
+}
+
+type csvWriter struct {
+	writer *csv.Writer
+}
+
+func (w *csvWriter) Write(record []string) error {
+	return w.writer.Write(record)
+}
+
+func (w *csvWriter) Close() error {
+	w.writer.Flush()
+	return w.writer.Error()
+}
+
+type ndjsonWriter struct {
+	writer *bufio.Writer
+	header []string
+}
+
+// Write writes the record as a JSON object, keys are written in the order of the header
+func (w *ndjsonWriter) Write(record []string) error {
+	w.writer.WriteByte('{')
+
+	for i, key := range w.header {
+		if i > 0 {
+			w.writer.WriteByte(',')
+		}
+
+		value := ""
+		if i < len(record) {
+			value = record[i]
+		}
+
+		keyData, err := json.Marshal(key)
+		if err != nil {
+			return err
+		}
+
+		valueData, err := json.Marshal(value)
+		if err != nil {
+			return err
+		}
+
+		w.writer.Write(keyData)
+		w.writer.WriteByte(':')
+		w.writer.Write(valueData)
+	}
+
+	_, err := w.writer.WriteString("}\n")
+	return err
+}
+
+func (w *ndjsonWriter) Close() error {
+	return w.writer.Flush()
+}
+
+// xlsxWriter uses the stream writer of excelize, which keeps rows on disk instead of in memory for large sheets.
+// The workbook can only be written once it is complete.
+type xlsxWriter struct {
+	out    io.Writer
+	file   *excelize.File
+	stream *excelize.StreamWriter
+	row    int
+}
+
+func newXLSXWriter(w io.Writer, header []string) (*xlsxWriter, error) {
+	file := excelize.NewFile()
+
+	stream, err := file.NewStreamWriter("Sheet1")
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+
+	writer := &xlsxWriter{out: w, file: file, stream: stream}
+	return writer, writer.Write(header)
+}
+
+func (w *xlsxWriter) Write(record []string) error {
+	w.row++
+
+	cell, err := excelize.CoordinatesToCellName(1, w.row)
+	if err != nil {
+		return err
+	}
+
+	values := make([]any, len(record))
+	for i, value := range record {
+		values[i] = value
+	}
+
+	return w.stream.SetRow(cell, values)
+}
+
+func (w *xlsxWriter) Close() error {
+	defer w.file.Close()
+
+	err := w.stream.Flush()
+	if err != nil {
+		return err
+	}
+
+	_, err = w.file.WriteTo(w.out)
+	return err
+}
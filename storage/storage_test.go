@@ -0,0 +1,133 @@
+package storage
+
+import (
+	"context"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFromEnv(t *testing.T) {
+	t.Setenv("STORAGE", "")
+	t.Setenv("STORAGE_DIR", t.TempDir())
+	s, err := FromEnv()
+	require.NoError(t, err)
+	require.IsType(t, &LocalStorage{}, s)
+
+	t.Setenv("STORAGE", "s3")
+	t.Setenv("S3_ENDPOINT", "")
+	_, err = FromEnv()
+	require.Error(t, err)
+
+	t.Setenv("S3_ENDPOINT", "http://localhost:9000")
+	t.Setenv("S3_BUCKET", "rsdb")
+	t.Setenv("S3_ACCESS_KEY_ID", "key")
+	t.Setenv("S3_SECRET_ACCESS_KEY", "secret")
+	s, err = FromEnv()
+	require.NoError(t, err)
+	require.IsType(t, &S3Storage{}, s)
+
+	t.Setenv("STORAGE", "floppy")
+	_, err = FromEnv()
+	require.EqualError(t, err, `unknown storage "floppy", use local or s3`)
+}
+
+// testStorage stores, reads and deletes a file through the storage
+func testStorage(t *testing.T, s Storage) {
+	ctx := context.Background()
+
+	_, err := s.Get(ctx, "attachments/missing")
+	require.ErrorIs(t, err, ErrNotFound)
+
+	for _, key := range []string{"", "/etc/passwd", "../secret", "attachments/../../secret", "a//b"} {
+		require.ErrorIs(t, s.Put(ctx, key, strings.NewReader("x"), 1, "text/plain"), ErrInvalidKey, key)
+	}
+
+	require.NoError(t, s.Put(ctx, "attachments/deck.pdf", strings.NewReader("%PDF-1.4 deck"), 13, "application/pdf"))
+
+	file, err := s.Get(ctx, "attachments/deck.pdf")
+	require.NoError(t, err)
+	data, err := io.ReadAll(file)
+	require.NoError(t, err)
+	require.NoError(t, file.Close())
+	require.Equal(t, "%PDF-1.4 deck", string(data))
+
+	require.NoError(t, s.Delete(ctx, "attachments/deck.pdf"))
+	require.NoError(t, s.Delete(ctx, "attachments/deck.pdf"))
+
+	_, err = s.Get(ctx, "attachments/deck.pdf")
+	require.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestLocalStorage(t *testing.T) {
+	s, err := NewLocalStorage(t.TempDir())
+	require.NoError(t, err)
+
+	testStorage(t, s)
+}
+
+// fakeS3 is a stand-in for an S3-compatible service that keeps objects in memory
+type fakeS3 struct {
+	mu      sync.Mutex
+	objects map[string][]byte
+	t       *testing.T
+}
+
+func (f *fakeS3) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	require.True(f.t, strings.HasPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 Credential=key/20240501/eu-west-1/s3/aws4_request, SignedHeaders=host;x-amz-content-sha256;x-amz-date, Signature="))
+	require.Equal(f.t, "20240501T100000Z", r.Header.Get("X-Amz-Date"))
+
+	if !strings.HasPrefix(r.URL.Path, "/rsdb/") {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	switch r.Method {
+	case http.MethodPut:
+		data, _ := io.ReadAll(r.Body)
+		f.objects[r.URL.Path] = data
+	case http.MethodGet:
+		data, ok := f.objects[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte("<Error><Code>NoSuchKey</Code></Error>"))
+			return
+		}
+		w.Write(data)
+	case http.MethodDelete:
+		delete(f.objects, r.URL.Path)
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func TestS3Storage(t *testing.T) {
+	server := httptest.NewServer(&fakeS3{objects: map[string][]byte{}, t: t})
+	defer server.Close()
+
+	s, err := NewS3Storage(S3Config{Endpoint: server.URL, Region: "eu-west-1", Bucket: "rsdb", AccessKeyId: "key", SecretAccessKey: "secret"})
+	require.NoError(t, err)
+	s.now = func() time.Time { return time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC) }
+
+	testStorage(t, s)
+
+	// Errors of the service are passed on
+	s.config.Bucket = "other"
+	err = s.Put(context.Background(), "attachments/deck.pdf", strings.NewReader("x"), 1, "application/pdf")
+	require.EqualError(t, err, "s3 PUT /other/attachments/deck.pdf: 404 Not Found")
+}
+
+func TestSigningKey(t *testing.T) {
+	// Example from the AWS Signature Version 4 documentation
+	key := signingKey("wJalrXUtnFEMI/K7MDENG+bPxRfiCYEXAMPLEKEY", "20120215", "us-east-1", "iam")
+	require.Equal(t, "f4780e2d9f65fa895f9c67b32ce1baf0b0d8a43505a000a1a9e090d414db404d", hex.EncodeToString(key))
+}
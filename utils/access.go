@@ -0,0 +1,33 @@
+package utils
+
+import (
+	"database/sql"
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/webstradev/rsdb-backend/auth"
+)
+
+// CheckRecordAccess aborts the request unless the record exists and the user has access to it. Attachments and
+// comments share the access of their record, so those of private platforms and contacts are only available to admins.
+func CheckRecordAccess(c *gin.Context, env *Environment, user auth.TokenData, entityType string, id int64) bool {
+	private, err := env.DB.RecordIsPrivate(entityType, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": "Record not found"})
+			return false
+		}
+		log.Println(err)
+		c.AbortWithStatus(http.StatusInternalServerError)
+		return false
+	}
+
+	if private && !user.IsAdmin() {
+		c.AbortWithStatus(http.StatusForbidden)
+		return false
+	}
+
+	return true
+}
@@ -7,8 +7,15 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/jmoiron/sqlx"
 	"github.com/webstradev/rsdb-backend/auth"
+	"github.com/webstradev/rsdb-backend/changes"
 	"github.com/webstradev/rsdb-backend/db"
+	"github.com/webstradev/rsdb-backend/enrich"
+	"github.com/webstradev/rsdb-backend/inbox"
 	"github.com/webstradev/rsdb-backend/mocks"
+	"github.com/webstradev/rsdb-backend/notify"
+	"github.com/webstradev/rsdb-backend/storage"
+	"github.com/webstradev/rsdb-backend/webhooks"
+	"github.com/webstradev/rsdb-backend/workflow"
 )
 
 type Environment struct {
@@ -16,6 +23,13 @@ type Environment struct {
 	JWT         auth.JWTServicer
 	UUID        auth.UUIDGenerator
 	AuthService auth.AuthServicer
+	Workflow    *workflow.Workflow
+	Enricher    *enrich.Service
+	Storage     storage.Storage
+	Notifier    notify.Notifier
+	Inbox       inbox.Servicer
+	Webhooks    *webhooks.Dispatcher
+	Changes     changes.Servicer
 }
 
 func SetupTestEnvironment(MockDbCall func(sqlmock.Sqlmock)) (*gin.Engine, *sql.DB, sqlmock.Sqlmock, *Environment, error) {
@@ -41,5 +55,12 @@ func SetupTestEnvironment(MockDbCall func(sqlmock.Sqlmock)) (*gin.Engine, *sql.D
 	// Create mock JWT service
 	env.JWT = mocks.CreateMockJWTService()
 
+	// Notifications and changes are not stored
+	env.Inbox = mocks.NewMockInbox()
+	env.Changes = mocks.NewMockChangeFeed()
+
+	// Use the default project workflow
+	env.Workflow = workflow.Default()
+
 	return r, mockDb, mockSql, &env, nil
 }
@@ -0,0 +1,69 @@
+package utils
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// How often event streams check for new events, this picks up events from other servers and keeps proxies from
+// closing the idle connection
+var STREAM_POLL_INTERVAL = 15 * time.Second
+
+// Maximum number of events read from the database at once
+const STREAM_BATCH_SIZE = 50
+
+// LastEventId reads the Last-Event-ID header clients send when they reconnect to an event stream, resumed is false
+// when the header was not sent
+func LastEventId(c *gin.Context) (id int64, resumed bool, err error) {
+	header := c.GetHeader("Last-Event-ID")
+	if header == "" {
+		return 0, false, nil
+	}
+
+	id, err = strconv.ParseInt(header, 10, 64)
+	return id, true, err
+}
+
+// StartEventStream sends the headers of a Server-Sent Events stream
+func StartEventStream(c *gin.Context) {
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no")
+	c.Status(http.StatusOK)
+	c.Writer.Flush()
+}
+
+// RunEventStream calls send whenever wake receives a value and every poll interval, until the client goes away or
+// sending fails
+func RunEventStream(c *gin.Context, wake <-chan struct{}, send func() error) {
+	ticker := time.NewTicker(STREAM_POLL_INTERVAL)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-c.Request.Context().Done():
+			return
+		case <-wake:
+		case <-ticker.C:
+			// Comment lines are ignored by clients, they keep the connection alive
+			_, err := fmt.Fprint(c.Writer, ": ping\n\n")
+			if err != nil {
+				log.Println(err)
+				return
+			}
+			c.Writer.Flush()
+		}
+
+		err := send()
+		if err != nil {
+			log.Println(err)
+			return
+		}
+	}
+}
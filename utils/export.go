@@ -0,0 +1,56 @@
+package utils
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/webstradev/rsdb-backend/spreadsheet"
+)
+
+// Time an export may take to be sent, the write timeout of the server is too short for large exports
+const EXPORT_TIMEOUT = 10 * time.Minute
+
+// StreamExport writes the records produced by export to the response as a file download.
+// The format is taken from ?format= (csv, xlsx or ndjson) and defaults to csv.
+func StreamExport(c *gin.Context, name string, columns []string, export func(write func([]string) error) error) {
+	format := c.DefaultQuery("format", spreadsheet.CSV)
+	if !spreadsheet.Writable(format) {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Invalid format"})
+		return
+	}
+
+	writer, err := spreadsheet.NewWriter(c.Writer, format, columns)
+	if err != nil {
+		log.Println(err)
+		c.AbortWithStatus(http.StatusInternalServerError)
+		return
+	}
+
+	http.NewResponseController(c.Writer).SetWriteDeadline(time.Now().Add(EXPORT_TIMEOUT))
+
+	c.Header("Content-Type", spreadsheet.ContentType(format))
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s.%s"`, name, format))
+
+	err = export(writer.Write)
+	if err != nil {
+		log.Println(err)
+		// Once part of the file has been sent the status can't be changed anymore
+		if !c.Writer.Written() {
+			c.Header("Content-Type", "")
+			c.Header("Content-Disposition", "")
+			c.AbortWithStatus(http.StatusInternalServerError)
+			return
+		}
+		c.Abort()
+		return
+	}
+
+	err = writer.Close()
+	if err != nil {
+		log.Println(err)
+		c.Abort()
+	}
+}
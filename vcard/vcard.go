@@ -0,0 +1,231 @@
+package vcard
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"strings"
+	"unicode/utf8"
+)
+
+const ContentType = "text/vcard; charset=utf-8"
+
+var ErrInvalidCard = errors.New("invalid vCard, missing BEGIN:VCARD or END:VCARD")
+
+// Card holds the properties of a vCard that map onto a contact
+type Card struct {
+	Name         string
+	Title        string
+	Organization string
+	Emails       []string
+	Phones       []string
+	Address      string
+	Note         string
+}
+
+// Maximum line length in octets before a line is folded (RFC 6350 section 3.2)
+const maxLineLength = 75
+
+// Encoder writes vCard 4.0 cards
+type Encoder struct {
+	w *bufio.Writer
+}
+
+func NewEncoder(w io.Writer) *Encoder {
+	return &Encoder{w: bufio.NewWriter(w)}
+}
+
+func (e *Encoder) Encode(card Card) error {
+	e.line("BEGIN:VCARD")
+	e.line("VERSION:4.0")
+	e.line("FN:" + escape(card.Name))
+
+	// N is structured as family;given;additional;prefix;suffix, the last word of the name is used as family name
+	name := strings.TrimSpace(card.Name)
+	given, family := name, ""
+	if i := strings.LastIndex(name, " "); i > 0 {
+		given, family = name[:i], name[i+1:]
+	}
+	e.line("N:" + escape(family) + ";" + escape(given) + ";;;")
+
+	if card.Organization != "" {
+		e.line("ORG:" + escape(card.Organization))
+	}
+	if card.Title != "" {
+		e.line("TITLE:" + escape(card.Title))
+	}
+	for _, email := range card.Emails {
+		if email != "" {
+			e.line("EMAIL;TYPE=work:" + escape(email))
+		}
+	}
+	for _, phone := range card.Phones {
+		if phone != "" {
+			e.line("TEL;TYPE=work,voice:" + escape(phone))
+		}
+	}
+	if card.Address != "" {
+		// The address isn't structured, so all of it goes in the street component
+		e.line("ADR;TYPE=work:;;" + escape(card.Address) + ";;;;")
+	}
+	if card.Note != "" {
+		e.line("NOTE:" + escape(card.Note))
+	}
+	e.line("END:VCARD")
+
+	return e.w.Flush()
+}
+
+// line writes a content line, folding it after 75 octets without splitting UTF-8 characters
+func (e *Encoder) line(content string) {
+	length := 0
+	for _, r := range content {
+		size := utf8.RuneLen(r)
+		if length+size > maxLineLength {
+			e.w.WriteString("\r\n ")
+			// The leading space of a continuation counts towards the line length
+			length = 1
+		}
+		e.w.WriteRune(r)
+		length += size
+	}
+	e.w.WriteString("\r\n")
+}
+
+func escape(value string) string {
+	return strings.NewReplacer(`\`, `\\`, ",", `\,`, ";", `\;`, "\r\n", `\n`, "\n", `\n`).Replace(value)
+}
+
+func unescape(value string) string {
+	return strings.NewReplacer(`\\`, `\`, `\,`, ",", `\;`, ";", `\n`, "\n", `\N`, "\n").Replace(value)
+}
+
+// splitComponents splits a structured value on unescaped semicolons
+func splitComponents(value string) []string {
+	components := []string{}
+
+	current := strings.Builder{}
+	escaped := false
+	for _, r := range value {
+		switch {
+		case escaped:
+			current.WriteRune('\\')
+			current.WriteRune(r)
+			escaped = false
+		case r == '\\':
+			escaped = true
+		case r == ';':
+			components = append(components, unescape(current.String()))
+			current.Reset()
+		default:
+			current.WriteRune(r)
+		}
+	}
+
+	return append(components, unescape(current.String()))
+}
+
+// Decode reads all cards from r. Versions 2.1, 3.0 and 4.0 are accepted, properties that don't map onto a Card are ignored.
+func Decode(r io.Reader) ([]Card, error) {
+	lines, err := unfold(r)
+	if err != nil {
+		return nil, err
+	}
+
+	cards := []Card{}
+	var card *Card
+	structuredName := ""
+
+	for _, line := range lines {
+		colon := strings.Index(line, ":")
+		if colon == -1 {
+			continue
+		}
+
+		// Property names are case insensitive and can have a group prefix (item1.EMAIL)
+		params := strings.Split(line[:colon], ";")
+		name := strings.ToUpper(params[0])
+		if dot := strings.LastIndex(name, "."); dot != -1 {
+			name = name[dot+1:]
+		}
+		value := line[colon+1:]
+
+		switch {
+		case name == "BEGIN" && strings.EqualFold(value, "VCARD"):
+			card = &Card{}
+			structuredName = ""
+			continue
+		case name == "END" && strings.EqualFold(value, "VCARD"):
+			if card == nil {
+				return nil, ErrInvalidCard
+			}
+			if card.Name == "" {
+				card.Name = structuredName
+			}
+			cards = append(cards, *card)
+			card = nil
+			continue
+		case card == nil:
+			continue
+		}
+
+		switch name {
+		case "FN":
+			card.Name = unescape(value)
+		case "N":
+			// Fallback for cards without FN: prefix, given, additional, family name and suffix
+			components := splitComponents(value)
+			parts := []string{}
+			for _, i := range []int{3, 1, 2, 0, 4} {
+				if i < len(components) && strings.TrimSpace(components[i]) != "" {
+					parts = append(parts, strings.TrimSpace(components[i]))
+				}
+			}
+			structuredName = strings.Join(parts, " ")
+		case "TITLE":
+			card.Title = unescape(value)
+		case "ORG":
+			card.Organization = strings.Join(splitComponents(value), ", ")
+		case "EMAIL":
+			card.Emails = append(card.Emails, unescape(value))
+		case "TEL":
+			card.Phones = append(card.Phones, strings.TrimPrefix(unescape(value), "tel:"))
+		case "ADR":
+			parts := []string{}
+			for _, component := range splitComponents(value) {
+				if component = strings.TrimSpace(component); component != "" {
+					parts = append(parts, component)
+				}
+			}
+			card.Address = strings.Join(parts, ", ")
+		case "NOTE":
+			card.Note = unescape(value)
+		}
+	}
+
+	if card != nil {
+		return nil, ErrInvalidCard
+	}
+
+	return cards, nil
+}
+
+// unfold joins folded lines, a line starting with a space or tab continues the previous line
+func unfold(r io.Reader) ([]string, error) {
+	lines := []string{}
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if len(lines) > 0 && (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		if strings.TrimSpace(line) != "" {
+			lines = append(lines, line)
+		}
+	}
+
+	return lines, scanner.Err()
+}
@@ -0,0 +1,94 @@
+package vcard
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncode(t *testing.T) {
+	buffer := bytes.Buffer{}
+
+	err := NewEncoder(&buffer).Encode(Card{
+		Name:         "Jane van Doe",
+		Title:        "Head of Acquisitions; Drama",
+		Organization: "Acme TV",
+		Emails:       []string{"jane@acme.tv"},
+		Phones:       []string{"+31 20 123 4567", ""},
+		Address:      "Main street 1, Amsterdam",
+		Note:         "Met at MIPCOM\nLikes drama",
+	})
+	require.NoError(t, err)
+
+	require.Equal(t, "BEGIN:VCARD\r\n"+
+		"VERSION:4.0\r\n"+
+		"FN:Jane van Doe\r\n"+
+		"N:Doe;Jane van;;;\r\n"+
+		"ORG:Acme TV\r\n"+
+		"TITLE:Head of Acquisitions\\; Drama\r\n"+
+		"EMAIL;TYPE=work:jane@acme.tv\r\n"+
+		"TEL;TYPE=work,voice:+31 20 123 4567\r\n"+
+		"ADR;TYPE=work:;;Main street 1\\, Amsterdam;;;;\r\n"+
+		"NOTE:Met at MIPCOM\\nLikes drama\r\n"+
+		"END:VCARD\r\n", buffer.String())
+}
+
+func TestEncodeFoldsLongLines(t *testing.T) {
+	buffer := bytes.Buffer{}
+
+	err := NewEncoder(&buffer).Encode(Card{Name: "Test", Note: strings.Repeat("é", 50)})
+	require.NoError(t, err)
+
+	for _, line := range strings.Split(buffer.String(), "\r\n") {
+		require.LessOrEqual(t, len(line), 75)
+	}
+
+	cards, err := Decode(&buffer)
+	require.NoError(t, err)
+	require.Equal(t, strings.Repeat("é", 50), cards[0].Note)
+}
+
+func TestDecode(t *testing.T) {
+	input := "BEGIN:VCARD\r\n" +
+		"VERSION:3.0\r\n" +
+		"N:Doe;John;;Dr.;\r\n" +
+		"item1.EMAIL;type=INTERNET:john@example.com\r\n" +
+		"TEL;TYPE=CELL:+44 20 7946 0000\r\n" +
+		"TEL;VALUE=uri:tel:+44-20-7946-0001\r\n" +
+		"ORG:Acme;Sales\r\n" +
+		"ADR;TYPE=WORK:;;1 High Street;London;;W1 1AA;United Kingdom\r\n" +
+		"NOTE:First line\\nsecond \r\n" +
+		" line\r\n" +
+		"END:VCARD\r\n" +
+		"begin:vcard\n" +
+		"fn:Jane\n" +
+		"title:Editor\\, News\n" +
+		"end:vcard\n"
+
+	cards, err := Decode(strings.NewReader(input))
+	require.NoError(t, err)
+	require.Equal(t, []Card{
+		{
+			Name:         "Dr. John Doe",
+			Organization: "Acme, Sales",
+			Emails:       []string{"john@example.com"},
+			Phones:       []string{"+44 20 7946 0000", "+44-20-7946-0001"},
+			Address:      "1 High Street, London, W1 1AA, United Kingdom",
+			Note:         "First line\nsecond line",
+		},
+		{
+			Name:  "Jane",
+			Title: "Editor, News",
+		},
+	}, cards)
+}
+
+func TestDecodeInvalid(t *testing.T) {
+	_, err := Decode(strings.NewReader("BEGIN:VCARD\r\nFN:Test\r\n"))
+	require.ErrorIs(t, err, ErrInvalidCard)
+
+	_, err = Decode(strings.NewReader("END:VCARD\r\n"))
+	require.ErrorIs(t, err, ErrInvalidCard)
+}
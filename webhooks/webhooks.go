@@ -0,0 +1,221 @@
+// Package webhooks calls the webhooks subscribed to changes of platforms, contacts, articles and projects. Events are
+// queued as deliveries in the database and sent in the background, failed deliveries are retried with exponential
+// backoff until they run out of attempts.
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/webstradev/rsdb-backend/db"
+)
+
+// Headers of the requests to webhooks. The signature is the HMAC-SHA256 of the body with the secret of the webhook,
+// as sha256=<hex>.
+const (
+	EVENT_HEADER     = "X-Rsdb-Event"
+	DELIVERY_HEADER  = "X-Rsdb-Delivery"
+	SIGNATURE_HEADER = "X-Rsdb-Signature"
+)
+
+// Number of attempts of a delivery before it fails, the first retry waits RETRY_BACKOFF and every next one twice as
+// long as the one before
+const (
+	MAX_ATTEMPTS  = 8
+	RETRY_BACKOFF = time.Minute
+)
+
+// How long an attempt may take before it is tried again (e.g. when the server stopped while delivering)
+const ATTEMPT_TIMEOUT = 5 * time.Minute
+
+// How often the queue is checked for deliveries that became due, and how many are sent at a time
+var (
+	POLL_INTERVAL = 30 * time.Second
+	BATCH_SIZE    = 50
+)
+
+// The body of the request to a webhook. ActorId is the user that made the change, it is left out when not known.
+type Payload struct {
+	Event      string    `json:"event"`
+	EntityType string    `json:"entityType"`
+	EntityId   int64     `json:"entityId"`
+	ActorId    int64     `json:"actorId,omitempty"`
+	OccurredAt time.Time `json:"occurredAt"`
+}
+
+type Dispatcher struct {
+	db     *db.Database
+	client *http.Client
+	wake   chan struct{}
+}
+
+func New(database *db.Database, client *http.Client) *Dispatcher {
+	return &Dispatcher{db: database, client: client, wake: make(chan struct{}, 1)}
+}
+
+// Publish queues the action on a record for the webhooks subscribed to it and wakes up the dispatcher
+func (d *Dispatcher) Publish(entityType, action string, entityId, actorId int64) error {
+	now := time.Now()
+	payload := Payload{
+		Event:      db.WebhookEvent(entityType, action),
+		EntityType: entityType,
+		EntityId:   entityId,
+		ActorId:    actorId,
+		OccurredAt: now.UTC(),
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	queued, err := d.db.QueueWebhookDeliveries(payload.Event, string(body), now)
+	if err != nil {
+		return err
+	}
+
+	if queued > 0 {
+		d.Wake()
+	}
+
+	return nil
+}
+
+// Wake makes the dispatcher check the queue now, e.g. after a delivery was queued for redelivery
+func (d *Dispatcher) Wake() {
+	select {
+	case d.wake <- struct{}{}:
+	default:
+		// A wake-up is already pending
+	}
+}
+
+// Run sends the deliveries as they become due until the context is cancelled. Errors are logged and the queue is
+// checked again at the next poll.
+func (d *Dispatcher) Run(ctx context.Context) {
+	ticker := time.NewTicker(POLL_INTERVAL)
+	defer ticker.Stop()
+
+	for {
+		err := d.DeliverDue(ctx, time.Now())
+		if err != nil {
+			log.Printf("Delivering webhooks failed: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-d.wake:
+		case <-ticker.C:
+		}
+	}
+}
+
+// DeliverDue sends the deliveries that are due at the given time, batch after batch until none are left. A failed
+// delivery does not stop the others from being sent.
+func (d *Dispatcher) DeliverDue(ctx context.Context, now time.Time) error {
+	for {
+		deliveries, err := d.db.GetDueWebhookDeliveries(now, BATCH_SIZE)
+		if err != nil {
+			return err
+		}
+
+		errs := []error{}
+		for _, delivery := range deliveries {
+			err = d.attempt(ctx, delivery, now)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("delivery %d: %w", delivery.ID, err))
+			}
+		}
+
+		if len(errs) > 0 || len(deliveries) < BATCH_SIZE || ctx.Err() != nil {
+			return errors.Join(errs...)
+		}
+	}
+}
+
+// attempt claims the delivery, sends it and records the outcome. Only errors of the database are returned, errors of
+// the webhook are recorded on the delivery.
+func (d *Dispatcher) attempt(ctx context.Context, delivery db.DueWebhookDelivery, now time.Time) error {
+	claimed, err := d.db.ClaimWebhookDelivery(delivery.WebhookDelivery, now, now.Add(ATTEMPT_TIMEOUT))
+	if err != nil {
+		return err
+	}
+
+	// Another server is sending it
+	if !claimed {
+		return nil
+	}
+
+	responseStatus, err := d.send(ctx, delivery)
+	if err == nil {
+		return d.db.SaveWebhookAttempt(delivery.ID, db.DELIVERY_SUCCEEDED, responseStatus, "", sql.NullTime{})
+	}
+
+	lastError := err.Error()
+	if len(lastError) > 1000 {
+		lastError = lastError[:1000]
+	}
+
+	attempts := delivery.Attempts + 1
+	if attempts >= MAX_ATTEMPTS {
+		return d.db.SaveWebhookAttempt(delivery.ID, db.DELIVERY_FAILED, responseStatus, lastError, sql.NullTime{})
+	}
+
+	retryAt := sql.NullTime{Time: now.Add(Backoff(attempts)), Valid: true}
+	return d.db.SaveWebhookAttempt(delivery.ID, db.DELIVERY_PENDING, responseStatus, lastError, retryAt)
+}
+
+// send posts the payload of the delivery to its webhook, responses outside of the 2xx range are errors
+func (d *Dispatcher) send(ctx context.Context, delivery db.DueWebhookDelivery) (sql.NullInt64, error) {
+	body := []byte(delivery.Payload)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, delivery.Url, bytes.NewReader(body))
+	if err != nil {
+		return sql.NullInt64{}, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(EVENT_HEADER, delivery.Event)
+	req.Header.Set(DELIVERY_HEADER, strconv.FormatInt(delivery.ID, 10))
+	req.Header.Set(SIGNATURE_HEADER, Sign(delivery.Secret, body))
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return sql.NullInt64{}, err
+	}
+	defer resp.Body.Close()
+
+	// Read the response so the connection can be reused
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 64*1024))
+
+	status := sql.NullInt64{Int64: int64(resp.StatusCode), Valid: true}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return status, fmt.Errorf("webhook responded with status %d", resp.StatusCode)
+	}
+
+	return status, nil
+}
+
+// Sign returns the signature of a body with a secret, as sent in the signature header
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Backoff returns how long to wait before retrying a delivery that failed the given number of attempts
+func Backoff(attempts int) time.Duration {
+	return RETRY_BACKOFF << (attempts - 1)
+}
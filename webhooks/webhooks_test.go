@@ -0,0 +1,255 @@
+package webhooks
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/require"
+	"github.com/webstradev/rsdb-backend/db"
+)
+
+// A local receiver of webhooks that records the requests it gets
+type receiver struct {
+	mu       sync.Mutex
+	requests []*http.Request
+	bodies   []string
+}
+
+func newReceiver() (*receiver, *httptest.Server) {
+	rec := &receiver{}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/ok", rec.record(http.StatusNoContent))
+	mux.HandleFunc("/broken", rec.record(http.StatusInternalServerError))
+
+	return rec, httptest.NewServer(mux)
+}
+
+func (rec *receiver) record(status int) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+
+		rec.mu.Lock()
+		rec.requests = append(rec.requests, r)
+		rec.bodies = append(rec.bodies, string(body))
+		rec.mu.Unlock()
+
+		w.WriteHeader(status)
+	}
+}
+
+func TestSign(t *testing.T) {
+	// Computed with: printf '{"event":"platform.created"}' | openssl dgst -sha256 -hmac secret
+	require.Equal(t, "sha256=6bfe5cfbe1623c6f1b4e5cdae0abd9213bc526358e7fc19455a1fc2f3ccfcf36", Sign("secret", []byte(`{"event":"platform.created"}`)))
+}
+
+func TestBackoff(t *testing.T) {
+	require.Equal(t, time.Minute, Backoff(1))
+	require.Equal(t, 2*time.Minute, Backoff(2))
+	require.Equal(t, 64*time.Minute, Backoff(MAX_ATTEMPTS-1))
+}
+
+func TestPublish(t *testing.T) {
+	tests := []struct {
+		Name       string
+		MockDbCall func(sqlmock.Sqlmock)
+		Error      string
+		Woken      bool
+	}{
+		{
+			"Publish - sql error",
+			func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec("INSERT INTO webhook_deliveries").WithArgs("project.updated", sqlmock.AnyArg(), sqlmock.AnyArg(), "project.updated").WillReturnError(errors.New("test"))
+			},
+			"test",
+			false,
+		},
+		{
+			"Publish - no webhooks subscribed",
+			func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec("INSERT INTO webhook_deliveries").WithArgs("project.updated", sqlmock.AnyArg(), sqlmock.AnyArg(), "project.updated").WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+			"",
+			false,
+		},
+		{
+			"Publish - queued for subscribed webhooks",
+			func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec("INSERT INTO webhook_deliveries (.+) WHERE we.event = \\? AND w.active").WithArgs("project.updated", payloadArg{"project.updated", "project", 4, 2}, sqlmock.AnyArg(), "project.updated").WillReturnResult(sqlmock.NewResult(1, 2))
+			},
+			"",
+			true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			mockDb, mock, err := sqlmock.New()
+			require.NoError(t, err)
+			defer mockDb.Close()
+
+			test.MockDbCall(mock)
+
+			dispatcher := New(db.SetupMockDB(sqlx.NewDb(mockDb, "sqlmock")), http.DefaultClient)
+
+			err = dispatcher.Publish(db.PROJECT_ENTITY, db.CHANGE_UPDATED, 4, 2)
+			if test.Error == "" {
+				require.NoError(t, err)
+			} else {
+				require.EqualError(t, err, test.Error)
+			}
+
+			select {
+			case <-dispatcher.wake:
+				require.True(t, test.Woken, "dispatcher was woken up")
+			default:
+				require.False(t, test.Woken, "dispatcher was not woken up")
+			}
+
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+// payloadArg matches the JSON payload of an event
+type payloadArg struct {
+	Event      string
+	EntityType string
+	EntityId   int64
+	ActorId    int64
+}
+
+func (a payloadArg) Match(v driver.Value) bool {
+	body, ok := v.(string)
+	if !ok {
+		return false
+	}
+
+	payload := Payload{}
+	err := json.Unmarshal([]byte(body), &payload)
+	return err == nil && payload.Event == a.Event && payload.EntityType == a.EntityType && payload.EntityId == a.EntityId &&
+		payload.ActorId == a.ActorId && !payload.OccurredAt.IsZero()
+}
+
+func TestDeliverDue(t *testing.T) {
+	rec, server := newReceiver()
+	defer server.Close()
+
+	now := time.Date(2024, 5, 8, 7, 0, 0, 0, time.UTC)
+	payload := `{"event":"platform.created","entityType":"platform","entityId":3,"actorId":1,"occurredAt":"2024-05-08T06:59:59Z"}`
+	deliveryColumns := []string{"id", "webhook_id", "event", "payload", "status", "attempts", "url", "secret"}
+
+	tests := []struct {
+		Name       string
+		MockDbCall func(sqlmock.Sqlmock)
+		Error      string
+		Requests   int
+	}{
+		{
+			"DeliverDue - sql error on GetDueWebhookDeliveries",
+			func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT d.(.+) FROM webhook_deliveries d").WithArgs("pending", now, BATCH_SIZE).WillReturnError(errors.New("test"))
+			},
+			"test",
+			0,
+		},
+		{
+			"DeliverDue - claimed by another server",
+			func(mock sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows(deliveryColumns).AddRow(1, 1, "platform.created", payload, "pending", 0, server.URL+"/ok", "secret")
+				mock.ExpectQuery("SELECT d.(.+) FROM webhook_deliveries d").WithArgs("pending", now, BATCH_SIZE).WillReturnRows(rows)
+				mock.ExpectExec("UPDATE webhook_deliveries SET attempts").WithArgs(now, now.Add(ATTEMPT_TIMEOUT), 1, "pending", 0).WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+			"",
+			0,
+		},
+		{
+			"DeliverDue - delivered, retried and failed",
+			func(mock sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows(deliveryColumns).
+					AddRow(1, 1, "platform.created", payload, "pending", 0, server.URL+"/ok", "secret").
+					AddRow(2, 2, "platform.created", payload, "pending", 2, server.URL+"/broken", "other").
+					AddRow(3, 2, "platform.created", payload, "pending", MAX_ATTEMPTS-1, server.URL+"/broken", "other")
+				mock.ExpectQuery("SELECT d.(.+) FROM webhook_deliveries d").WithArgs("pending", now, BATCH_SIZE).WillReturnRows(rows)
+
+				mock.ExpectExec("UPDATE webhook_deliveries SET attempts").WithArgs(now, now.Add(ATTEMPT_TIMEOUT), 1, "pending", 0).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("UPDATE webhook_deliveries SET status").
+					WithArgs("succeeded", sql.NullInt64{Int64: 204, Valid: true}, "", sql.NullTime{}, 1).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+
+				mock.ExpectExec("UPDATE webhook_deliveries SET attempts").WithArgs(now, now.Add(ATTEMPT_TIMEOUT), 2, "pending", 2).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("UPDATE webhook_deliveries SET status").
+					WithArgs("pending", sql.NullInt64{Int64: 500, Valid: true}, "webhook responded with status 500", sql.NullTime{Time: now.Add(4 * time.Minute), Valid: true}, 2).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+
+				mock.ExpectExec("UPDATE webhook_deliveries SET attempts").WithArgs(now, now.Add(ATTEMPT_TIMEOUT), 3, "pending", MAX_ATTEMPTS-1).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("UPDATE webhook_deliveries SET status").
+					WithArgs("failed", sql.NullInt64{Int64: 500, Valid: true}, "webhook responded with status 500", sql.NullTime{}, 3).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			"",
+			3,
+		},
+		{
+			"DeliverDue - sql error on SaveWebhookAttempt",
+			func(mock sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows(deliveryColumns).AddRow(1, 1, "platform.created", payload, "pending", 0, server.URL+"/ok", "secret")
+				mock.ExpectQuery("SELECT d.(.+) FROM webhook_deliveries d").WithArgs("pending", now, BATCH_SIZE).WillReturnRows(rows)
+				mock.ExpectExec("UPDATE webhook_deliveries SET attempts").WithArgs(now, now.Add(ATTEMPT_TIMEOUT), 1, "pending", 0).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("UPDATE webhook_deliveries SET status").WillReturnError(errors.New("test"))
+			},
+			"delivery 1: test",
+			1,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			mockDb, mock, err := sqlmock.New()
+			require.NoError(t, err)
+			defer mockDb.Close()
+
+			test.MockDbCall(mock)
+
+			rec.mu.Lock()
+			rec.requests, rec.bodies = nil, nil
+			rec.mu.Unlock()
+
+			dispatcher := New(db.SetupMockDB(sqlx.NewDb(mockDb, "sqlmock")), server.Client())
+
+			err = dispatcher.DeliverDue(context.Background(), now)
+			if test.Error == "" {
+				require.NoError(t, err)
+			} else {
+				require.EqualError(t, err, test.Error)
+			}
+
+			// Every request is signed with the secret of its webhook
+			require.Len(t, rec.requests, test.Requests)
+			for i, req := range rec.requests {
+				require.Equal(t, payload, rec.bodies[i])
+				require.Equal(t, "application/json", req.Header.Get("Content-Type"))
+				require.Equal(t, "platform.created", req.Header.Get(EVENT_HEADER))
+
+				secret := "secret"
+				if req.URL.Path == "/broken" {
+					secret = "other"
+				}
+				require.Equal(t, Sign(secret, []byte(payload)), req.Header.Get(SIGNATURE_HEADER))
+			}
+
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
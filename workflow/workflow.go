@@ -0,0 +1,83 @@
+// Package workflow describes the statuses a project goes through and which status changes are allowed. The workflow
+// is configured with an environment variable so that it can be changed without a new release.
+package workflow
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+)
+
+// Used when no workflow is configured, projects can move to the next status or back to the previous one
+const DEFAULT_WORKFLOW = "development>production,production>development,production>sales,sales>production,sales>delivered,delivered>sales"
+
+type Workflow struct {
+	// Statuses in the order they are shown on the pipeline board, the first one is given to new projects
+	Statuses    []string
+	transitions map[string][]string
+}
+
+// Parse reads a workflow from a comma separated list of allowed transitions written as from>to.
+// Statuses are ordered by their first appearance.
+func Parse(spec string) (*Workflow, error) {
+	w := &Workflow{Statuses: []string{}, transitions: map[string][]string{}}
+
+	for _, transition := range strings.Split(spec, ",") {
+		from, to, ok := strings.Cut(transition, ">")
+		from, to = strings.TrimSpace(from), strings.TrimSpace(to)
+		if !ok || from == "" || to == "" || from == to {
+			return nil, fmt.Errorf("invalid transition %q, use from>to", transition)
+		}
+
+		for _, status := range []string{from, to} {
+			if !slices.Contains(w.Statuses, status) {
+				w.Statuses = append(w.Statuses, status)
+			}
+		}
+
+		w.transitions[from] = append(w.transitions[from], to)
+	}
+
+	return w, nil
+}
+
+// Default returns the development, production, sales and delivered workflow
+func Default() *Workflow {
+	w, _ := Parse(DEFAULT_WORKFLOW)
+	return w
+}
+
+// FromEnv reads the workflow from PROJECT_WORKFLOW, the default workflow is used when it is not set
+func FromEnv() (*Workflow, error) {
+	spec := os.Getenv("PROJECT_WORKFLOW")
+	if spec == "" {
+		return Default(), nil
+	}
+
+	return Parse(spec)
+}
+
+// Initial is the status of new projects
+func (w *Workflow) Initial() string {
+	return w.Statuses[0]
+}
+
+func (w *Workflow) Valid(status string) bool {
+	return slices.Contains(w.Statuses, status)
+}
+
+// Next lists the statuses a project with the given status can move to
+func (w *Workflow) Next(status string) []string {
+	next := w.transitions[status]
+	if next == nil {
+		return []string{}
+	}
+
+	return next
+}
+
+// CanTransition reports whether a project can move from one status to the other
+func (w *Workflow) CanTransition(from, to string) bool {
+	return slices.Contains(w.transitions[from], to)
+}
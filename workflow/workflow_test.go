@@ -0,0 +1,47 @@
+package workflow
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	w, err := Parse("idea>development, development>production,production>delivered,development>idea")
+	require.NoError(t, err)
+	require.Equal(t, []string{"idea", "development", "production", "delivered"}, w.Statuses)
+	require.Equal(t, "idea", w.Initial())
+	require.Equal(t, []string{"production", "idea"}, w.Next("development"))
+	require.Equal(t, []string{}, w.Next("delivered"))
+
+	require.True(t, w.CanTransition("development", "idea"))
+	require.False(t, w.CanTransition("idea", "production"))
+	require.False(t, w.CanTransition("delivered", "production"))
+
+	require.True(t, w.Valid("delivered"))
+	require.False(t, w.Valid("sales"))
+
+	for _, spec := range []string{"", "idea", "idea>", ">idea", "idea>idea", "idea>development,"} {
+		_, err = Parse(spec)
+		require.Error(t, err, spec)
+	}
+}
+
+func TestFromEnv(t *testing.T) {
+	t.Setenv("PROJECT_WORKFLOW", "")
+	w, err := FromEnv()
+	require.NoError(t, err)
+	require.Equal(t, []string{"development", "production", "sales", "delivered"}, w.Statuses)
+	require.True(t, w.CanTransition("sales", "delivered"))
+	require.True(t, w.CanTransition("sales", "production"))
+	require.False(t, w.CanTransition("development", "delivered"))
+
+	t.Setenv("PROJECT_WORKFLOW", "draft>done")
+	w, err = FromEnv()
+	require.NoError(t, err)
+	require.Equal(t, []string{"draft", "done"}, w.Statuses)
+
+	t.Setenv("PROJECT_WORKFLOW", "draft")
+	_, err = FromEnv()
+	require.Error(t, err)
+}